@@ -33,6 +33,10 @@ import (
 var (
 	flCACert = flag.String("ca-cert", os.Getenv(reconcilermanager.HelmCACert),
 		"CA cert to use for validating HTTPS connections")
+	flClientCert = flag.String("client-cert", os.Getenv(reconcilermanager.HelmClientCert),
+		"client cert to use for mutual TLS connections")
+	flClientKey = flag.String("client-key", os.Getenv(reconcilermanager.HelmClientKey),
+		"client private key to use for mutual TLS connections")
 	flRepo = flag.String("repo", os.Getenv(reconcilermanager.HelmRepo),
 		"helm repository url where to locate the requested chart")
 	flChart = flag.String("chart", os.Getenv(reconcilermanager.HelmChart),
@@ -45,6 +49,10 @@ var (
 		"comma-separated list of filepaths to helm chart values, will be used to override the default values")
 	flIncludeCRDs = flag.String("include-crds", os.Getenv(reconcilermanager.HelmIncludeCRDs),
 		"include CRDs in the helm rendering output")
+	flAPIVersions = flag.String("api-versions", os.Getenv(reconcilermanager.HelmAPIVersions),
+		"comma-separated list of Kubernetes API versions used for Capabilities.APIVersions in the helm rendering")
+	flKubeVersion = flag.String("kube-version", os.Getenv(reconcilermanager.HelmKubeVersion),
+		"the Kubernetes version used for Capabilities.KubeVersion in the helm rendering")
 	flAuth = flag.String("auth", util.EnvString(reconcilermanager.HelmAuthType, string(configsync.AuthNone)),
 		fmt.Sprintf("the authentication type for access to the Helm repository. Must be one of %s, %s, %s, %s or %s. Defaults to %s",
 			configsync.AuthGCPServiceAccount, configsync.AuthK8sServiceAccount, configsync.AuthToken, configsync.AuthGCENode, configsync.AuthNone, configsync.AuthNone))
@@ -80,7 +88,9 @@ func main() {
 	log.Info("rendering Helm chart with arguments", "--repo", *flRepo,
 		"--chart", *flChart, "--version", *flVersion, "--root", *flRoot,
 		"--values", *flValuesYAML, "--values-file-paths", *flValuesFilePaths,
-		"--include-crds", *flIncludeCRDs, "--dest", *flDest, "--wait", *flWait,
+		"--include-crds", *flIncludeCRDs,
+		"--api-versions", *flAPIVersions, "--kube-version", *flKubeVersion,
+		"--dest", *flDest, "--wait", *flWait,
 		"--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
 		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
 
@@ -116,22 +126,31 @@ func main() {
 			valuesFilePaths = strings.Split(*flValuesFilePaths, ",")
 		}
 
+		apiVersions := []string{}
+		if len(*flAPIVersions) != 0 {
+			apiVersions = strings.Split(*flAPIVersions, ",")
+		}
+
 		hydrator := &helm.Hydrator{
-			Chart:           *flChart,
-			Repo:            *flRepo,
-			Version:         *flVersion,
-			ReleaseName:     *flReleaseName,
-			Namespace:       *flNamespace,
-			DeployNamespace: *flDeployNamespace,
-			ValuesYAML:      *flValuesYAML,
-			ValuesFilePaths: valuesFilePaths,
-			IncludeCRDs:     *flIncludeCRDs,
-			Auth:            configsync.AuthType(*flAuth),
-			HydrateRoot:     *flRoot,
-			Dest:            *flDest,
-			UserName:        *flUsername,
-			Password:        *flPassword,
-			CACertFilePath:  *flCACert,
+			Chart:              *flChart,
+			Repo:               *flRepo,
+			Version:            *flVersion,
+			ReleaseName:        *flReleaseName,
+			Namespace:          *flNamespace,
+			DeployNamespace:    *flDeployNamespace,
+			ValuesYAML:         *flValuesYAML,
+			ValuesFilePaths:    valuesFilePaths,
+			IncludeCRDs:        *flIncludeCRDs,
+			APIVersions:        apiVersions,
+			KubeVersion:        *flKubeVersion,
+			Auth:               configsync.AuthType(*flAuth),
+			HydrateRoot:        *flRoot,
+			Dest:               *flDest,
+			UserName:           *flUsername,
+			Password:           *flPassword,
+			CACertFilePath:     *flCACert,
+			ClientCertFilePath: *flClientCert,
+			ClientKeyFilePath:  *flClientKey,
 		}
 
 		if err := hydrator.HelmTemplate(ctx); err != nil {
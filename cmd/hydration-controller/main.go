@@ -120,6 +120,9 @@ func main() {
 		PollingPeriod:   *pollingPeriod,
 		RehydratePeriod: *rehydratePeriod,
 		ReconcilerName:  *reconcilerName,
+		// Detect the tool versions once, as they do not change while running.
+		KustomizeVersion: hydrate.ToolVersion(hydrate.Kustomize),
+		HelmVersion:      hydrate.ToolVersion(hydrate.Helm),
 	}
 
 	hydrator.Run(context.Background())
@@ -0,0 +1,200 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/customresources"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/parse"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/util/discovery"
+)
+
+var namespaceValue string
+
+func init() {
+	flags.AddPath(Cmd)
+	flags.AddSkipAPIServerCheck(Cmd)
+	flags.AddSourceFormat(Cmd)
+	flags.AddAPIServerTimeout(Cmd)
+	Cmd.Flags().StringVar(&namespaceValue, "namespace", "",
+		fmt.Sprintf(
+			"If set, parse the repository as a Namespace Repo with the provided name. Automatically sets --source-format=%s",
+			filesystem.SourceFormatUnstructured))
+}
+
+// Cmd is the Cobra object representing the nomos explain command.
+var Cmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Print the metadata Config Sync computes for each object in a directory",
+	Long: `Print the metadata Config Sync computes for each object in a directory
+Parses and validates the directory exactly as the reconciler does, then prints
+the resource ID, scope and declared fields of each object that would be applied.
+The declared fields are only computed when the API Server is reachable.
+Kustomizations are not rendered.
+`,
+	Example: `  nomos explain
+  nomos explain --path=my/directory --source-format=unstructured
+  nomos explain --path=my/directory --namespace=bookstore`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		rootDir, err := rootDir(flags.Path)
+		if err != nil {
+			return err
+		}
+		opts, err := offlineOptions(namespaceValue, filesystem.SourceFormat(flags.SourceFormat))
+		if err != nil {
+			return err
+		}
+		if !flags.SkipAPIServer {
+			if err := addAPIServerDiscovery(&opts); err != nil {
+				return err
+			}
+		}
+		return runExplain(cmd.Context(), cmd.OutOrStdout(), rootDir, opts)
+	},
+}
+
+func rootDir(path string) (cmpath.Absolute, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rootDir, err := cmpath.AbsoluteOS(abs)
+	if err != nil {
+		return "", err
+	}
+	return rootDir.EvalSymlinks()
+}
+
+// offlineOptions returns the options to parse the directory with, defaulting
+// the source format the same way as nomos vet.
+func offlineOptions(namespace string, sourceFormat filesystem.SourceFormat) (parse.OfflineOptions, error) {
+	if namespace == "" {
+		if sourceFormat == "" {
+			sourceFormat = filesystem.SourceFormatHierarchy
+		}
+		return parse.OfflineOptions{
+			Scope:             declared.RootReconciler,
+			SyncName:          configsync.RootSyncName,
+			SourceFormat:      sourceFormat,
+			NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+		}, nil
+	}
+	if sourceFormat != "" && sourceFormat != filesystem.SourceFormatUnstructured {
+		return parse.OfflineOptions{}, fmt.Errorf("if --namespace is provided, --source-format must be omitted or set to %s",
+			filesystem.SourceFormatUnstructured)
+	}
+	return parse.OfflineOptions{
+		Scope:        declared.Scope(namespace),
+		SyncName:     configsync.RepoSyncName,
+		SourceFormat: filesystem.SourceFormatUnstructured,
+	}, nil
+}
+
+// addAPIServerDiscovery configures the options to discover the resource types
+// and compute the declared fields using the API Server.
+func addAPIServerDiscovery(opts *parse.OfflineOptions) error {
+	cfg, err := restconfig.NewRestConfig(flags.APIServerTimeout)
+	if err != nil {
+		return apiServerCheckError(err, "failed to create rest config")
+	}
+	cf, err := restconfig.NewConfigFlags(cfg)
+	if err != nil {
+		return apiServerCheckError(err, "failed to create config flags from rest config")
+	}
+	dc, err := cf.ToDiscoveryClient()
+	if err != nil {
+		return apiServerCheckError(err, "failed to create discovery client")
+	}
+	converter, err := declared.NewValueConverter(dc)
+	if err != nil {
+		return apiServerCheckError(err, "failed to create value converter")
+	}
+	opts.DiscoveryInterface = dc
+	opts.Converter = converter
+	return nil
+}
+
+func apiServerCheckError(err error, message string) status.Error {
+	return status.APIServerError(err, fmt.Sprintf("%s. Did you mean to run with --%s?", message, flags.SkipAPIServerFlag))
+}
+
+// runExplain parses the directory and prints the computed metadata of each
+// object to out.
+func runExplain(ctx context.Context, out io.Writer, rootDir cmpath.Absolute, opts parse.OfflineOptions) error {
+	objs, errs := parse.ParseOffline(ctx, rootDir, opts)
+	if status.HasBlockingErrors(errs) {
+		return errs
+	}
+	if errs != nil {
+		_ = util.PrintErr(errs)
+	}
+
+	// Sort by resource ID, so the output is stable across runs.
+	sort.Slice(objs, func(i, j int) bool {
+		return objs[i].GetAnnotations()[metadata.ResourceIDKey] < objs[j].GetAnnotations()[metadata.ResourceIDKey]
+	})
+	scoper := buildScoper(objs, opts.DiscoveryInterface)
+	writer := util.NewWriter(out)
+	fmt.Fprintln(writer, "RESOURCE ID\tSCOPE\tDECLARED FIELDS")
+	for _, obj := range objs {
+		// The scope is Unknown if the type is neither built in, declared in
+		// the directory nor discovered from the API Server.
+		scope, _ := scoper.GetObjectScope(obj)
+		fields, found := obj.GetAnnotations()[metadata.DeclaredFieldsKey]
+		if !found {
+			fields = util.UnknownMsg
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", obj.GetAnnotations()[metadata.ResourceIDKey], scope, fields)
+	}
+	return writer.Flush()
+}
+
+// buildScoper returns the Scoper used to look up the scope of the objects, the
+// same way the reconciler does: from the built-in types, the CRDs declared in
+// the directory and, if available, the types discovered from the API Server.
+func buildScoper(objs []ast.FileObject, sr discovery.ServerResourcer) discovery.Scoper {
+	if sr == nil {
+		sr = discovery.NoOpServerResourcer{}
+	}
+	crds, errs := customresources.GetCRDs(objs)
+	if errs != nil {
+		_ = util.PrintErr(errs)
+	}
+	scoper, errs := discovery.ScoperBuilder(sr)(crds, objs)
+	if errs != nil {
+		_ = util.PrintErr(errs)
+	}
+	return scoper
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+const clusterRole = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+`
+
+const role = `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: reader
+  namespace: bookstore
+`
+
+const anvilCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: anvils.acme.com
+spec:
+  group: acme.com
+  names:
+    kind: Anvil
+    plural: anvils
+  scope: Cluster
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+`
+
+const anvil = `apiVersion: acme.com/v1
+kind: Anvil
+metadata:
+  name: heavy
+`
+
+const unknownKind = `apiVersion: acme.com/v1
+kind: Rocket
+metadata:
+  name: fast
+`
+
+func TestRunExplain(t *testing.T) {
+	testCases := []struct {
+		name         string
+		namespace    string
+		sourceFormat filesystem.SourceFormat
+		files        map[string]string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "root repo",
+			sourceFormat: filesystem.SourceFormatUnstructured,
+			files: map[string]string{
+				"cluster-role.yaml": clusterRole,
+				"role.yaml":         role,
+			},
+			want: "RESOURCE ID                                       SCOPE       DECLARED FIELDS\n" +
+				"_namespace_bookstore                              Cluster     UNKNOWN\n" +
+				"rbac.authorization.k8s.io_clusterrole_reader      Cluster     UNKNOWN\n" +
+				"rbac.authorization.k8s.io_role_bookstore_reader   Namespace   UNKNOWN\n",
+		},
+		{
+			name:         "declared custom resource",
+			sourceFormat: filesystem.SourceFormatUnstructured,
+			files: map[string]string{
+				"crd.yaml":   anvilCRD,
+				"anvil.yaml": anvil,
+			},
+			want: "RESOURCE ID                                                     SCOPE     DECLARED FIELDS\n" +
+				"acme.com_anvil_heavy                                            Cluster   UNKNOWN\n" +
+				"apiextensions.k8s.io_customresourcedefinition_anvils.acme.com   Cluster   UNKNOWN\n",
+		},
+		{
+			name:         "unknown kind",
+			sourceFormat: filesystem.SourceFormatUnstructured,
+			files: map[string]string{
+				"rocket.yaml": unknownKind,
+			},
+			want: "RESOURCE ID            SCOPE     DECLARED FIELDS\n" +
+				"acme.com_rocket_fast   Unknown   UNKNOWN\n",
+		},
+		{
+			name:      "namespace repo",
+			namespace: "bookstore",
+			files: map[string]string{
+				"role.yaml": role,
+			},
+			want: "RESOURCE ID                                       SCOPE       DECLARED FIELDS\n" +
+				"rbac.authorization.k8s.io_role_bookstore_reader   Namespace   UNKNOWN\n",
+		},
+		{
+			name:      "namespace repo with cluster-scoped object",
+			namespace: "bookstore",
+			files: map[string]string{
+				"cluster-role.yaml": clusterRole,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for path, content := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+			}
+			absDir, err := cmpath.AbsoluteOS(dir)
+			require.NoError(t, err)
+			absDir, err = absDir.EvalSymlinks()
+			require.NoError(t, err)
+			opts, err := offlineOptions(tc.namespace, tc.sourceFormat)
+			require.NoError(t, err)
+
+			out := &bytes.Buffer{}
+			err = runExplain(context.Background(), out, absDir, opts)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, out.String())
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/cmd/nomos/bugreport"
+	"kpt.dev/configsync/cmd/nomos/explain"
 	"kpt.dev/configsync/cmd/nomos/hydrate"
 	"kpt.dev/configsync/cmd/nomos/initialize"
 	"kpt.dev/configsync/cmd/nomos/migrate"
@@ -46,6 +47,7 @@ func init() {
 	rootCmd.AddCommand(initialize.Cmd)
 	rootCmd.AddCommand(hydrate.Cmd)
 	rootCmd.AddCommand(vet.Cmd)
+	rootCmd.AddCommand(explain.Cmd)
 	rootCmd.AddCommand(version.Cmd)
 	rootCmd.AddCommand(status.Cmd)
 	rootCmd.AddCommand(bugreport.Cmd)
@@ -321,6 +321,17 @@ func Generate() AllExamples {
 	// 1069
 	result.add(validate.SelfReconcileError(fake.RootSyncV1Beta1(configsync.RootSyncName)))
 
+	// 1070
+	result.add(validate.IllegalIgnoreMutationFieldsError(fake.DeploymentObject(),
+		errors.New(`field path "spec.replicas" must start with "."`)))
+
+	// 1071
+	result.add(validate.IllegalHandoffAnnotationError(fake.DeploymentObject(),
+		"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters"))
+
+	// 1072
+	result.add(hierarchyconfig.IllegalHierarchyConfigPlacementError(fake.HierarchyConfigAtPath("namespaces/foo/hc.yaml")))
+
 	// 2001
 	result.add(status.PathWrapError(errors.New("error creating directory"), "namespaces/foo"))
 
@@ -378,6 +389,27 @@ func Generate() AllExamples {
 	// 2017
 	result.add(selectors.ListNamespaceError(errors.New("k8s api List error")))
 
+	// 2018
+	result.add(status.SourceTimeoutError.Sprint("timed out fetching the Git repository").Build())
+
+	// 2019
+	result.add(status.SourceBranchNotFoundError.Sprint(`branch "main" does not exist in the Git repository`).Build())
+
+	// 2022
+	result.add(status.SourceAuthError.Sprint("failed to authenticate to the Git repository").Build())
+
+	// 2023
+	result.add(status.SourceUnreachableError.Sprint("failed to connect to the Git repository").Build())
+
+	// 2024
+	result.add(status.DeletionProtectedError(fake.ConfigMapObject(core.Name("config"), core.Namespace("bookstore"))))
+
+	// 2025
+	result.add(status.ObservedDriftError("update", fake.ConfigMapObject(core.Name("config"), core.Namespace("bookstore"))))
+
+	// 2026
+	result.add(status.RemediationPausedError("update", fake.ConfigMapObject(core.Name("config"), core.Namespace("bookstore"))))
+
 	// 9998
 	result.add(status.InternalError("we made a mistake"))
 
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/klog/v2/klogr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/oci"
@@ -47,6 +48,10 @@ var flSyncTimeout = flag.Int("timeout", util.EnvInt("OCI_SYNC_TIMEOUT", 120),
 	"the max number of seconds allowed for a complete sync")
 var flOneTime = flag.Bool("one-time", util.EnvBool("OCI_SYNC_ONE_TIME", false),
 	"exit after the first sync")
+var flClientCert = flag.String("client-cert", util.EnvString(reconcilermanager.OciClientCert, ""),
+	"the client certificate file to use for mutual TLS connections to the registry")
+var flClientKey = flag.String("client-key", util.EnvString(reconcilermanager.OciClientKey, ""),
+	"the client private key file to use for mutual TLS connections to the registry")
 var flMaxSyncFailures = flag.Int("max-sync-failures", util.EnvInt("OCI_SYNC_MAX_SYNC_FAILURES", 0),
 	"the number of consecutive failures allowed before aborting (the first sync must succeed, -1 will retry forever after the initial sync)")
 
@@ -57,7 +62,8 @@ func main() {
 	log.Info("pulling OCI image with arguments", "--image", *flImage,
 		"--auth", *flAuth, "--root", *flRoot, "--dest", *flDest, "--wait", *flWait,
 		"--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
-		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
+		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures,
+		"--client-cert", *flClientCert, "--client-key", *flClientKey)
 
 	if *flImage == "" {
 		utillog.HandleError(log, true, "ERROR: --image must be specified")
@@ -80,11 +86,20 @@ func main() {
 		utillog.HandleError(log, true, "ERROR: --timeout must be greater than 0")
 	}
 
+	if (*flClientCert == "") != (*flClientKey == "") {
+		utillog.HandleError(log, true, "ERROR: --client-cert and --client-key must be specified together")
+	}
+
+	var remoteOptions []remote.Option
+	if *flClientCert != "" {
+		remoteOptions = append(remoteOptions, remote.WithTransport(oci.ClientCertTransport(*flClientCert, *flClientKey)))
+	}
+
 	initialSync := true
 	failCount := 0
 	for {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(*flSyncTimeout))
-		if err := oci.FetchPackage(ctx, log, *flAuth, *flImage, *flRoot, *flDest); err != nil {
+		if err := oci.FetchPackage(ctx, log, *flAuth, *flImage, *flRoot, *flDest, remoteOptions...); err != nil {
 			if *flMaxSyncFailures != -1 && failCount >= *flMaxSyncFailures {
 				// Exit after too many retries, maybe the error is not recoverable.
 				log.Error(err, "too many failures, aborting", "failCount", failCount)
@@ -24,7 +24,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/klog/v2/klogr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
@@ -33,6 +32,7 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/util/log"
+	"kpt.dev/configsync/pkg/util/mutate"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	// +kubebuilder:scaffold:imports
@@ -50,6 +50,18 @@ var (
 		controllers.PollingPeriod(reconcilermanager.HydrationPollingPeriod, configsync.DefaultHydrationPollingPeriod),
 		"Period of time between checking the filesystem for source updates to render.")
 
+	statusConflictThreshold = flag.Int("status-conflict-threshold", controllers.DefaultStatusConflictThreshold,
+		"The number of conflicts updating the status of a RootSync or RepoSync within the status-conflict-window "+
+			"which are reported as a StatusContention condition. Zero disables the detection.")
+	statusConflictWindow = flag.Duration("status-conflict-window", controllers.DefaultStatusConflictWindow,
+		"The period of time over which the conflicts updating the status of a RootSync or RepoSync are counted.")
+
+	logSamplingInitial = flag.Int("log-sampling-initial", 0,
+		"The number of info logs with the same verbosity and message written per second before sampling starts. "+
+			"Zero disables sampling. Error logs are never sampled. Only the controller logs are sampled.")
+	logSamplingThereafter = flag.Int("log-sampling-thereafter", 0,
+		"When sampling, write only one in every N info logs with the same verbosity and message after the initial logs. Zero drops them all.")
+
 	setupLog = ctrl.Log.WithName("setup")
 )
 
@@ -63,7 +75,13 @@ func main() {
 
 	log.Setup()
 	profiler.Service()
-	ctrl.SetLogger(klogr.New())
+	if err := log.SetFormat(log.FormatText, log.Sampling{
+		Initial:    *logSamplingInitial,
+		Thereafter: *logSamplingThereafter,
+	}); err != nil {
+		setupLog.Error(err, "failed to set up logging")
+		os.Exit(1)
+	}
 
 	setupLog.Info(fmt.Sprintf("running with flags --cluster-name=%s; --reconciler-polling-period=%s; --hydration-polling-period=%s",
 		*clusterName, *reconcilerPollingPeriod, *hydrationPollingPeriod))
@@ -104,6 +122,7 @@ func main() {
 
 	repoSyncController := controllers.NewRepoSyncReconciler(*clusterName,
 		*reconcilerPollingPeriod, *hydrationPollingPeriod,
+		mutate.NewConflictDetector(*statusConflictThreshold, *statusConflictWindow),
 		mgr.GetClient(), watcher, dynamicClient,
 		ctrl.Log.WithName("controllers").WithName(configsync.RepoSyncKind),
 		mgr.GetScheme())
@@ -118,6 +137,7 @@ func main() {
 
 	rootSyncController := controllers.NewRootSyncReconciler(*clusterName,
 		*reconcilerPollingPeriod, *hydrationPollingPeriod,
+		mutate.NewConflictDetector(*statusConflictThreshold, *statusConflictWindow),
 		mgr.GetClient(), watcher, dynamicClient,
 		ctrl.Log.WithName("controllers").WithName(configsync.RootSyncKind),
 		mgr.GetScheme())
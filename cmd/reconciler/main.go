@@ -19,23 +19,25 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/klogr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	ocmetrics "kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/parse"
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/reconciler"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/remediator/watch"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util"
 	"kpt.dev/configsync/pkg/util/log"
-	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var (
@@ -45,6 +47,8 @@ var (
 		"Scope of the reconciler, either a namespace or ':root'.")
 	syncName = flag.String("sync-name", os.Getenv(reconcilermanager.SyncNameKey),
 		"Name of the RootSync or RepoSync object.")
+	syncGeneration = flag.Int64("sync-generation", int64(util.EnvInt(reconcilermanager.SyncGenerationKey, 0)),
+		"Generation of the RootSync or RepoSync object.")
 	reconcilerName = flag.String("reconciler-name", os.Getenv(reconcilermanager.ReconcilerNameKey),
 		"Name of the reconciler Deployment.")
 
@@ -60,6 +64,11 @@ var (
 		"The reference we're syncing to in the repo. Could be a specific commit or a chart version.")
 	syncDir = flag.String("sync-dir", os.Getenv(reconcilermanager.SyncDirKey),
 		"The relative path of the root configuration directory within the repo.")
+	syncDirs = flag.String("sync-dirs", os.Getenv(reconcilermanager.SyncDirsKey),
+		"A comma-separated, ordered list of relative paths of configuration directories within the repo, synced as a union. Overrides --sync-dir.")
+	dirsCollisionPolicy = flag.String("dirs-collision-policy", util.EnvString(reconcilermanager.DirsCollisionPolicy, ""),
+		fmt.Sprintf("Set how the reconciler handles an object declared in more than one of the sync directories. Must be %s or %s. Default: %s.",
+			configsync.DirsCollisionOverride, configsync.DirsCollisionError, configsync.DirsCollisionOverride))
 
 	// Performance tuning flags.
 	sourceDir = flag.String(flags.sourceDir, "/repo/source/rev",
@@ -73,13 +82,68 @@ var (
 	fightDetectionThreshold = flag.Float64(
 		"fight-detection-threshold", 5.0,
 		"The rate of updates per minute to an API Resource at which the Syncer logs warnings about too many updates to the resource.")
-	resyncPeriod = flag.Duration("resync-period", configsync.DefaultReconcilerResyncPeriod,
+	frequentEditThreshold = flag.Float64(
+		"frequent-edit-threshold", 2.0,
+		"The rate of manual edits per minute to a managed object at which the object is reported as frequently edited in the sync status. Zero disables the reporting.")
+	watchJitterFactor = flag.Float64(
+		"watch-jitter-factor", watch.DefaultJitterFactor,
+		"The maximum fraction by which the remediator watch timeouts and retry delays are randomly extended, to avoid many reconcilers restarting their watches at the same time.")
+	resyncPeriod = flag.Duration("resync-period",
+		controllers.PollingPeriod(reconcilermanager.ResyncPeriod, configsync.DefaultReconcilerResyncPeriod),
 		"Period of time between forced re-syncs from source (even without a new commit).")
+	statusUpdatePeriod = flag.Duration("status-update-period",
+		controllers.PollingPeriod(reconcilermanager.StatusUpdatePeriod, configsync.DefaultReconcilerSyncStatusUpdatePeriod),
+		"Period of time between the periodic updates of the sync status. A random jitter of up to 10% is added to each period.")
+	renderingTimeout = flag.Duration("rendering-timeout",
+		controllers.PollingPeriod(reconcilermanager.RenderingTimeout, 0),
+		"How long to wait for the rendering of a new commit before reporting the rendering as failed. Zero waits indefinitely.")
+	circuitBreakerThreshold = flag.Int("circuit-breaker-threshold", util.EnvInt(reconcilermanager.CircuitBreakerThreshold, 0),
+		"The number of consecutive syncs failing because the API server is unavailable after which syncing is paused, and only resumed once a periodic probe succeeds. Zero disables the circuit breaker.")
+	circuitBreakerProbePeriod = flag.Duration("circuit-breaker-probe-period",
+		controllers.PollingPeriod(reconcilermanager.CircuitBreakerProbePeriod, 30*time.Second),
+		"The initial period between two probes while syncing is paused by the circuit breaker. It doubles after each failed probe, up to 10m.")
 	workers = flag.Int("workers", 1,
 		"Number of concurrent remediator workers to run at once.")
+	readConcurrency = flag.Int("read-concurrency", 1,
+		"Number of source files to read and decode at once.")
+	discoveryCacheTTL = flag.Duration("discovery-cache-ttl", 0,
+		"How long to reuse the API resources discovered by the parser across parses. They are discovered again earlier if the source uses an unknown type. Zero discovers them on every parse.")
 	pollingPeriod = flag.Duration("filesystem-polling-period",
 		controllers.PollingPeriod(reconcilermanager.ReconcilerPollingPeriod, configsync.DefaultReconcilerPollingPeriod),
 		"Period of time between checking the filesystem for source updates to sync.")
+	applyDebouncePeriod = flag.Duration("apply-debounce-period",
+		controllers.PollingPeriod(reconcilermanager.ApplyDebouncePeriod, 0),
+		"Period of time to wait after detecting a new commit before applying it, to coalesce rapid commits. Zero applies every commit immediately.")
+	minRemediationInterval = flag.Duration("min-remediation-interval",
+		controllers.PollingPeriod(reconcilermanager.MinRemediationInterval, 0),
+		"Minimum period of time between two corrections of the same object by the remediator.")
+	applyCallTimeout = flag.Duration("apply-call-timeout",
+		controllers.PollingPeriod(reconcilermanager.ApplyCallTimeout, 0),
+		"The timeout of each individual apply, patch, or delete call. Calls which time out are retried.")
+	fieldManager = flag.String("field-manager", util.EnvString(reconcilermanager.FieldManager, configsync.FieldManager),
+		"The field manager name used to apply the managed objects with server-side apply.")
+	requiredMetadata = flag.String("required-metadata", util.EnvString(reconcilermanager.RequiredMetadata, ""),
+		"A comma-separated list of <type>:<key> pairs of the labels and annotations which every managed object must have. The type must be label or annotation.")
+	annotateSyncGeneration = flag.Bool("annotate-sync-generation", util.EnvBool(reconcilermanager.AnnotateSyncGeneration, false),
+		"Whether to annotate the managed objects with the generation of the RootSync or RepoSync object.")
+	pauseApply = flag.Bool("pause-apply", util.EnvBool(reconcilermanager.PauseApply, false),
+		"Whether to pause applying the resources from the source, while still reporting drifted objects.")
+	maxPruneCount = flag.Int("max-prune-count", util.EnvInt(reconcilermanager.MaxPruneCount, 0),
+		"The maximum number of objects to prune in one sync without confirmation. Zero prunes any number of objects.")
+	validateWithAdmission = flag.Bool("validate-with-admission", util.EnvBool(reconcilermanager.ValidateWithAdmission, false),
+		"Whether to dry-run apply the objects from the source through the admission chain, and report the rejected objects as source errors, before applying.")
+	remediatorExcludedKinds = flag.String("remediator-excluded-kinds", util.EnvString(reconcilermanager.RemediatorExcludedKinds, ""),
+		"A comma-separated list of <kind>.<group> resource types not to watch for drift. Objects of these types are still applied.")
+	objectSelector = flag.String("object-selector", util.EnvString(reconcilermanager.ObjectSelector, ""),
+		"A label selector which limits the objects from the source to apply. Objects which do not match are ignored. Empty applies all the objects.")
+	substituteClusterName = flag.Bool("substitute-cluster-name", util.EnvBool(reconcilermanager.SubstituteClusterName, false),
+		"Whether to replace the ${CLUSTER_NAME} token in the string field values of the objects from the source with the cluster name.")
+	webhookEnabled = flag.Bool("webhook-enabled", util.EnvBool(reconcilermanager.WebhookEnabled, false),
+		"Whether the Config Sync admission webhook is enabled, protecting the declared fields of the managed objects.")
+	healthProbePort = flag.Int("health-probe-port", configsync.DefaultReconcilerHealthProbePort,
+		"The port of the /readyz endpoint, which reports ready once the latest source commit is synced without blocking errors. Zero disables the endpoint.")
+	leaderElection = flag.Bool("leader-election", util.EnvBool(reconcilermanager.LeaderElection, false),
+		"Whether to use leader election, so that only one of the reconciler replicas is active at a time.")
 
 	// Root-Repo-only flags. If set for a Namespace-scoped Reconciler, causes the Reconciler to fail immediately.
 	sourceFormat = flag.String(flags.sourceFormat, os.Getenv(filesystem.SourceFormatKey),
@@ -88,10 +152,24 @@ var (
 	reconcileTimeout = flag.String(flags.reconcileTimeout, os.Getenv(reconcilermanager.ReconcileTimeout), "The timeout of applier reconcile and prune tasks")
 	// Enable the applier to inject actuation status data into the ResourceGroup object
 	statusMode = flag.String(flags.statusMode, os.Getenv(reconcilermanager.StatusMode),
-		"When the value is enabled or empty, the applier injects actuation status data into the ResourceGroup object")
+		"When the value is enabled, the applier injects actuation status data into the ResourceGroup object. When the value is timestamps, it also records when each object was last applied or pruned")
 
 	apiServerTimeout = flag.String("api-server-timeout", os.Getenv(reconcilermanager.APIServerTimeout), "The client-side timeout for requests to the API server")
 
+	clientQPS = flag.Float64("client-qps", util.EnvFloat(reconcilermanager.ClientQPS, 0),
+		"The client-side throttling QPS for requests to the API server. Zero keeps the default, which disables client-side throttling when the API server has flow control enabled. "+
+			"A higher value speeds up large syncs, at the cost of more load on the API server.")
+	clientBurst = flag.Int("client-burst", util.EnvInt(reconcilermanager.ClientBurst, 0),
+		"The client-side throttling burst for requests to the API server. Only used with a positive --client-qps. Zero defaults to twice --client-qps.")
+
+	logFormat = flag.String("log-format", log.FormatText,
+		fmt.Sprintf("The format of the logs, either %q or %q. With %q, klog output is also written as JSON.", log.FormatText, log.FormatJSON, log.FormatJSON))
+	logSamplingInitial = flag.Int("log-sampling-initial", 0,
+		"The number of info logs with the same verbosity and message written per second before sampling starts. "+
+			"Zero disables sampling. Error logs are never sampled. With the text log format, only the controller logs are sampled.")
+	logSamplingThereafter = flag.Int("log-sampling-thereafter", 0,
+		"When sampling, write only one in every N info logs with the same verbosity and message after the initial logs. Zero drops them all.")
+
 	debug = flag.Bool("debug", false,
 		"Enable debug mode, panicking in many scenarios where normally an InternalError would be logged. "+
 			"Do not use in production.")
@@ -100,8 +178,26 @@ var (
 	namespaceStrategy = flag.String(flags.namespaceStrategy, util.EnvString(reconcilermanager.NamespaceStrategy, ""),
 		fmt.Sprintf("Set the namespace strategy for the reconciler. Must be %s or %s. Default: %s.",
 			configsync.NamespaceStrategyImplicit, configsync.NamespaceStrategyExplicit, configsync.NamespaceStrategyImplicit))
+	ambiguousSourceFormat = flag.String(flags.ambiguousSourceFormat, util.EnvString(reconcilermanager.AmbiguousSourceFormat, ""),
+		fmt.Sprintf("Set how the reconciler handles a source which signals a different source format. Must be %s or %s. Default: %s.",
+			configsync.AmbiguousSourceFormatError, configsync.AmbiguousSourceFormatWarn, configsync.AmbiguousSourceFormatWarn))
+	namespaceMismatchPolicy = flag.String(flags.namespaceMismatchPolicy, util.EnvString(reconcilermanager.NamespaceMismatchPolicy, ""),
+		fmt.Sprintf("Set how the reconciler handles an object whose metadata.namespace differs from its directory. Must be %s or %s. Default: %s.",
+			configsync.NamespaceMismatchError, configsync.NamespaceMismatchCorrect, configsync.NamespaceMismatchError))
+	conflictPolicy = flag.String(flags.conflictPolicy, util.EnvString(reconcilermanager.ConflictPolicy, ""),
+		fmt.Sprintf("Set which objects managed by another RootSync or RepoSync the reconciler may adopt. Must be %s or %s. Default: %s.",
+			configsync.ConflictPolicyAdoptAll, configsync.ConflictPolicyAdoptIfNoInventory, configsync.ConflictPolicyAdoptAll))
+	syncMode = flag.String(flags.syncMode, util.EnvString(reconcilermanager.SyncMode, ""),
+		fmt.Sprintf("Set whether the reconciler applies the resources from the source, or only observes them. Must be %s or %s. Default: %s.",
+			configsync.SyncModeSync, configsync.SyncModeObserve, configsync.SyncModeSync))
 
 	dynamicNSSelectorEnabled = flag.Bool("dynamic-ns-selector-enabled", util.EnvBool(reconcilermanager.DynamicNSSelectorEnabled, false), "")
+	dynamicNamespaceSelector = flag.Bool("dynamic-namespace-selector", util.EnvBool(reconcilermanager.DynamicNamespaceSelector, false),
+		"Whether NamespaceSelectors which do not set a mode use the dynamic mode. Only applies to the root reconciler.")
+	deferUnestablishedCRs = flag.Bool("defer-unestablished-crs", util.EnvBool(reconcilermanager.DeferUnestablishedCRs, false),
+		"Whether to defer applying custom resources whose CRD is not yet established until a later sync. Only applies to the root reconciler.")
+	clusterScopedConflictCheck = flag.Bool("cluster-scoped-conflict-check", util.EnvBool(reconcilermanager.ClusterScopedConflictCheck, false),
+		"Whether to reject the cluster-scoped objects which are also declared by another RootSync. Only applies to the root reconciler.")
 )
 
 var flags = struct {
@@ -113,6 +209,11 @@ var flags = struct {
 	statusMode        string
 	reconcileTimeout  string
 	namespaceStrategy string
+
+	ambiguousSourceFormat   string
+	namespaceMismatchPolicy string
+	conflictPolicy          string
+	syncMode                string
 }{
 	repoRootDir:       "repo-root",
 	sourceDir:         "source-dir",
@@ -122,12 +223,22 @@ var flags = struct {
 	statusMode:        "status-mode",
 	reconcileTimeout:  "reconcile-timeout",
 	namespaceStrategy: "namespace-strategy",
+
+	ambiguousSourceFormat:   "ambiguous-source-format",
+	namespaceMismatchPolicy: "namespace-mismatch-policy",
+	conflictPolicy:          "conflict-policy",
+	syncMode:                "sync-mode",
 }
 
 func main() {
 	log.Setup()
+	if err := log.SetFormat(*logFormat, log.Sampling{
+		Initial:    *logSamplingInitial,
+		Thereafter: *logSamplingThereafter,
+	}); err != nil {
+		klog.Fatal(err)
+	}
 	profiler.Service()
-	ctrl.SetLogger(klogr.New())
 
 	if *debug {
 		status.EnablePanicOnMisuse()
@@ -161,6 +272,17 @@ func main() {
 	// expected.
 	dir := strings.TrimPrefix(*syncDir, "/")
 	relSyncDir := cmpath.RelativeOS(dir)
+	var relSyncDirs []cmpath.Relative
+	if *syncDirs != "" {
+		for _, d := range strings.Split(*syncDirs, ",") {
+			relSyncDirs = append(relSyncDirs, cmpath.RelativeOS(strings.TrimPrefix(d, "/")))
+		}
+	}
+	// Default to "override" if unset.
+	dirsCollisionPol := configsync.DirsCollisionPolicy(*dirsCollisionPolicy)
+	if dirsCollisionPol == "" {
+		dirsCollisionPol = configsync.DirsCollisionOverride
+	}
 	absSourceDir, err := cmpath.AbsoluteOS(*sourceDir)
 	if err != nil {
 		klog.Fatalf("%s must be an absolute path: %v", flags.sourceDir, err)
@@ -171,15 +293,51 @@ func main() {
 		klog.Fatal(err)
 	}
 
+	required, err := parse.ParseRequiredMetadata(*requiredMetadata)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	excludedKinds, err := parse.ParseRemediatorExcludedKinds(*remediatorExcludedKinds)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	selector, err := labels.Parse(*objectSelector)
+	if err != nil {
+		klog.Fatalf("Invalid object selector %q: %v", *objectSelector, err)
+	}
+
 	opts := reconciler.Options{
 		ClusterName:              *clusterName,
 		FightDetectionThreshold:  *fightDetectionThreshold,
+		FrequentEditThreshold:    *frequentEditThreshold,
+		WatchJitterFactor:        *watchJitterFactor,
 		NumWorkers:               *workers,
+		ReadConcurrency:          *readConcurrency,
+		DiscoveryCacheTTL:        *discoveryCacheTTL,
+		MinRemediationInterval:   *minRemediationInterval,
+		ApplyCallTimeout:         *applyCallTimeout,
+		FieldManager:             *fieldManager,
+		RequiredMetadata:         required,
+		AnnotateSyncGeneration:   *annotateSyncGeneration,
+		PauseApply:               *pauseApply,
+		MaxPruneCount:            *maxPruneCount,
+		ValidateWithAdmission:    *validateWithAdmission,
+		RemediatorExcludedKinds:  excludedKinds,
+		ObjectSelector:           selector,
+		SubstituteClusterName:    *substituteClusterName,
+		WebhookEnabled:           *webhookEnabled,
+		LeaderElection:           *leaderElection,
+		HealthProbePort:          *healthProbePort,
 		ReconcilerScope:          declared.Scope(*scope),
 		ResyncPeriod:             *resyncPeriod,
 		PollingPeriod:            *pollingPeriod,
+		ApplyDebouncePeriod:      *applyDebouncePeriod,
 		RetryPeriod:              configsync.DefaultReconcilerRetryPeriod,
-		StatusUpdatePeriod:       configsync.DefaultReconcilerSyncStatusUpdatePeriod,
+		StatusUpdatePeriod:       *statusUpdatePeriod,
+		RenderingTimeout:         *renderingTimeout,
+		CircuitBreaker:           parse.CircuitBreakerOptions{Threshold: *circuitBreakerThreshold, ProbePeriod: *circuitBreakerProbePeriod},
 		SourceRoot:               absSourceDir,
 		RepoRoot:                 absRepoRoot,
 		HydratedRoot:             *hydratedRootDir,
@@ -189,11 +347,16 @@ func main() {
 		SourceType:               v1beta1.SourceType(*sourceType),
 		SourceRepo:               *sourceRepo,
 		SyncDir:                  relSyncDir,
+		SyncDirs:                 relSyncDirs,
+		DirsCollisionPolicy:      dirsCollisionPol,
 		SyncName:                 *syncName,
+		SyncGeneration:           *syncGeneration,
 		ReconcilerName:           *reconcilerName,
 		StatusMode:               *statusMode,
 		ReconcileTimeout:         *reconcileTimeout,
 		APIServerTimeout:         *apiServerTimeout,
+		ClientQPS:                float32(*clientQPS),
+		ClientBurst:              *clientBurst,
 		RenderingEnabled:         *renderingEnabled,
 		DynamicNSSelectorEnabled: *dynamicNSSelectorEnabled,
 	}
@@ -209,11 +372,38 @@ func main() {
 		if nsStrat == "" {
 			nsStrat = configsync.NamespaceStrategyImplicit
 		}
+		// Default to "warn" if unset.
+		ambiguousFormat := configsync.AmbiguousSourceFormatPolicy(*ambiguousSourceFormat)
+		if ambiguousFormat == "" {
+			ambiguousFormat = configsync.AmbiguousSourceFormatWarn
+		}
+		// Default to "error" if unset.
+		nsMismatchPolicy := configsync.NamespaceMismatchPolicy(*namespaceMismatchPolicy)
+		if nsMismatchPolicy == "" {
+			nsMismatchPolicy = configsync.NamespaceMismatchError
+		}
+		// Default to "adoptAll" if unset.
+		conflictPol := configsync.ConflictPolicy(*conflictPolicy)
+		if conflictPol == "" {
+			conflictPol = configsync.ConflictPolicyAdoptAll
+		}
+		// Default to "sync" if unset.
+		mode := configsync.SyncMode(*syncMode)
+		if mode == "" {
+			mode = configsync.SyncModeSync
+		}
 
 		klog.Info("Starting reconciler for: root")
 		opts.RootOptions = &reconciler.RootOptions{
-			SourceFormat:      format,
-			NamespaceStrategy: nsStrat,
+			SourceFormat:               format,
+			NamespaceStrategy:          nsStrat,
+			AmbiguousSourceFormat:      ambiguousFormat,
+			NamespaceMismatchPolicy:    nsMismatchPolicy,
+			ConflictPolicy:             conflictPol,
+			SyncMode:                   mode,
+			DynamicNamespaceSelector:   *dynamicNamespaceSelector,
+			DeferUnestablishedCRs:      *deferUnestablishedCRs,
+			ClusterScopedConflictCheck: *clusterScopedConflictCheck,
 		}
 	} else {
 		klog.Infof("Starting reconciler for: %s", *scope)
@@ -226,6 +416,22 @@ func main() {
 			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
 				flags.namespaceStrategy, reconcilermanager.NamespaceStrategy)
 		}
+		if *ambiguousSourceFormat != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				flags.ambiguousSourceFormat, reconcilermanager.AmbiguousSourceFormat)
+		}
+		if *namespaceMismatchPolicy != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				flags.namespaceMismatchPolicy, reconcilermanager.NamespaceMismatchPolicy)
+		}
+		if *conflictPolicy != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				flags.conflictPolicy, reconcilermanager.ConflictPolicy)
+		}
+		if *syncMode != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				flags.syncMode, reconcilermanager.SyncMode)
+		}
 	}
 	reconciler.Run(opts)
 }
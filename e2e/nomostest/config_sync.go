@@ -822,6 +822,45 @@ func RepoSyncObjectV1Beta1(nn types.NamespacedName, repoURL string, sourceFormat
 	return rs
 }
 
+// RepoSyncObjectV1Beta1Oci returns the default RepoSync object
+// with version v1beta1 in the given namespace, which syncs the given OCI image.
+func RepoSyncObjectV1Beta1Oci(nn types.NamespacedName, image string, sourceFormat filesystem.SourceFormat) *v1beta1.RepoSync {
+	rs := fake.RepoSyncObjectV1Beta1(nn.Namespace, nn.Name)
+	rs.Spec.SourceFormat = string(sourceFormat)
+	rs.Spec.SourceType = string(v1beta1.OciSource)
+	rs.Spec.Oci = &v1beta1.Oci{
+		Image: image,
+		Dir:   gitproviders.DefaultSyncDir,
+		Auth:  configsync.AuthNone,
+	}
+	// Enable automatic deletion of managed objects by default.
+	// This helps ensure that test artifacts are cleaned up.
+	EnableDeletionPropagation(rs)
+	return rs
+}
+
+// RepoSyncObjectV1Beta1Helm returns the default RepoSync object
+// with version v1beta1 in the given namespace, which syncs the given chart
+// version from the Helm repository.
+func RepoSyncObjectV1Beta1Helm(nn types.NamespacedName, repoURL, chart, version string) *v1beta1.RepoSync {
+	rs := fake.RepoSyncObjectV1Beta1(nn.Namespace, nn.Name)
+	rs.Spec.SourceType = string(v1beta1.HelmSource)
+	rs.Spec.Helm = &v1beta1.HelmRepoSync{
+		HelmBase: v1beta1.HelmBase{
+			Repo:    repoURL,
+			Chart:   chart,
+			Version: version,
+			Auth:    configsync.AuthNone,
+			// Override the default period of 1 hour for responsiveness
+			Period: metav1.Duration{Duration: helmPeriodOverride},
+		},
+	}
+	// Enable automatic deletion of managed objects by default.
+	// This helps ensure that test artifacts are cleaned up.
+	EnableDeletionPropagation(rs)
+	return rs
+}
+
 // RootSyncObjectOCI returns a RootSync object that syncs the provided OCIImage.
 func (nt *NT) RootSyncObjectOCI(name string, image *registryproviders.OCIImage) *v1beta1.RootSync {
 	rs := RootSyncObjectV1Beta1FromRootRepo(nt, name)
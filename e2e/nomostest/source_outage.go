@@ -0,0 +1,176 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomostest
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/e2e"
+	"kpt.dev/configsync/e2e/nomostest/retry"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+// fetchRetryMessage is logged by the git-sync, oci-sync and helm-sync
+// containers each time a fetch fails.
+const fetchRetryMessage = "will retry"
+
+// SourceOutage simulates an outage of an in-cluster source server, so that
+// the git-sync, oci-sync and helm-sync containers fail to fetch from it.
+//
+// The outage is simulated by pointing the server Services at a selector that
+// matches no Pods. Unlike deleting the server, this leaves the Pods and their
+// ephemeral repositories intact, and does not interrupt the port forwarders
+// that the tests use to push to the server.
+type SourceOutage struct {
+	nt         *NT
+	services   []*corev1.Service
+	reconciler string
+	container  string
+	// failures is the number of failed fetches after which the outage ends.
+	failures int
+	// baseline is the number of failed fetches logged before the outage.
+	baseline int
+	stopped  bool
+}
+
+// StartSourceOutage starts an outage of the in-cluster server that hosts the
+// given source type, which lasts for the given number of failed fetches by the
+// reconciler. Tests can assert the expected source error is surfaced, then
+// call Wait to end the outage once the fetches have failed, and wait for the
+// reconciler to recover. The outage is stopped automatically when the test
+// completes.
+//
+// Only the local providers are supported, since the outage relies on
+// modifying the in-cluster test servers.
+func (nt *NT) StartSourceOutage(reconcilerName string, sourceType v1beta1.SourceType, failures int) (*SourceOutage, error) {
+	var namespace, container string
+	var serviceNames []string
+	switch sourceType {
+	case v1beta1.GitSource:
+		if *e2e.GitProvider != e2e.Local {
+			return nil, fmt.Errorf("source outage requires the %s git provider, got %s", e2e.Local, *e2e.GitProvider)
+		}
+		namespace = testGitNamespace
+		serviceNames = []string{testGitServer}
+		container = reconcilermanager.GitSync
+	case v1beta1.OciSource:
+		if *e2e.OCIProvider != e2e.Local {
+			return nil, fmt.Errorf("source outage requires the %s oci provider, got %s", e2e.Local, *e2e.OCIProvider)
+		}
+		namespace = TestRegistryNamespace
+		serviceNames = []string{TestRegistryServer, TestRegistryServerAuthenticated}
+		container = reconcilermanager.OciSync
+	case v1beta1.HelmSource:
+		if *e2e.HelmProvider != e2e.Local {
+			return nil, fmt.Errorf("source outage requires the %s helm provider, got %s", e2e.Local, *e2e.HelmProvider)
+		}
+		namespace = TestRegistryNamespace
+		serviceNames = []string{TestRegistryServer, TestRegistryServerAuthenticated}
+		container = reconcilermanager.HelmSync
+	default:
+		return nil, fmt.Errorf("unsupported source type for source outage: %s", sourceType)
+	}
+	if failures < 1 {
+		return nil, fmt.Errorf("source outage requires at least one failure, got %d", failures)
+	}
+
+	outage := &SourceOutage{
+		nt:         nt,
+		reconciler: reconcilerName,
+		container:  container,
+		failures:   failures,
+	}
+	baseline, err := outage.failedFetches()
+	if err != nil {
+		return nil, err
+	}
+	outage.baseline = baseline
+	nt.T.Cleanup(func() {
+		if err := outage.Stop(); err != nil {
+			nt.T.Error(err)
+		}
+	})
+	for _, name := range serviceNames {
+		service := &corev1.Service{}
+		if err := nt.KubeClient.Get(name, namespace, service); err != nil {
+			return outage, err
+		}
+		original := service.DeepCopy()
+		service.Spec.Selector = map[string]string{"app": name + "-outage"}
+		if err := nt.KubeClient.Update(service); err != nil {
+			return outage, err
+		}
+		outage.services = append(outage.services, original)
+		nt.T.Logf("Started source outage for Service %s", core.ObjectNamespacedName(service))
+	}
+	return outage, nil
+}
+
+// Wait waits until the reconciler has failed to fetch the number of times the
+// outage was started with, and then ends the outage.
+func (o *SourceOutage) Wait() error {
+	_, err := retry.Retry(o.nt.DefaultWaitTimeout, func() error {
+		count, err := o.failedFetches()
+		if err != nil {
+			return err
+		}
+		if failed := count - o.baseline; failed < o.failures {
+			return fmt.Errorf("%s container of %s failed to fetch %d times, want %d",
+				o.container, o.reconciler, failed, o.failures)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return o.Stop()
+}
+
+// failedFetches returns the number of failed fetches logged by the sync
+// container of the reconciler.
+func (o *SourceOutage) failedFetches() (int, error) {
+	out, err := o.nt.Shell.Kubectl("logs", fmt.Sprintf("deployment/%s", o.reconciler),
+		"-n", configmanagement.ControllerNamespace, "-c", o.container)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the %s container logs of %s: %v\n%s", o.container, o.reconciler, err, out)
+	}
+	return strings.Count(string(out), fetchRetryMessage), nil
+}
+
+// Stop ends the outage by restoring the original selectors of the server
+// Services. It is a no-op if the outage has already been stopped.
+func (o *SourceOutage) Stop() error {
+	if o.stopped {
+		return nil
+	}
+	for _, original := range o.services {
+		service := &corev1.Service{}
+		if err := o.nt.KubeClient.Get(original.Name, original.Namespace, service); err != nil {
+			return err
+		}
+		service.Spec.Selector = original.Spec.Selector
+		if err := o.nt.KubeClient.Update(service); err != nil {
+			return err
+		}
+		o.nt.T.Logf("Stopped source outage for Service %s", core.ObjectNamespacedName(service))
+	}
+	o.stopped = true
+	return nil
+}
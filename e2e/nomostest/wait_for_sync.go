@@ -40,6 +40,7 @@ import (
 
 type watchForAllSyncsOptions struct {
 	timeout            time.Duration
+	stableDuration     time.Duration
 	readyCheck         bool
 	syncRootRepos      bool
 	syncNamespaceRepos bool
@@ -60,6 +61,16 @@ func WithTimeout(timeout time.Duration) WatchForAllSyncsOptions {
 	}
 }
 
+// WithStableDuration specifies how long each RootSync|RepoSync must remain
+// synced after it is first observed synced, to make WaitForRepoSyncs wait for
+// the syncs to be stable, not just momentarily reconciled.
+// The stable duration is not included in the timeout.
+func WithStableDuration(duration time.Duration) WatchForAllSyncsOptions {
+	return func(options *watchForAllSyncsOptions) {
+		options.stableDuration = duration
+	}
+}
+
 // Sha1Func is the function type that retrieves the commit sha1.
 type Sha1Func func(nt *NT, nn types.NamespacedName) (string, error)
 
@@ -178,10 +189,15 @@ func (nt *NT) WatchForAllSyncs(options ...WatchForAllSyncsOptions) error {
 			nn := RootSyncNN(name)
 			syncDir := syncDirectory(waitForRepoSyncsOptions.syncDirectoryMap, nn)
 			tg.Go(func() error {
-				return nt.WatchForSync(kinds.RootSyncV1Beta1(), nn.Name, nn.Namespace,
-					waitForRepoSyncsOptions.rootSha1Fn, RootSyncHasStatusSyncCommit,
-					&SyncDirPredicatePair{Dir: syncDir, Predicate: RootSyncHasStatusSyncDirectory},
-					testwatcher.WatchTimeout(waitForRepoSyncsOptions.timeout))
+				syncDirPair := &SyncDirPredicatePair{Dir: syncDir, Predicate: RootSyncHasStatusSyncDirectory}
+				if err := nt.WatchForSync(kinds.RootSyncV1Beta1(), nn.Name, nn.Namespace,
+					waitForRepoSyncsOptions.rootSha1Fn, RootSyncHasStatusSyncCommit, syncDirPair,
+					testwatcher.WatchTimeout(waitForRepoSyncsOptions.timeout)); err != nil {
+					return err
+				}
+				return nt.validateSyncStable(kinds.RootSyncV1Beta1(), nn.Name, nn.Namespace,
+					waitForRepoSyncsOptions.rootSha1Fn, RootSyncHasStatusSyncCommit, syncDirPair,
+					waitForRepoSyncsOptions.stableDuration)
 			})
 		}
 	}
@@ -194,10 +210,15 @@ func (nt *NT) WatchForAllSyncs(options ...WatchForAllSyncsOptions) error {
 			syncDir := syncDirectory(waitForRepoSyncsOptions.syncDirectoryMap, nn)
 			nnPtr := nn
 			tg.Go(func() error {
-				return nt.WatchForSync(kinds.RepoSyncV1Beta1(), nnPtr.Name, nnPtr.Namespace,
-					waitForRepoSyncsOptions.repoSha1Fn, RepoSyncHasStatusSyncCommit,
-					&SyncDirPredicatePair{Dir: syncDir, Predicate: RepoSyncHasStatusSyncDirectory},
-					testwatcher.WatchTimeout(waitForRepoSyncsOptions.timeout))
+				syncDirPair := &SyncDirPredicatePair{Dir: syncDir, Predicate: RepoSyncHasStatusSyncDirectory}
+				if err := nt.WatchForSync(kinds.RepoSyncV1Beta1(), nnPtr.Name, nnPtr.Namespace,
+					waitForRepoSyncsOptions.repoSha1Fn, RepoSyncHasStatusSyncCommit, syncDirPair,
+					testwatcher.WatchTimeout(waitForRepoSyncsOptions.timeout)); err != nil {
+					return err
+				}
+				return nt.validateSyncStable(kinds.RepoSyncV1Beta1(), nnPtr.Name, nnPtr.Namespace,
+					waitForRepoSyncsOptions.repoSha1Fn, RepoSyncHasStatusSyncCommit, syncDirPair,
+					waitForRepoSyncsOptions.stableDuration)
 			})
 		}
 	}
@@ -228,13 +249,34 @@ func (nt *NT) WatchForSync(
 		// If namespace is empty, use the default namespace
 		namespace = configsync.ControllerNamespace
 	}
+	predicates, err := nt.syncPredicates(name, namespace, sha1Func, syncSha1, syncDirPair)
+	if err != nil {
+		return err
+	}
+
+	err = nt.Watcher.WatchObject(gvk, name, namespace, predicates, opts...)
+	if err != nil {
+		return errors.Wrap(err, "waiting for sync")
+	}
+	nt.T.Logf("%s %s/%s is synced", gvk.Kind, namespace, name)
+	return nil
+}
+
+// syncPredicates returns the predicates which validate that the specified
+// sync object is synced. See WatchForSync for the parameters.
+func (nt *NT) syncPredicates(
+	name, namespace string,
+	sha1Func Sha1Func,
+	syncSha1 func(string) testpredicates.Predicate,
+	syncDirPair *SyncDirPredicatePair,
+) ([]testpredicates.Predicate, error) {
 	nn := types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}
 	sha1, err := sha1Func(nt, nn)
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve sha1")
+		return nil, errors.Wrap(err, "failed to retrieve sha1")
 	}
 
 	predicates := []testpredicates.Predicate{
@@ -248,12 +290,43 @@ func (nt *NT) WatchForSync(
 	if syncDirPair != nil {
 		predicates = append(predicates, syncDirPair.Predicate(syncDirPair.Dir))
 	}
+	return predicates, nil
+}
 
-	err = nt.Watcher.WatchObject(gvk, name, namespace, predicates, opts...)
+// stableSyncPollInterval is how often validateSyncStable checks the sync object.
+const stableSyncPollInterval = time.Second
+
+// validateSyncStable validates that the specified sync object, which is
+// expected to be synced already, remains synced for the specified duration.
+// See WatchForSync for the other parameters.
+func (nt *NT) validateSyncStable(
+	gvk schema.GroupVersionKind,
+	name, namespace string,
+	sha1Func Sha1Func,
+	syncSha1 func(string) testpredicates.Predicate,
+	syncDirPair *SyncDirPredicatePair,
+	duration time.Duration,
+) error {
+	if duration <= 0 {
+		return nil
+	}
+	if namespace == "" {
+		// If namespace is empty, use the default namespace
+		namespace = configsync.ControllerNamespace
+	}
+	predicates, err := nt.syncPredicates(name, namespace, sha1Func, syncSha1, syncDirPair)
 	if err != nil {
-		return errors.Wrap(err, "waiting for sync")
+		return err
 	}
-	nt.T.Logf("%s %s/%s is synced", gvk.Kind, namespace, name)
+
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		if err := nt.ValidateSyncObject(gvk, name, namespace, predicates...); err != nil {
+			return errors.Wrapf(err, "%s %s/%s was not stable for %v", gvk.Kind, namespace, name, duration)
+		}
+		time.Sleep(stableSyncPollInterval)
+	}
+	nt.T.Logf("%s %s/%s is stable for %v", gvk.Kind, namespace, name, duration)
 	return nil
 }
 
@@ -34,6 +34,7 @@ import (
 	"kpt.dev/configsync/pkg/api/configsync/v1alpha1"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
@@ -432,7 +433,6 @@ func TestOCICACertSecretRefNamespaceRepo(t *testing.T) {
 	caCertSecret := nomostest.PublicCertSecretName(nomostest.RegistrySyncSource)
 
 	nn := nomostest.RepoSyncNN(backendNamespace, configsync.RepoSyncName)
-	rs := nomostest.RepoSyncObjectV1Beta1FromNonRootRepo(nt, nn)
 	upsertedSecret := controllers.ReconcilerResourceName(
 		core.NsReconcilerName(nn.Namespace, nn.Name), caCertSecret)
 
@@ -446,11 +446,10 @@ func TestOCICACertSecretRefNamespaceRepo(t *testing.T) {
 	}
 
 	nt.T.Log("Set the RepoSync to sync the OCI image without providing a CA cert")
-	rs.Spec.SourceType = string(v1beta1.OciSource)
-	rs.Spec.Oci = &v1beta1.Oci{
-		Image: image.FloatingBranchTag(),
-		Auth:  "none",
-	}
+	rs := nomostest.RepoSyncObjectV1Beta1Oci(nn, image.FloatingBranchTag(), filesystem.SourceFormatUnstructured)
+	// Sync from the root directory of the image.
+	rs.Spec.Oci.Dir = ""
+	nt.Must(nomostest.SetRepoSyncDependencies(nt, rs))
 	nt.Must(nt.RootRepos[configsync.RootSyncName].Add(
 		nomostest.StructuredNSPath(nn.Namespace, nn.Name), rs))
 	nt.Must(nt.RootRepos[configsync.RootSyncName].CommitAndPush("Set the RepoSync to use OCI without providing CA cert"))
@@ -480,7 +479,7 @@ func TestOCICACertSecretRefNamespaceRepo(t *testing.T) {
 	}
 
 	nt.T.Log("Set the RepoSync to sync from git")
-	rs.Spec.SourceType = string(v1beta1.GitSource)
+	rs = nomostest.RepoSyncObjectV1Beta1FromNonRootRepo(nt, nn)
 	nt.Must(nt.RootRepos[configsync.RootSyncName].Add(
 		nomostest.StructuredNSPath(nn.Namespace, nn.Name), rs))
 	nt.Must(nt.RootRepos[configsync.RootSyncName].CommitAndPush("Set the RepoSync to sync from Git"))
@@ -546,7 +545,6 @@ func TestHelmCACertSecretRefNamespaceRepo(t *testing.T) {
 	caCertSecret := nomostest.PublicCertSecretName(nomostest.RegistrySyncSource)
 
 	nn := nomostest.RepoSyncNN(backendNamespace, configsync.RepoSyncName)
-	rs := nomostest.RepoSyncObjectV1Beta1FromNonRootRepo(nt, nn)
 	upsertedSecret := controllers.ReconcilerResourceName(
 		core.NsReconcilerName(nn.Namespace, nn.Name), caCertSecret)
 
@@ -560,16 +558,9 @@ func TestHelmCACertSecretRefNamespaceRepo(t *testing.T) {
 	}
 
 	nt.T.Log("Set the RepoSync to sync the Helm package without providing a CA cert")
-	rs.Spec.SourceType = string(v1beta1.HelmSource)
-	rs.Spec.Helm = &v1beta1.HelmRepoSync{
-		HelmBase: v1beta1.HelmBase{
-			Repo:    nt.HelmProvider.SyncURL(chart.Name),
-			Chart:   chart.Name,
-			Version: chart.Version,
-			Auth:    "none",
-			Period:  metav1.Duration{Duration: 15 * time.Second},
-		},
-	}
+	rs := nomostest.RepoSyncObjectV1Beta1Helm(nn, nt.HelmProvider.SyncURL(chart.Name), chart.Name, chart.Version)
+	rs.Spec.Helm.Period = metav1.Duration{Duration: 15 * time.Second}
+	nt.Must(nomostest.SetRepoSyncDependencies(nt, rs))
 	nt.Must(nt.RootRepos[configsync.RootSyncName].Add(
 		nomostest.StructuredNSPath(nn.Namespace, nn.Name), rs))
 	nt.Must(nt.RootRepos[configsync.RootSyncName].CommitAndPush("Set the RepoSync to use Helm without providing CA cert"))
@@ -599,7 +590,7 @@ func TestHelmCACertSecretRefNamespaceRepo(t *testing.T) {
 	}
 
 	nt.T.Log("Set the RepoSync to sync from git")
-	rs.Spec.SourceType = string(v1beta1.GitSource)
+	rs = nomostest.RepoSyncObjectV1Beta1FromNonRootRepo(nt, nn)
 	nt.Must(nt.RootRepos[configsync.RootSyncName].Add(
 		nomostest.StructuredNSPath(nn.Namespace, nn.Name), rs))
 	nt.Must(nt.RootRepos[configsync.RootSyncName].CommitAndPush("Set the RepoSync to sync from Git"))
@@ -38,6 +38,7 @@ import (
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/testing/fake"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -379,6 +380,31 @@ func TestManageSelfRootSync(t *testing.T) {
 	nt.WaitForRootSyncSourceError(configsync.RootSyncName, validate.SelfReconcileErrorCode, "RootSync config-management-system/root-sync must not manage itself in its repo")
 }
 
+func TestRootSyncRecoversFromSourceOutage(t *testing.T) {
+	nt := nomostest.New(t, nomostesting.SyncSource)
+
+	outage, err := nt.StartSourceOutage(nomostest.DefaultRootReconcilerName, v1beta1.GitSource, 3)
+	if err != nil {
+		nt.T.Fatal(err)
+	}
+
+	// Push a new commit that the reconciler can not fetch during the outage.
+	nsName := "source-outage"
+	nt.Must(nt.RootRepos[configsync.RootSyncName].Add(fmt.Sprintf("acme/namespaces/%s/ns.yaml", nsName), fake.NamespaceObject(nsName)))
+	nt.Must(nt.RootRepos[configsync.RootSyncName].CommitAndPush("add a Namespace during the source outage"))
+	nt.WaitForRootSyncSourceError(configsync.RootSyncName, status.SourceUnreachableErrorCode, "")
+
+	if err := outage.Wait(); err != nil {
+		nt.T.Fatal(err)
+	}
+	if err := nt.WatchForAllSyncs(); err != nil {
+		nt.T.Fatal(err)
+	}
+	if err := nt.Validate(nsName, "", &corev1.Namespace{}); err != nil {
+		nt.T.Fatal(err)
+	}
+}
+
 func hasRootSyncReconcilingStatus(r metav1.ConditionStatus) testpredicates.Predicate {
 	return func(o client.Object) error {
 		if o == nil {
@@ -79,6 +79,16 @@ const (
 	// conflict errors from the remediator, if there are any.
 	DefaultReconcilerSyncStatusUpdatePeriod = 5 * time.Second
 
+	// ReconcilerSyncStatusUpdateJitterFactor is the maximum fraction of the
+	// status update period added as a random jitter to each period, to avoid
+	// synchronized status updates across many reconcilers.
+	ReconcilerSyncStatusUpdateJitterFactor = 0.1
+
+	// DefaultReconcilerHealthProbePort is the port of the readiness endpoint of
+	// the reconciler, which reports ready once the latest source commit is
+	// synced without blocking errors.
+	DefaultReconcilerHealthProbePort = 9081
+
 	// DefaultReconcileTimeout is the default wait timeout used by the applier
 	// when waiting for reconciliation after actuation.
 	// For Apply, it waits for Current status.
@@ -123,3 +133,117 @@ const (
 	// declared to be created by the reconciler.
 	NamespaceStrategyExplicit NamespaceStrategy = "explicit"
 )
+
+// AmbiguousSourceFormatPolicy specifies how the reconciler handles a source
+// which contains signals of a different source format than the configured one.
+type AmbiguousSourceFormatPolicy string
+
+const (
+	// AmbiguousSourceFormatError indicates that the reconciler reports an
+	// ambiguous source format as a source error.
+	AmbiguousSourceFormatError AmbiguousSourceFormatPolicy = "error"
+	// AmbiguousSourceFormatWarn indicates that the reconciler logs a warning
+	// for an ambiguous source format and continues to sync, so that
+	// repositories migrating between source formats keep syncing. Default
+	AmbiguousSourceFormatWarn AmbiguousSourceFormatPolicy = "warn"
+)
+
+// NamespaceMismatchPolicy specifies how the reconciler handles an object in a
+// hierarchical source whose metadata.namespace differs from the namespace
+// derived from its directory.
+type NamespaceMismatchPolicy string
+
+const (
+	// NamespaceMismatchError indicates that the reconciler reports a namespace
+	// mismatch as a source error. Default
+	NamespaceMismatchError NamespaceMismatchPolicy = "error"
+	// NamespaceMismatchCorrect indicates that the reconciler logs a warning
+	// for a namespace mismatch and sets the metadata.namespace of the object
+	// to the namespace derived from its directory.
+	NamespaceMismatchCorrect NamespaceMismatchPolicy = "correct"
+)
+
+// DirsCollisionPolicy specifies how the reconciler handles an object which is
+// declared in more than one of the directories listed in spec.git.dirs.
+type DirsCollisionPolicy string
+
+const (
+	// DirsCollisionOverride indicates that an object declared in a later
+	// directory overrides the object with the same ID declared in an earlier
+	// directory. Default
+	DirsCollisionOverride DirsCollisionPolicy = "override"
+	// DirsCollisionError indicates that the reconciler reports an object
+	// declared in more than one directory as a source error.
+	DirsCollisionError DirsCollisionPolicy = "error"
+)
+
+// SyncMode specifies whether a RootSync applies the resources from its
+// source, or only observes them.
+type SyncMode string
+
+const (
+	// SyncModeSync indicates that the reconciler applies the resources from
+	// the source, and corrects their drift. Default
+	SyncModeSync SyncMode = "sync"
+	// SyncModeObserve indicates that the reconciler only computes and reports
+	// the drift of the resources from the source, and never writes to them.
+	SyncModeObserve SyncMode = "observe"
+)
+
+// ConflictPolicy specifies how the applier of a RootSync handles an object
+// which is already managed by another RootSync or RepoSync.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyAdoptAll indicates that the applier takes over objects
+	// managed by another RootSync or RepoSync. Default
+	ConflictPolicyAdoptAll ConflictPolicy = "adoptAll"
+	// ConflictPolicyAdoptIfNoInventory indicates that the applier only takes
+	// over objects which are not managed by another RootSync or RepoSync, and
+	// reports the others as management conflicts.
+	ConflictPolicyAdoptIfNoInventory ConflictPolicy = "adoptIfNoInventory"
+)
+
+// DeletionPropagationPolicy specifies what happens to the objects managed by
+// a RootSync or RepoSync when the RootSync or RepoSync is deleted.
+type DeletionPropagationPolicy string
+
+const (
+	// DeletionPropagationPolicyForeground indicates that the managed objects
+	// are deleted before the RootSync or RepoSync is deleted.
+	DeletionPropagationPolicyForeground DeletionPropagationPolicy = "Foreground"
+	// DeletionPropagationPolicyOrphan indicates that the managed objects are
+	// left on the cluster when the RootSync or RepoSync is deleted, with the
+	// Config Sync metadata removed, and the inventory is deleted.
+	DeletionPropagationPolicyOrphan DeletionPropagationPolicy = "Orphan"
+)
+
+// RequiredMetadataType specifies whether a required metadata key is a label
+// or an annotation.
+type RequiredMetadataType string
+
+const (
+	// RequiredMetadataLabel indicates that the required metadata is a label.
+	RequiredMetadataLabel RequiredMetadataType = "label"
+	// RequiredMetadataAnnotation indicates that the required metadata is an
+	// annotation.
+	RequiredMetadataAnnotation RequiredMetadataType = "annotation"
+)
+
+// SyncState is the overall state of a RootSync or RepoSync, as reported in
+// the status summary.
+type SyncState string
+
+const (
+	// SyncStateSyncing indicates that the reconciler is syncing the source.
+	SyncStateSyncing SyncState = "Syncing"
+	// SyncStateSynced indicates that the source is synced without errors.
+	SyncStateSynced SyncState = "Synced"
+	// SyncStatePaused indicates that applying the source is paused.
+	SyncStatePaused SyncState = "Paused"
+	// SyncStateObserving indicates that the source is observed, but never
+	// applied, because the RootSync is in observe mode.
+	SyncStateObserving SyncState = "Observing"
+	// SyncStateError indicates that the source is not synced due to errors.
+	SyncStateError SyncState = "Error"
+)
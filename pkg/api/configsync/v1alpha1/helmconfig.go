@@ -78,12 +78,31 @@ type HelmBase struct {
 	// +optional
 	ValuesFileRefs []ValuesFileRef `json:"valuesFileRefs,omitempty"`
 
-	// includeCRDs specifies if Helm template should also generate CustomResourceDefinitions.
-	// If IncludeCRDs is set to false, no CustomeResourceDefinition will be generated.
+	// includeCRDs specifies if Helm template should also generate the
+	// CustomResourceDefinitions in the crds/ directory of the chart.
+	// If set to false, they are not generated. Leave it false when the
+	// CustomResourceDefinitions of the chart are managed separately, for
+	// example by another RootSync, to avoid fights over them.
+	// CustomResourceDefinitions in the templates/ directory are always generated.
 	// Default: false.
 	// +optional
 	IncludeCRDs bool `json:"includeCRDs,omitempty"`
 
+	// apiVersions is a list of Kubernetes API versions used by Helm template for
+	// .Capabilities.APIVersions, in the form of "group/version" or
+	// "group/version/Kind", like "monitoring.coreos.com/v1".
+	// Set it for charts which only render some templates if an API is available
+	// on the cluster.
+	// +optional
+	APIVersions []string `json:"apiVersions,omitempty"`
+
+	// kubeVersion is the Kubernetes version used by Helm template for
+	// .Capabilities.KubeVersion, like "v1.27.3".
+	// Must be a semantic version.
+	// Default: the default Kubernetes version of Helm.
+	// +optional
+	KubeVersion string `json:"kubeVersion,omitempty"`
+
 	// period is the time duration that Config Sync waits before refetching the chart.
 	// Default: 1 hour.
 	// Use string to specify this field value, like "30s", "5m".
@@ -120,6 +139,28 @@ type HelmBase struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// clientCertSecretRef specifies the name of the secret where the client certificate
+	// and private key used for mutual TLS with the Helm repository are stored. The creation of
+	// the secret should be done out of band by the user and should store the certificate
+	// in a key named "tls.crt" and the private key in a key named "tls.key", e.g. a
+	// Secret of type kubernetes.io/tls. For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
+
+	// proxy specifies an HTTPS proxy for accessing the Helm repository.
+	// It is passed to the sync container as the HTTPS_PROXY environment variable.
+	// +optional
+	Proxy string `json:"proxy,omitempty"`
+
+	// noProxy specifies a comma-separated list of hosts that should bypass
+	// the proxy. It is passed to the sync container as the NO_PROXY
+	// environment variable. Only has an effect when proxy is set.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
 }
 
 // ValuesFileRef references a ConfigMap object that contains a values file to use for
@@ -64,4 +64,26 @@ type Oci struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// clientCertSecretRef specifies the name of the secret where the client certificate
+	// and private key used for mutual TLS with the OCI registry are stored. The creation of
+	// the secret should be done out of band by the user and should store the certificate
+	// in a key named "tls.crt" and the private key in a key named "tls.key", e.g. a
+	// Secret of type kubernetes.io/tls. For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
+
+	// proxy specifies an HTTPS proxy for accessing the OCI image.
+	// It is passed to the sync container as the HTTPS_PROXY environment variable.
+	// +optional
+	Proxy string `json:"proxy,omitempty"`
+
+	// noProxy specifies a comma-separated list of hosts that should bypass
+	// the proxy. It is passed to the sync container as the NO_PROXY
+	// environment variable. Only has an effect when proxy is set.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
 }
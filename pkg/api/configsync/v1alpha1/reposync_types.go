@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 )
 
 // +kubebuilder:object:root=true
@@ -76,6 +77,23 @@ type RepoSyncSpec struct {
 	// +nullable
 	// +optional
 	Override *RepoSyncOverrideSpec `json:"override,omitempty"`
+
+	// suspend specifies whether syncing is suspended. If true, the reconciler
+	// Deployment is scaled down to zero replicas until suspend is unset,
+	// preserving the status of the RepoSync.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// deletionPropagationPolicy specifies what happens to the managed objects
+	// when the RepoSync is deleted. Orphan leaves the managed objects on the
+	// cluster, removes their Config Sync metadata, and deletes the inventory.
+	// Foreground deletes the managed objects before the RepoSync is deleted.
+	// If unset, the `configsync.gke.io/deletion-propagation-policy`
+	// annotation is used, which defaults to leaving the managed objects
+	// untouched.
+	// +kubebuilder:validation:Enum=Foreground;Orphan
+	// +optional
+	DeletionPropagationPolicy configsync.DeletionPropagationPolicy `json:"deletionPropagationPolicy,omitempty"`
 }
 
 // RepoSyncStatus defines the observed state of a RepoSync.
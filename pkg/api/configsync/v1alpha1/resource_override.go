@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
@@ -36,12 +38,23 @@ type OverrideSpec struct {
 	// +optional
 	GitSyncDepth *int64 `json:"gitSyncDepth,omitempty"`
 
+	// gitSyncTimeout allows one to override how long git-sync waits for a
+	// single fetch of the git repository before giving up.
+	// Default: 120s, the git-sync default.
+	// Use string to specify this field value, like "5m", "10m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// Consider increasing it for large repositories whose clone exceeds the default.
+	// +optional
+	GitSyncTimeout *metav1.Duration `json:"gitSyncTimeout,omitempty"`
+
 	// statusMode controls whether the actuation status
 	// such as apply failed or not should be embedded into the ResourceGroup object.
-	// Must be "enabled" or "disabled".
+	// Must be "enabled", "disabled" or "timestamps".
 	// If set to "enabled", it increases the size of the ResourceGroup object.
+	// If set to "timestamps", it also records when each object was last applied
+	// or pruned, further increasing the size of the ResourceGroup object.
 	//
-	// +kubebuilder:validation:Pattern=^(enabled|disabled|)$
+	// +kubebuilder:validation:Pattern=^(enabled|disabled|timestamps|)$
 	// +optional
 	StatusMode string `json:"statusMode,omitempty"`
 
@@ -62,6 +75,25 @@ type OverrideSpec struct {
 	// +optional
 	APIServerTimeout *metav1.Duration `json:"apiServerTimeout,omitempty"`
 
+	// clientQPS allows one to override the client-side throttling QPS for
+	// requests to the API server.
+	// Default: 0, which disables client-side throttling when the API server
+	// has flow control enabled.
+	// A higher value speeds up large syncs, at the cost of more load on the
+	// API server.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClientQPS *int32 `json:"clientQPS,omitempty"`
+
+	// clientBurst allows one to override the client-side throttling burst for
+	// requests to the API server. It is only used with a positive clientQPS.
+	// Default: 0, which uses twice the clientQPS.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ClientBurst *int32 `json:"clientBurst,omitempty"`
+
 	// enableShellInRendering specifies whether to enable or disable the shell access in rendering process. Default: false.
 	// Kustomize remote bases requires shell access. Setting this field to true will enable shell in the rendering process and
 	// support pulling remote bases from public repositories.
@@ -74,6 +106,238 @@ type OverrideSpec struct {
 	// +listMapKey=containerName
 	// +optional
 	LogLevels []ContainerLogLevelOverride `json:"logLevels,omitempty"`
+
+	// deletionGracePeriod allows one to override how long the reconciler-manager
+	// waits for an in-progress sync, including pruning, to finish before deleting
+	// the reconciler Deployment when the RootSync or RepoSync is deleted.
+	// Default: 0s, which deletes the reconciler immediately.
+	// Use string to specify this field value, like "30s", "5m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	DeletionGracePeriod *metav1.Duration `json:"deletionGracePeriod,omitempty"`
+
+	// minRemediationInterval allows one to override the minimum interval between
+	// two corrections of the same object by the remediator. Changes made to a
+	// managed object within this interval of its last correction are reverted
+	// once the interval elapses, and reported as a fight with another controller.
+	// Default: 0s, which corrects objects immediately.
+	// Use string to specify this field value, like "10s", "1m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	MinRemediationInterval *metav1.Duration `json:"minRemediationInterval,omitempty"`
+
+	// applyCallTimeout allows one to override the timeout of each individual
+	// apply, patch, or delete call made by the reconciler, both when applying
+	// the source and when correcting drifted objects. Patch and delete calls
+	// which time out are retried, so that a single slow call does not stall the
+	// sync. Unlike reconcileTimeout, this does not bound how long to wait for
+	// the applied resources to become ready.
+	// Default: 0s, which only bounds each call by apiServerTimeout.
+	// Use string to specify this field value, like "10s", "1m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	ApplyCallTimeout *metav1.Duration `json:"applyCallTimeout,omitempty"`
+
+	// resyncPeriod allows one to override the period of time between forced
+	// re-syncs from the source, even without a new commit.
+	// Default: 1h.
+	// Use string to specify this field value, like "10m", "1h".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// statusUpdatePeriod allows one to override the period of time between
+	// the periodic updates of the sync status by the reconciler. A random
+	// jitter of up to 10% is added to each period, so that many reconcilers
+	// do not update their sync status at the same time.
+	// Default: 5s.
+	// Use string to specify this field value, like "5s", "30s".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// Consider increasing it on large fleets to reduce the API server load.
+	// +optional
+	StatusUpdatePeriod *metav1.Duration `json:"statusUpdatePeriod,omitempty"`
+
+	// renderingTimeout allows one to override how long the reconciler waits
+	// for the hydration-controller to render a new commit before reporting the
+	// rendering as failed. The timeout is measured from when the reconciler
+	// detects the commit which triggered the rendering.
+	// Default: 0s, which waits for the rendering indefinitely.
+	// Use string to specify this field value, like "5m", "10m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	RenderingTimeout *metav1.Duration `json:"renderingTimeout,omitempty"`
+
+	// pauseApply specifies whether to pause applying the resources from the
+	// source. Default: false.
+	// If set to true, the reconciler keeps tracking the latest commit and
+	// reports objects which drift from it as sync errors, but neither applies
+	// nor corrects them, and the Syncing condition has the "Paused" reason.
+	// Unsetting it resumes syncing the latest commit.
+	// +optional
+	PauseApply *bool `json:"pauseApply,omitempty"`
+
+	// replicas specifies the number of replicas of the reconciler Deployment.
+	// Default: 1.
+	// If greater than 1, the replicas use leader election, so that only one
+	// replica syncs at a time, while the others are on standby.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// fieldManager allows one to override the field manager name used by the
+	// reconciler to apply and correct the managed objects with server-side
+	// apply, which makes it easier to attribute conflicts when multiple
+	// controllers apply to shared objects.
+	// Default: "configsync.gke.io".
+	// When overridden, the fields applied with the default field manager are
+	// released by it once the objects are applied with the new field manager.
+	// Must consist of alphanumeric characters, '-', '_', '.' or ':', and
+	// start and end with an alphanumeric character.
+	//
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9]([-a-zA-Z0-9_.:]*[a-zA-Z0-9])?$`
+	// +optional
+	FieldManager string `json:"fieldManager,omitempty"`
+
+	// requiredMetadata is a list of labels and annotations which every
+	// managed object declared in the source must have, for example to enforce
+	// cost or owner labels. The reconciler reports each object missing one of
+	// them as a source error, and does not sync the source.
+	// +optional
+	RequiredMetadata []RequiredMetadata `json:"requiredMetadata,omitempty"`
+
+	// annotateSyncGeneration specifies whether to annotate every managed
+	// object with the configsync.gke.io/sync-generation annotation, whose value
+	// is the metadata.generation of the RootSync or RepoSync that last applied
+	// the object. Default: false.
+	// +optional
+	AnnotateSyncGeneration *bool `json:"annotateSyncGeneration,omitempty"`
+
+	// hydrationControllerImage allows one to override the image of the
+	// hydration-controller container, for example to pull it from a private
+	// registry. It takes precedence over the image selected by
+	// enableShellInRendering.
+	// Only supported for RootSync: a RepoSync which sets it is rejected.
+	// Default: the image bundled with the reconciler-manager.
+	// +optional
+	HydrationControllerImage string `json:"hydrationControllerImage,omitempty"`
+
+	// gitSyncImage allows one to override the image of the git-sync container.
+	// Only supported for RootSync: a RepoSync which sets it is rejected.
+	// Default: the image bundled with the reconciler-manager.
+	// +optional
+	GitSyncImage string `json:"gitSyncImage,omitempty"`
+
+	// ociSyncImage allows one to override the image of the oci-sync container.
+	// Only supported for RootSync: a RepoSync which sets it is rejected.
+	// Default: the image bundled with the reconciler-manager.
+	// +optional
+	OciSyncImage string `json:"ociSyncImage,omitempty"`
+
+	// helmSyncImage allows one to override the image of the helm-sync
+	// container.
+	// Only supported for RootSync: a RepoSync which sets it is rejected.
+	// Default: the image bundled with the reconciler-manager.
+	// +optional
+	HelmSyncImage string `json:"helmSyncImage,omitempty"`
+
+	// imagePullSecrets is a list of references to Secrets used to pull the
+	// images of the reconciler Pod, for example from a private registry.
+	// The Secrets must exist in the config-management-system namespace, where
+	// the reconciler Pod runs. They are only referenced, not copied or managed
+	// by Config Sync.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// maxPruneCount is the maximum number of objects the reconciler prunes in
+	// one sync. If a sync would prune more objects, the reconciler reports an
+	// error instead of applying, until the pruning is confirmed by annotating
+	// the RootSync or RepoSync with `configsync.gke.io/confirm-prune`, set to
+	// the hash of the objects to prune reported in the error.
+	// Default: 0, which prunes any number of objects.
+	// Consider setting it to protect against commits which accidentally
+	// remove large parts of the source.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPruneCount *int32 `json:"maxPruneCount,omitempty"`
+
+	// circuitBreakerThreshold is the number of consecutive syncs failing
+	// because the API server is unavailable or overloaded after which the
+	// reconciler stops syncing and remediating, and only probes the API server
+	// periodically, until a probe succeeds.
+	// Default: 0, which disables the circuit breaker.
+	// Consider setting it to reduce the load of many reconcilers on an
+	// overloaded API server.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CircuitBreakerThreshold *int32 `json:"circuitBreakerThreshold,omitempty"`
+
+	// circuitBreakerProbePeriod allows one to override the initial period
+	// between two probes while the circuit breaker is open. The period doubles
+	// after each failed probe, up to 10m.
+	// Default: 30s.
+	// Use string to specify this field value, like "30s", "1m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	CircuitBreakerProbePeriod *metav1.Duration `json:"circuitBreakerProbePeriod,omitempty"`
+
+	// validateWithAdmission specifies whether to validate the objects from the
+	// source with the admission chain of the cluster, including validating
+	// webhooks like those of OPA Gatekeeper, before applying any of them.
+	// The objects are dry-run applied, and each rejected object is reported
+	// as a source error, so that a commit violating a policy is not applied.
+	// Default: false.
+	// Enabling it costs one API call per object for every new commit.
+	// +optional
+	ValidateWithAdmission *bool `json:"validateWithAdmission,omitempty"`
+
+	// remediatorExcludedKinds is a list of resource types which the
+	// reconciler does not watch for drift, to reduce the watch load of
+	// high-churn types, like Event-like custom resources.
+	// Drift on objects of these types is not corrected automatically, but
+	// the objects are still applied when the source changes, and on every
+	// resync.
+	// +optional
+	RemediatorExcludedKinds []RemediatorExcludedKind `json:"remediatorExcludedKinds,omitempty"`
+
+	// objectSelector is a label selector which limits the objects from the
+	// source which the reconciler applies. Objects whose labels do not match
+	// are ignored: they are neither applied nor watched for drift.
+	// This allows a single source to be shared by multiple clusters, each
+	// applying only the objects labeled for it.
+	// Objects which matched before, but no longer match, are removed from
+	// the inventory and left on the cluster, like objects with the
+	// `configmanagement.gke.io/managed: disabled` annotation.
+	// Default: unset, which applies all the objects.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
+
+	// substituteClusterName specifies whether to replace the `${CLUSTER_NAME}`
+	// token in the string field values of the objects from the source with
+	// the name of the cluster, so that a single source can carry
+	// per-cluster values, like a ConfigMap value.
+	// The substitution runs after rendering, on the final objects, and does
+	// not apply to the apiVersion, kind, metadata.name and metadata.namespace
+	// fields. Write `$${CLUSTER_NAME}` to keep a literal `${CLUSTER_NAME}`.
+	// The token is left unchanged if the cluster name is not set.
+	// Default: false.
+	// +optional
+	SubstituteClusterName *bool `json:"substituteClusterName,omitempty"`
+
+	// applyDebouncePeriod allows one to override how long the reconciler
+	// waits after detecting a new commit before applying it, so that the
+	// commits pushed within this period are coalesced, and only the latest
+	// one is applied. The period starts when the first of these commits is
+	// detected, and the latest commit is applied at the first source poll
+	// after it elapses. The first commit after the reconciler starts is
+	// applied immediately.
+	// Default: 0s, which applies every commit immediately.
+	// Use string to specify this field value, like "30s", "1m".
+	// More details about valid inputs: https://pkg.go.dev/time#ParseDuration.
+	// +optional
+	ApplyDebouncePeriod *metav1.Duration `json:"applyDebouncePeriod,omitempty"`
 }
 
 // RootSyncOverrideSpec allows to override the settings for a RootSync reconciler pod
@@ -87,12 +351,93 @@ type RootSyncOverrideSpec struct {
 	// "implicit" means that the reconciler will implicitly create Namespaces
 	// if they do not exist, even if they are not declared in the source.
 	// "explicit" means that the reconciler will not create Namespaces which
-	// are not declared in the source.
+	// are not declared in the source. Resources in a Namespace which is neither
+	// declared in the source nor present on the cluster are reported as source
+	// errors.
 	//
 	// +kubebuilder:validation:Enum=implicit;explicit
 	// +optional
 	NamespaceStrategy configsync.NamespaceStrategy `json:"namespaceStrategy,omitempty"`
 
+	// ambiguousSourceFormat controls how the reconciler handles a source which
+	// contains signals of a different sourceFormat than the configured one,
+	// such as a top-level system/ directory with the unstructured sourceFormat,
+	// or no top-level system/, cluster/, clusterregistry/ or namespaces/
+	// directory with the hierarchy sourceFormat.
+	// Must be "error" or "warn". Default: "warn".
+	// "warn" means that the reconciler logs a warning and continues to sync,
+	// so that repositories migrating between source formats keep syncing.
+	// "error" means that the reconciler reports an ambiguous source as a source
+	// error and does not sync it.
+	//
+	// +kubebuilder:validation:Enum=error;warn
+	// +optional
+	AmbiguousSourceFormat configsync.AmbiguousSourceFormatPolicy `json:"ambiguousSourceFormat,omitempty"`
+
+	// namespaceMismatchPolicy controls how the reconciler handles an object
+	// whose metadata.namespace differs from the namespace derived from its
+	// directory. Only applies when using the hierarchy sourceFormat.
+	// Must be "error" or "correct". Default: "error".
+	// "error" means that the reconciler reports the mismatch as a source error
+	// and does not sync the source.
+	// "correct" means that the reconciler logs a warning and syncs the object
+	// to the namespace derived from its directory.
+	//
+	// +kubebuilder:validation:Enum=error;correct
+	// +optional
+	NamespaceMismatchPolicy configsync.NamespaceMismatchPolicy `json:"namespaceMismatchPolicy,omitempty"`
+
+	// dynamicNamespaceSelector specifies whether NamespaceSelectors which do not
+	// set spec.mode use the dynamic mode. Default: false, which uses the static mode.
+	// In the dynamic mode, a NamespaceSelector also selects the on-cluster
+	// Namespaces matching its labels, and objects are re-expanded into the
+	// selected Namespaces whenever a Namespace's labels change.
+	// NamespaceSelectors which explicitly set spec.mode are not affected.
+	// +optional
+	DynamicNamespaceSelector *bool `json:"dynamicNamespaceSelector,omitempty"`
+
+	// deferUnestablishedCRs specifies whether to defer applying custom resources
+	// whose CustomResourceDefinition is not yet established on the cluster.
+	// Default: false, which reports such custom resources as apply errors.
+	// If set to true, the deferred custom resources are reported as pending, and
+	// applied in a later sync once their CustomResourceDefinition is established.
+	// +optional
+	DeferUnestablishedCRs *bool `json:"deferUnestablishedCRs,omitempty"`
+
+	// clusterScopedConflictCheck specifies whether to reject the cluster-scoped
+	// objects which are also declared by another RootSync. Default: false.
+	// If set to true, before applying, the reconciler compares the
+	// cluster-scoped objects declared in the source with the inventories of
+	// the other RootSyncs, and reports each object declared by both as a
+	// management conflict error, which blocks the sync.
+	// +optional
+	ClusterScopedConflictCheck *bool `json:"clusterScopedConflictCheck,omitempty"`
+
+	// conflictPolicy controls how the reconciler handles an object declared in
+	// the source which is already managed by another RootSync or RepoSync.
+	// Must be "adoptAll" or "adoptIfNoInventory". Default: "adoptAll".
+	// "adoptAll" means that the reconciler takes over the object. If another
+	// RootSync using "adoptAll" declares the object too, and the admission
+	// webhook is disabled, the two reconcilers fight over the object, each
+	// reverting the changes of the other.
+	// "adoptIfNoInventory" means that the reconciler backs off, and reports the
+	// object as a management conflict error until the other RootSync or
+	// RepoSync stops managing it. This avoids fights, but an object can not be
+	// moved to this RootSync without first removing it from the other source.
+	//
+	// +kubebuilder:validation:Enum=adoptAll;adoptIfNoInventory
+	// +optional
+	ConflictPolicy configsync.ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// postSyncVerification specifies a Job to run after each commit is synced
+	// successfully, such as a smoke test of the synced resources.
+	// The Job is created in the config-management-system namespace, and
+	// replaced whenever a new commit is synced.
+	// If the Job fails, the RootSync reports a True PostSyncVerificationFailed
+	// condition.
+	// +optional
+	PostSyncVerification *PostSyncVerification `json:"postSyncVerification,omitempty"`
+
 	// roleRefs is a list of Roles or ClusterRoles to create bindings.
 	// If unset, a binding to cluster-admin will be created.
 	//
@@ -100,6 +445,19 @@ type RootSyncOverrideSpec struct {
 	RoleRefs []RootSyncRoleRef `json:"roleRefs,omitempty"`
 }
 
+// PostSyncVerification specifies a Job to verify a successful sync.
+type PostSyncVerification struct {
+	// jobTemplate is the template of the verification Job.
+	// The name and namespace of the Job are set by the reconciler-manager.
+	// The Job runs as the default ServiceAccount of the namespace, so the
+	// serviceAccountName of the Pod template must not be set.
+	//
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	JobTemplate batchv1.JobTemplateSpec `json:"jobTemplate"`
+}
+
 // each item references a Role or ClusterRole to create
 // a binding to for this reconciler. It supports a namespace field that can be used
 // to create RoleBindings rather than ClusterRoleBindings.
@@ -151,6 +509,36 @@ type ContainerResourcesSpec struct {
 	MemoryLimit resource.Quantity `json:"memoryLimit,omitempty"`
 }
 
+// RequiredMetadata specifies a label or annotation which every managed object
+// must have.
+type RequiredMetadata struct {
+	// key is the key of the required label or annotation.
+	//
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// type specifies whether the key is a label or an annotation.
+	// Must be "label" or "annotation".
+	//
+	// +kubebuilder:validation:Enum=label;annotation
+	// +kubebuilder:validation:Required
+	Type configsync.RequiredMetadataType `json:"type"`
+}
+
+// RemediatorExcludedKind specifies a resource type which the reconciler does
+// not watch for drift.
+type RemediatorExcludedKind struct {
+	// group is the API group of the resource type. Empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the kind of the resource type.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+}
+
 // ContainerLogLevelOverride specifies the container name and log level override value
 type ContainerLogLevelOverride struct {
 	// containerName specifies the name of the reconciler deployment container for which log level will be overridden.
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 )
 
 // +kubebuilder:object:root=true
@@ -40,6 +41,7 @@ type RootSync struct {
 }
 
 // RootSyncSpec defines the desired state of RootSync
+// +kubebuilder:validation:XValidation:rule="(has(self.mode) ? self.mode : 'sync') == (has(oldSelf.mode) ? oldSelf.mode : 'sync')",message="mode is immutable"
 type RootSyncSpec struct {
 	// sourceFormat specifies how the repository is formatted.
 	// See documentation for specifics of what these options do.
@@ -60,6 +62,17 @@ type RootSyncSpec struct {
 	// +optional
 	SourceType string `json:"sourceType,omitempty"`
 
+	// mode specifies whether the root reconciler applies the resources from
+	// the source, or only observes them.
+	//
+	// Must be one of sync, observe. Optional. Set to sync if not specified.
+	// In observe mode, the root reconciler computes and reports the drift of
+	// the declared resources, but never applies, prunes, or corrects them.
+	// The mode is immutable once the RootSync is created.
+	// +kubebuilder:validation:Enum=sync;observe
+	// +optional
+	Mode configsync.SyncMode `json:"mode,omitempty"`
+
 	// git contains configuration specific to importing resources from a Git repo.
 	// +optional
 	*Git `json:"git,omitempty"`
@@ -76,6 +89,23 @@ type RootSyncSpec struct {
 	// +nullable
 	// +optional
 	Override *RootSyncOverrideSpec `json:"override,omitempty"`
+
+	// suspend specifies whether syncing is suspended. If true, the reconciler
+	// Deployment is scaled down to zero replicas until suspend is unset,
+	// preserving the status of the RootSync.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// deletionPropagationPolicy specifies what happens to the managed objects
+	// when the RootSync is deleted. Orphan leaves the managed objects on the
+	// cluster, removes their Config Sync metadata, and deletes the inventory.
+	// Foreground deletes the managed objects before the RootSync is deleted.
+	// If unset, the `configsync.gke.io/deletion-propagation-policy`
+	// annotation is used, which defaults to leaving the managed objects
+	// untouched.
+	// +kubebuilder:validation:Enum=Foreground;Orphan
+	// +optional
+	DeletionPropagationPolicy configsync.DeletionPropagationPolicy `json:"deletionPropagationPolicy,omitempty"`
 }
 
 // RootSyncStatus defines the observed state of RootSync
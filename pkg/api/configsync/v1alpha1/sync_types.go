@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 )
 
 // Status provides a common type that is embedded in RepoSyncStatus and RootSyncStatus.
@@ -31,6 +32,29 @@ type Status struct {
 	// +optional
 	Reconciler string `json:"reconciler,omitempty"`
 
+	// reconcilerVersion is the Config Sync version of the reconciler which most
+	// recently took over syncing the sync resource. During a rollout of Config
+	// Sync, a reconciler stops applying once a reconciler of another version has
+	// taken over.
+	// +optional
+	ReconcilerVersion string `json:"reconcilerVersion,omitempty"`
+
+	// webhookEnforcing indicates whether the Config Sync admission webhook is
+	// enabled, protecting the fields declared in the source of truth from being
+	// changed by other controllers or users.
+	// +optional
+	WebhookEnforcing bool `json:"webhookEnforcing,omitempty"`
+
+	// summary is a compact summary of the status, designed for listing the
+	// status of many RootSyncs and RepoSyncs without parsing the full status.
+	// +optional
+	Summary *StatusSummary `json:"summary,omitempty"`
+
+	// fleetWorkloadIdentity describes the Fleet Workload Identity credentials
+	// which the reconciler is configured with, if any.
+	// +optional
+	FleetWorkloadIdentity *FleetWorkloadIdentityStatus `json:"fleetWorkloadIdentity,omitempty"`
+
 	// lastSyncedCommit describes the most recent hash that is successfully synced.
 	// It can be a git commit hash, or an OCI image digest.
 	// +optional
@@ -84,6 +108,40 @@ type SourceStatus struct {
 	// errorSummary summarizes the errors encountered during the process of reading from the source of truth.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// sources reports the health of each configured source of truth
+	// separately, so that it is clear which source is failing.
+	// Currently, only one source can be configured.
+	// +listType=map
+	// +listMapKey=sourceType
+	// +optional
+	Sources []SourceHealth `json:"sources,omitempty"`
+}
+
+// SourceHealth describes the health of a single source of truth.
+type SourceHealth struct {
+	// sourceType is the type of the source of truth.
+	// Must be "git", "oci", or "helm".
+	SourceType SourceType `json:"sourceType"`
+
+	// commit is the hash of the source of truth that is read.
+	// It can be a git commit hash, an OCI image digest, or a helm chart version.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// lastUpdate is the timestamp of when the health of the source of truth was
+	// last updated by a reconciler.
+	// +nullable
+	// +optional
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+
+	// errors is a list of any errors that occurred while reading from the source of truth.
+	// +optional
+	Errors []ConfigSyncError `json:"errors,omitempty"`
+
+	// errorSummary summarizes the errors encountered while reading from the source of truth.
+	// +optional
+	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
 }
 
 // RenderingStatus describes the status of rendering the source DRY configs to the WET format.
@@ -121,6 +179,33 @@ type RenderingStatus struct {
 	// errorSummary summarizes the errors encountered during the process of rendering the source of truth.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// kustomizeVersion is the version of Kustomize used by the
+	// hydration-controller to render the commit.
+	// +optional
+	KustomizeVersion string `json:"kustomizeVersion,omitempty"`
+
+	// helmVersion is the version of Helm used by the hydration-controller to
+	// render the commit.
+	// +optional
+	HelmVersion string `json:"helmVersion,omitempty"`
+
+	// tool is the tool which rendered the source of truth: `helm`,
+	// `kustomize`, or `none` if the source of truth was not rendered.
+	// Kustomize also inflates the Helm charts referenced by a kustomization.
+	// +optional
+	Tool string `json:"tool,omitempty"`
+
+	// sourceChecksum is the checksum of the source configs which were
+	// rendered by the hydration-controller. It is only set if the rendering
+	// succeeded.
+	// +optional
+	SourceChecksum string `json:"sourceChecksum,omitempty"`
+
+	// duration is how long the hydration-controller took to render the
+	// commit. It is only set if the rendering succeeded.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // SyncStatus provides the status of the syncing of resources from a source-of-truth on to the cluster.
@@ -156,6 +241,47 @@ type SyncStatus struct {
 	// errorSummary summarizes the errors encountered during the process of syncing the resources.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// implicitNamespaces is the sorted list of Namespaces which are not
+	// declared in the source, but are created and managed by the reconciler
+	// because resources declared in the source use them. Only set for a
+	// RootSync with the implicit namespaceStrategy.
+	// +optional
+	ImplicitNamespaces []string `json:"implicitNamespaces,omitempty"`
+
+	// managedNamespaceCount is the number of distinct Namespaces which contain
+	// at least one of the managed objects.
+	// +optional
+	ManagedNamespaceCount int `json:"managedNamespaceCount,omitempty"`
+
+	// frequentlyEdited is an advisory list of the managed objects whose manual
+	// edits are most frequently reverted by the reconciler, most frequently
+	// edited first. At most 10 objects are listed.
+	// +optional
+	FrequentlyEdited []FrequentlyEditedObject `json:"frequentlyEdited,omitempty"`
+}
+
+// FrequentlyEditedObject identifies a managed object which is frequently
+// edited outside of the source of truth.
+type FrequentlyEditedObject struct {
+	// group is the API group of the object.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the kind of the object.
+	Kind string `json:"kind"`
+
+	// namespace is the namespace of the object. Empty for cluster-scoped
+	// objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the object.
+	Name string `json:"name"`
+
+	// editsPerMinute is the estimated number of manual edits per minute
+	// reverted by the reconciler.
+	EditsPerMinute int `json:"editsPerMinute"`
 }
 
 // GitStatus describes the status of a Git source of truth.
@@ -211,6 +337,50 @@ type ConfigSyncError struct {
 	Resources []ResourceRef `json:"errorResources,omitempty"`
 }
 
+// StatusSummary is a compact, versioned summary of the status of a RootSync
+// or RepoSync.
+type StatusSummary struct {
+	// version is the version of the summary format.
+	Version int `json:"version"`
+
+	// state is the overall state of the sync.
+	// +kubebuilder:validation:Enum=Syncing;Synced;Paused;Error
+	State configsync.SyncState `json:"state"`
+
+	// commit is the hash of the source of truth being synced, or most
+	// recently synced.
+	// It can be a git commit hash, or an OCI image digest.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// errorCount is the total number of source and sync errors.
+	// +optional
+	ErrorCount int `json:"errorCount,omitempty"`
+
+	// lastSyncTime is the timestamp of when the most recent sync completed.
+	// +nullable
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// FleetWorkloadIdentityStatus describes the Fleet Workload Identity
+// credentials, which are built from the Fleet Membership of the cluster.
+type FleetWorkloadIdentityStatus struct {
+	// membershipGeneration is the generation of the Fleet Membership which the
+	// credentials are built from. The credentials are refreshed whenever the
+	// generation of the Membership changes.
+	// +optional
+	MembershipGeneration int64 `json:"membershipGeneration,omitempty"`
+
+	// workloadIdentityPool is the Workload Identity Pool of the fleet.
+	// +optional
+	WorkloadIdentityPool string `json:"workloadIdentityPool,omitempty"`
+
+	// identityProvider is the identity provider of the cluster.
+	// +optional
+	IdentityProvider string `json:"identityProvider,omitempty"`
+}
+
 // ErrorSummary summarizes the errors encountered.
 type ErrorSummary struct {
 	// totalCount tracks the total number of errors.
@@ -8,6 +8,7 @@ package v1alpha1
 import (
 	unsafe "unsafe"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
@@ -63,6 +64,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FleetWorkloadIdentityStatus)(nil), (*v1beta1.FleetWorkloadIdentityStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FleetWorkloadIdentityStatus_To_v1beta1_FleetWorkloadIdentityStatus(a.(*FleetWorkloadIdentityStatus), b.(*v1beta1.FleetWorkloadIdentityStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.FleetWorkloadIdentityStatus)(nil), (*FleetWorkloadIdentityStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FleetWorkloadIdentityStatus_To_v1alpha1_FleetWorkloadIdentityStatus(a.(*v1beta1.FleetWorkloadIdentityStatus), b.(*FleetWorkloadIdentityStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FrequentlyEditedObject)(nil), (*v1beta1.FrequentlyEditedObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FrequentlyEditedObject_To_v1beta1_FrequentlyEditedObject(a.(*FrequentlyEditedObject), b.(*v1beta1.FrequentlyEditedObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.FrequentlyEditedObject)(nil), (*FrequentlyEditedObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FrequentlyEditedObject_To_v1alpha1_FrequentlyEditedObject(a.(*v1beta1.FrequentlyEditedObject), b.(*FrequentlyEditedObject), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Git)(nil), (*v1beta1.Git)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Git_To_v1beta1_Git(a.(*Git), b.(*v1beta1.Git), scope)
 	}); err != nil {
@@ -113,6 +134,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KnownHostsConfigMapRef)(nil), (*v1beta1.KnownHostsConfigMapRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KnownHostsConfigMapRef_To_v1beta1_KnownHostsConfigMapRef(a.(*KnownHostsConfigMapRef), b.(*v1beta1.KnownHostsConfigMapRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.KnownHostsConfigMapRef)(nil), (*KnownHostsConfigMapRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KnownHostsConfigMapRef_To_v1alpha1_KnownHostsConfigMapRef(a.(*v1beta1.KnownHostsConfigMapRef), b.(*KnownHostsConfigMapRef), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Oci)(nil), (*v1beta1.Oci)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Oci_To_v1beta1_Oci(a.(*Oci), b.(*v1beta1.Oci), scope)
 	}); err != nil {
@@ -143,6 +174,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PostSyncVerification)(nil), (*v1beta1.PostSyncVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PostSyncVerification_To_v1beta1_PostSyncVerification(a.(*PostSyncVerification), b.(*v1beta1.PostSyncVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PostSyncVerification)(nil), (*PostSyncVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PostSyncVerification_To_v1alpha1_PostSyncVerification(a.(*v1beta1.PostSyncVerification), b.(*PostSyncVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RemediatorExcludedKind)(nil), (*v1beta1.RemediatorExcludedKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RemediatorExcludedKind_To_v1beta1_RemediatorExcludedKind(a.(*RemediatorExcludedKind), b.(*v1beta1.RemediatorExcludedKind), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RemediatorExcludedKind)(nil), (*RemediatorExcludedKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RemediatorExcludedKind_To_v1alpha1_RemediatorExcludedKind(a.(*v1beta1.RemediatorExcludedKind), b.(*RemediatorExcludedKind), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RenderingStatus)(nil), (*v1beta1.RenderingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(a.(*RenderingStatus), b.(*v1beta1.RenderingStatus), scope)
 	}); err != nil {
@@ -213,6 +264,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RequiredMetadata)(nil), (*v1beta1.RequiredMetadata)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RequiredMetadata_To_v1beta1_RequiredMetadata(a.(*RequiredMetadata), b.(*v1beta1.RequiredMetadata), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RequiredMetadata)(nil), (*RequiredMetadata)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RequiredMetadata_To_v1alpha1_RequiredMetadata(a.(*v1beta1.RequiredMetadata), b.(*RequiredMetadata), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ResourceRef)(nil), (*v1beta1.ResourceRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(a.(*ResourceRef), b.(*v1beta1.ResourceRef), scope)
 	}); err != nil {
@@ -303,6 +364,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SourceHealth)(nil), (*v1beta1.SourceHealth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SourceHealth_To_v1beta1_SourceHealth(a.(*SourceHealth), b.(*v1beta1.SourceHealth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.SourceHealth)(nil), (*SourceHealth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SourceHealth_To_v1alpha1_SourceHealth(a.(*v1beta1.SourceHealth), b.(*SourceHealth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SourceStatus)(nil), (*v1beta1.SourceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(a.(*SourceStatus), b.(*v1beta1.SourceStatus), scope)
 	}); err != nil {
@@ -323,6 +394,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StatusSummary)(nil), (*v1beta1.StatusSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StatusSummary_To_v1beta1_StatusSummary(a.(*StatusSummary), b.(*v1beta1.StatusSummary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.StatusSummary)(nil), (*StatusSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_StatusSummary_To_v1alpha1_StatusSummary(a.(*v1beta1.StatusSummary), b.(*StatusSummary), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SyncStatus)(nil), (*v1beta1.SyncStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(a.(*SyncStatus), b.(*v1beta1.SyncStatus), scope)
 	}); err != nil {
@@ -454,18 +535,75 @@ func Convert_v1beta1_ErrorSummary_To_v1alpha1_ErrorSummary(in *v1beta1.ErrorSumm
 	return autoConvert_v1beta1_ErrorSummary_To_v1alpha1_ErrorSummary(in, out, s)
 }
 
+func autoConvert_v1alpha1_FleetWorkloadIdentityStatus_To_v1beta1_FleetWorkloadIdentityStatus(in *FleetWorkloadIdentityStatus, out *v1beta1.FleetWorkloadIdentityStatus, s conversion.Scope) error {
+	out.MembershipGeneration = in.MembershipGeneration
+	out.WorkloadIdentityPool = in.WorkloadIdentityPool
+	out.IdentityProvider = in.IdentityProvider
+	return nil
+}
+
+// Convert_v1alpha1_FleetWorkloadIdentityStatus_To_v1beta1_FleetWorkloadIdentityStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FleetWorkloadIdentityStatus_To_v1beta1_FleetWorkloadIdentityStatus(in *FleetWorkloadIdentityStatus, out *v1beta1.FleetWorkloadIdentityStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FleetWorkloadIdentityStatus_To_v1beta1_FleetWorkloadIdentityStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_FleetWorkloadIdentityStatus_To_v1alpha1_FleetWorkloadIdentityStatus(in *v1beta1.FleetWorkloadIdentityStatus, out *FleetWorkloadIdentityStatus, s conversion.Scope) error {
+	out.MembershipGeneration = in.MembershipGeneration
+	out.WorkloadIdentityPool = in.WorkloadIdentityPool
+	out.IdentityProvider = in.IdentityProvider
+	return nil
+}
+
+// Convert_v1beta1_FleetWorkloadIdentityStatus_To_v1alpha1_FleetWorkloadIdentityStatus is an autogenerated conversion function.
+func Convert_v1beta1_FleetWorkloadIdentityStatus_To_v1alpha1_FleetWorkloadIdentityStatus(in *v1beta1.FleetWorkloadIdentityStatus, out *FleetWorkloadIdentityStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_FleetWorkloadIdentityStatus_To_v1alpha1_FleetWorkloadIdentityStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_FrequentlyEditedObject_To_v1beta1_FrequentlyEditedObject(in *FrequentlyEditedObject, out *v1beta1.FrequentlyEditedObject, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.EditsPerMinute = in.EditsPerMinute
+	return nil
+}
+
+// Convert_v1alpha1_FrequentlyEditedObject_To_v1beta1_FrequentlyEditedObject is an autogenerated conversion function.
+func Convert_v1alpha1_FrequentlyEditedObject_To_v1beta1_FrequentlyEditedObject(in *FrequentlyEditedObject, out *v1beta1.FrequentlyEditedObject, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FrequentlyEditedObject_To_v1beta1_FrequentlyEditedObject(in, out, s)
+}
+
+func autoConvert_v1beta1_FrequentlyEditedObject_To_v1alpha1_FrequentlyEditedObject(in *v1beta1.FrequentlyEditedObject, out *FrequentlyEditedObject, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.EditsPerMinute = in.EditsPerMinute
+	return nil
+}
+
+// Convert_v1beta1_FrequentlyEditedObject_To_v1alpha1_FrequentlyEditedObject is an autogenerated conversion function.
+func Convert_v1beta1_FrequentlyEditedObject_To_v1alpha1_FrequentlyEditedObject(in *v1beta1.FrequentlyEditedObject, out *FrequentlyEditedObject, s conversion.Scope) error {
+	return autoConvert_v1beta1_FrequentlyEditedObject_To_v1alpha1_FrequentlyEditedObject(in, out, s)
+}
+
 func autoConvert_v1alpha1_Git_To_v1beta1_Git(in *Git, out *v1beta1.Git, s conversion.Scope) error {
 	out.Repo = in.Repo
 	out.Branch = in.Branch
 	out.Revision = in.Revision
 	out.Dir = in.Dir
+	out.Dirs = *(*[]string)(unsafe.Pointer(&in.Dirs))
+	out.DirsCollisionPolicy = configsync.DirsCollisionPolicy(in.DirsCollisionPolicy)
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.Proxy = in.Proxy
+	out.NoProxy = in.NoProxy
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.NoSSLVerify = in.NoSSLVerify
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.ClientCertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.KnownHostsConfigMapRef = (*v1beta1.KnownHostsConfigMapRef)(unsafe.Pointer(in.KnownHostsConfigMapRef))
 	return nil
 }
 
@@ -479,13 +617,18 @@ func autoConvert_v1beta1_Git_To_v1alpha1_Git(in *v1beta1.Git, out *Git, s conver
 	out.Branch = in.Branch
 	out.Revision = in.Revision
 	out.Dir = in.Dir
+	out.Dirs = *(*[]string)(unsafe.Pointer(&in.Dirs))
+	out.DirsCollisionPolicy = configsync.DirsCollisionPolicy(in.DirsCollisionPolicy)
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.Proxy = in.Proxy
+	out.NoProxy = in.NoProxy
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.NoSSLVerify = in.NoSSLVerify
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.ClientCertSecretRef = (*SecretReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.KnownHostsConfigMapRef = (*KnownHostsConfigMapRef)(unsafe.Pointer(in.KnownHostsConfigMapRef))
 	return nil
 }
 
@@ -528,10 +671,15 @@ func autoConvert_v1alpha1_HelmBase_To_v1beta1_HelmBase(in *HelmBase, out *v1beta
 	out.Values = (*v1.JSON)(unsafe.Pointer(in.Values))
 	out.ValuesFileRefs = *(*[]v1beta1.ValuesFileRef)(unsafe.Pointer(&in.ValuesFileRefs))
 	out.IncludeCRDs = in.IncludeCRDs
+	out.APIVersions = *(*[]string)(unsafe.Pointer(&in.APIVersions))
+	out.KubeVersion = in.KubeVersion
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.Proxy = in.Proxy
+	out.NoProxy = in.NoProxy
+	out.ClientCertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.ClientCertSecretRef))
 	return nil
 }
 
@@ -548,10 +696,15 @@ func autoConvert_v1beta1_HelmBase_To_v1alpha1_HelmBase(in *v1beta1.HelmBase, out
 	out.Values = (*v1.JSON)(unsafe.Pointer(in.Values))
 	out.ValuesFileRefs = *(*[]ValuesFileRef)(unsafe.Pointer(&in.ValuesFileRefs))
 	out.IncludeCRDs = in.IncludeCRDs
+	out.APIVersions = *(*[]string)(unsafe.Pointer(&in.APIVersions))
+	out.KubeVersion = in.KubeVersion
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.Proxy = in.Proxy
+	out.NoProxy = in.NoProxy
+	out.ClientCertSecretRef = (*SecretReference)(unsafe.Pointer(in.ClientCertSecretRef))
 	return nil
 }
 
@@ -626,12 +779,37 @@ func Convert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in *v1beta1.HelmStatus, o
 	return autoConvert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_KnownHostsConfigMapRef_To_v1beta1_KnownHostsConfigMapRef(in *KnownHostsConfigMapRef, out *v1beta1.KnownHostsConfigMapRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DataKey = in.DataKey
+	return nil
+}
+
+// Convert_v1alpha1_KnownHostsConfigMapRef_To_v1beta1_KnownHostsConfigMapRef is an autogenerated conversion function.
+func Convert_v1alpha1_KnownHostsConfigMapRef_To_v1beta1_KnownHostsConfigMapRef(in *KnownHostsConfigMapRef, out *v1beta1.KnownHostsConfigMapRef, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KnownHostsConfigMapRef_To_v1beta1_KnownHostsConfigMapRef(in, out, s)
+}
+
+func autoConvert_v1beta1_KnownHostsConfigMapRef_To_v1alpha1_KnownHostsConfigMapRef(in *v1beta1.KnownHostsConfigMapRef, out *KnownHostsConfigMapRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DataKey = in.DataKey
+	return nil
+}
+
+// Convert_v1beta1_KnownHostsConfigMapRef_To_v1alpha1_KnownHostsConfigMapRef is an autogenerated conversion function.
+func Convert_v1beta1_KnownHostsConfigMapRef_To_v1alpha1_KnownHostsConfigMapRef(in *v1beta1.KnownHostsConfigMapRef, out *KnownHostsConfigMapRef, s conversion.Scope) error {
+	return autoConvert_v1beta1_KnownHostsConfigMapRef_To_v1alpha1_KnownHostsConfigMapRef(in, out, s)
+}
+
 func autoConvert_v1alpha1_Oci_To_v1beta1_Oci(in *Oci, out *v1beta1.Oci, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Dir = in.Dir
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
+	out.Proxy = in.Proxy
+	out.NoProxy = in.NoProxy
+	out.ClientCertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.ClientCertSecretRef))
 	return nil
 }
 
@@ -646,6 +824,9 @@ func autoConvert_v1beta1_Oci_To_v1alpha1_Oci(in *v1beta1.Oci, out *Oci, s conver
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
+	out.Proxy = in.Proxy
+	out.NoProxy = in.NoProxy
+	out.ClientCertSecretRef = (*SecretReference)(unsafe.Pointer(in.ClientCertSecretRef))
 	return nil
 }
 
@@ -679,11 +860,38 @@ func Convert_v1beta1_OciStatus_To_v1alpha1_OciStatus(in *v1beta1.OciStatus, out
 func autoConvert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(in *OverrideSpec, out *v1beta1.OverrideSpec, s conversion.Scope) error {
 	out.Resources = *(*[]v1beta1.ContainerResourcesSpec)(unsafe.Pointer(&in.Resources))
 	out.GitSyncDepth = (*int64)(unsafe.Pointer(in.GitSyncDepth))
+	out.GitSyncTimeout = (*metav1.Duration)(unsafe.Pointer(in.GitSyncTimeout))
 	out.StatusMode = in.StatusMode
 	out.ReconcileTimeout = (*metav1.Duration)(unsafe.Pointer(in.ReconcileTimeout))
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
+	out.ClientQPS = (*int32)(unsafe.Pointer(in.ClientQPS))
+	out.ClientBurst = (*int32)(unsafe.Pointer(in.ClientBurst))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
 	out.LogLevels = *(*[]v1beta1.ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
+	out.DeletionGracePeriod = (*metav1.Duration)(unsafe.Pointer(in.DeletionGracePeriod))
+	out.MinRemediationInterval = (*metav1.Duration)(unsafe.Pointer(in.MinRemediationInterval))
+	out.ApplyCallTimeout = (*metav1.Duration)(unsafe.Pointer(in.ApplyCallTimeout))
+	out.ResyncPeriod = (*metav1.Duration)(unsafe.Pointer(in.ResyncPeriod))
+	out.StatusUpdatePeriod = (*metav1.Duration)(unsafe.Pointer(in.StatusUpdatePeriod))
+	out.RenderingTimeout = (*metav1.Duration)(unsafe.Pointer(in.RenderingTimeout))
+	out.PauseApply = (*bool)(unsafe.Pointer(in.PauseApply))
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.FieldManager = in.FieldManager
+	out.RequiredMetadata = *(*[]v1beta1.RequiredMetadata)(unsafe.Pointer(&in.RequiredMetadata))
+	out.AnnotateSyncGeneration = (*bool)(unsafe.Pointer(in.AnnotateSyncGeneration))
+	out.HydrationControllerImage = in.HydrationControllerImage
+	out.GitSyncImage = in.GitSyncImage
+	out.OciSyncImage = in.OciSyncImage
+	out.HelmSyncImage = in.HelmSyncImage
+	out.ImagePullSecrets = *(*[]corev1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.MaxPruneCount = (*int32)(unsafe.Pointer(in.MaxPruneCount))
+	out.CircuitBreakerThreshold = (*int32)(unsafe.Pointer(in.CircuitBreakerThreshold))
+	out.CircuitBreakerProbePeriod = (*metav1.Duration)(unsafe.Pointer(in.CircuitBreakerProbePeriod))
+	out.ValidateWithAdmission = (*bool)(unsafe.Pointer(in.ValidateWithAdmission))
+	out.RemediatorExcludedKinds = *(*[]v1beta1.RemediatorExcludedKind)(unsafe.Pointer(&in.RemediatorExcludedKinds))
+	out.ObjectSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.ObjectSelector))
+	out.SubstituteClusterName = (*bool)(unsafe.Pointer(in.SubstituteClusterName))
+	out.ApplyDebouncePeriod = (*metav1.Duration)(unsafe.Pointer(in.ApplyDebouncePeriod))
 	return nil
 }
 
@@ -695,11 +903,38 @@ func Convert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(in *OverrideSpec, out
 func autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.OverrideSpec, out *OverrideSpec, s conversion.Scope) error {
 	out.Resources = *(*[]ContainerResourcesSpec)(unsafe.Pointer(&in.Resources))
 	out.GitSyncDepth = (*int64)(unsafe.Pointer(in.GitSyncDepth))
+	out.GitSyncTimeout = (*metav1.Duration)(unsafe.Pointer(in.GitSyncTimeout))
 	out.StatusMode = in.StatusMode
 	out.ReconcileTimeout = (*metav1.Duration)(unsafe.Pointer(in.ReconcileTimeout))
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
+	out.ClientQPS = (*int32)(unsafe.Pointer(in.ClientQPS))
+	out.ClientBurst = (*int32)(unsafe.Pointer(in.ClientBurst))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
 	out.LogLevels = *(*[]ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
+	out.DeletionGracePeriod = (*metav1.Duration)(unsafe.Pointer(in.DeletionGracePeriod))
+	out.MinRemediationInterval = (*metav1.Duration)(unsafe.Pointer(in.MinRemediationInterval))
+	out.ApplyCallTimeout = (*metav1.Duration)(unsafe.Pointer(in.ApplyCallTimeout))
+	out.ResyncPeriod = (*metav1.Duration)(unsafe.Pointer(in.ResyncPeriod))
+	out.StatusUpdatePeriod = (*metav1.Duration)(unsafe.Pointer(in.StatusUpdatePeriod))
+	out.RenderingTimeout = (*metav1.Duration)(unsafe.Pointer(in.RenderingTimeout))
+	out.PauseApply = (*bool)(unsafe.Pointer(in.PauseApply))
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.FieldManager = in.FieldManager
+	out.RequiredMetadata = *(*[]RequiredMetadata)(unsafe.Pointer(&in.RequiredMetadata))
+	out.AnnotateSyncGeneration = (*bool)(unsafe.Pointer(in.AnnotateSyncGeneration))
+	out.HydrationControllerImage = in.HydrationControllerImage
+	out.GitSyncImage = in.GitSyncImage
+	out.OciSyncImage = in.OciSyncImage
+	out.HelmSyncImage = in.HelmSyncImage
+	out.ImagePullSecrets = *(*[]corev1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.MaxPruneCount = (*int32)(unsafe.Pointer(in.MaxPruneCount))
+	out.CircuitBreakerThreshold = (*int32)(unsafe.Pointer(in.CircuitBreakerThreshold))
+	out.CircuitBreakerProbePeriod = (*metav1.Duration)(unsafe.Pointer(in.CircuitBreakerProbePeriod))
+	out.ValidateWithAdmission = (*bool)(unsafe.Pointer(in.ValidateWithAdmission))
+	out.RemediatorExcludedKinds = *(*[]RemediatorExcludedKind)(unsafe.Pointer(&in.RemediatorExcludedKinds))
+	out.ObjectSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.ObjectSelector))
+	out.SubstituteClusterName = (*bool)(unsafe.Pointer(in.SubstituteClusterName))
+	out.ApplyDebouncePeriod = (*metav1.Duration)(unsafe.Pointer(in.ApplyDebouncePeriod))
 	return nil
 }
 
@@ -708,6 +943,48 @@ func Convert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.OverrideS
 	return autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_PostSyncVerification_To_v1beta1_PostSyncVerification(in *PostSyncVerification, out *v1beta1.PostSyncVerification, s conversion.Scope) error {
+	out.JobTemplate = in.JobTemplate
+	return nil
+}
+
+// Convert_v1alpha1_PostSyncVerification_To_v1beta1_PostSyncVerification is an autogenerated conversion function.
+func Convert_v1alpha1_PostSyncVerification_To_v1beta1_PostSyncVerification(in *PostSyncVerification, out *v1beta1.PostSyncVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PostSyncVerification_To_v1beta1_PostSyncVerification(in, out, s)
+}
+
+func autoConvert_v1beta1_PostSyncVerification_To_v1alpha1_PostSyncVerification(in *v1beta1.PostSyncVerification, out *PostSyncVerification, s conversion.Scope) error {
+	out.JobTemplate = in.JobTemplate
+	return nil
+}
+
+// Convert_v1beta1_PostSyncVerification_To_v1alpha1_PostSyncVerification is an autogenerated conversion function.
+func Convert_v1beta1_PostSyncVerification_To_v1alpha1_PostSyncVerification(in *v1beta1.PostSyncVerification, out *PostSyncVerification, s conversion.Scope) error {
+	return autoConvert_v1beta1_PostSyncVerification_To_v1alpha1_PostSyncVerification(in, out, s)
+}
+
+func autoConvert_v1alpha1_RemediatorExcludedKind_To_v1beta1_RemediatorExcludedKind(in *RemediatorExcludedKind, out *v1beta1.RemediatorExcludedKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	return nil
+}
+
+// Convert_v1alpha1_RemediatorExcludedKind_To_v1beta1_RemediatorExcludedKind is an autogenerated conversion function.
+func Convert_v1alpha1_RemediatorExcludedKind_To_v1beta1_RemediatorExcludedKind(in *RemediatorExcludedKind, out *v1beta1.RemediatorExcludedKind, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RemediatorExcludedKind_To_v1beta1_RemediatorExcludedKind(in, out, s)
+}
+
+func autoConvert_v1beta1_RemediatorExcludedKind_To_v1alpha1_RemediatorExcludedKind(in *v1beta1.RemediatorExcludedKind, out *RemediatorExcludedKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	return nil
+}
+
+// Convert_v1beta1_RemediatorExcludedKind_To_v1alpha1_RemediatorExcludedKind is an autogenerated conversion function.
+func Convert_v1beta1_RemediatorExcludedKind_To_v1alpha1_RemediatorExcludedKind(in *v1beta1.RemediatorExcludedKind, out *RemediatorExcludedKind, s conversion.Scope) error {
+	return autoConvert_v1beta1_RemediatorExcludedKind_To_v1alpha1_RemediatorExcludedKind(in, out, s)
+}
+
 func autoConvert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *RenderingStatus, out *v1beta1.RenderingStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
@@ -717,6 +994,11 @@ func autoConvert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *Renderi
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.KustomizeVersion = in.KustomizeVersion
+	out.HelmVersion = in.HelmVersion
+	out.Tool = in.Tool
+	out.SourceChecksum = in.SourceChecksum
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	return nil
 }
 
@@ -734,6 +1016,11 @@ func autoConvert_v1beta1_RenderingStatus_To_v1alpha1_RenderingStatus(in *v1beta1
 	out.Message = in.Message
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.KustomizeVersion = in.KustomizeVersion
+	out.HelmVersion = in.HelmVersion
+	out.Tool = in.Tool
+	out.SourceChecksum = in.SourceChecksum
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
 	return nil
 }
 
@@ -893,6 +1180,8 @@ func autoConvert_v1alpha1_RepoSyncSpec_To_v1beta1_RepoSyncSpec(in *RepoSyncSpec,
 		out.Helm = nil
 	}
 	out.Override = (*v1beta1.RepoSyncOverrideSpec)(unsafe.Pointer(in.Override))
+	out.Suspend = in.Suspend
+	out.DeletionPropagationPolicy = configsync.DeletionPropagationPolicy(in.DeletionPropagationPolicy)
 	return nil
 }
 
@@ -916,6 +1205,8 @@ func autoConvert_v1beta1_RepoSyncSpec_To_v1alpha1_RepoSyncSpec(in *v1beta1.RepoS
 		out.Helm = nil
 	}
 	out.Override = (*RepoSyncOverrideSpec)(unsafe.Pointer(in.Override))
+	out.Suspend = in.Suspend
+	out.DeletionPropagationPolicy = configsync.DeletionPropagationPolicy(in.DeletionPropagationPolicy)
 	return nil
 }
 
@@ -950,6 +1241,28 @@ func Convert_v1beta1_RepoSyncStatus_To_v1alpha1_RepoSyncStatus(in *v1beta1.RepoS
 	return autoConvert_v1beta1_RepoSyncStatus_To_v1alpha1_RepoSyncStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_RequiredMetadata_To_v1beta1_RequiredMetadata(in *RequiredMetadata, out *v1beta1.RequiredMetadata, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Type = configsync.RequiredMetadataType(in.Type)
+	return nil
+}
+
+// Convert_v1alpha1_RequiredMetadata_To_v1beta1_RequiredMetadata is an autogenerated conversion function.
+func Convert_v1alpha1_RequiredMetadata_To_v1beta1_RequiredMetadata(in *RequiredMetadata, out *v1beta1.RequiredMetadata, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RequiredMetadata_To_v1beta1_RequiredMetadata(in, out, s)
+}
+
+func autoConvert_v1beta1_RequiredMetadata_To_v1alpha1_RequiredMetadata(in *v1beta1.RequiredMetadata, out *RequiredMetadata, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Type = configsync.RequiredMetadataType(in.Type)
+	return nil
+}
+
+// Convert_v1beta1_RequiredMetadata_To_v1alpha1_RequiredMetadata is an autogenerated conversion function.
+func Convert_v1beta1_RequiredMetadata_To_v1alpha1_RequiredMetadata(in *v1beta1.RequiredMetadata, out *RequiredMetadata, s conversion.Scope) error {
+	return autoConvert_v1beta1_RequiredMetadata_To_v1alpha1_RequiredMetadata(in, out, s)
+}
+
 func autoConvert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(in *ResourceRef, out *v1beta1.ResourceRef, s conversion.Scope) error {
 	out.SourcePath = in.SourcePath
 	out.Name = in.Name
@@ -1093,6 +1406,13 @@ func autoConvert_v1alpha1_RootSyncOverrideSpec_To_v1beta1_RootSyncOverrideSpec(i
 		return err
 	}
 	out.NamespaceStrategy = configsync.NamespaceStrategy(in.NamespaceStrategy)
+	out.AmbiguousSourceFormat = configsync.AmbiguousSourceFormatPolicy(in.AmbiguousSourceFormat)
+	out.NamespaceMismatchPolicy = configsync.NamespaceMismatchPolicy(in.NamespaceMismatchPolicy)
+	out.DynamicNamespaceSelector = (*bool)(unsafe.Pointer(in.DynamicNamespaceSelector))
+	out.DeferUnestablishedCRs = (*bool)(unsafe.Pointer(in.DeferUnestablishedCRs))
+	out.ClusterScopedConflictCheck = (*bool)(unsafe.Pointer(in.ClusterScopedConflictCheck))
+	out.ConflictPolicy = configsync.ConflictPolicy(in.ConflictPolicy)
+	out.PostSyncVerification = (*v1beta1.PostSyncVerification)(unsafe.Pointer(in.PostSyncVerification))
 	out.RoleRefs = *(*[]v1beta1.RootSyncRoleRef)(unsafe.Pointer(&in.RoleRefs))
 	return nil
 }
@@ -1107,6 +1427,13 @@ func autoConvert_v1beta1_RootSyncOverrideSpec_To_v1alpha1_RootSyncOverrideSpec(i
 		return err
 	}
 	out.NamespaceStrategy = configsync.NamespaceStrategy(in.NamespaceStrategy)
+	out.AmbiguousSourceFormat = configsync.AmbiguousSourceFormatPolicy(in.AmbiguousSourceFormat)
+	out.NamespaceMismatchPolicy = configsync.NamespaceMismatchPolicy(in.NamespaceMismatchPolicy)
+	out.DynamicNamespaceSelector = (*bool)(unsafe.Pointer(in.DynamicNamespaceSelector))
+	out.DeferUnestablishedCRs = (*bool)(unsafe.Pointer(in.DeferUnestablishedCRs))
+	out.ClusterScopedConflictCheck = (*bool)(unsafe.Pointer(in.ClusterScopedConflictCheck))
+	out.ConflictPolicy = configsync.ConflictPolicy(in.ConflictPolicy)
+	out.PostSyncVerification = (*PostSyncVerification)(unsafe.Pointer(in.PostSyncVerification))
 	out.RoleRefs = *(*[]RootSyncRoleRef)(unsafe.Pointer(&in.RoleRefs))
 	return nil
 }
@@ -1143,6 +1470,7 @@ func Convert_v1beta1_RootSyncRoleRef_To_v1alpha1_RootSyncRoleRef(in *v1beta1.Roo
 func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec, out *v1beta1.RootSyncSpec, s conversion.Scope) error {
 	out.SourceFormat = in.SourceFormat
 	out.SourceType = in.SourceType
+	out.Mode = configsync.SyncMode(in.Mode)
 	out.Git = (*v1beta1.Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.Oci)(unsafe.Pointer(in.Oci))
 	if in.Helm != nil {
@@ -1155,6 +1483,8 @@ func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec,
 		out.Helm = nil
 	}
 	out.Override = (*v1beta1.RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
+	out.Suspend = in.Suspend
+	out.DeletionPropagationPolicy = configsync.DeletionPropagationPolicy(in.DeletionPropagationPolicy)
 	return nil
 }
 
@@ -1166,6 +1496,7 @@ func Convert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec, out
 func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootSyncSpec, out *RootSyncSpec, s conversion.Scope) error {
 	out.SourceFormat = in.SourceFormat
 	out.SourceType = in.SourceType
+	out.Mode = configsync.SyncMode(in.Mode)
 	out.Git = (*Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*Oci)(unsafe.Pointer(in.Oci))
 	if in.Helm != nil {
@@ -1178,6 +1509,8 @@ func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootS
 		out.Helm = nil
 	}
 	out.Override = (*RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
+	out.Suspend = in.Suspend
+	out.DeletionPropagationPolicy = configsync.DeletionPropagationPolicy(in.DeletionPropagationPolicy)
 	return nil
 }
 
@@ -1232,6 +1565,34 @@ func Convert_v1beta1_SecretReference_To_v1alpha1_SecretReference(in *v1beta1.Sec
 	return autoConvert_v1beta1_SecretReference_To_v1alpha1_SecretReference(in, out, s)
 }
 
+func autoConvert_v1alpha1_SourceHealth_To_v1beta1_SourceHealth(in *SourceHealth, out *v1beta1.SourceHealth, s conversion.Scope) error {
+	out.SourceType = v1beta1.SourceType(in.SourceType)
+	out.Commit = in.Commit
+	out.LastUpdate = in.LastUpdate
+	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
+	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	return nil
+}
+
+// Convert_v1alpha1_SourceHealth_To_v1beta1_SourceHealth is an autogenerated conversion function.
+func Convert_v1alpha1_SourceHealth_To_v1beta1_SourceHealth(in *SourceHealth, out *v1beta1.SourceHealth, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SourceHealth_To_v1beta1_SourceHealth(in, out, s)
+}
+
+func autoConvert_v1beta1_SourceHealth_To_v1alpha1_SourceHealth(in *v1beta1.SourceHealth, out *SourceHealth, s conversion.Scope) error {
+	out.SourceType = SourceType(in.SourceType)
+	out.Commit = in.Commit
+	out.LastUpdate = in.LastUpdate
+	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
+	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	return nil
+}
+
+// Convert_v1beta1_SourceHealth_To_v1alpha1_SourceHealth is an autogenerated conversion function.
+func Convert_v1beta1_SourceHealth_To_v1alpha1_SourceHealth(in *v1beta1.SourceHealth, out *SourceHealth, s conversion.Scope) error {
+	return autoConvert_v1beta1_SourceHealth_To_v1alpha1_SourceHealth(in, out, s)
+}
+
 func autoConvert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus, out *v1beta1.SourceStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
@@ -1240,6 +1601,7 @@ func autoConvert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus,
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Sources = *(*[]v1beta1.SourceHealth)(unsafe.Pointer(&in.Sources))
 	return nil
 }
 
@@ -1256,6 +1618,7 @@ func autoConvert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.Sourc
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Sources = *(*[]SourceHealth)(unsafe.Pointer(&in.Sources))
 	return nil
 }
 
@@ -1267,6 +1630,10 @@ func Convert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.SourceSta
 func autoConvert_v1alpha1_Status_To_v1beta1_Status(in *Status, out *v1beta1.Status, s conversion.Scope) error {
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Reconciler = in.Reconciler
+	out.ReconcilerVersion = in.ReconcilerVersion
+	out.WebhookEnforcing = in.WebhookEnforcing
+	out.Summary = (*v1beta1.StatusSummary)(unsafe.Pointer(in.Summary))
+	out.FleetWorkloadIdentity = (*v1beta1.FleetWorkloadIdentityStatus)(unsafe.Pointer(in.FleetWorkloadIdentity))
 	out.LastSyncedCommit = in.LastSyncedCommit
 	if err := Convert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(&in.Source, &out.Source, s); err != nil {
 		return err
@@ -1288,6 +1655,10 @@ func Convert_v1alpha1_Status_To_v1beta1_Status(in *Status, out *v1beta1.Status,
 func autoConvert_v1beta1_Status_To_v1alpha1_Status(in *v1beta1.Status, out *Status, s conversion.Scope) error {
 	out.ObservedGeneration = in.ObservedGeneration
 	out.Reconciler = in.Reconciler
+	out.ReconcilerVersion = in.ReconcilerVersion
+	out.WebhookEnforcing = in.WebhookEnforcing
+	out.Summary = (*StatusSummary)(unsafe.Pointer(in.Summary))
+	out.FleetWorkloadIdentity = (*FleetWorkloadIdentityStatus)(unsafe.Pointer(in.FleetWorkloadIdentity))
 	out.LastSyncedCommit = in.LastSyncedCommit
 	if err := Convert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(&in.Source, &out.Source, s); err != nil {
 		return err
@@ -1306,6 +1677,34 @@ func Convert_v1beta1_Status_To_v1alpha1_Status(in *v1beta1.Status, out *Status,
 	return autoConvert_v1beta1_Status_To_v1alpha1_Status(in, out, s)
 }
 
+func autoConvert_v1alpha1_StatusSummary_To_v1beta1_StatusSummary(in *StatusSummary, out *v1beta1.StatusSummary, s conversion.Scope) error {
+	out.Version = in.Version
+	out.State = configsync.SyncState(in.State)
+	out.Commit = in.Commit
+	out.ErrorCount = in.ErrorCount
+	out.LastSyncTime = in.LastSyncTime
+	return nil
+}
+
+// Convert_v1alpha1_StatusSummary_To_v1beta1_StatusSummary is an autogenerated conversion function.
+func Convert_v1alpha1_StatusSummary_To_v1beta1_StatusSummary(in *StatusSummary, out *v1beta1.StatusSummary, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StatusSummary_To_v1beta1_StatusSummary(in, out, s)
+}
+
+func autoConvert_v1beta1_StatusSummary_To_v1alpha1_StatusSummary(in *v1beta1.StatusSummary, out *StatusSummary, s conversion.Scope) error {
+	out.Version = in.Version
+	out.State = configsync.SyncState(in.State)
+	out.Commit = in.Commit
+	out.ErrorCount = in.ErrorCount
+	out.LastSyncTime = in.LastSyncTime
+	return nil
+}
+
+// Convert_v1beta1_StatusSummary_To_v1alpha1_StatusSummary is an autogenerated conversion function.
+func Convert_v1beta1_StatusSummary_To_v1alpha1_StatusSummary(in *v1beta1.StatusSummary, out *StatusSummary, s conversion.Scope) error {
+	return autoConvert_v1beta1_StatusSummary_To_v1alpha1_StatusSummary(in, out, s)
+}
+
 func autoConvert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *v1beta1.SyncStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
@@ -1314,6 +1713,9 @@ func autoConvert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.ImplicitNamespaces = *(*[]string)(unsafe.Pointer(&in.ImplicitNamespaces))
+	out.ManagedNamespaceCount = in.ManagedNamespaceCount
+	out.FrequentlyEdited = *(*[]v1beta1.FrequentlyEditedObject)(unsafe.Pointer(&in.FrequentlyEdited))
 	return nil
 }
 
@@ -1330,6 +1732,9 @@ func autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatu
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.ImplicitNamespaces = *(*[]string)(unsafe.Pointer(&in.ImplicitNamespaces))
+	out.ManagedNamespaceCount = in.ManagedNamespaceCount
+	out.FrequentlyEdited = *(*[]FrequentlyEditedObject)(unsafe.Pointer(&in.FrequentlyEdited))
 	return nil
 }
 
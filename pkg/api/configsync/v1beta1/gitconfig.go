@@ -44,6 +44,20 @@ type Git struct {
 	// +optional
 	Dir string `json:"dir,omitempty"`
 
+	// dirs is an ordered list of absolute paths of directories that contain
+	// the local resources. The resources from all the directories are synced
+	// as a union. Mutually exclusive with 'dir'.
+	// +optional
+	Dirs []string `json:"dirs,omitempty"`
+
+	// dirsCollisionPolicy specifies how an object declared in more than one
+	// of the 'dirs' is handled. Must be one of override or error.
+	// If override, the object in the later directory takes precedence.
+	// Default: override.
+	// +kubebuilder:validation:Enum=override;error
+	// +optional
+	DirsCollisionPolicy configsync.DirsCollisionPolicy `json:"dirsCollisionPolicy,omitempty"`
+
 	// period is the time duration between consecutive syncs. Default: 15s.
 	// Note to developers that customers specify this value using
 	// string (https://golang.org/pkg/time/#Duration.String) like "3s"
@@ -74,6 +88,13 @@ type Git struct {
 	// +optional
 	Proxy string `json:"proxy,omitempty"`
 
+	// noProxy specifies a comma-separated list of hosts, domains, IP addresses
+	// or CIDR ranges that should bypass the proxy, e.g. "localhost,.svc,10.0.0.0/8".
+	// It is passed to git-sync as the NO_PROXY environment variable.
+	// Only has an effect when secretType is one of ("cookiefile", "none", "token").
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
 	// secretRef is the secret used to connect to the Git source of truth.
 	// +nullable
 	// +optional
@@ -93,6 +114,39 @@ type Git struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// clientCertSecretRef specifies the name of the secret where the client certificate
+	// and private key used for mutual TLS with the Git repository are stored. The creation of
+	// the secret should be done out of band by the user and should store the certificate
+	// in a key named "tls.crt" and the private key in a key named "tls.key", e.g. a
+	// Secret of type kubernetes.io/tls. For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
+
+	// knownHostsConfigMapRef specifies the ConfigMap where the known_hosts used
+	// to verify the SSH host keys of the Git server are stored. It is only used
+	// when `auth` is `ssh`, and takes precedence over the `known_hosts` key of
+	// the `secretRef` Secret. For RepoSync resources, the ConfigMap must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the
+	// ConfigMap must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	KnownHostsConfigMapRef *KnownHostsConfigMapRef `json:"knownHostsConfigMapRef,omitempty"`
+}
+
+// KnownHostsConfigMapRef contains the reference to the ConfigMap which stores
+// the known_hosts used to connect to the Git server.
+type KnownHostsConfigMapRef struct {
+	// name represents the ConfigMap name. Required.
+	Name string `json:"name,omitempty"`
+
+	// dataKey represents the ConfigMap data key to read the known_hosts from.
+	// Default: `known_hosts`
+	// +optional
+	DataKey string `json:"dataKey,omitempty"`
 }
 
 // SecretReference contains the reference to the secret used to connect to
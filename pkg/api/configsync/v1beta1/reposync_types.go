@@ -16,6 +16,7 @@ package v1beta1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 )
 
 // +kubebuilder:object:root=true
@@ -77,6 +78,23 @@ type RepoSyncSpec struct {
 	// +nullable
 	// +optional
 	Override *RepoSyncOverrideSpec `json:"override,omitempty"`
+
+	// suspend specifies whether syncing is suspended. If true, the reconciler
+	// Deployment is scaled down to zero replicas until suspend is unset,
+	// preserving the status of the RepoSync.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// deletionPropagationPolicy specifies what happens to the managed objects
+	// when the RepoSync is deleted. Orphan leaves the managed objects on the
+	// cluster, removes their Config Sync metadata, and deletes the inventory.
+	// Foreground deletes the managed objects before the RepoSync is deleted.
+	// If unset, the `configsync.gke.io/deletion-propagation-policy`
+	// annotation is used, which defaults to leaving the managed objects
+	// untouched.
+	// +kubebuilder:validation:Enum=Foreground;Orphan
+	// +optional
+	DeletionPropagationPolicy configsync.DeletionPropagationPolicy `json:"deletionPropagationPolicy,omitempty"`
 }
 
 // RepoSyncStatus defines the observed state of a RepoSync.
@@ -110,6 +128,15 @@ const (
 	RepoSyncReconcilerFinalizing RepoSyncConditionType = "ReconcilerFinalizing"
 	// RepoSyncReconcilerFinalizerFailure means that the namespace reconciler finalizer has errored, blocking deletion.
 	RepoSyncReconcilerFinalizerFailure RepoSyncConditionType = "ReconcilerFinalizerFailure"
+	// RepoSyncNamespaceManagedByRootSync means that the namespace of the RepoSync is managed by a RootSync,
+	// which affects what is deleted when either of them is deleted.
+	RepoSyncNamespaceManagedByRootSync RepoSyncConditionType = "NamespaceManagedByRootSync"
+	// RepoSyncSuspended means that syncing is suspended, and the reconciler Deployment is scaled down to zero replicas.
+	RepoSyncSuspended RepoSyncConditionType = "Suspended"
+	// RepoSyncStatusContention means that the status updates of the RepoSync repeatedly conflict with the writes of another actor.
+	RepoSyncStatusContention RepoSyncConditionType = "StatusContention"
+	// RepoSyncAPIServerUnavailable means that the namespace reconciler paused syncing after repeated API server errors, and is probing for the API server to recover.
+	RepoSyncAPIServerUnavailable RepoSyncConditionType = "APIServerUnavailable"
 )
 
 // ErrorSource indicates the origination of errors.
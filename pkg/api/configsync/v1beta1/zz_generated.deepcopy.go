@@ -5,6 +5,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -79,9 +80,44 @@ func (in *ErrorSummary) DeepCopy() *ErrorSummary {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetWorkloadIdentityStatus) DeepCopyInto(out *FleetWorkloadIdentityStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkloadIdentityStatus.
+func (in *FleetWorkloadIdentityStatus) DeepCopy() *FleetWorkloadIdentityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetWorkloadIdentityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrequentlyEditedObject) DeepCopyInto(out *FrequentlyEditedObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrequentlyEditedObject.
+func (in *FrequentlyEditedObject) DeepCopy() *FrequentlyEditedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(FrequentlyEditedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Git) DeepCopyInto(out *Git) {
 	*out = *in
+	if in.Dirs != nil {
+		in, out := &in.Dirs, &out.Dirs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.Period = in.Period
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
@@ -93,6 +129,16 @@ func (in *Git) DeepCopyInto(out *Git) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.KnownHostsConfigMapRef != nil {
+		in, out := &in.KnownHostsConfigMapRef, &out.KnownHostsConfigMapRef
+		*out = new(KnownHostsConfigMapRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Git.
@@ -133,6 +179,11 @@ func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 		*out = make([]ValuesFileRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.APIVersions != nil {
+		in, out := &in.APIVersions, &out.APIVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.Period = in.Period
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
@@ -144,6 +195,11 @@ func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmBase.
@@ -203,6 +259,21 @@ func (in *HelmStatus) DeepCopy() *HelmStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnownHostsConfigMapRef) DeepCopyInto(out *KnownHostsConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnownHostsConfigMapRef.
+func (in *KnownHostsConfigMapRef) DeepCopy() *KnownHostsConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KnownHostsConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Oci) DeepCopyInto(out *Oci) {
 	*out = *in
@@ -212,6 +283,11 @@ func (in *Oci) DeepCopyInto(out *Oci) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Oci.
@@ -254,6 +330,11 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.GitSyncTimeout != nil {
+		in, out := &in.GitSyncTimeout, &out.GitSyncTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ReconcileTimeout != nil {
 		in, out := &in.ReconcileTimeout, &out.ReconcileTimeout
 		*out = new(metav1.Duration)
@@ -264,6 +345,16 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.ClientQPS != nil {
+		in, out := &in.ClientQPS, &out.ClientQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientBurst != nil {
+		in, out := &in.ClientBurst, &out.ClientBurst
+		*out = new(int32)
+		**out = **in
+	}
 	if in.EnableShellInRendering != nil {
 		in, out := &in.EnableShellInRendering, &out.EnableShellInRendering
 		*out = new(bool)
@@ -274,6 +365,101 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = make([]ContainerLogLevelOverride, len(*in))
 		copy(*out, *in)
 	}
+	if in.DeletionGracePeriod != nil {
+		in, out := &in.DeletionGracePeriod, &out.DeletionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinRemediationInterval != nil {
+		in, out := &in.MinRemediationInterval, &out.MinRemediationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ApplyCallTimeout != nil {
+		in, out := &in.ApplyCallTimeout, &out.ApplyCallTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StatusUpdatePeriod != nil {
+		in, out := &in.StatusUpdatePeriod, &out.StatusUpdatePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenderingTimeout != nil {
+		in, out := &in.RenderingTimeout, &out.RenderingTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PauseApply != nil {
+		in, out := &in.PauseApply, &out.PauseApply
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequiredMetadata != nil {
+		in, out := &in.RequiredMetadata, &out.RequiredMetadata
+		*out = make([]RequiredMetadata, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotateSyncGeneration != nil {
+		in, out := &in.AnnotateSyncGeneration, &out.AnnotateSyncGeneration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxPruneCount != nil {
+		in, out := &in.MaxPruneCount, &out.MaxPruneCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CircuitBreakerThreshold != nil {
+		in, out := &in.CircuitBreakerThreshold, &out.CircuitBreakerThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CircuitBreakerProbePeriod != nil {
+		in, out := &in.CircuitBreakerProbePeriod, &out.CircuitBreakerProbePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ValidateWithAdmission != nil {
+		in, out := &in.ValidateWithAdmission, &out.ValidateWithAdmission
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RemediatorExcludedKinds != nil {
+		in, out := &in.RemediatorExcludedKinds, &out.RemediatorExcludedKinds
+		*out = make([]RemediatorExcludedKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubstituteClusterName != nil {
+		in, out := &in.SubstituteClusterName, &out.SubstituteClusterName
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ApplyDebouncePeriod != nil {
+		in, out := &in.ApplyDebouncePeriod, &out.ApplyDebouncePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideSpec.
@@ -286,6 +472,37 @@ func (in *OverrideSpec) DeepCopy() *OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostSyncVerification) DeepCopyInto(out *PostSyncVerification) {
+	*out = *in
+	in.JobTemplate.DeepCopyInto(&out.JobTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostSyncVerification.
+func (in *PostSyncVerification) DeepCopy() *PostSyncVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(PostSyncVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediatorExcludedKind) DeepCopyInto(out *RemediatorExcludedKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediatorExcludedKind.
+func (in *RemediatorExcludedKind) DeepCopy() *RemediatorExcludedKind {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediatorExcludedKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
@@ -317,6 +534,11 @@ func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderingStatus.
@@ -496,6 +718,21 @@ func (in *RepoSyncStatus) DeepCopy() *RepoSyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredMetadata) DeepCopyInto(out *RequiredMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredMetadata.
+func (in *RequiredMetadata) DeepCopy() *RequiredMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
 	*out = *in
@@ -609,6 +846,26 @@ func (in *RootSyncList) DeepCopyObject() runtime.Object {
 func (in *RootSyncOverrideSpec) DeepCopyInto(out *RootSyncOverrideSpec) {
 	*out = *in
 	in.OverrideSpec.DeepCopyInto(&out.OverrideSpec)
+	if in.DynamicNamespaceSelector != nil {
+		in, out := &in.DynamicNamespaceSelector, &out.DynamicNamespaceSelector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeferUnestablishedCRs != nil {
+		in, out := &in.DeferUnestablishedCRs, &out.DeferUnestablishedCRs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClusterScopedConflictCheck != nil {
+		in, out := &in.ClusterScopedConflictCheck, &out.ClusterScopedConflictCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PostSyncVerification != nil {
+		in, out := &in.PostSyncVerification, &out.PostSyncVerification
+		*out = new(PostSyncVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RoleRefs != nil {
 		in, out := &in.RoleRefs, &out.RoleRefs
 		*out = make([]RootSyncRoleRef, len(*in))
@@ -714,6 +971,34 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceHealth) DeepCopyInto(out *SourceHealth) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]ConfigSyncError, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ErrorSummary != nil {
+		in, out := &in.ErrorSummary, &out.ErrorSummary
+		*out = new(ErrorSummary)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceHealth.
+func (in *SourceHealth) DeepCopy() *SourceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 	*out = *in
@@ -745,6 +1030,13 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SourceHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatus.
@@ -760,6 +1052,16 @@ func (in *SourceStatus) DeepCopy() *SourceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(StatusSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FleetWorkloadIdentity != nil {
+		in, out := &in.FleetWorkloadIdentity, &out.FleetWorkloadIdentity
+		*out = new(FleetWorkloadIdentityStatus)
+		**out = **in
+	}
 	in.Source.DeepCopyInto(&out.Source)
 	in.Rendering.DeepCopyInto(&out.Rendering)
 	in.Sync.DeepCopyInto(&out.Sync)
@@ -775,6 +1077,22 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusSummary) DeepCopyInto(out *StatusSummary) {
+	*out = *in
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusSummary.
+func (in *StatusSummary) DeepCopy() *StatusSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 	*out = *in
@@ -806,6 +1124,16 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.ImplicitNamespaces != nil {
+		in, out := &in.ImplicitNamespaces, &out.ImplicitNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FrequentlyEdited != nil {
+		in, out := &in.FrequentlyEdited, &out.FrequentlyEdited
+		*out = make([]FrequentlyEditedObject, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncStatus.
@@ -82,6 +82,12 @@ type ResourceGroupStatus struct {
 	// conditions lists the conditions of the current status for the group
 	// +optional
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// prunedResources lists the resources pruned from the group and when they
+	// were pruned. Only recorded when the status mode of the ResourceGroup is
+	// "timestamps".
+	// +optional
+	PrunedResources []PrunedResource `json:"prunedResources,omitempty"`
 }
 
 // each item organizes and stores the identifying information
@@ -195,6 +201,17 @@ type ResourceStatus struct {
 	Strategy    Strategy    `json:"strategy,omitempty"`
 	Actuation   Actuation   `json:"actuation,omitempty"`
 	Reconcile   Reconcile   `json:"reconcile,omitempty"`
+	// actuationTime is when the resource was last applied or pruned. Only
+	// recorded when the status mode of the ResourceGroup is "timestamps".
+	ActuationTime *metav1.Time `json:"actuationTime,omitempty"`
+}
+
+// each item records when a resource, uniquely identified by its group, kind,
+// name and namespace, was pruned from the group.
+type PrunedResource struct {
+	ObjMetadata `json:",inline"`
+	// pruneTime is when the resource was pruned.
+	PruneTime metav1.Time `json:"pruneTime"`
 }
 
 // Each item contains the status of a given group uniquely identified by
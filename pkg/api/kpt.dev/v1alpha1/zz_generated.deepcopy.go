@@ -128,6 +128,23 @@ func (in *ObjMetadata) DeepCopy() *ObjMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunedResource) DeepCopyInto(out *PrunedResource) {
+	*out = *in
+	out.ObjMetadata = in.ObjMetadata
+	in.PruneTime.DeepCopyInto(&out.PruneTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunedResource.
+func (in *PrunedResource) DeepCopy() *PrunedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceGroup) DeepCopyInto(out *ResourceGroup) {
 	*out = *in
@@ -237,6 +254,13 @@ func (in *ResourceGroupStatus) DeepCopyInto(out *ResourceGroupStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PrunedResources != nil {
+		in, out := &in.PrunedResources, &out.PrunedResources
+		*out = make([]PrunedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceGroupStatus.
@@ -260,6 +284,10 @@ func (in *ResourceStatus) DeepCopyInto(out *ResourceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ActuationTime != nil {
+		in, out := &in.ActuationTime, &out.ActuationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceStatus.
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configmanagement"
@@ -40,6 +42,7 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/differ"
 	"kpt.dev/configsync/pkg/syncer/metrics"
+	syncerreconcile "kpt.dev/configsync/pkg/syncer/reconcile"
 	"kpt.dev/configsync/pkg/util"
 	nomosutil "kpt.dev/configsync/pkg/util"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
@@ -77,6 +80,11 @@ type Applier interface {
 	// This method may be called while Destroy is running, to get the set of
 	// errors encountered so far.
 	Errors() status.MultiError
+	// Pending returns the objects whose apply was deferred by the previous
+	// Apply, until their CustomResourceDefinition is established.
+	// Pending objects are not errors: the apply is retried until they are
+	// applied.
+	Pending() []core.ID
 }
 
 // Destroyer is a bulk client for deleting all the managed resource objects
@@ -87,6 +95,12 @@ type Destroyer interface {
 	// This is called by the reconciler finalizer when deletion propagation is
 	// enabled.
 	Destroy(ctx context.Context) status.MultiError
+	// Orphan removes the Config Sync metadata from all managed resources,
+	// leaving them on the cluster, and deletes the inventory.
+	// Returns any errors encountered while orphaning.
+	// This is called by the reconciler finalizer when the deletion propagation
+	// policy is Orphan.
+	Orphan(ctx context.Context) status.MultiError
 	// Errors returns the errors encountered during destroy.
 	// This method may be called while Destroy is running, to get the set of
 	// errors encountered so far.
@@ -123,6 +137,15 @@ type supervisor struct {
 	syncNamespace string
 	// reconcileTimeout controls the reconcile and prune timeout
 	reconcileTimeout time.Duration
+	// deferUnestablishedCRs controls whether custom resources whose CRD is not
+	// yet established are deferred to a later apply, instead of being applied.
+	deferUnestablishedCRs bool
+	// maxPruneCount is the maximum number of objects to prune in one apply
+	// without confirmation. Zero prunes any number of objects.
+	maxPruneCount int
+	// objectSelector is the selector of the objects from the source which are
+	// applied. Nil or empty selects all the objects.
+	objectSelector labels.Selector
 
 	// execMux prevents concurrent Apply/Destroy calls
 	execMux sync.Mutex
@@ -131,24 +154,46 @@ type supervisor struct {
 	// errs received from the current (if running) or previous Apply/Destroy.
 	// These errors is cleared at the start of the Apply/Destroy methods.
 	errs status.MultiError
+	// pending objects deferred by the current (if running) or previous Apply.
+	// These objects are cleared at the start of the Apply/Destroy methods.
+	pending []core.ID
 }
 
 var _ Applier = &supervisor{}
 var _ Destroyer = &supervisor{}
 var _ Supervisor = &supervisor{}
 
+// Options holds the optional configuration of a Supervisor.
+// The zero value keeps the default behavior.
+type Options struct {
+	// DeferUnestablishedCRs controls whether custom resources whose CRD is not
+	// yet established are deferred to a later apply, instead of being applied.
+	DeferUnestablishedCRs bool
+	// MaxPruneCount is the maximum number of objects to prune in one apply
+	// without confirmation. Zero prunes any number of objects.
+	MaxPruneCount int
+	// ConflictPolicy controls which objects managed by another RootSync or
+	// RepoSync are adopted. It only applies to the cluster-level Supervisor.
+	ConflictPolicy configsync.ConflictPolicy
+	// ObjectSelector is the selector of the objects from the source which are
+	// applied. The unselected objects are ignored, unless they are still in
+	// the inventory, in which case they are abandoned instead of pruned.
+	// Nil or empty selects all the objects.
+	ObjectSelector labels.Selector
+}
+
 // NewSupervisor constructs either a cluster-level or namespace-level Supervisor,
 // based on the specified scope.
-func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconcileTimeout time.Duration) (Supervisor, error) {
+func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconcileTimeout time.Duration, opts Options) (Supervisor, error) {
 	if scope == declared.RootReconciler {
-		return NewRootSupervisor(cs, syncName, reconcileTimeout)
+		return NewRootSupervisor(cs, syncName, reconcileTimeout, opts)
 	}
-	return NewNamespaceSupervisor(cs, scope, syncName, reconcileTimeout)
+	return NewNamespaceSupervisor(cs, scope, syncName, reconcileTimeout, opts)
 }
 
 // NewNamespaceSupervisor constructs a Supervisor that can manage resource
 // objects in a single namespace.
-func NewNamespaceSupervisor(cs *ClientSet, namespace declared.Scope, syncName string, reconcileTimeout time.Duration) (Supervisor, error) {
+func NewNamespaceSupervisor(cs *ClientSet, namespace declared.Scope, syncName string, reconcileTimeout time.Duration, opts Options) (Supervisor, error) {
 	syncKind := configsync.RepoSyncKind
 	invObj := newInventoryUnstructured(syncKind, syncName, string(namespace), cs.StatusMode)
 	// If the ResourceGroup object exists, annotate the status mode on the
@@ -163,13 +208,16 @@ func NewNamespaceSupervisor(cs *ClientSet, namespace declared.Scope, syncName st
 		return nil, err
 	}
 	a := &supervisor{
-		inventory:        inv,
-		clientSet:        cs,
-		policy:           inventory.PolicyAdoptIfNoInventory,
-		syncKind:         syncKind,
-		syncName:         syncName,
-		syncNamespace:    string(namespace),
-		reconcileTimeout: reconcileTimeout,
+		inventory:             inv,
+		clientSet:             cs,
+		policy:                inventory.PolicyAdoptIfNoInventory,
+		syncKind:              syncKind,
+		syncName:              syncName,
+		syncNamespace:         string(namespace),
+		reconcileTimeout:      reconcileTimeout,
+		deferUnestablishedCRs: opts.DeferUnestablishedCRs,
+		maxPruneCount:         opts.MaxPruneCount,
+		objectSelector:        opts.ObjectSelector,
 	}
 	klog.V(4).Infof("Namespace Supervisor %s/%s is initialized", namespace, syncName)
 	return a, nil
@@ -177,7 +225,11 @@ func NewNamespaceSupervisor(cs *ClientSet, namespace declared.Scope, syncName st
 
 // NewRootSupervisor constructs a Supervisor that can manage both cluster-level
 // and namespace-level resource objects in a single cluster.
-func NewRootSupervisor(cs *ClientSet, syncName string, reconcileTimeout time.Duration) (Supervisor, error) {
+//
+// By default, it adopts any object, even if the object is managed by another
+// RootSync or RepoSync. With the adoptIfNoInventory conflict policy, it only
+// adopts unmanaged objects, and reports a management conflict for the others.
+func NewRootSupervisor(cs *ClientSet, syncName string, reconcileTimeout time.Duration, opts Options) (Supervisor, error) {
 	syncKind := configsync.RootSyncKind
 	u := newInventoryUnstructured(syncKind, syncName, configmanagement.ControllerNamespace, cs.StatusMode)
 	// If the ResourceGroup object exists, annotate the status mode on the
@@ -192,18 +244,29 @@ func NewRootSupervisor(cs *ClientSet, syncName string, reconcileTimeout time.Dur
 		return nil, err
 	}
 	a := &supervisor{
-		inventory:        inv,
-		clientSet:        cs,
-		policy:           inventory.PolicyAdoptAll,
-		syncKind:         syncKind,
-		syncName:         syncName,
-		syncNamespace:    string(configmanagement.ControllerNamespace),
-		reconcileTimeout: reconcileTimeout,
+		inventory:             inv,
+		clientSet:             cs,
+		policy:                inventoryPolicy(opts.ConflictPolicy),
+		syncKind:              syncKind,
+		syncName:              syncName,
+		syncNamespace:         string(configmanagement.ControllerNamespace),
+		reconcileTimeout:      reconcileTimeout,
+		deferUnestablishedCRs: opts.DeferUnestablishedCRs,
+		maxPruneCount:         opts.MaxPruneCount,
+		objectSelector:        opts.ObjectSelector,
 	}
 	klog.V(4).Infof("Root Supervisor %s is initialized and synced with the API server", syncName)
 	return a, nil
 }
 
+// inventoryPolicy returns the inventory policy of the conflict policy.
+func inventoryPolicy(conflictPolicy configsync.ConflictPolicy) inventory.Policy {
+	if conflictPolicy == configsync.ConflictPolicyAdoptIfNoInventory {
+		return inventory.PolicyAdoptIfNoInventory
+	}
+	return inventory.PolicyAdoptAll
+}
+
 func wrapInventoryObj(obj *unstructured.Unstructured) (*live.InventoryResourceGroup, error) {
 	inv, ok := live.WrapInventoryObj(obj).(*live.InventoryResourceGroup)
 	if !ok {
@@ -217,6 +280,29 @@ type eventHandler struct {
 	isDestroy bool
 	// clientSet is used for accessing various k8s clients
 	clientSet *ClientSet
+	// orphanedBy is the value of the orphaned-by annotation set on the
+	// objects left on the cluster when pruned or deleted.
+	orphanedBy string
+	// resourceManager is the manager of the objects applied by this
+	// reconciler. Objects managed by another reconciler are never abandoned.
+	resourceManager string
+	// prunedObjects is the number of objects successfully pruned, by GVK.
+	prunedObjects map[schema.GroupVersionKind]int
+	// sourcePaths are the paths of the files declaring the desired objects.
+	sourcePaths map[core.ID]string
+}
+
+// sourcePath returns the path of the file declaring the object in the source.
+// Objects which are no longer declared, like pruned objects, still have the
+// path in the annotation of the object on the cluster.
+func (h *eventHandler) sourcePath(id core.ID, obj *unstructured.Unstructured) string {
+	if path, found := h.sourcePaths[id]; found {
+		return path
+	}
+	if obj == nil {
+		return ""
+	}
+	return obj.GetAnnotations()[metadata.SourcePathAnnotationKey]
 }
 
 func (h *eventHandler) processApplyEvent(ctx context.Context, e event.ApplyEvent, s *stats.ApplyEventStats, objectStatusMap ObjectStatusMap, unknownTypeResources map[core.ID]struct{}) status.Error {
@@ -238,6 +324,23 @@ func (h *eventHandler) processApplyEvent(ctx context.Context, e event.ApplyEvent
 	case event.ApplySuccessful:
 		objectStatus.Actuation = actuation.ActuationSucceeded
 		handleMetrics(ctx, "update", e.Error)
+		h.clientSet.actuations.recordApply(e.Identifier, e.Resource)
+		// Every sync applies all the objects, which fulfills the one-shot
+		// force-apply requested by the reconcile-now annotation.
+		if e.Resource != nil && metadata.HasReconcileNow(e.Resource) {
+			if err := syncerreconcile.ClearReconcileNow(ctx, h.clientSet.Client, e.Resource, h.clientSet.FieldManager); err != nil {
+				klog.Warningf("Failed to clear the %s annotation of %s: %v",
+					metadata.ReconcileNowAnnotationKey, id, err)
+			}
+		}
+		// Migrate the fields applied with the default field manager, before the
+		// field manager was overridden, to the configured field manager.
+		if e.Resource != nil && h.clientSet.FieldManager != "" {
+			if err := syncerreconcile.RemoveStaleFieldManager(ctx, h.clientSet.Client, e.Resource, configsync.FieldManager, h.clientSet.FieldManager); err != nil {
+				klog.Warningf("Failed to remove the managed fields of the %s field manager from %s: %v",
+					configsync.FieldManager, id, err)
+			}
+		}
 		return nil
 
 	case event.ApplyFailed:
@@ -246,9 +349,9 @@ func (h *eventHandler) processApplyEvent(ctx context.Context, e event.ApplyEvent
 		switch e.Error.(type) {
 		case *applyerror.UnknownTypeError:
 			unknownTypeResources[id] = struct{}{}
-			return ErrorForResource(e.Error, id)
+			return ErrorForResource(e.Error, id, h.sourcePath(id, e.Resource))
 		default:
-			return ErrorForResource(e.Error, id)
+			return ErrorForResource(e.Error, id, h.sourcePath(id, e.Resource))
 		}
 
 	case event.ApplySkipped:
@@ -257,7 +360,7 @@ func (h *eventHandler) processApplyEvent(ctx context.Context, e event.ApplyEvent
 		return h.handleApplySkippedEvent(e.Resource, id, e.Error)
 
 	default:
-		return ErrorForResource(fmt.Errorf("unexpected prune event status: %v", e.Status), id)
+		return ErrorForResource(fmt.Errorf("unexpected prune event status: %v", e.Status), id, h.sourcePath(id, e.Resource))
 	}
 }
 
@@ -282,16 +385,16 @@ func (h *eventHandler) processWaitEvent(e event.WaitEvent, s *stats.WaitEventSta
 		objectStatus.Reconcile = actuation.ReconcileFailed
 		// ReconcileFailed is treated as an error for destroy
 		if h.isDestroy {
-			return WaitErrorForResource(fmt.Errorf("reconcile failed"), id)
+			return WaitErrorForResource(fmt.Errorf("reconcile failed"), id, h.sourcePath(id, nil))
 		}
 	case event.ReconcileTimeout:
 		objectStatus.Reconcile = actuation.ReconcileTimeout
 		// ReconcileTimeout is treated as an error for destroy
 		if h.isDestroy {
-			return WaitErrorForResource(fmt.Errorf("reconcile timeout"), id)
+			return WaitErrorForResource(fmt.Errorf("reconcile timeout"), id, h.sourcePath(id, nil))
 		}
 	default:
-		return ErrorForResource(fmt.Errorf("unexpected wait event status: %v", e.Status), id)
+		return ErrorForResource(fmt.Errorf("unexpected wait event status: %v", e.Status), id, h.sourcePath(id, nil))
 	}
 	return nil
 }
@@ -300,12 +403,12 @@ func (h *eventHandler) processWaitEvent(e event.WaitEvent, s *stats.WaitEventSta
 func (h *eventHandler) handleApplySkippedEvent(obj *unstructured.Unstructured, id core.ID, err error) status.Error {
 	var depErr *filter.DependencyPreventedActuationError
 	if errors.As(err, &depErr) {
-		return SkipErrorForResource(err, id, depErr.Strategy)
+		return SkipErrorForResource(err, id, h.sourcePath(id, obj), depErr.Strategy)
 	}
 
 	var depMismatchErr *filter.DependencyActuationMismatchError
 	if errors.As(err, &depMismatchErr) {
-		return SkipErrorForResource(err, id, depMismatchErr.Strategy)
+		return SkipErrorForResource(err, id, h.sourcePath(id, obj), depMismatchErr.Strategy)
 	}
 
 	var policyErr *inventory.PolicyPreventedActuationError
@@ -313,11 +416,11 @@ func (h *eventHandler) handleApplySkippedEvent(obj *unstructured.Unstructured, i
 		// TODO: return ManagementConflictError with the conflicting manager if
 		// cli-utils supports reporting the conflicting manager in
 		// PolicyPreventedActuationError.
-		// return SkipErrorForResource(err, id, policyErr.Strategy)
+		// return SkipErrorForResource(err, id, h.sourcePath(id, obj), policyErr.Strategy)
 		return KptManagementConflictError(obj)
 	}
 
-	return SkipErrorForResource(err, id, actuation.ActuationStrategyApply)
+	return SkipErrorForResource(err, id, h.sourcePath(id, obj), actuation.ActuationStrategyApply)
 }
 
 // processPruneEvent handles PruneEvents from the Applier
@@ -340,12 +443,14 @@ func (h *eventHandler) processPruneEvent(ctx context.Context, e event.PruneEvent
 	case event.PruneSuccessful:
 		objectStatus.Actuation = actuation.ActuationSucceeded
 		handleMetrics(ctx, "delete", e.Error)
+		h.countPrunedObject(e)
+		h.clientSet.actuations.recordPrune(e.Identifier)
 		return nil
 
 	case event.PruneFailed:
 		objectStatus.Actuation = actuation.ActuationFailed
 		handleMetrics(ctx, "delete", e.Error)
-		return PruneErrorForResource(e.Error, id)
+		return PruneErrorForResource(e.Error, id, h.sourcePath(id, e.Object))
 
 	case event.PruneSkipped:
 		objectStatus.Actuation = actuation.ActuationSkipped
@@ -353,7 +458,27 @@ func (h *eventHandler) processPruneEvent(ctx context.Context, e event.PruneEvent
 		return h.handleDeleteSkippedEvent(ctx, event.PruneType, e.Object, id, e.Error)
 
 	default:
-		return PruneErrorForResource(fmt.Errorf("unexpected prune event status: %v", e.Status), id)
+		return PruneErrorForResource(fmt.Errorf("unexpected prune event status: %v", e.Status), id, h.sourcePath(id, e.Object))
+	}
+}
+
+// countPrunedObject counts a successfully pruned object by its GVK.
+// The version is unknown if the event doesn't include the object.
+func (h *eventHandler) countPrunedObject(e event.PruneEvent) {
+	gvk := e.Identifier.GroupKind.WithVersion("")
+	if e.Object != nil {
+		gvk = e.Object.GroupVersionKind()
+	}
+	if h.prunedObjects == nil {
+		h.prunedObjects = make(map[schema.GroupVersionKind]int)
+	}
+	h.prunedObjects[gvk]++
+}
+
+// recordPrunedObjects records the number of objects pruned in this sync, by GVK.
+func (h *eventHandler) recordPrunedObjects(ctx context.Context) {
+	for gvk, count := range h.prunedObjects {
+		m.RecordPrunedObjects(ctx, gvk.String(), count)
 	}
 }
 
@@ -382,7 +507,7 @@ func (h *eventHandler) processDeleteEvent(ctx context.Context, e event.DeleteEve
 	case event.DeleteFailed:
 		objectStatus.Actuation = actuation.ActuationFailed
 		handleMetrics(ctx, "delete", e.Error)
-		return DeleteErrorForResource(e.Error, id)
+		return DeleteErrorForResource(e.Error, id, h.sourcePath(id, e.Object))
 
 	case event.DeleteSkipped:
 		objectStatus.Actuation = actuation.ActuationSkipped
@@ -390,7 +515,7 @@ func (h *eventHandler) processDeleteEvent(ctx context.Context, e event.DeleteEve
 		return h.handleDeleteSkippedEvent(ctx, event.DeleteType, e.Object, id, e.Error)
 
 	default:
-		return DeleteErrorForResource(fmt.Errorf("unexpected delete event status: %v", e.Status), id)
+		return DeleteErrorForResource(fmt.Errorf("unexpected delete event status: %v", e.Status), id, h.sourcePath(id, e.Object))
 	}
 }
 
@@ -400,7 +525,7 @@ func (h *eventHandler) handleDeleteSkippedEvent(ctx context.Context, eventType e
 	// Disable protected namespaces that were removed from the desired object set.
 	if isNamespace(obj) && differ.SpecialNamespaces[obj.GetName()] {
 		// the `client.lifecycle.config.k8s.io/deletion: detach` annotation is not a part of the Config Sync metadata, and will not be removed here.
-		err := h.abandonObject(ctx, obj)
+		err := h.abandonObject(ctx, obj, true)
 		handleMetrics(ctx, "unmanage", err)
 		if err != nil {
 			err = fmt.Errorf("failed to remove the Config Sync metadata from %v (protected namespace): %v",
@@ -413,12 +538,12 @@ func (h *eventHandler) handleDeleteSkippedEvent(ctx context.Context, eventType e
 
 	var depErr *filter.DependencyPreventedActuationError
 	if errors.As(err, &depErr) {
-		return SkipErrorForResource(err, id, depErr.Strategy)
+		return SkipErrorForResource(err, id, h.sourcePath(id, obj), depErr.Strategy)
 	}
 
 	var depMismatchErr *filter.DependencyActuationMismatchError
 	if errors.As(err, &depMismatchErr) {
-		return SkipErrorForResource(err, id, depMismatchErr.Strategy)
+		return SkipErrorForResource(err, id, h.sourcePath(id, obj), depMismatchErr.Strategy)
 	}
 
 	// ApplyPreventedDeletionError is only sent by the Applier in a PruneEvent,
@@ -427,7 +552,7 @@ func (h *eventHandler) handleDeleteSkippedEvent(ctx context.Context, eventType e
 	if eventType == event.PruneType {
 		var applyDeleteErr *filter.ApplyPreventedDeletionError
 		if errors.As(err, &applyDeleteErr) {
-			return SkipErrorForResource(err, id, actuation.ActuationStrategyDelete)
+			return SkipErrorForResource(err, id, h.sourcePath(id, obj), actuation.ActuationStrategyDelete)
 		}
 	}
 
@@ -440,7 +565,7 @@ func (h *eventHandler) handleDeleteSkippedEvent(ctx context.Context, eventType e
 
 	var namespaceErr *filter.NamespaceInUseError
 	if errors.As(err, &namespaceErr) {
-		return SkipErrorForResource(err, id, actuation.ActuationStrategyDelete)
+		return SkipErrorForResource(err, id, h.sourcePath(id, obj), actuation.ActuationStrategyDelete)
 	}
 
 	var abandonErr *filter.AnnotationPreventedDeletionError
@@ -448,7 +573,7 @@ func (h *eventHandler) handleDeleteSkippedEvent(ctx context.Context, eventType e
 		// For prunes/deletes, this is desired behavior, not a fatal error.
 		klog.Infof("Resource object removed from inventory, but not deleted: %v: %v", id, err)
 		// The `client.lifecycle.config.k8s.io/deletion: detach` annotation is not a part of the Config Sync metadata, and will not be removed here.
-		err := h.abandonObject(ctx, obj)
+		err := h.abandonObject(ctx, obj, true)
 		handleMetrics(ctx, "unmanage", err)
 		if err != nil {
 			err = fmt.Errorf("failed to remove the Config Sync metadata from %v (%s: %s): %v",
@@ -460,7 +585,7 @@ func (h *eventHandler) handleDeleteSkippedEvent(ctx context.Context, eventType e
 		return nil
 	}
 
-	return SkipErrorForResource(err, id, actuation.ActuationStrategyDelete)
+	return SkipErrorForResource(err, id, h.sourcePath(id, obj), actuation.ActuationStrategyDelete)
 }
 
 func isNamespace(obj *unstructured.Unstructured) bool {
@@ -494,12 +619,21 @@ func (a *supervisor) checkInventoryObjectSize(ctx context.Context, c client.Clie
 	}
 }
 
+// orphanedBy returns the value of the orphaned-by annotation, identifying the
+// RootSync or RepoSync of this supervisor.
+func (a *supervisor) orphanedBy() string {
+	return fmt.Sprintf("%s/%s/%s", a.syncKind, a.syncNamespace, a.syncName)
+}
+
 // applyInner triggers a kpt live apply library call to apply a set of resources.
 func (a *supervisor) applyInner(ctx context.Context, objs []client.Object) (map[schema.GroupVersionKind]struct{}, status.MultiError) {
 	a.checkInventoryObjectSize(ctx, a.clientSet.Client)
 	eh := eventHandler{
-		isDestroy: false,
-		clientSet: a.clientSet,
+		isDestroy:       false,
+		clientSet:       a.clientSet,
+		orphanedBy:      a.orphanedBy(),
+		resourceManager: a.resourceManager(),
+		sourcePaths:     sourcePaths(objs),
 	}
 
 	s := stats.NewSyncStats()
@@ -507,6 +641,13 @@ func (a *supervisor) applyInner(ctx context.Context, objs []client.Object) (map[
 	// disabledObjs are objects for which the management are disabled
 	// through annotation.
 	enabledObjs, disabledObjs := partitionObjs(objs)
+	// The objects which do not match the object selector are ignored, unless
+	// they were applied before.
+	disabledObjs, err := a.withoutIgnoredObjects(ctx, disabledObjs)
+	if err != nil {
+		a.addError(err)
+		return nil, a.Errors()
+	}
 	if len(disabledObjs) > 0 {
 		klog.Infof("%v objects to be disabled: %v", len(disabledObjs), core.GKNNs(disabledObjs))
 		disabledCount, err := eh.handleDisabledObjects(ctx, a.inventory, disabledObjs)
@@ -519,19 +660,50 @@ func (a *supervisor) applyInner(ctx context.Context, objs []client.Object) (map[
 			Succeeded: disabledCount,
 		}
 	}
-	klog.Infof("%v objects to be applied: %v", len(enabledObjs), core.GKNNs(enabledObjs))
-	resources, err := toUnstructured(enabledObjs)
+	// handedOffObjs are the objects which are no longer declared, but are
+	// handed off to another RootSync through annotation, instead of pruned.
+	handedOffObjs, err := a.handedOffObjects(ctx, objs)
 	if err != nil {
 		a.addError(err)
 		return nil, a.Errors()
 	}
-
+	if len(handedOffObjs) > 0 {
+		klog.Infof("%v objects to be handed off: %v", len(handedOffObjs), core.GKNNs(handedOffObjs))
+		handedOffCount, err := eh.handleHandedOffObjects(ctx, a.inventory, a.resourceManager(), handedOffObjs)
+		if err != nil {
+			a.addError(err)
+			return nil, a.Errors()
+		}
+		klog.Infof("%v objects handed off", handedOffCount)
+	}
+	// protectedObjs are the objects which are no longer declared, but are
+	// retained instead of pruned because of the deletion-protection annotation.
+	protectedObjs, err := a.deletionProtectedObjects(ctx, enabledObjs)
+	if err != nil {
+		a.addError(err)
+		return nil, a.Errors()
+	}
+	if len(protectedObjs) > 0 {
+		klog.Infof("%v objects protected from deletion: %v", len(protectedObjs), core.GKNNs(protectedObjs))
+		for _, obj := range protectedObjs {
+			objStatusMap[core.IDOf(obj)] = &ObjectStatus{
+				Strategy:  actuation.ActuationStrategyDelete,
+				Actuation: actuation.ActuationSkipped,
+			}
+			a.addError(status.DeletionProtectedError(obj))
+		}
+	}
+	if err := a.checkPruneCount(ctx, append(enabledObjs[:len(enabledObjs):len(enabledObjs)], protectedObjs...)); err != nil {
+		a.addError(err)
+		return nil, a.Errors()
+	}
+	klog.Infof("%v objects to be applied: %v", len(enabledObjs), core.GKNNs(enabledObjs))
 	unknownTypeResources := make(map[core.ID]struct{})
 	options := apply.ApplierOptions{
 		ServerSideOptions: common.ServerSideOptions{
 			ServerSideApply: true,
 			ForceConflicts:  true,
-			FieldManager:    configsync.FieldManager,
+			FieldManager:    a.clientSet.FieldManager,
 		},
 		InventoryPolicy: a.policy,
 		// Leaving ReconcileTimeout and PruneTimeout unset may cause a WaitTask to wait forever.
@@ -554,7 +726,97 @@ func (a *supervisor) applyInner(ctx context.Context, objs []client.Object) (map[
 	// This allows for picking up CRD changes.
 	meta.MaybeResetRESTMapper(a.clientSet.Mapper)
 
-	events := a.clientSet.KptApplier.Run(ctx, a.inventory, object.UnstructuredSet(resources), options)
+	resources, convErrs := toUnstructured(enabledObjs)
+	if convErrs != nil {
+		a.addError(convErrs)
+		return nil, a.Errors()
+	}
+
+	// The objects protected from deletion are retained in the inventory, so
+	// that the kpt applier does not prune them.
+	retained := objMetasFromObjects(protectedObjs)
+
+	// Defer the custom resources whose CRD is not yet established. They are
+	// reported as pending, instead of failing with an unknown type error, and
+	// the apply is retried until their CRD is established. They are also
+	// retained in the inventory, so that they are not pruned meanwhile.
+	if a.deferUnestablishedCRs {
+		var deferred map[core.ID]string
+		var crdErr error
+		resources, deferred, crdErr = partitionUnestablishedCRs(ctx, a.clientSet.Client, a.clientSet.Mapper, resources)
+		if crdErr != nil {
+			a.addError(Error(fmt.Errorf("failed to check whether CustomResourceDefinitions are established: %w", crdErr)))
+			return nil, a.Errors()
+		}
+		if len(deferred) > 0 {
+			ids := make([]core.ID, 0, len(deferred))
+			for id := range deferred {
+				ids = append(ids, id)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+			klog.Infof("%v objects deferred until their CustomResourceDefinition is established: %v", len(ids), ids)
+			for _, id := range ids {
+				objStatusMap[id] = &ObjectStatus{
+					Strategy:  actuation.ActuationStrategyApply,
+					Actuation: actuation.ActuationPending,
+				}
+				// Deferred objects are not applied, so their types are not watched.
+				unknownTypeResources[id] = struct{}{}
+				retained = append(retained, object.ObjMetadata{
+					GroupKind: id.GroupKind,
+					Namespace: id.Namespace,
+					Name:      id.Name,
+				})
+			}
+			a.addPending(ids...)
+		}
+	}
+
+	kptApplier := a.clientSet.KptApplier
+	if len(retained) > 0 {
+		kptApplier = a.clientSet.retainKptApplier
+		a.clientSet.retainInvClient.retain(retained, objStatusMap)
+	}
+	a.runKptApplier(ctx, kptApplier, &eh, resources, options, s, objStatusMap, unknownTypeResources)
+
+	eh.recordPrunedObjects(ctx)
+
+	gvks := make(map[schema.GroupVersionKind]struct{})
+	for _, resource := range objs {
+		id := core.IDOf(resource)
+		if _, found := unknownTypeResources[id]; found {
+			continue
+		}
+		gvks[resource.GetObjectKind().GroupVersionKind()] = struct{}{}
+	}
+
+	errs := a.Errors()
+	if errs == nil {
+		klog.V(4).Infof("Apply completed without error: all resources are up to date.")
+	}
+	if s.Empty() {
+		klog.V(4).Infof("Applier made no new progress")
+	} else {
+		klog.Infof("Applier made new progress: %s", s.String())
+		objStatusMap.Log(klog.V(0))
+	}
+	return gvks, errs
+}
+
+// runKptApplier runs the kpt applier on the resources, and processes the
+// events until the apply completes.
+func (a *supervisor) runKptApplier(ctx context.Context, kptApplier KptApplier, eh *eventHandler, resources []*unstructured.Unstructured, options apply.ApplierOptions, s *stats.SyncStats, objStatusMap ObjectStatusMap, unknownTypeResources map[core.ID]struct{}) {
+	// actuationStart and actuationErrs track the current apply or prune task,
+	// to record how long the objects take to be applied or pruned.
+	var actuationStart time.Time
+	var actuationErrs status.MultiError
+	// waitStart and waitErrs track the current wait task, to record how long
+	// the applied objects take to reconcile.
+	var waitStart time.Time
+	var waitErrs status.MultiError
+
+	a.clientSet.actuations.reset()
+	events := kptApplier.Run(ctx, a.inventory, object.UnstructuredSet(resources), options)
 	for e := range events {
 		switch e.Type {
 		case event.InitType:
@@ -563,6 +825,24 @@ func (a *supervisor) applyInner(ctx context.Context, objs []client.Object) (map[
 			}
 		case event.ActionGroupType:
 			klog.Info(e.ActionGroupEvent)
+			switch e.ActionGroupEvent.Action {
+			case event.ApplyAction, event.PruneAction:
+				switch e.ActionGroupEvent.Status {
+				case event.Started:
+					actuationStart = time.Now()
+					actuationErrs = nil
+				case event.Finished:
+					m.RecordSyncStageDuration(ctx, m.StageApply, m.StatusTagKey(actuationErrs), actuationStart)
+				}
+			case event.WaitAction:
+				switch e.ActionGroupEvent.Status {
+				case event.Started:
+					waitStart = time.Now()
+					waitErrs = nil
+				case event.Finished:
+					m.RecordSyncStageDuration(ctx, m.StageWait, m.StatusTagKey(waitErrs), waitStart)
+				}
+			}
 		case event.ErrorType:
 			klog.Info(e.ErrorEvent)
 			if util.IsRequestTooLargeError(e.ErrorEvent.Err) {
@@ -580,46 +860,37 @@ func (a *supervisor) applyInner(ctx context.Context, objs []client.Object) (map[
 			} else {
 				klog.V(1).Info(e.WaitEvent)
 			}
-			a.addError(eh.processWaitEvent(e.WaitEvent, s.WaitEvent, objStatusMap))
+			err := eh.processWaitEvent(e.WaitEvent, s.WaitEvent, objStatusMap)
+			if err != nil {
+				waitErrs = status.Append(waitErrs, err)
+			}
+			a.addError(err)
 		case event.ApplyType:
 			if e.ApplyEvent.Error != nil {
 				klog.Info(e.ApplyEvent)
 			} else {
 				klog.V(1).Info(e.ApplyEvent)
 			}
-			a.addError(eh.processApplyEvent(ctx, e.ApplyEvent, s.ApplyEvent, objStatusMap, unknownTypeResources))
+			err := eh.processApplyEvent(ctx, e.ApplyEvent, s.ApplyEvent, objStatusMap, unknownTypeResources)
+			if err != nil {
+				actuationErrs = status.Append(actuationErrs, err)
+			}
+			a.addError(err)
 		case event.PruneType:
 			if e.PruneEvent.Error != nil {
 				klog.Info(e.PruneEvent)
 			} else {
 				klog.V(1).Info(e.PruneEvent)
 			}
-			a.addError(eh.processPruneEvent(ctx, e.PruneEvent, s.PruneEvent, objStatusMap))
+			err := eh.processPruneEvent(ctx, e.PruneEvent, s.PruneEvent, objStatusMap)
+			if err != nil {
+				actuationErrs = status.Append(actuationErrs, err)
+			}
+			a.addError(err)
 		default:
 			klog.Infof("Unhandled event (%s): %v", e.Type, e)
 		}
 	}
-
-	gvks := make(map[schema.GroupVersionKind]struct{})
-	for _, resource := range objs {
-		id := core.IDOf(resource)
-		if _, found := unknownTypeResources[id]; found {
-			continue
-		}
-		gvks[resource.GetObjectKind().GroupVersionKind()] = struct{}{}
-	}
-
-	errs := a.Errors()
-	if errs == nil {
-		klog.V(4).Infof("Apply completed without error: all resources are up to date.")
-	}
-	if s.Empty() {
-		klog.V(4).Infof("Applier made no new progress")
-	} else {
-		klog.Infof("Applier made new progress: %s", s.String())
-		objStatusMap.Log(klog.V(0))
-	}
-	return gvks, errs
 }
 
 // Errors returns the errors encountered during the last apply or current apply
@@ -650,6 +921,23 @@ func (a *supervisor) invalidateErrors() {
 	defer a.errorMux.Unlock()
 
 	a.errs = nil
+	a.pending = nil
+}
+
+// Pending implements the Applier interface.
+func (a *supervisor) Pending() []core.ID {
+	a.errorMux.RLock()
+	defer a.errorMux.RUnlock()
+
+	// Return a copy to avoid persisting caller modifications
+	return append([]core.ID(nil), a.pending...)
+}
+
+func (a *supervisor) addPending(ids ...core.ID) {
+	a.errorMux.Lock()
+	defer a.errorMux.Unlock()
+
+	a.pending = append(a.pending, ids...)
 }
 
 // destroyInner triggers a kpt live destroy library call to destroy a set of resources.
@@ -657,8 +945,10 @@ func (a *supervisor) destroyInner(ctx context.Context) status.MultiError {
 	s := stats.NewSyncStats()
 	objStatusMap := make(ObjectStatusMap)
 	eh := eventHandler{
-		isDestroy: true,
-		clientSet: a.clientSet,
+		isDestroy:       true,
+		clientSet:       a.clientSet,
+		orphanedBy:      a.orphanedBy(),
+		resourceManager: a.resourceManager(),
 	}
 
 	options := apply.DestroyerOptions{
@@ -756,6 +1046,16 @@ func (a *supervisor) Destroy(ctx context.Context) status.MultiError {
 	return a.destroyInner(ctx)
 }
 
+// Orphan all managed resource objects and return any errors.
+// Orphan implements the Destroyer interface.
+func (a *supervisor) Orphan(ctx context.Context) status.MultiError {
+	a.execMux.Lock()
+	defer a.execMux.Unlock()
+
+	a.invalidateErrors()
+	return a.orphanInner(ctx)
+}
+
 // newInventoryUnstructured creates an inventory object as an unstructured.
 func newInventoryUnstructured(kind, name, namespace, statusMode string) *unstructured.Unstructured {
 	id := InventoryID(name, namespace)
@@ -793,7 +1093,7 @@ func (h *eventHandler) handleDisabledObjects(ctx context.Context, rg *live.Inven
 	var errs status.MultiError
 	for _, obj := range objs {
 		id := core.IDOf(obj)
-		err := h.abandonObject(ctx, obj)
+		err := h.abandonObject(ctx, obj, false)
 		handleMetrics(ctx, "unmanage", err)
 		if err != nil {
 			err = fmt.Errorf("failed to remove the Config Sync metadata from %v (%s: %s): %v",
@@ -837,8 +1137,11 @@ func (h *eventHandler) removeFromInventory(rg *live.InventoryResourceGroup, objs
 }
 
 // abandonObject removes ConfigSync labels and annotations from an object,
-// disabling management.
-func (h *eventHandler) abandonObject(ctx context.Context, obj client.Object) error {
+// disabling management. Objects managed by another reconciler are left
+// untouched. If orphaned is true, the object is annotated with the
+// orphaned-by annotation, so that objects intentionally left on the cluster
+// can be found and cleaned up later.
+func (h *eventHandler) abandonObject(ctx context.Context, obj client.Object, orphaned bool) error {
 	gvk, err := kinds.Lookup(obj, h.clientSet.Client.Scheme())
 	if err != nil {
 		return err
@@ -853,6 +1156,10 @@ func (h *eventHandler) abandonObject(ctx context.Context, obj client.Object) err
 		}
 		return err
 	}
+	if manager := core.GetAnnotation(uObj, metadata.ResourceManagerKey); manager != "" && h.resourceManager != "" && manager != h.resourceManager {
+		klog.V(4).Infof("Skipped abandoning object managed by %s: %s", manager, core.IDOf(obj))
+		return nil
+	}
 	klog.Infof("Abandoning object: %s", core.IDOf(obj))
 	if metadata.HasConfigSyncMetadata(uObj) {
 		// Use minimal before & after objects to simplify DeepCopy and building
@@ -869,11 +1176,14 @@ func (h *eventHandler) abandonObject(ctx context.Context, obj client.Object) err
 		if !updated {
 			return nil
 		}
+		if orphaned && h.orphanedBy != "" {
+			core.SetAnnotation(toObj, metadata.OrphanedByAnnotationKey, h.orphanedBy)
+		}
 		// Use merge-patch instead of server-side-apply, because we don't have
 		// the object's source of truth handy and don't want to take ownership
 		// of all the fields managed by other clients.
 		return h.clientSet.Client.Patch(ctx, toObj, client.MergeFrom(fromObj),
-			client.FieldOwner(configsync.FieldManager))
+			client.FieldOwner(h.clientSet.FieldManager))
 	}
 	return nil
 }
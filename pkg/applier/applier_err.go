@@ -19,12 +19,13 @@ import (
 	"strings"
 
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 )
 
 // ApplierErrorCode is the error code for apply failures.
-const ApplierErrorCode = "2009"
+const ApplierErrorCode = status.ApplierErrorCode
 
 var applierErrorBuilder = status.NewErrorBuilder(ApplierErrorCode)
 
@@ -35,33 +36,53 @@ func Error(err error) status.Error {
 
 // ErrorForResource indicates that the applier failed to apply
 // the given resource.
-func ErrorForResource(err error, id core.ID) status.Error {
-	return applierErrorBuilder.Wrap(fmt.Errorf("failed to apply %v: %w", id, err)).Build()
+// The sourcePath is the path of the file declaring the resource, if known.
+func ErrorForResource(err error, id core.ID, sourcePath string) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("failed to apply %s: %w", resourceRef(id, sourcePath), err)).Build()
 }
 
 // PruneErrorForResource indicates that the applier failed to prune
 // the given resource.
-func PruneErrorForResource(err error, id core.ID) status.Error {
-	return applierErrorBuilder.Wrap(fmt.Errorf("failed to prune %v: %w", id, err)).Build()
+func PruneErrorForResource(err error, id core.ID, sourcePath string) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("failed to prune %s: %w", resourceRef(id, sourcePath), err)).Build()
 }
 
 // DeleteErrorForResource indicates that the applier failed to delete
 // the given resource.
-func DeleteErrorForResource(err error, id core.ID) status.Error {
-	return applierErrorBuilder.Wrap(fmt.Errorf("failed to delete %v: %w", id, err)).Build()
+func DeleteErrorForResource(err error, id core.ID, sourcePath string) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("failed to delete %s: %w", resourceRef(id, sourcePath), err)).Build()
 }
 
 // WaitErrorForResource indicates that the applier failed to wait for
 // the given resource.
-func WaitErrorForResource(err error, id core.ID) status.Error {
-	return applierErrorBuilder.Wrap(fmt.Errorf("failed to wait for %v: %w", id, err)).Build()
+func WaitErrorForResource(err error, id core.ID, sourcePath string) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("failed to wait for %s: %w", resourceRef(id, sourcePath), err)).Build()
 }
 
 // SkipErrorForResource indicates that the applier skipped apply or delete of
 // the given resource.
-func SkipErrorForResource(err error, id core.ID, strategy actuation.ActuationStrategy) status.Error {
-	return applierErrorBuilder.Wrap(fmt.Errorf("skipped %s of %v: %w",
-		strings.ToLower(strategy.String()), id, err)).Build()
+func SkipErrorForResource(err error, id core.ID, sourcePath string, strategy actuation.ActuationStrategy) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("skipped %s of %s: %w",
+		strings.ToLower(strategy.String()), resourceRef(id, sourcePath), err)).Build()
+}
+
+// resourceRef formats the ID of the resource, followed by the path of the
+// file declaring it in the source, if known, so that the applier errors point
+// to the file to fix.
+func resourceRef(id core.ID, sourcePath string) string {
+	if sourcePath == "" {
+		return id.String()
+	}
+	return fmt.Sprintf("%v (source: %s)", id, sourcePath)
+}
+
+// PruneLimitError indicates that the applier did not apply the resources,
+// because it would prune more objects than the maximum allowed in one sync.
+func PruneLimitError(pruneCount, maxPruneCount int, pruneHash, syncKind, syncNamespace, syncName string) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("the sync would prune %d objects, which exceeds spec.override.maxPruneCount (%d). "+
+		"To confirm the pruning, run `kubectl annotate %s -n %s %s %s=%s --overwrite`",
+		pruneCount, maxPruneCount, strings.ToLower(syncKind), syncNamespace, syncName,
+		metadata.PruneConfirmationAnnotationKey, pruneHash)).Build()
 }
 
 // largeResourceGroupError indicates that the source repo has too many objects
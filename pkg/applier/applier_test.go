@@ -26,13 +26,25 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	resourcegroupv1alpha1 "kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
 	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
+	m "kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/status"
 	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/fake"
@@ -51,6 +63,14 @@ import (
 
 type fakeKptApplier struct {
 	events []event.Event
+	// objs are the objects passed to the last Run call.
+	objs object.UnstructuredSet
+	// runs are the objects passed to every Run call, in order.
+	runs []object.UnstructuredSet
+	// noPrune are the NoPrune options passed to every Run call, in order.
+	noPrune []bool
+	// fieldManager is the field manager passed to the last Run call.
+	fieldManager string
 }
 
 var _ KptApplier = &fakeKptApplier{}
@@ -61,7 +81,11 @@ func newFakeKptApplier(events []event.Event) *fakeKptApplier {
 	}
 }
 
-func (a *fakeKptApplier) Run(_ context.Context, _ inventory.Info, _ object.UnstructuredSet, _ apply.ApplierOptions) <-chan event.Event {
+func (a *fakeKptApplier) Run(_ context.Context, _ inventory.Info, objs object.UnstructuredSet, options apply.ApplierOptions) <-chan event.Event {
+	a.objs = objs
+	a.runs = append(a.runs, objs)
+	a.noPrune = append(a.noPrune, options.NoPrune)
+	a.fieldManager = options.ServerSideOptions.FieldManager
 	events := make(chan event.Event, len(a.events))
 	go func() {
 		for _, e := range a.events {
@@ -104,6 +128,11 @@ func TestApply(t *testing.T) {
 
 	testObj2 := newTestObj("test-2")
 	testObj3 := newTestObj("test-3")
+	sourcedObj := newTestObj("sourced")
+	sourcedObj.SetAnnotations(map[string]string{
+		metadata.SourcePathAnnotationKey: "namespaces/test-namespace/sourced.yaml",
+	})
+	sourcedID := object.UnstructuredToObjMetadata(sourcedObj)
 
 	objs := []client.Object{deploymentObj, testObj}
 
@@ -137,7 +166,7 @@ func TestApply(t *testing.T) {
 				formApplyEvent(event.ApplyFailed, testObj, applyerror.NewUnknownTypeError(errors.New("unknown type"))),
 				formApplyEvent(event.ApplyPending, testObj2, nil),
 			},
-			expectedError: ErrorForResource(errors.New("unknown type"), idFrom(testID)),
+			expectedError: ErrorForResource(errors.New("unknown type"), idFrom(testID), ""),
 			expectedGVKs:  map[schema.GroupVersionKind]struct{}{kinds.Deployment(): {}},
 		},
 		{
@@ -173,7 +202,29 @@ func TestApply(t *testing.T) {
 				formApplyEvent(event.ApplyFailed, testObj, applyerror.NewApplyRunError(errors.New("failed apply"))),
 				formApplyEvent(event.ApplyPending, testObj2, nil),
 			},
-			expectedError: ErrorForResource(errors.New("failed apply"), idFrom(testID)),
+			expectedError: ErrorForResource(errors.New("failed apply"), idFrom(testID), ""),
+			expectedGVKs: map[schema.GroupVersionKind]struct{}{
+				kinds.Deployment(): {},
+				testGVK:            {},
+			},
+		},
+		{
+			name: "failed to apply object with source path",
+			events: []event.Event{
+				formApplyEvent(event.ApplyFailed, sourcedObj, applyerror.NewApplyRunError(errors.New("failed apply"))),
+			},
+			expectedError: ErrorForResource(errors.New("failed apply"), idFrom(sourcedID), "namespaces/test-namespace/sourced.yaml"),
+			expectedGVKs: map[schema.GroupVersionKind]struct{}{
+				kinds.Deployment(): {},
+				testGVK:            {},
+			},
+		},
+		{
+			name: "failed to prune object with source path",
+			events: []event.Event{
+				formPruneEvent(event.PruneFailed, sourcedObj, errors.New("failed pruning")),
+			},
+			expectedError: PruneErrorForResource(errors.New("failed pruning"), idFrom(sourcedID), "namespaces/test-namespace/sourced.yaml"),
 			expectedGVKs: map[schema.GroupVersionKind]struct{}{
 				kinds.Deployment(): {},
 				testGVK:            {},
@@ -185,7 +236,7 @@ func TestApply(t *testing.T) {
 				formPruneEvent(event.PruneFailed, testObj, errors.New("failed pruning")),
 				formPruneEvent(event.PruneSuccessful, testObj2, nil),
 			},
-			expectedError: PruneErrorForResource(errors.New("failed pruning"), idFrom(testID)),
+			expectedError: PruneErrorForResource(errors.New("failed pruning"), idFrom(testID), ""),
 			expectedGVKs: map[schema.GroupVersionKind]struct{}{
 				kinds.Deployment(): {},
 				testGVK:            {},
@@ -203,6 +254,7 @@ func TestApply(t *testing.T) {
 			expectedError: SkipErrorForResource(
 				errors.New("namespace still in use: test-namespace"),
 				idFrom(namespaceID),
+				"",
 				actuation.ActuationStrategyDelete),
 			expectedGVKs: map[schema.GroupVersionKind]struct{}{
 				kinds.Deployment(): {},
@@ -234,8 +286,8 @@ func TestApply(t *testing.T) {
 				kinds.Deployment(): {},
 			},
 			expectedError: status.Append(
-				ErrorForResource(errors.New("unknown type"), idFrom(testID)),
-				ErrorForResource(errors.New("failed apply"), idFrom(deploymentID))),
+				ErrorForResource(errors.New("unknown type"), idFrom(testID), ""),
+				ErrorForResource(errors.New("failed apply"), idFrom(deploymentID), "")),
 		},
 		{
 			name: "failed dependency during apply",
@@ -249,6 +301,7 @@ func TestApply(t *testing.T) {
 			expectedError: status.Append(SkipErrorForResource(
 				errors.New("dependency apply reconcile timeout: namespace_name_group_kind"),
 				idFrom(deploymentID),
+				"",
 				actuation.ActuationStrategyApply),
 				nil),
 		},
@@ -260,6 +313,7 @@ func TestApply(t *testing.T) {
 			expectedError: SkipErrorForResource(
 				errors.New("dependent delete actuation failed: namespace_name_group_kind"),
 				idFrom(deploymentID),
+				"",
 				actuation.ActuationStrategyDelete),
 			expectedGVKs: map[schema.GroupVersionKind]struct{}{
 				kinds.Deployment(): {},
@@ -286,6 +340,8 @@ func TestApply(t *testing.T) {
 						common.LifecycleDeleteAnnotation: common.PreventDeletion,
 						// all configsync annotations removed
 						"example-to-not-delete": "anything",
+						// except the orphaned-by annotation
+						metadata.OrphanedByAnnotationKey: "RepoSync/test-namespace/rs",
 					})
 					obj.SetLabels(map[string]string{
 						// all configsync labels removed
@@ -321,7 +377,7 @@ func TestApply(t *testing.T) {
 				Mapper:     fakeClient.RESTMapper(),
 				// TODO: Add tests to cover status mode
 			}
-			applier, err := NewNamespaceSupervisor(cs, syncScope, syncName, 5*time.Minute)
+			applier, err := NewNamespaceSupervisor(cs, syncScope, syncName, 5*time.Minute, Options{})
 			require.NoError(t, err)
 
 			gvks, errs := applier.Apply(context.Background(), objs)
@@ -355,6 +411,120 @@ func TestApply(t *testing.T) {
 	}
 }
 
+// TestApply_FieldManager verifies that the objects are applied with the
+// configured field manager, and that the managed fields of the default field
+// manager are removed once the objects are applied with it.
+func TestApply_FieldManager(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+	fieldManager := "team-a-sync"
+
+	deploymentObj := newDeploymentObj()
+	liveObj := deploymentObj.DeepCopy()
+	liveObj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: configsync.FieldManager, Operation: metav1.ManagedFieldsOperationApply},
+		{Manager: fieldManager, Operation: metav1.ManagedFieldsOperationApply},
+		{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate},
+	})
+	fakeClient := testingfake.NewClient(t, core.Scheme, liveObj)
+	fakeClient.Storage().FieldManager = fieldManager
+	// The kpt applier returns the applied object, as stored on the cluster.
+	appliedObj := &unstructured.Unstructured{}
+	appliedObj.SetGroupVersionKind(kinds.Deployment())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(liveObj), appliedObj))
+
+	kptApplier := newFakeKptApplier([]event.Event{
+		formApplyEvent(event.ApplySuccessful, appliedObj, nil),
+	})
+	cs := &ClientSet{
+		KptApplier:   kptApplier,
+		Client:       fakeClient,
+		Mapper:       fakeClient.RESTMapper(),
+		FieldManager: fieldManager,
+	}
+	applier, err := NewNamespaceSupervisor(cs, syncScope, syncName, 5*time.Minute, Options{})
+	require.NoError(t, err)
+
+	_, errs := applier.Apply(context.Background(), []client.Object{deploymentObj})
+	require.Nil(t, errs)
+	assert.Equal(t, fieldManager, kptApplier.fieldManager)
+
+	gotObj := &unstructured.Unstructured{}
+	gotObj.SetGroupVersionKind(kinds.Deployment())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(liveObj), gotObj))
+	var managers []string
+	for _, entry := range gotObj.GetManagedFields() {
+		managers = append(managers, entry.Manager)
+	}
+	assert.Equal(t, []string{fieldManager, "kubectl"}, managers)
+}
+
+func TestApply_DeferUnestablishedCRs(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+
+	deploymentObj := newDeploymentObj()
+	testObj := newTestObj("test-1")
+	testGVK := testObj.GroupVersionKind()
+	objs := []client.Object{deploymentObj, testObj}
+
+	crdObj := fake.CustomResourceDefinitionV1Object(core.Name("tests.configsync.test"))
+	crdObj.Spec.Group = testGVK.Group
+	crdObj.Spec.Names.Kind = testGVK.Kind
+
+	fakeClient := testingfake.NewClient(t, core.Scheme, crdObj)
+	kptApplier := newFakeKptApplier(nil)
+	retainKptApplier := newFakeKptApplier([]event.Event{
+		formApplyEvent(event.ApplySuccessful, deploymentObj, nil),
+	})
+	cs := &ClientSet{
+		KptApplier:       kptApplier,
+		Client:           fakeClient,
+		Mapper:           fakeClient.RESTMapper(),
+		retainKptApplier: retainKptApplier,
+		retainInvClient:  &retainInventoryClient{Client: inventory.NewFakeClient(nil)},
+	}
+	applier, err := NewNamespaceSupervisor(cs, syncScope, syncName, 5*time.Minute, Options{DeferUnestablishedCRs: true})
+	require.NoError(t, err)
+
+	// The CRD is not established, so the custom resource is deferred, and
+	// retained in the inventory, so that it is not pruned meanwhile.
+	gvks, errs := applier.Apply(context.Background(), objs)
+	testutil.AssertEqual(t, map[schema.GroupVersionKind]struct{}{
+		kinds.Deployment(): {},
+	}, gvks)
+	require.Nil(t, errs)
+	testutil.AssertEqual(t, []core.ID{core.IDOf(testObj)}, applier.Pending())
+	require.Empty(t, kptApplier.runs)
+	testutil.AssertEqual(t, object.UnstructuredSet{deploymentObj}, retainKptApplier.objs)
+	assert.Equal(t, object.ObjMetadataSet{ObjMetaFromObject(testObj)}, cs.retainInvClient.retained)
+	assert.Equal(t, []actuation.ObjectStatus{{
+		ObjectReference: inventory.ObjectReferenceFromObjMetadata(ObjMetaFromObject(testObj)),
+		Strategy:        actuation.ActuationStrategyApply,
+		Actuation:       actuation.ActuationPending,
+	}}, cs.retainInvClient.retainedStatus)
+
+	// Once the CRD is established, the custom resource is applied.
+	crdObj.Status.Conditions = []apiextensionsv1.CustomResourceDefinitionCondition{
+		{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+	}
+	require.NoError(t, fakeClient.Status().Update(context.Background(), crdObj))
+	kptApplier.events = []event.Event{
+		formApplyEvent(event.ApplySuccessful, deploymentObj, nil),
+		formApplyEvent(event.ApplySuccessful, testObj, nil),
+	}
+
+	gvks, errs = applier.Apply(context.Background(), objs)
+	testutil.AssertEqual(t, map[schema.GroupVersionKind]struct{}{
+		kinds.Deployment(): {},
+		testGVK:            {},
+	}, gvks)
+	require.Nil(t, errs)
+	require.Empty(t, applier.Pending())
+	testutil.AssertEqual(t, object.UnstructuredSet{deploymentObj, testObj}, kptApplier.objs)
+	require.Len(t, retainKptApplier.runs, 1)
+}
+
 func formApplyEvent(status event.ApplyEventStatus, obj *unstructured.Unstructured, err error) event.Event {
 	return event.Event{
 		Type: event.ApplyType,
@@ -475,6 +645,16 @@ func formWaitEvent(status event.WaitEventStatus, id *object.ObjMetadata) event.E
 	return e
 }
 
+func formActionGroupEvent(action event.ResourceAction, status event.ActionGroupEventStatus) event.Event {
+	return event.Event{
+		Type: event.ActionGroupType,
+		ActionGroupEvent: event.ActionGroupEvent{
+			Action: action,
+			Status: status,
+		},
+	}
+}
+
 func formErrorEvent(err error) event.Event {
 	e := event.Event{
 		Type: event.ErrorType,
@@ -497,10 +677,11 @@ func TestProcessApplyEvent(t *testing.T) {
 	unknownTypeResources := make(map[core.ID]struct{})
 	eh := eventHandler{
 		isDestroy: false,
+		clientSet: &ClientSet{},
 	}
 
 	err := eh.processApplyEvent(ctx, formApplyEvent(event.ApplyFailed, deploymentObj, fmt.Errorf("test error")).ApplyEvent, s.ApplyEvent, objStatusMap, unknownTypeResources)
-	expectedError := ErrorForResource(fmt.Errorf("test error"), idFrom(deploymentID))
+	expectedError := ErrorForResource(fmt.Errorf("test error"), idFrom(deploymentID), "")
 	testutil.AssertEqual(t, expectedError, err, "expected processPruneEvent to error on apply %s", event.ApplyFailed)
 
 	err = eh.processApplyEvent(ctx, formApplyEvent(event.ApplySuccessful, testObj, nil).ApplyEvent, s.ApplyEvent, objStatusMap, unknownTypeResources)
@@ -544,7 +725,7 @@ func TestProcessPruneEvent(t *testing.T) {
 	}
 
 	err := eh.processPruneEvent(ctx, formPruneEvent(event.PruneFailed, deploymentObj, fmt.Errorf("test error")).PruneEvent, s.PruneEvent, objStatusMap)
-	expectedError := ErrorForResource(fmt.Errorf("test error"), idFrom(deploymentID))
+	expectedError := ErrorForResource(fmt.Errorf("test error"), idFrom(deploymentID), "")
 	testutil.AssertEqual(t, expectedError, err, "expected processPruneEvent to error on prune %s", event.PruneFailed)
 
 	err = eh.processPruneEvent(ctx, formPruneEvent(event.PruneSuccessful, testObj, nil).PruneEvent, s.PruneEvent, objStatusMap)
@@ -686,10 +867,565 @@ func newDeploymentObj() *unstructured.Unstructured {
 		core.Namespace("test-namespace"), core.Name("random-name"))
 }
 
-func newTestObj(name string) *unstructured.Unstructured {
+func newTestObj(name string, opts ...core.MetaMutator) *unstructured.Unstructured {
 	return fake.UnstructuredObject(schema.GroupVersionKind{
 		Group:   "configsync.test",
 		Version: "v1",
 		Kind:    "Test",
-	}, core.Namespace("test-namespace"), core.Name(name))
+	}, append([]core.MetaMutator{core.Namespace("test-namespace"), core.Name(name)}, opts...)...)
+}
+
+func TestNewRootSupervisor_ConflictPolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		conflictPolicy configsync.ConflictPolicy
+		expectedPolicy inventory.Policy
+	}{
+		{
+			name:           "unset defaults to PolicyAdoptAll",
+			expectedPolicy: inventory.PolicyAdoptAll,
+		},
+		{
+			name:           "adoptAll uses PolicyAdoptAll",
+			conflictPolicy: configsync.ConflictPolicyAdoptAll,
+			expectedPolicy: inventory.PolicyAdoptAll,
+		},
+		{
+			name:           "adoptIfNoInventory uses PolicyAdoptIfNoInventory",
+			conflictPolicy: configsync.ConflictPolicyAdoptIfNoInventory,
+			expectedPolicy: inventory.PolicyAdoptIfNoInventory,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme)
+			cs := &ClientSet{
+				Client: fakeClient,
+				Mapper: fakeClient.RESTMapper(),
+			}
+			s, err := NewRootSupervisor(cs, "root-sync", 5*time.Minute, Options{ConflictPolicy: tc.conflictPolicy})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPolicy, s.(*supervisor).policy)
+		})
+	}
+}
+
+// TestApply_SyncStageDuration verifies that the apply and the wait tasks are
+// recorded as separate stages.
+func TestApply_SyncStageDuration(t *testing.T) {
+	require.NoError(t, view.Register(m.SyncStageDurationView))
+	defer view.Unregister(m.SyncStageDurationView)
+
+	testObj := newTestObj("test-1")
+	testID := object.UnstructuredToObjMetadata(testObj)
+	fakeClient := testingfake.NewClient(t, core.Scheme)
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier([]event.Event{
+			formActionGroupEvent(event.ApplyAction, event.Started),
+			formApplyEvent(event.ApplyFailed, testObj, applyerror.NewApplyRunError(errors.New("failed apply"))),
+			formActionGroupEvent(event.ApplyAction, event.Finished),
+			formActionGroupEvent(event.WaitAction, event.Started),
+			formWaitEvent(event.ReconcileSuccessful, &testID),
+			formActionGroupEvent(event.WaitAction, event.Finished),
+		}),
+		Client: fakeClient,
+		Mapper: fakeClient.RESTMapper(),
+	}
+	applier, err := NewNamespaceSupervisor(cs, "test-namespace", "rs", 5*time.Minute, Options{})
+	require.NoError(t, err)
+	_, errs := applier.Apply(context.Background(), []client.Object{testObj})
+	require.NotNil(t, errs)
+
+	rows, err := view.RetrieveData(m.SyncStageDurationView.Name)
+	require.NoError(t, err)
+	got := make(map[string]int64)
+	for _, row := range rows {
+		var stage, status string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case m.KeySyncStage:
+				stage = tg.Value
+			case m.KeyStatus:
+				status = tg.Value
+			}
+		}
+		got[stage+"/"+status] = row.Data.(*view.DistributionData).Count
+	}
+	assert.Equal(t, map[string]int64{
+		m.StageApply + "/" + m.StatusError:  1,
+		m.StageWait + "/" + m.StatusSuccess: 1,
+	}, got)
+}
+
+// policyKptApplier is a KptApplier which applies the objects which the
+// inventory policy allows to apply, like the kpt applier does, given the
+// owning inventory of the objects on the cluster.
+type policyKptApplier struct {
+	// liveObjs are the objects on the cluster, by ID.
+	liveObjs map[object.ObjMetadata]*unstructured.Unstructured
+}
+
+var _ KptApplier = &policyKptApplier{}
+
+func (a *policyKptApplier) Run(_ context.Context, inv inventory.Info, objs object.UnstructuredSet, options apply.ApplierOptions) <-chan event.Event {
+	events := make(chan event.Event, len(objs))
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		liveObj, found := a.liveObjs[id]
+		if !found {
+			liveObj = obj
+		}
+		if _, err := inventory.CanApply(inv, liveObj, options.InventoryPolicy); err != nil {
+			events <- formApplySkipEvent(id, obj, err)
+		} else {
+			events <- formApplyEvent(event.ApplySuccessful, obj, nil)
+		}
+	}
+	close(events)
+	return events
+}
+
+// TestApply_ConflictPolicy verifies which objects managed by another RootSync
+// or RepoSync the RootSync applier takes over, depending on the conflict
+// policy.
+func TestApply_ConflictPolicy(t *testing.T) {
+	syncName := "root-sync"
+	inventoryID := InventoryID(syncName, configsync.ControllerNamespace)
+
+	withOwningInventory := func(obj *unstructured.Unstructured, id string) *unstructured.Unstructured {
+		obj = obj.DeepCopy()
+		core.SetAnnotation(obj, metadata.OwningInventoryKey, id)
+		return obj
+	}
+	unmanagedObj := newTestObj("unmanaged")
+	ownObj := newTestObj("own")
+	otherObj := newTestObj("other")
+	liveObjs := map[object.ObjMetadata]*unstructured.Unstructured{
+		object.UnstructuredToObjMetadata(ownObj):   withOwningInventory(ownObj, inventoryID),
+		object.UnstructuredToObjMetadata(otherObj): withOwningInventory(otherObj, InventoryID("other-sync", configsync.ControllerNamespace)),
+	}
+
+	testCases := []struct {
+		name           string
+		conflictPolicy configsync.ConflictPolicy
+		expectedErrs   status.MultiError
+	}{
+		{
+			name:           "adoptAll takes over the objects managed by others",
+			conflictPolicy: configsync.ConflictPolicyAdoptAll,
+		},
+		{
+			name:           "adoptIfNoInventory reports the objects managed by others as conflicts",
+			conflictPolicy: configsync.ConflictPolicyAdoptIfNoInventory,
+			expectedErrs:   KptManagementConflictError(otherObj),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme)
+			cs := &ClientSet{
+				KptApplier: &policyKptApplier{liveObjs: liveObjs},
+				Client:     fakeClient,
+				Mapper:     fakeClient.RESTMapper(),
+			}
+			applier, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{ConflictPolicy: tc.conflictPolicy})
+			require.NoError(t, err)
+
+			_, errs := applier.Apply(context.Background(), []client.Object{unmanagedObj, ownObj, otherObj})
+			testutil.AssertEqual(t, tc.expectedErrs, errs)
+		})
+	}
+}
+
+func TestApply_Handoff(t *testing.T) {
+	syncName := "root-a"
+	resourceManager := declared.ResourceManager(declared.RootReconciler, syncName)
+	newManager := declared.ResourceManager(declared.RootReconciler, "root-b")
+
+	deploymentObj := newDeploymentObj()
+	managedCM := func(name, manager string, opts ...core.MetaMutator) *unstructured.Unstructured {
+		obj := fake.UnstructuredObject(kinds.ConfigMap(), core.Name(name), core.Namespace("test-namespace"),
+			core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+			core.Annotation(metadata.ResourceManagerKey, manager),
+			core.Annotation(metadata.OwningInventoryKey, "anything"),
+			core.Annotation("example-to-not-delete", "anything"),
+			core.Label(metadata.ManagedByKey, metadata.ManagedByValue))
+		for _, opt := range opts {
+			opt(obj)
+		}
+		return obj
+	}
+	// pendingObj is handed off to root-b, which has not adopted it yet.
+	pendingObj := managedCM("pending", resourceManager, core.Annotation(metadata.HandoffToAnnotationKey, "root-b"))
+	// adoptedObj is handed off to root-b, which has already adopted it.
+	adoptedObj := managedCM("adopted", newManager, core.Annotation(metadata.HandoffToAnnotationKey, "root-b"))
+	// prunedObj is not handed off, so it is left to the kpt applier to prune.
+	prunedObj := managedCM("pruned", resourceManager)
+	// selfObj is handed off to the current RootSync, so it is not handed off.
+	selfObj := managedCM("self", resourceManager, core.Annotation(metadata.HandoffToAnnotationKey, syncName))
+
+	invObj := newInventoryUnstructured(configsync.RootSyncKind, syncName, configmanagement.ControllerNamespace, StatusDisabled)
+	var resources []interface{}
+	for _, obj := range []client.Object{deploymentObj, pendingObj, adoptedObj, prunedObj, selfObj} {
+		id := core.IDOf(obj)
+		resources = append(resources, map[string]interface{}{
+			"group":     id.Group,
+			"kind":      id.Kind,
+			"namespace": id.Namespace,
+			"name":      id.Name,
+		})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(invObj.Object, resources, "spec", "resources"))
+
+	// The ResourceGroup type is not registered in core.Scheme.
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+
+	fakeClient := testingfake.NewClient(t, scheme, invObj, deploymentObj, pendingObj, adoptedObj, prunedObj, selfObj)
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		InvClient:  inventory.NewFakeClient(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+	}
+	applier, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{ConflictPolicy: configsync.ConflictPolicyAdoptAll})
+	require.NoError(t, err)
+
+	handedOff, handoffErr := applier.(*supervisor).handedOffObjects(context.Background(), []client.Object{deploymentObj})
+	require.NoError(t, handoffErr)
+	var handedOffIDs []core.ID
+	for _, obj := range handedOff {
+		handedOffIDs = append(handedOffIDs, core.IDOf(obj))
+	}
+	assert.ElementsMatch(t, []core.ID{core.IDOf(pendingObj), core.IDOf(adoptedObj)}, handedOffIDs)
+
+	_, errs := applier.Apply(context.Background(), []client.Object{deploymentObj})
+	require.Nil(t, errs)
+
+	// The pending object is released for root-b to adopt, without the
+	// orphaned-by annotation.
+	got := &unstructured.Unstructured{}
+	got.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(pendingObj), got))
+	assert.Equal(t, map[string]string{"example-to-not-delete": "anything"}, got.GetAnnotations())
+	assert.Empty(t, got.GetLabels())
+
+	// The other objects are left untouched.
+	for _, obj := range []*unstructured.Unstructured{adoptedObj, prunedObj, selfObj} {
+		got := &unstructured.Unstructured{}
+		got.SetGroupVersionKind(kinds.ConfigMap())
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), got))
+		assert.Equal(t, obj.GetAnnotations(), got.GetAnnotations())
+		assert.Equal(t, obj.GetLabels(), got.GetLabels())
+	}
+}
+
+func TestApply_DisabledObjects(t *testing.T) {
+	syncName := "root-sync"
+	resourceManager := declared.ResourceManager(declared.RootReconciler, syncName)
+	otherManager := declared.ResourceManager(declared.RootReconciler, "other-sync")
+
+	deploymentObj := newDeploymentObj()
+	managedCM := func(name, manager string) *unstructured.Unstructured {
+		return fake.UnstructuredObject(kinds.ConfigMap(), core.Name(name), core.Namespace("test-namespace"),
+			core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+			core.Annotation(metadata.ResourceManagerKey, manager),
+			core.Annotation(metadata.OwningInventoryKey, "anything"),
+			core.Annotation("example-to-not-delete", "anything"),
+			core.Label(metadata.ManagedByKey, metadata.ManagedByValue))
+	}
+	// appliedObj was applied by this RootSync before it was disabled, like an
+	// object which no longer matches the object selector.
+	appliedObj := managedCM("applied", resourceManager)
+	// otherObj is managed by another RootSync.
+	otherObj := managedCM("other", otherManager)
+
+	invObj := newInventoryUnstructured(configsync.RootSyncKind, syncName, configmanagement.ControllerNamespace, StatusDisabled)
+	var resources []interface{}
+	for _, obj := range []client.Object{deploymentObj, appliedObj} {
+		id := core.IDOf(obj)
+		resources = append(resources, map[string]interface{}{
+			"group":     id.Group,
+			"kind":      id.Kind,
+			"namespace": id.Namespace,
+			"name":      id.Name,
+		})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(invObj.Object, resources, "spec", "resources"))
+
+	// The ResourceGroup type is not registered in core.Scheme.
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+
+	fakeClient := testingfake.NewClient(t, scheme, invObj, deploymentObj, appliedObj, otherObj)
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		InvClient:  inventory.NewFakeClient(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+	}
+	applier, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{ConflictPolicy: configsync.ConflictPolicyAdoptAll})
+	require.NoError(t, err)
+
+	disabled := func(obj *unstructured.Unstructured) client.Object {
+		obj = obj.DeepCopy()
+		core.SetAnnotation(obj, metadata.ResourceManagementKey, metadata.ResourceManagementDisabled)
+		return obj
+	}
+	_, errs := applier.Apply(context.Background(), []client.Object{deploymentObj, disabled(appliedObj), disabled(otherObj)})
+	require.Nil(t, errs)
+
+	// The object applied before is left on the cluster, without the Config
+	// Sync metadata.
+	got := &unstructured.Unstructured{}
+	got.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(appliedObj), got))
+	assert.Equal(t, map[string]string{"example-to-not-delete": "anything"}, got.GetAnnotations())
+	assert.Empty(t, got.GetLabels())
+
+	// The object managed by another RootSync is left untouched.
+	got = &unstructured.Unstructured{}
+	got.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(otherObj), got))
+	assert.Equal(t, otherObj.GetAnnotations(), got.GetAnnotations())
+	assert.Equal(t, otherObj.GetLabels(), got.GetLabels())
+}
+
+func TestApply_UnselectedObjects(t *testing.T) {
+	syncName := "root-sync"
+	resourceManager := declared.ResourceManager(declared.RootReconciler, syncName)
+
+	deploymentObj := newDeploymentObj()
+	managedCM := func(name string) *unstructured.Unstructured {
+		return fake.UnstructuredObject(kinds.ConfigMap(), core.Name(name), core.Namespace("test-namespace"),
+			core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+			core.Annotation(metadata.ResourceManagerKey, resourceManager),
+			core.Annotation(metadata.OwningInventoryKey, "anything"),
+			core.Label(metadata.ManagedByKey, metadata.ManagedByValue),
+			core.Label("env", "dev"))
+	}
+	// appliedObj was applied by this RootSync before it stopped matching the
+	// object selector.
+	appliedObj := managedCM("applied")
+	// notAppliedObj is on the cluster, but not in the inventory, so it was not
+	// applied by this RootSync.
+	notAppliedObj := managedCM("not-applied")
+
+	invObj := newInventoryUnstructured(configsync.RootSyncKind, syncName, configmanagement.ControllerNamespace, StatusDisabled)
+	var resources []interface{}
+	for _, obj := range []client.Object{deploymentObj, appliedObj} {
+		id := core.IDOf(obj)
+		resources = append(resources, map[string]interface{}{
+			"group":     id.Group,
+			"kind":      id.Kind,
+			"namespace": id.Namespace,
+			"name":      id.Name,
+		})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(invObj.Object, resources, "spec", "resources"))
+
+	// The ResourceGroup type is not registered in core.Scheme.
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+
+	fakeClient := testingfake.NewClient(t, scheme, invObj, deploymentObj, appliedObj, notAppliedObj)
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		InvClient:  inventory.NewFakeClient(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+	}
+	selector, err := labels.Parse("env=prod")
+	require.NoError(t, err)
+	applier, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{ConflictPolicy: configsync.ConflictPolicyAdoptAll, ObjectSelector: selector})
+	require.NoError(t, err)
+
+	// The parser marks the unselected objects as management disabled.
+	unselected := func(obj *unstructured.Unstructured) client.Object {
+		obj = obj.DeepCopy()
+		core.SetAnnotation(obj, metadata.ResourceManagementKey, metadata.ResourceManagementDisabled)
+		return obj
+	}
+	// missingObj is neither in the inventory nor on the cluster, like an
+	// object meant for another cluster.
+	missingObj := unselected(managedCM("missing"))
+	_, errs := applier.Apply(context.Background(), []client.Object{deploymentObj, unselected(appliedObj), unselected(notAppliedObj), missingObj})
+	require.Nil(t, errs)
+
+	// The object applied before is left on the cluster, without the Config
+	// Sync metadata.
+	got := &unstructured.Unstructured{}
+	got.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(appliedObj), got))
+	assert.Empty(t, got.GetAnnotations())
+	assert.Equal(t, map[string]string{"env": "dev"}, got.GetLabels())
+
+	// The object which was not applied before is ignored, so it is left
+	// untouched.
+	got = &unstructured.Unstructured{}
+	got.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(notAppliedObj), got))
+	assert.Equal(t, notAppliedObj.GetAnnotations(), got.GetAnnotations())
+	assert.Equal(t, notAppliedObj.GetLabels(), got.GetLabels())
+}
+
+func TestApply_MaxPruneCount(t *testing.T) {
+	syncName := "root-sync"
+	deploymentObj := newDeploymentObj()
+	invObj := newInventoryUnstructured(configsync.RootSyncKind, syncName, configmanagement.ControllerNamespace, StatusDisabled)
+	resources := []interface{}{
+		map[string]interface{}{"group": "apps", "kind": "Deployment", "namespace": deploymentObj.GetNamespace(), "name": deploymentObj.GetName()},
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		resources = append(resources, map[string]interface{}{"group": "", "kind": "ConfigMap", "namespace": "test-namespace", "name": name})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(invObj.Object, resources, "spec", "resources"))
+	configMapIDs := func(names ...string) object.ObjMetadataSet {
+		var ids object.ObjMetadataSet
+		for _, name := range names {
+			ids = append(ids, object.ObjMetadata{GroupKind: kinds.ConfigMap().GroupKind(), Namespace: "test-namespace", Name: name})
+		}
+		return ids
+	}
+	pruneHash := pruneSetHash(configMapIDs("c", "b", "a"))
+
+	testCases := []struct {
+		name          string
+		maxPruneCount int
+		confirmation  string
+		wantErr       bool
+	}{
+		{
+			name:          "unlimited",
+			maxPruneCount: 0,
+		},
+		{
+			name:          "within the limit",
+			maxPruneCount: 3,
+		},
+		{
+			name:          "exceeds the limit",
+			maxPruneCount: 2,
+			wantErr:       true,
+		},
+		{
+			name:          "exceeds the limit with the number of objects to prune",
+			maxPruneCount: 2,
+			confirmation:  "3",
+			wantErr:       true,
+		},
+		{
+			name:          "exceeds the limit with a confirmation for a different set of objects",
+			maxPruneCount: 2,
+			confirmation:  pruneSetHash(configMapIDs("a", "b", "d")),
+			wantErr:       true,
+		},
+		{
+			name:          "exceeds the limit with a confirmation",
+			maxPruneCount: 2,
+			confirmation:  pruneHash,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := fake.RootSyncObjectV1Beta1(syncName)
+			if tc.confirmation != "" {
+				core.SetAnnotation(rs, metadata.PruneConfirmationAnnotationKey, tc.confirmation)
+			}
+			// The ResourceGroup type is not registered in core.Scheme.
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, appsv1.AddToScheme(scheme))
+			require.NoError(t, v1beta1.AddToScheme(scheme))
+			require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+			fakeClient := testingfake.NewClient(t, scheme, invObj.DeepCopy(), rs)
+			cs := &ClientSet{
+				KptApplier: newFakeKptApplier(nil),
+				InvClient:  inventory.NewFakeClient(nil),
+				Client:     fakeClient,
+				Mapper:     fakeClient.RESTMapper(),
+			}
+			applier, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{MaxPruneCount: tc.maxPruneCount, ConflictPolicy: configsync.ConflictPolicyAdoptAll})
+			require.NoError(t, err)
+
+			_, errs := applier.Apply(context.Background(), []client.Object{deploymentObj})
+			if tc.wantErr {
+				testutil.AssertEqual(t, status.Append(nil, PruneLimitError(3, tc.maxPruneCount, pruneHash, configsync.RootSyncKind, configmanagement.ControllerNamespace, syncName)), errs)
+			} else {
+				require.Nil(t, errs)
+			}
+		})
+	}
+}
+
+func TestApply_DeletionProtection(t *testing.T) {
+	syncName := "root-sync"
+	deploymentObj := newDeploymentObj()
+	protectedObj := fake.UnstructuredObject(kinds.ConfigMap(), core.Name("protected"), core.Namespace("test-namespace"),
+		core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+		core.Annotation(metadata.DeletionProtectionAnnotationKey, metadata.DeletionProtectionEnabled))
+	prunedObj := fake.UnstructuredObject(kinds.ConfigMap(), core.Name("pruned"), core.Namespace("test-namespace"),
+		core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled))
+
+	invObj := newInventoryUnstructured(configsync.RootSyncKind, syncName, configmanagement.ControllerNamespace, StatusDisabled)
+	var resources []interface{}
+	for _, obj := range []client.Object{deploymentObj, protectedObj, prunedObj} {
+		id := core.IDOf(obj)
+		resources = append(resources, map[string]interface{}{
+			"group":     id.Group,
+			"kind":      id.Kind,
+			"namespace": id.Namespace,
+			"name":      id.Name,
+		})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(invObj.Object, resources, "spec", "resources"))
+
+	// The ResourceGroup type is not registered in core.Scheme.
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+
+	fakeClient := testingfake.NewClient(t, scheme, invObj, deploymentObj, protectedObj, prunedObj)
+	retainKptApplier := newFakeKptApplier(nil)
+	cs := &ClientSet{
+		KptApplier:       newFakeKptApplier(nil),
+		InvClient:        inventory.NewFakeClient(nil),
+		Client:           fakeClient,
+		Mapper:           fakeClient.RESTMapper(),
+		retainKptApplier: retainKptApplier,
+		retainInvClient:  &retainInventoryClient{Client: inventory.NewFakeClient(nil)},
+	}
+	applier, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{ConflictPolicy: configsync.ConflictPolicyAdoptAll})
+	require.NoError(t, err)
+
+	_, errs := applier.Apply(context.Background(), []client.Object{deploymentObj})
+	testutil.AssertEqual(t, status.Append(nil, status.DeletionProtectedError(protectedObj)), errs)
+
+	// The protected object is retained in the inventory, so that the kpt
+	// applier does not prune it.
+	require.Len(t, retainKptApplier.runs, 1)
+	assert.Equal(t, []bool{false}, retainKptApplier.noPrune)
+	assert.Equal(t, object.ObjMetadataSet{ObjMetaFromObject(protectedObj)}, cs.retainInvClient.retained)
+	assert.Equal(t, []actuation.ObjectStatus{{
+		ObjectReference: inventory.ObjectReferenceFromObjMetadata(ObjMetaFromObject(protectedObj)),
+		Strategy:        actuation.ActuationStrategyDelete,
+		Actuation:       actuation.ActuationSkipped,
+	}}, cs.retainInvClient.retainedStatus)
+}
+
+func TestErrorForResource_SourcePath(t *testing.T) {
+	id := core.IDOf(newDeploymentObj())
+	err := ErrorForResource(errors.New("failed apply"), id, "namespaces/test-namespace/deployment.yaml")
+	assert.Contains(t, err.Error(), "failed to apply Deployment.apps, test-namespace/random-name (source: namespaces/test-namespace/deployment.yaml): failed apply")
+
+	err = ErrorForResource(errors.New("failed apply"), id, "")
+	assert.Contains(t, err.Error(), "failed to apply Deployment.apps, test-namespace/random-name: failed apply")
 }
@@ -22,6 +22,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -50,22 +51,43 @@ type ClientSet struct {
 	Client       client.Client
 	Mapper       meta.RESTMapper
 	StatusMode   string
+	// FieldManager is the field manager name used for server-side apply.
+	FieldManager string
+
+	// actuations tracks the objects actuated by the applier, to record their
+	// actuation times. It is nil unless the status mode is "timestamps".
+	actuations *actuationTracker
+
+	// retainKptApplier applies the declared objects, using retainInvClient as
+	// its inventory client.
+	retainKptApplier KptApplier
+	// retainInvClient retains the objects protected from deletion and the
+	// deferred custom resources in the inventory.
+	retainInvClient *retainInventoryClient
 }
 
 // NewClientSet constructs a new ClientSet.
-func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, statusMode string) (*ClientSet, error) {
+func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, statusMode, fieldManager string) (*ClientSet, error) {
 	matchVersionKubeConfigFlags := util.NewMatchVersionFlags(configFlags)
 	f := util.NewFactory(matchVersionKubeConfigFlags)
 
 	var statusPolicy inventory.StatusPolicy
-	if statusMode == StatusEnabled {
+	var actuations *actuationTracker
+	storageFunc := live.WrapInventoryObj
+	switch statusMode {
+	case StatusEnabled:
 		klog.Infof("Enabled status reporting")
 		statusPolicy = inventory.StatusPolicyAll
-	} else {
+	case StatusTimestamps:
+		klog.Infof("Enabled status reporting with actuation timestamps")
+		statusPolicy = inventory.StatusPolicyAll
+		actuations = newActuationTracker()
+		storageFunc = wrapTimestampInventoryObj(clock.RealClock{}, actuations)
+	default:
 		klog.Infof("Disabled status reporting")
 		statusPolicy = inventory.StatusPolicyNone
 	}
-	invClient, err := inventory.NewClient(f, live.WrapInventoryObj,
+	invClient, err := inventory.NewClient(f, storageFunc,
 		live.InvToUnstructuredFunc, statusPolicy, live.ResourceGroupGVK)
 	if err != nil {
 		return nil, err
@@ -79,6 +101,15 @@ func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, s
 		return nil, err
 	}
 
+	retainInvClient := &retainInventoryClient{Client: invClient}
+	retainApplier, err := apply.NewApplierBuilder().
+		WithInventoryClient(retainInvClient).
+		WithFactory(f).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
 	destroyer, err := apply.NewDestroyerBuilder().
 		WithInventoryClient(invClient).
 		WithFactory(f).
@@ -99,5 +130,10 @@ func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, s
 		Client:       c,
 		Mapper:       mapper,
 		StatusMode:   statusMode,
+		FieldManager: fieldManager,
+
+		actuations:       actuations,
+		retainKptApplier: retainApplier,
+		retainInvClient:  retainInvClient,
 	}, nil
 }
@@ -23,6 +23,10 @@ const (
 	//  StatusDisabled is used to stop kpt applier to inject the actuation status
 	// into the ResourceGroup object.
 	StatusDisabled = "disabled"
+	// StatusTimestamps is used to allow kpt applier to inject the actuation
+	// status into the ResourceGroup object, along with the time each object
+	// was last applied or pruned.
+	StatusTimestamps = "timestamps"
 
 	// StatusModeKey annotates a ResourceGroup CR
 	// to communicate with the ResourceGroup controller.
@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/differ"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deletionProtectedObjects returns the objects in the inventory which are no
+// longer declared, but have the deletion-protection annotation. These objects
+// are retained in the inventory instead of being pruned.
+func (a *supervisor) deletionProtectedObjects(ctx context.Context, objs []client.Object) ([]client.Object, status.Error) {
+	invIDs, invErr := a.inventoryIDs(ctx)
+	if invErr != nil {
+		return nil, invErr
+	}
+
+	var protected []client.Object
+	for _, id := range invIDs.Diff(objMetasFromObjects(objs)) {
+		mapping, err := a.clientSet.Mapper.RESTMapping(id.GroupKind)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// The resource type is gone, so there is nothing to protect.
+				continue
+			}
+			return nil, Error(fmt.Errorf("failed to get the mapping of %v: %w", idFrom(id), err))
+		}
+		uObj := &unstructured.Unstructured{}
+		uObj.SetGroupVersionKind(mapping.GroupVersionKind)
+		if err := a.clientSet.Client.Get(ctx, client.ObjectKey{Namespace: id.Namespace, Name: id.Name}, uObj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, Error(fmt.Errorf("failed to get %v: %w", idFrom(id), err))
+		}
+		if differ.HasDeletionProtection(uObj) {
+			protected = append(protected, uObj)
+		}
+	}
+	return protected, nil
+}
+
+// retainInventoryClient wraps an inventory.Client, so that the kpt applier
+// neither prunes the retained objects, nor removes them from the inventory.
+// The objects protected from deletion and the custom resources deferred until
+// their CRD is established are retained.
+type retainInventoryClient struct {
+	inventory.Client
+
+	// retained are the objects which are not pruned.
+	retained object.ObjMetadataSet
+	// retainedStatus are the known actuation statuses of the retained objects.
+	retainedStatus []actuation.ObjectStatus
+}
+
+var _ inventory.Client = &retainInventoryClient{}
+
+// retain sets the objects to retain with the next apply, along with their
+// known statuses.
+func (c *retainInventoryClient) retain(ids object.ObjMetadataSet, objStatusMap ObjectStatusMap) {
+	c.retained = ids
+	c.retainedStatus = nil
+	for _, id := range ids {
+		objStatus, found := objStatusMap[idFrom(id)]
+		if !found {
+			continue
+		}
+		c.retainedStatus = append(c.retainedStatus, actuation.ObjectStatus{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(id),
+			Strategy:        objStatus.Strategy,
+			Actuation:       objStatus.Actuation,
+			Reconcile:       objStatus.Reconcile,
+		})
+	}
+}
+
+// GetClusterObjs hides the retained objects from the kpt applier, so that
+// they are not pruned.
+func (c *retainInventoryClient) GetClusterObjs(inv inventory.Info) (object.ObjMetadataSet, error) {
+	objs, err := c.Client.GetClusterObjs(inv)
+	if err != nil {
+		return nil, err
+	}
+	return objs.Diff(c.retained), nil
+}
+
+// Replace keeps the retained objects which are already in the inventory, so
+// that they are still tracked after the apply.
+func (c *retainInventoryClient) Replace(inv inventory.Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus, dryRun common.DryRunStrategy) error {
+	clusterObjs, err := c.Client.GetClusterObjs(inv)
+	if err != nil {
+		return err
+	}
+	objs = objs.Union(clusterObjs.Intersection(c.retained))
+	status = append(status, c.retainedStatus...)
+	return c.Client.Replace(inv, objs, status, dryRun)
+}
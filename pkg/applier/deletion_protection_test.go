@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestRetainInventoryClient(t *testing.T) {
+	retained := ObjMetaFromObject(newTestObj("retained"))
+	unknown := ObjMetaFromObject(newTestObj("unknown"))
+	stale := ObjMetaFromObject(newTestObj("stale"))
+	applied := ObjMetaFromObject(newTestObj("applied"))
+
+	fakeInvClient := inventory.NewFakeClient(object.ObjMetadataSet{retained, stale, applied})
+	c := &retainInventoryClient{Client: fakeInvClient}
+	c.retain(object.ObjMetadataSet{retained, unknown}, ObjectStatusMap{
+		idFrom(retained): {
+			Strategy:  actuation.ActuationStrategyApply,
+			Actuation: actuation.ActuationSucceeded,
+			Reconcile: actuation.ReconcileSucceeded,
+		},
+	})
+
+	// The retained objects are hidden from the kpt applier, so they are not pruned.
+	objs, err := c.GetClusterObjs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{stale, applied}, objs)
+
+	// The retained objects already in the inventory are kept, with their status.
+	appliedStatus := actuation.ObjectStatus{
+		ObjectReference: inventory.ObjectReferenceFromObjMetadata(applied),
+		Strategy:        actuation.ActuationStrategyApply,
+		Actuation:       actuation.ActuationSucceeded,
+		Reconcile:       actuation.ReconcileSucceeded,
+	}
+	err = c.Replace(nil, object.ObjMetadataSet{applied}, []actuation.ObjectStatus{appliedStatus}, common.DryRunNone)
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{applied, retained}, fakeInvClient.Objs)
+	assert.Equal(t, []actuation.ObjectStatus{
+		appliedStatus,
+		{
+			ObjectReference: inventory.ObjectReferenceFromObjMetadata(retained),
+			Strategy:        actuation.ActuationStrategyApply,
+			Actuation:       actuation.ActuationSucceeded,
+			Reconcile:       actuation.ReconcileSucceeded,
+		},
+	}, fakeInvClient.Status)
+}
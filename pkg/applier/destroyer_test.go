@@ -24,7 +24,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
 	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/fake"
@@ -33,6 +35,7 @@ import (
 	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
@@ -74,6 +77,18 @@ func TestDestroy(t *testing.T) {
 
 	testObj2 := newTestObj("test-2")
 
+	orphanObj := deploymentObj.DeepCopy()
+	orphanObj.SetName("orphan-me")
+	orphanObj.SetAnnotations(map[string]string{
+		common.LifecycleDeleteAnnotation: common.PreventDeletion,
+		metadata.ResourceManagementKey:   metadata.ResourceManagementEnabled,
+		metadata.ResourceManagerKey:      declared.ResourceManager("test-namespace", "rs"),
+		"example-to-not-delete":          "anything",
+	})
+	orphanObj.SetLabels(map[string]string{
+		metadata.ManagedByKey: metadata.ManagedByValue,
+	})
+
 	namespaceObj := fake.UnstructuredObject(kinds.Namespace(),
 		core.Name("test-namespace"))
 	namespaceID := object.UnstructuredToObjMetadata(namespaceObj)
@@ -92,9 +107,11 @@ func TestDestroy(t *testing.T) {
 	etcdError := errors.New("etcdserver: request is too large") // satisfies util.IsRequestTooLargeError
 
 	testcases := []struct {
-		name     string
-		events   []event.Event
-		multiErr error
+		name               string
+		serverObjs         []client.Object
+		events             []event.Event
+		multiErr           error
+		expectedServerObjs []client.Object
 	}{
 		{
 			name: "unknown type for some resource",
@@ -102,7 +119,7 @@ func TestDestroy(t *testing.T) {
 				formDeleteEvent(event.DeleteFailed, testObj, applyerror.NewUnknownTypeError(testError1)),
 				formDeleteEvent(event.DeletePending, testObj2, nil),
 			},
-			multiErr: newMultiError(DeleteErrorForResource(testError1, idFrom(testID))),
+			multiErr: newMultiError(DeleteErrorForResource(testError1, idFrom(testID), "")),
 		},
 		{
 			name: "conflict error for some resource",
@@ -131,7 +148,7 @@ func TestDestroy(t *testing.T) {
 				formDeleteEvent(event.DeleteFailed, testObj, testError1),
 				formDeleteEvent(event.DeletePending, testObj2, nil),
 			},
-			multiErr: newMultiError(DeleteErrorForResource(testError1, idFrom(testID))),
+			multiErr: newMultiError(DeleteErrorForResource(testError1, idFrom(testID), "")),
 		},
 		{
 			name: "skipped delete",
@@ -145,8 +162,35 @@ func TestDestroy(t *testing.T) {
 			multiErr: newMultiError(SkipErrorForResource(
 				errors.New("namespace still in use: test-namespace"),
 				idFrom(namespaceID),
+				"",
 				actuation.ActuationStrategyDelete)),
 		},
+		{
+			name:       "orphan object",
+			serverObjs: []client.Object{orphanObj},
+			events: []event.Event{
+				formDeleteSkipEvent(object.UnstructuredToObjMetadata(orphanObj), orphanObj.DeepCopy(),
+					&filter.AnnotationPreventedDeletionError{
+						Annotation: common.LifecycleDeleteAnnotation,
+						Value:      common.PreventDeletion,
+					}),
+			},
+			expectedServerObjs: []client.Object{
+				func() client.Object {
+					obj := orphanObj.DeepCopy()
+					obj.SetAnnotations(map[string]string{
+						common.LifecycleDeleteAnnotation: common.PreventDeletion,
+						"example-to-not-delete":          "anything",
+						metadata.OrphanedByAnnotationKey: "RepoSync/test-namespace/rs",
+					})
+					obj.SetLabels(nil)
+					obj.SetUID("1")
+					obj.SetResourceVersion("2")
+					obj.SetGeneration(1)
+					return obj
+				}(),
+			},
+		},
 		{
 			name: "all passed",
 			events: []event.Event{
@@ -163,8 +207,8 @@ func TestDestroy(t *testing.T) {
 				formDeleteEvent(event.DeleteFailed, deploymentObj, testError2),
 			},
 			multiErr: newMultiError(
-				DeleteErrorForResource(testError1, idFrom(testID)),
-				DeleteErrorForResource(testError2, idFrom(deploymentID))),
+				DeleteErrorForResource(testError1, idFrom(testID), ""),
+				DeleteErrorForResource(testError2, idFrom(deploymentID), "")),
 		},
 		{
 			name: "failed dependency during delete",
@@ -183,24 +227,29 @@ func TestDestroy(t *testing.T) {
 						RelationReconcileStatus: actuation.ReconcileTimeout,
 					},
 					idFrom(deploymentID),
+					"",
 					actuation.ActuationStrategyDelete)),
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			fakeClient := testingfake.NewClient(t, core.Scheme)
+			fakeClient := testingfake.NewClient(t, core.Scheme, tc.serverObjs...)
 			cs := &ClientSet{
 				KptDestroyer: newFakeKptDestroyer(tc.events),
 				Client:       fakeClient,
 				// TODO: Add tests to cover disabling objects
 				// TODO: Add tests to cover status mode
 			}
-			destroyer, err := NewNamespaceSupervisor(cs, "test-namespace", "rs", 5*time.Minute)
+			destroyer, err := NewNamespaceSupervisor(cs, "test-namespace", "rs", 5*time.Minute, Options{})
 			require.NoError(t, err)
 
 			errs := destroyer.Destroy(context.Background())
 			testutil.AssertEqual(t, tc.multiErr, errs)
+
+			if tc.expectedServerObjs != nil {
+				fakeClient.Check(t, tc.expectedServerObjs...)
+			}
 		})
 	}
 }
@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	nomosutil "kpt.dev/configsync/pkg/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resourceManager returns the manager of the objects applied by this
+// supervisor.
+func (a *supervisor) resourceManager() string {
+	if a.syncKind == configsync.RootSyncKind {
+		return declared.ResourceManager(declared.RootReconciler, a.syncName)
+	}
+	return declared.ResourceManager(declared.Scope(a.syncNamespace), a.syncName)
+}
+
+// handedOffObjects returns the objects in the inventory which are no longer
+// declared, and whose handoff-to annotation names another RootSync. These
+// objects are handed off to the named RootSync instead of being pruned.
+func (a *supervisor) handedOffObjects(ctx context.Context, objs []client.Object) ([]client.Object, status.Error) {
+	invIDs, invErr := a.inventoryIDs(ctx)
+	if invErr != nil {
+		return nil, invErr
+	}
+
+	manager := a.resourceManager()
+	var handedOff []client.Object
+	for _, id := range invIDs.Diff(objMetasFromObjects(objs)) {
+		mapping, err := a.clientSet.Mapper.RESTMapping(id.GroupKind)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// The resource type is gone, so there is nothing to hand off.
+				continue
+			}
+			return nil, Error(fmt.Errorf("failed to get the mapping of %v: %w", idFrom(id), err))
+		}
+		uObj := &unstructured.Unstructured{}
+		uObj.SetGroupVersionKind(mapping.GroupVersionKind)
+		if err := a.clientSet.Client.Get(ctx, client.ObjectKey{Namespace: id.Namespace, Name: id.Name}, uObj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, Error(fmt.Errorf("failed to get %v: %w", idFrom(id), err))
+		}
+		handoffTo := core.GetAnnotation(uObj, metadata.HandoffToAnnotationKey)
+		if handoffTo == "" || declared.ResourceManager(declared.RootReconciler, handoffTo) == manager {
+			continue
+		}
+		handedOff = append(handedOff, uObj)
+	}
+	return handedOff, nil
+}
+
+// handleHandedOffObjects removes the specified objects from the inventory, so
+// that they are not pruned, and then releases the objects which are not yet
+// adopted by the RootSync named by their handoff-to annotation, by removing the
+// Config Sync metadata. The objects already adopted are left untouched.
+// Returns the number of objects which are handed off successfully, and any
+// errors encountered.
+func (h *eventHandler) handleHandedOffObjects(ctx context.Context, rg *live.InventoryResourceGroup, manager string, objs []client.Object) (uint64, status.MultiError) {
+	// handedOffCount tracks the number of objects which are handed off successfully
+	var handedOffCount uint64
+	err := h.removeFromInventory(rg, objs)
+	if err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return handedOffCount, largeResourceGroupError(err, idFromInventory(rg))
+		}
+		return handedOffCount, Error(err)
+	}
+	var errs status.MultiError
+	for _, obj := range objs {
+		id := core.IDOf(obj)
+		handoffTo := core.GetAnnotation(obj, metadata.HandoffToAnnotationKey)
+		if core.GetAnnotation(obj, metadata.ResourceManagerKey) != manager {
+			// The new manager already adopted the object.
+			klog.V(4).Infof("%v is already handed off (%s: %s)", id, metadata.HandoffToAnnotationKey, handoffTo)
+			handedOffCount++
+			continue
+		}
+		err := h.abandonObject(ctx, obj, false)
+		handleMetrics(ctx, "unmanage", err)
+		if err != nil {
+			err = fmt.Errorf("failed to remove the Config Sync metadata from %v (%s: %s): %v",
+				id, metadata.HandoffToAnnotationKey, handoffTo, err)
+			klog.Warning(err)
+			errs = status.Append(errs, Error(err))
+		} else {
+			klog.V(4).Infof("removed the Config Sync metadata from %v (%s: %s)",
+				id, metadata.HandoffToAnnotationKey, handoffTo)
+			handedOffCount++
+		}
+	}
+	return handedOffCount, errs
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// withoutIgnoredObjects returns the disabled objects without the ones which do
+// not match the object selector and are not in the inventory. These objects
+// were never applied by this reconciler, usually because they are meant for
+// another cluster, so there is nothing to abandon.
+// The unselected objects in the inventory are kept, so that they are removed
+// from the inventory and abandoned, instead of being pruned.
+func (a *supervisor) withoutIgnoredObjects(ctx context.Context, disabledObjs []client.Object) ([]client.Object, status.Error) {
+	if a.objectSelector == nil || a.objectSelector.Empty() || len(disabledObjs) == 0 {
+		return disabledObjs, nil
+	}
+	invIDs, invErr := a.inventoryIDs(ctx)
+	if invErr != nil {
+		return nil, invErr
+	}
+
+	var result []client.Object
+	var ignored int
+	for _, obj := range disabledObjs {
+		if !a.objectSelector.Matches(labels.Set(obj.GetLabels())) && !invIDs.Contains(ObjMetaFromObject(obj)) {
+			ignored++
+			continue
+		}
+		result = append(result, obj)
+	}
+	if ignored > 0 {
+		klog.V(3).Infof("Ignoring %d objects which do not match the object selector %q and are not in the inventory", ignored, a.objectSelector)
+	}
+	return result, nil
+}
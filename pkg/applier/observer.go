@@ -0,0 +1,146 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// observer is an Applier which compares the desired resource objects with the
+// objects on the cluster, without ever creating, updating, or pruning them.
+// It is used by RootSyncs in observe mode.
+type observer struct {
+	reader client.Reader
+	// driftHandler tracks the drifted objects. It is shared with the
+	// remediator, which keeps reporting the drift observed after an Apply, so
+	// that each drifted object is reported once.
+	driftHandler fight.Handler
+	// observed is the set of objects observed by the previous Apply.
+	observed map[core.ID]struct{}
+
+	// execMux prevents concurrent Apply calls
+	execMux sync.Mutex
+	// errorMux prevents concurrent modifications to the cached set of errors
+	errorMux sync.RWMutex
+	// errs received from the current (if running) or previous Apply.
+	// These errors is cleared at the start of the Apply method.
+	errs status.MultiError
+}
+
+var _ Applier = &observer{}
+
+// NewObserver returns an Applier which reports the desired resource objects
+// which are missing from, or drifted on, the cluster as ObservedDriftErrors
+// to driftHandler. Apply only returns the errors which prevented observing
+// the objects.
+func NewObserver(reader client.Reader, driftHandler fight.Handler) Applier {
+	return &observer{reader: reader, driftHandler: driftHandler}
+}
+
+// Apply implements Applier.
+func (o *observer) Apply(ctx context.Context, desiredResources []client.Object) (map[schema.GroupVersionKind]struct{}, status.MultiError) {
+	o.execMux.Lock()
+	defer o.execMux.Unlock()
+
+	o.invalidateErrors()
+
+	gvks := make(map[schema.GroupVersionKind]struct{})
+	observed := make(map[core.ID]struct{}, len(desiredResources))
+	var errs status.MultiError
+	for _, obj := range desiredResources {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		gvks[gvk] = struct{}{}
+		id := core.IDOf(obj)
+		observed[id] = struct{}{}
+		driftErr, err := o.observe(ctx, obj)
+		switch {
+		case err != nil:
+			errs = status.Append(errs, err)
+		case driftErr != nil:
+			o.driftHandler.AddFightError(id, driftErr)
+		default:
+			o.driftHandler.RemoveFightError(id)
+		}
+	}
+	// Stop reporting the drift of the objects removed from the source.
+	for id := range o.observed {
+		if _, found := observed[id]; !found {
+			o.driftHandler.RemoveFightError(id)
+		}
+	}
+	o.observed = observed
+	klog.V(4).Infof("Observed %d objects", len(desiredResources))
+
+	o.errorMux.Lock()
+	o.errs = errs
+	o.errorMux.Unlock()
+	return gvks, errs
+}
+
+// observe compares the desired object with the object on the cluster, and
+// returns the drift, if any, or the error which prevented the comparison.
+func (o *observer) observe(ctx context.Context, obj client.Object) (driftErr status.Error, err status.Error) {
+	actual := &unstructured.Unstructured{}
+	actual.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if getErr := o.reader.Get(ctx, client.ObjectKeyFromObject(obj), actual); getErr != nil {
+		if apierrors.IsNotFound(getErr) || meta.IsNoMatchError(getErr) {
+			return status.ObservedDriftError("create", obj), nil
+		}
+		return nil, status.APIServerError(getErr, "failed to get object to observe", obj)
+	}
+	drifted, err := diff.Diff{Declared: obj, Actual: actual}.HasDrifted()
+	if err != nil {
+		return nil, err
+	}
+	if drifted {
+		return status.ObservedDriftError("update", obj), nil
+	}
+	klog.V(4).Infof("Observed object in sync: %s", core.GKNN(obj))
+	return nil, nil
+}
+
+// Errors implements Applier.
+func (o *observer) Errors() status.MultiError {
+	o.errorMux.RLock()
+	defer o.errorMux.RUnlock()
+
+	// Return a copy to avoid persisting caller modifications
+	return status.Append(nil, o.errs)
+}
+
+// Pending implements Applier. The observer never applies, so no object is
+// pending.
+func (o *observer) Pending() []core.ID {
+	return nil
+}
+
+func (o *observer) invalidateErrors() {
+	o.errorMux.Lock()
+	defer o.errorMux.Unlock()
+
+	o.errs = nil
+}
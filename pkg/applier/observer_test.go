@@ -0,0 +1,101 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	syncerclient "kpt.dev/configsync/pkg/syncer/client"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newObservedConfigMap(data string, opts ...core.MetaMutator) *unstructured.Unstructured {
+	obj := fake.UnstructuredObject(kinds.ConfigMap(), append(opts,
+		core.Namespace("test-namespace"), core.Name("observed"))...)
+	obj.Object["data"] = map[string]interface{}{"key": data}
+	return obj
+}
+
+func TestObserver_Apply(t *testing.T) {
+	testCases := []struct {
+		name       string
+		serverObjs []client.Object
+		// declared is the object declared in the source.
+		declared *unstructured.Unstructured
+		// expectedDrift is the expected drift errors, built from the declared
+		// object.
+		expectedDrift func(obj client.Object) []status.Error
+	}{
+		{
+			name:     "missing object",
+			declared: newObservedConfigMap("value"),
+			expectedDrift: func(obj client.Object) []status.Error {
+				return []status.Error{status.ObservedDriftError("create", obj)}
+			},
+		},
+		{
+			name:       "drifted object",
+			serverObjs: []client.Object{newObservedConfigMap("drifted")},
+			declared:   newObservedConfigMap("value"),
+			expectedDrift: func(obj client.Object) []status.Error {
+				return []status.Error{status.ObservedDriftError("update", obj)}
+			},
+		},
+		{
+			name:       "object in sync",
+			serverObjs: []client.Object{newObservedConfigMap("value", core.Label("extra", "label"))},
+			declared: newObservedConfigMap("value",
+				core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled)),
+			expectedDrift: func(client.Object) []status.Error { return nil },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme, tc.serverObjs...)
+			driftHandler := fight.NewHandler()
+			observer := NewObserver(syncerclient.NewReadOnly(fakeClient), driftHandler)
+
+			// The drift is reported to the drift handler, not as apply errors,
+			// so that it is reported once, along with the drift observed by
+			// the remediator.
+			gvks, errs := observer.Apply(context.Background(), []client.Object{tc.declared})
+			assert.Nil(t, errs)
+			assert.Nil(t, observer.Errors())
+			testutil.AssertEqual(t, tc.expectedDrift(tc.declared), driftHandler.FightErrors())
+			assert.Equal(t, map[schema.GroupVersionKind]struct{}{kinds.ConfigMap(): {}}, gvks)
+
+			// The drift of objects removed from the source is no longer
+			// reported.
+			_, errs = observer.Apply(context.Background(), nil)
+			assert.Nil(t, errs)
+			assert.Empty(t, driftHandler.FightErrors())
+
+			// The observer never writes to the cluster.
+			fakeClient.Check(t, tc.serverObjs...)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// orphanInner removes the Config Sync metadata from the objects in the
+// inventory, leaving them on the cluster with the orphaned-by annotation, and
+// then deletes the inventory. Objects managed by another reconciler are left
+// untouched.
+// The inventory is only deleted once all the objects are orphaned, so that
+// the objects which failed are retried.
+func (a *supervisor) orphanInner(ctx context.Context) status.MultiError {
+	invIDs, invErr := a.inventoryIDs(ctx)
+	if invErr != nil {
+		a.addError(invErr)
+		return a.Errors()
+	}
+	eh := eventHandler{
+		isDestroy:       true,
+		clientSet:       a.clientSet,
+		orphanedBy:      a.orphanedBy(),
+		resourceManager: a.resourceManager(),
+	}
+	for _, id := range invIDs {
+		mapping, err := a.clientSet.Client.RESTMapper().RESTMapping(id.GroupKind)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// The resource type was deleted, so the object is gone too.
+				continue
+			}
+			a.addError(Error(fmt.Errorf("failed to orphan %v: %w", id, err)))
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(mapping.GroupVersionKind)
+		obj.SetNamespace(id.Namespace)
+		obj.SetName(id.Name)
+		err = eh.abandonObject(ctx, obj, true)
+		handleMetrics(ctx, "unmanage", err)
+		if err != nil {
+			a.addError(Error(fmt.Errorf("failed to orphan %v: %w", id, err)))
+		}
+	}
+	if errs := a.Errors(); errs != nil {
+		return errs
+	}
+	klog.Infof("Orphan completed without error: all managed objects are orphaned")
+
+	invObj := &unstructured.Unstructured{}
+	invObj.SetGroupVersionKind(live.ResourceGroupGVK)
+	invObj.SetNamespace(a.syncNamespace)
+	invObj.SetName(a.syncName)
+	if err := a.clientSet.Client.Delete(ctx, invObj); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		a.addError(Error(fmt.Errorf("failed to delete the inventory %s: %w", client.ObjectKeyFromObject(invObj), err)))
+	}
+	return a.Errors()
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	resourcegroupv1alpha1 "kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// managerCheckClient is a client which rejects the changes to managed objects
+// which the admission webhook would deny to the specified service account.
+type managerCheckClient struct {
+	client.Client
+	username string
+}
+
+func (c *managerCheckClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.validateManager(ctx, obj, admissionv1.Update); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *managerCheckClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.validateManager(ctx, obj, admissionv1.Delete); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *managerCheckClient) validateManager(ctx context.Context, obj client.Object, op admissionv1.Operation) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	id := core.IDOf(current)
+	if err := diff.ValidateManager(c.username, core.GetAnnotation(current, metadata.ResourceManagerKey), id, op); err != nil {
+		return apierrors.NewForbidden(schema.GroupResource{Group: id.Group, Resource: id.Kind}, id.Name, err)
+	}
+	return nil
+}
+
+func TestOrphan(t *testing.T) {
+	syncName := "root-sync"
+	resourceManager := declared.ResourceManager(declared.RootReconciler, syncName)
+	otherManager := declared.ResourceManager(declared.RootReconciler, "other-sync")
+
+	managedCM := func(name, manager string) *unstructured.Unstructured {
+		return fake.UnstructuredObject(kinds.ConfigMap(), core.Name(name), core.Namespace("test-namespace"),
+			core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+			core.Annotation(metadata.ResourceManagerKey, manager),
+			core.Annotation(metadata.OwningInventoryKey, InventoryID(syncName, configmanagement.ControllerNamespace)),
+			core.Annotation("user-annotation", "value"),
+			core.Label(metadata.ManagedByKey, metadata.ManagedByValue))
+	}
+	managedObj := managedCM("managed", resourceManager)
+	// otherObj was adopted by another RootSync, so it is not orphaned.
+	otherObj := managedCM("other", otherManager)
+
+	testCases := []struct {
+		name        string
+		username    string
+		wantErr     bool
+		wantOrphans bool
+	}{
+		{
+			name:        "as the reconciler",
+			username:    core.RootReconcilerName(syncName),
+			wantOrphans: true,
+		},
+		{
+			name:     "as the reconciler-manager",
+			username: reconcilermanager.ManagerName,
+			wantErr:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			invObj := newInventoryUnstructured(configsync.RootSyncKind, syncName, configmanagement.ControllerNamespace, StatusDisabled)
+			var resources []interface{}
+			for _, obj := range []client.Object{managedObj, otherObj} {
+				id := core.IDOf(obj)
+				resources = append(resources, map[string]interface{}{
+					"group":     id.Group,
+					"kind":      id.Kind,
+					"namespace": id.Namespace,
+					"name":      id.Name,
+				})
+			}
+			require.NoError(t, unstructured.SetNestedSlice(invObj.Object, resources, "spec", "resources"))
+
+			// The ResourceGroup type is not registered in core.Scheme.
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+
+			fakeClient := testingfake.NewClient(t, scheme, invObj, managedObj.DeepCopy(), otherObj.DeepCopy())
+			cs := &ClientSet{
+				Client: &managerCheckClient{Client: fakeClient, username: tc.username},
+				Mapper: fakeClient.RESTMapper(),
+			}
+			destroyer, err := NewRootSupervisor(cs, syncName, 5*time.Minute, Options{})
+			require.NoError(t, err)
+
+			errs := destroyer.Orphan(context.Background())
+			if tc.wantErr {
+				require.NotNil(t, errs)
+			} else {
+				require.Nil(t, errs)
+			}
+
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(kinds.ConfigMap())
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(managedObj), got))
+			if tc.wantOrphans {
+				// The managed object is left on the cluster, without the
+				// Config Sync metadata.
+				assert.Equal(t, map[string]string{
+					"user-annotation":                "value",
+					metadata.OrphanedByAnnotationKey: fmt.Sprintf("%s/%s/%s", configsync.RootSyncKind, configmanagement.ControllerNamespace, syncName),
+				}, got.GetAnnotations())
+				assert.Empty(t, got.GetLabels())
+			} else {
+				assert.Equal(t, managedObj.GetAnnotations(), got.GetAnnotations())
+			}
+
+			// The object managed by another RootSync is left untouched.
+			got = &unstructured.Unstructured{}
+			got.SetGroupVersionKind(kinds.ConfigMap())
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(otherObj), got))
+			assert.Equal(t, otherObj.GetAnnotations(), got.GetAnnotations())
+
+			// The inventory is only deleted once all the objects are orphaned.
+			err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(invObj), invObj.DeepCopy())
+			if tc.wantOrphans {
+				assert.True(t, apierrors.IsNotFound(err), "expected the inventory to be deleted, got %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
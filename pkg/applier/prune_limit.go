@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// inventoryIDs returns the IDs of the objects in the inventory on the cluster.
+// It returns no IDs if the inventory doesn't exist yet.
+func (a *supervisor) inventoryIDs(ctx context.Context) (object.ObjMetadataSet, status.Error) {
+	invObj := &unstructured.Unstructured{}
+	invObj.SetGroupVersionKind(live.ResourceGroupGVK)
+	invKey := client.ObjectKey{Namespace: a.syncNamespace, Name: a.syncName}
+	if err := a.clientSet.Client.Get(ctx, invKey, invObj); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			// No inventory, so there is nothing to prune.
+			return nil, nil
+		}
+		return nil, Error(fmt.Errorf("failed to get the inventory %s: %w", invKey, err))
+	}
+	inv, err := wrapInventoryObj(invObj)
+	if err != nil {
+		return nil, Error(err)
+	}
+	invIDs, err := inv.Load()
+	if err != nil {
+		return nil, Error(err)
+	}
+	return invIDs, nil
+}
+
+// checkPruneCount returns an error if applying the objects would prune more
+// objects than maxPruneCount allows, unless the RootSync or RepoSync confirms
+// the set of objects to prune with the confirm-prune annotation.
+func (a *supervisor) checkPruneCount(ctx context.Context, objs []client.Object) status.Error {
+	if a.maxPruneCount <= 0 {
+		return nil
+	}
+	invIDs, invErr := a.inventoryIDs(ctx)
+	if invErr != nil {
+		return invErr
+	}
+	pruneIDs := invIDs.Diff(objMetasFromObjects(objs))
+	if len(pruneIDs) <= a.maxPruneCount {
+		return nil
+	}
+
+	rsObj := &unstructured.Unstructured{}
+	rsObj.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(a.syncKind))
+	rsKey := client.ObjectKey{Namespace: a.syncNamespace, Name: a.syncName}
+	if err := a.clientSet.Client.Get(ctx, rsKey, rsObj); err != nil {
+		return Error(fmt.Errorf("failed to get the %s %s: %w", a.syncKind, rsKey, err))
+	}
+	pruneHash := pruneSetHash(pruneIDs)
+	if core.GetAnnotation(rsObj, metadata.PruneConfirmationAnnotationKey) == pruneHash {
+		klog.Infof("Pruning %d objects, which exceeds the maximum of %d, as confirmed by the %s annotation",
+			len(pruneIDs), a.maxPruneCount, metadata.PruneConfirmationAnnotationKey)
+		return nil
+	}
+	return PruneLimitError(len(pruneIDs), a.maxPruneCount, pruneHash, a.syncKind, a.syncNamespace, a.syncName)
+}
+
+// pruneSetHash returns the hexadecimal encoding of the SHA-256 hash of the
+// sorted IDs of the objects to prune, so that a confirmation only applies to
+// the exact set of objects it was given for.
+func pruneSetHash(ids object.ObjMetadataSet) string {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	sort.Strings(keys)
+	hash := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return hex.EncodeToString(hash[:])
+}
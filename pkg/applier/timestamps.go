@@ -0,0 +1,371 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/kpt/pkg/live"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// actuationTimeField is the field of the ResourceGroup resource statuses
+	// which records when the resource was last applied.
+	actuationTimeField = "actuationTime"
+	// pruneTimeField is the field of the ResourceGroup pruned resources which
+	// records when the resource was pruned.
+	pruneTimeField = "pruneTime"
+)
+
+// actuationTracker tracks the objects successfully actuated by the applier,
+// so that only the objects which were changed are stamped with a new
+// actuation time. It is safe for concurrent use, and a nil actuationTracker
+// tracks nothing.
+type actuationTracker struct {
+	mux sync.Mutex
+	// resourceVersions are the resourceVersions of the objects after their
+	// last successful apply.
+	resourceVersions map[object.ObjMetadata]string
+	// applied are the objects successfully applied by the current applier
+	// run, mapped to whether the apply is known to have changed the object.
+	// The first apply of an object since the reconciler started is not known
+	// to have changed it.
+	applied map[object.ObjMetadata]bool
+	// pruned are the objects successfully pruned by the current applier run.
+	pruned map[object.ObjMetadata]struct{}
+}
+
+// newActuationTracker returns a new actuationTracker.
+func newActuationTracker() *actuationTracker {
+	return &actuationTracker{
+		resourceVersions: make(map[object.ObjMetadata]string),
+		applied:          make(map[object.ObjMetadata]bool),
+		pruned:           make(map[object.ObjMetadata]struct{}),
+	}
+}
+
+// reset forgets the objects actuated by the previous applier run.
+func (t *actuationTracker) reset() {
+	if t == nil {
+		return
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.applied = make(map[object.ObjMetadata]bool)
+	t.pruned = make(map[object.ObjMetadata]struct{})
+}
+
+// recordApply records the successful apply of an object. obj is the object
+// returned by the server, if any. Applies which left the resourceVersion of
+// the object unchanged are ignored.
+func (t *actuationTracker) recordApply(id object.ObjMetadata, obj *unstructured.Unstructured) {
+	if t == nil {
+		return
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	delete(t.pruned, id)
+	if obj == nil || obj.GetResourceVersion() == "" {
+		delete(t.resourceVersions, id)
+		t.applied[id] = false
+		return
+	}
+	rv := obj.GetResourceVersion()
+	previousRV, found := t.resourceVersions[id]
+	t.resourceVersions[id] = rv
+	switch {
+	case !found:
+		t.applied[id] = false
+	case previousRV != rv:
+		t.applied[id] = true
+	}
+}
+
+// recordPrune records the successful prune of an object.
+func (t *actuationTracker) recordPrune(id object.ObjMetadata) {
+	if t == nil {
+		return
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	delete(t.resourceVersions, id)
+	delete(t.applied, id)
+	t.pruned[id] = struct{}{}
+}
+
+// actuated returns copies of the objects applied and pruned by the current
+// applier run.
+func (t *actuationTracker) actuated() (map[object.ObjMetadata]bool, map[object.ObjMetadata]struct{}) {
+	applied := make(map[object.ObjMetadata]bool)
+	pruned := make(map[object.ObjMetadata]struct{})
+	if t == nil {
+		return applied, pruned
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	for id, changed := range t.applied {
+		applied[id] = changed
+	}
+	for id := range t.pruned {
+		pruned[id] = struct{}{}
+	}
+	return applied, pruned
+}
+
+// timestampInventory wraps the ResourceGroup inventory storage of kpt, so that
+// the time each object is applied or pruned is recorded in the ResourceGroup
+// status, along with its actuation status.
+type timestampInventory struct {
+	inventory.Storage
+
+	// clusterObj is the ResourceGroup object the storage was created from,
+	// which holds the previously recorded actuation times.
+	clusterObj *unstructured.Unstructured
+	// objs are the latest stored objects of the inventory.
+	objs object.ObjMetadataSet
+	// status is the latest stored actuation status of the objects.
+	status  []actuation.ObjectStatus
+	tracker *actuationTracker
+	clock   clock.PassiveClock
+}
+
+var _ inventory.Storage = &timestampInventory{}
+
+// wrapTimestampInventoryObj returns an inventory.StorageFactoryFunc which
+// records the actuation times of the objects tracked by the tracker with the
+// clock.
+func wrapTimestampInventoryObj(c clock.PassiveClock, tracker *actuationTracker) inventory.StorageFactoryFunc {
+	return func(obj *unstructured.Unstructured) inventory.Storage {
+		return &timestampInventory{
+			Storage:    live.WrapInventoryObj(obj),
+			clusterObj: obj,
+			tracker:    tracker,
+			clock:      c,
+		}
+	}
+}
+
+// Store stores the objects and their actuation status in the wrapped storage.
+func (t *timestampInventory) Store(objs object.ObjMetadataSet, status []actuation.ObjectStatus) error {
+	t.objs = objs
+	t.status = status
+	return t.Storage.Store(objs, status)
+}
+
+// GetObject returns the ResourceGroup object from the wrapped storage, with
+// the actuation time of the objects changed by a successful apply set to now,
+// and the objects successfully pruned recorded with the prune time set to now.
+// The other objects keep their previously recorded actuation time.
+func (t *timestampInventory) GetObject() (*unstructured.Unstructured, error) {
+	obj, err := t.Storage.GetObject()
+	if err != nil || obj == nil {
+		return obj, err
+	}
+	now := t.clock.Now().UTC().Format(time.RFC3339)
+	applied, pruned := t.tracker.actuated()
+	if err := t.setActuationTimes(obj, applied, now); err != nil {
+		return nil, err
+	}
+	if err := t.setPrunedResources(obj, pruned, now); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// setActuationTimes sets the actuation time of the resource statuses of obj.
+func (t *timestampInventory) setActuationTimes(obj *unstructured.Unstructured, applied map[object.ObjMetadata]bool, now string) error {
+	items, found, err := unstructured.NestedSlice(obj.Object, "status", "resourceStatuses")
+	if err != nil || !found {
+		return err
+	}
+	succeeded := make(map[object.ObjMetadata]bool, len(t.status))
+	for _, s := range t.status {
+		if s.Strategy == actuation.ActuationStrategyApply && s.Actuation == actuation.ActuationSucceeded {
+			succeeded[inventory.ObjMetadataFromObjectReference(s.ObjectReference)] = true
+		}
+	}
+	previousTimes := make(map[object.ObjMetadata]interface{})
+	previousItems, err := t.previousSlice("status", "resourceStatuses")
+	if err != nil {
+		return err
+	}
+	for _, itemMap := range previousItems {
+		if itemMap[actuationTimeField] != nil {
+			previousTimes[resourceStatusID(itemMap)] = itemMap[actuationTimeField]
+		}
+	}
+
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := resourceStatusID(itemMap)
+		previousTime, hasPreviousTime := previousTimes[id]
+		// Objects applied for the first time since the reconciler started are
+		// only stamped if they were never stamped before.
+		changed, wasApplied := applied[id]
+		if succeeded[id] && wasApplied && (changed || !hasPreviousTime) {
+			itemMap[actuationTimeField] = now
+		} else if hasPreviousTime {
+			itemMap[actuationTimeField] = previousTime
+		}
+	}
+	return unstructured.SetNestedSlice(obj.Object, items, "status", "resourceStatuses")
+}
+
+// setPrunedResources records the objects pruned from the inventory in the
+// status of obj, along with when they were pruned. Objects added back to the
+// inventory are no longer recorded.
+func (t *timestampInventory) setPrunedResources(obj *unstructured.Unstructured, pruned map[object.ObjMetadata]struct{}, now string) error {
+	previousItems, err := t.previousSlice("status", "prunedResources")
+	if err != nil {
+		return err
+	}
+	var items []interface{}
+	for _, itemMap := range previousItems {
+		id := resourceStatusID(itemMap)
+		if _, found := pruned[id]; found || t.objs.Contains(id) {
+			continue
+		}
+		items = append(items, itemMap)
+	}
+	var prunedIDs []object.ObjMetadata
+	for id := range pruned {
+		if !t.objs.Contains(id) {
+			prunedIDs = append(prunedIDs, id)
+		}
+	}
+	sort.Slice(prunedIDs, func(i, j int) bool {
+		return prunedIDs[i].String() < prunedIDs[j].String()
+	})
+	for _, id := range prunedIDs {
+		items = append(items, map[string]interface{}{
+			"group":        id.GroupKind.Group,
+			"kind":         id.GroupKind.Kind,
+			"namespace":    id.Namespace,
+			"name":         id.Name,
+			pruneTimeField: now,
+		})
+	}
+	if len(items) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "status", "prunedResources")
+		return nil
+	}
+	return unstructured.SetNestedSlice(obj.Object, items, "status", "prunedResources")
+}
+
+// previousSlice returns the items of the slice field of the ResourceGroup
+// object the storage was created from.
+func (t *timestampInventory) previousSlice(fields ...string) ([]map[string]interface{}, error) {
+	if t.clusterObj == nil {
+		return nil, nil
+	}
+	items, _, err := unstructured.NestedSlice(t.clusterObj.Object, fields...)
+	if err != nil {
+		return nil, err
+	}
+	var itemMaps []map[string]interface{}
+	for _, item := range items {
+		if itemMap, ok := item.(map[string]interface{}); ok {
+			itemMaps = append(itemMaps, itemMap)
+		}
+	}
+	return itemMaps, nil
+}
+
+// Apply creates or updates the ResourceGroup object with the wrapped storage,
+// and then updates its status, including the actuation times.
+func (t *timestampInventory) Apply(dc dynamic.Interface, mapper meta.RESTMapper, statusPolicy inventory.StatusPolicy) error {
+	if err := t.Storage.Apply(dc, mapper, inventory.StatusPolicyNone); err != nil {
+		return err
+	}
+	if statusPolicy != inventory.StatusPolicyAll {
+		return nil
+	}
+	return t.updateStatus(dc, mapper)
+}
+
+// ApplyWithPrune updates the ResourceGroup object with the wrapped storage,
+// and then updates its status, including the actuation times.
+func (t *timestampInventory) ApplyWithPrune(dc dynamic.Interface, mapper meta.RESTMapper, statusPolicy inventory.StatusPolicy, objs object.ObjMetadataSet) error {
+	if err := t.Storage.ApplyWithPrune(dc, mapper, inventory.StatusPolicyNone, objs); err != nil {
+		return err
+	}
+	if statusPolicy != inventory.StatusPolicyAll {
+		return nil
+	}
+	return t.updateStatus(dc, mapper)
+}
+
+// updateStatus copies the status of the desired ResourceGroup object to the
+// one on the cluster, so that modifications from mutating webhooks are kept,
+// and updates the status.
+func (t *timestampInventory) updateStatus(dc dynamic.Interface, mapper meta.RESTMapper) error {
+	invObj, err := t.GetObject()
+	if err != nil {
+		return err
+	}
+	if invObj == nil {
+		return fmt.Errorf("attempting to update the status of a nil inventory object")
+	}
+	status, found, err := unstructured.NestedMap(invObj.Object, "status")
+	if err != nil || !found {
+		return err
+	}
+	gvk := invObj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	namespacedClient := dc.Resource(mapping.Resource).Namespace(invObj.GetNamespace())
+	ctx := context.TODO()
+	clusterObj, err := namespacedClient.Get(ctx, invObj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(clusterObj.Object, status, "status"); err != nil {
+		return err
+	}
+	_, err = namespacedClient.UpdateStatus(ctx, clusterObj, metav1.UpdateOptions{})
+	return err
+}
+
+// resourceStatusID returns the ID of the object of a ResourceGroup resource
+// status.
+func resourceStatusID(item map[string]interface{}) object.ObjMetadata {
+	group, _, _ := unstructured.NestedString(item, "group")
+	kind, _, _ := unstructured.NestedString(item, "kind")
+	namespace, _, _ := unstructured.NestedString(item, "namespace")
+	name, _, _ := unstructured.NestedString(item, "name")
+	return object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: group, Kind: kind},
+		Namespace: namespace,
+		Name:      name,
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clocktesting "k8s.io/utils/clock/testing"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestTimestampInventory_GetObject(t *testing.T) {
+	changed := ObjMetaFromObject(newTestObj("changed"))
+	unchanged := ObjMetaFromObject(newTestObj("unchanged"))
+	pending := ObjMetaFromObject(newTestObj("pending"))
+	failed := ObjMetaFromObject(newTestObj("failed"))
+	added := ObjMetaFromObject(newTestObj("added"))
+	pruned := ObjMetaFromObject(newTestObj("pruned"))
+	prunedBefore := ObjMetaFromObject(newTestObj("pruned-before"))
+	readded := ObjMetaFromObject(newTestObj("readded"))
+	previousTime := "2023-01-02T03:04:05Z"
+	now := time.Date(2023, 6, 7, 8, 9, 10, 0, time.UTC)
+	nowTime := "2023-06-07T08:09:10Z"
+
+	clusterObj := newInventoryUnstructured(configsync.RootSyncKind, "root-sync", configsync.ControllerNamespace, StatusTimestamps)
+	require.NoError(t, unstructured.SetNestedSlice(clusterObj.Object, []interface{}{
+		resourceStatusItem(changed, previousTime),
+		resourceStatusItem(unchanged, previousTime),
+		resourceStatusItem(pending, previousTime),
+		resourceStatusItem(failed, previousTime),
+		resourceStatusItem(pruned, previousTime),
+	}, "status", "resourceStatuses"))
+	require.NoError(t, unstructured.SetNestedSlice(clusterObj.Object, []interface{}{
+		prunedResourceItem(prunedBefore, previousTime),
+		prunedResourceItem(readded, previousTime),
+	}, "status", "prunedResources"))
+
+	// The previous run applied the objects already on the cluster.
+	tracker := newActuationTracker()
+	tracker.recordApply(changed, resourceVersionObj("1"))
+	tracker.recordApply(unchanged, resourceVersionObj("1"))
+	tracker.reset()
+	tracker.recordApply(changed, resourceVersionObj("2"))
+	tracker.recordApply(unchanged, resourceVersionObj("1"))
+	tracker.recordApply(added, resourceVersionObj("1"))
+	tracker.recordApply(readded, resourceVersionObj("1"))
+	tracker.recordPrune(pruned)
+
+	storage := wrapTimestampInventoryObj(clocktesting.NewFakePassiveClock(now), tracker)(clusterObj)
+	err := storage.Store(object.ObjMetadataSet{changed, unchanged, pending, failed, added, readded}, []actuation.ObjectStatus{
+		objectStatus(changed, actuation.ActuationSucceeded),
+		objectStatus(unchanged, actuation.ActuationSucceeded),
+		objectStatus(pending, actuation.ActuationPending),
+		objectStatus(failed, actuation.ActuationFailed),
+		objectStatus(added, actuation.ActuationSucceeded),
+		objectStatus(readded, actuation.ActuationSucceeded),
+	})
+	require.NoError(t, err)
+
+	obj, err := storage.GetObject()
+	require.NoError(t, err)
+	items, found, err := unstructured.NestedSlice(obj.Object, "status", "resourceStatuses")
+	require.NoError(t, err)
+	require.True(t, found)
+
+	gotTimes := make(map[object.ObjMetadata]interface{})
+	for _, item := range items {
+		itemMap := item.(map[string]interface{})
+		gotTimes[resourceStatusID(itemMap)] = itemMap[actuationTimeField]
+	}
+	assert.Equal(t, map[object.ObjMetadata]interface{}{
+		// Objects changed by a successful apply are recorded with the current time.
+		changed: nowTime,
+		// Objects never recorded before are recorded with the current time.
+		added:   nowTime,
+		readded: nowTime,
+		// Objects left unchanged, not actuated yet or which failed to apply
+		// keep their previous actuation time.
+		unchanged: previousTime,
+		pending:   previousTime,
+		failed:    previousTime,
+	}, gotTimes)
+
+	items, found, err = unstructured.NestedSlice(obj.Object, "status", "prunedResources")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, []interface{}{
+		// Objects pruned before keep their prune time, unless added back.
+		prunedResourceItem(prunedBefore, previousTime),
+		// Objects just pruned are recorded with the current time.
+		prunedResourceItem(pruned, nowTime),
+	}, items)
+}
+
+func TestActuationTracker(t *testing.T) {
+	id := ObjMetaFromObject(newTestObj("test"))
+
+	var nilTracker *actuationTracker
+	nilTracker.recordApply(id, resourceVersionObj("1"))
+	nilTracker.recordPrune(id)
+	nilTracker.reset()
+	applied, pruned := nilTracker.actuated()
+	assert.Empty(t, applied)
+	assert.Empty(t, pruned)
+
+	tracker := newActuationTracker()
+	tracker.recordApply(id, resourceVersionObj("1"))
+	applied, _ = tracker.actuated()
+	assert.Equal(t, map[object.ObjMetadata]bool{id: false}, applied, "first apply is not known to change the object")
+
+	tracker.reset()
+	tracker.recordApply(id, resourceVersionObj("1"))
+	applied, _ = tracker.actuated()
+	assert.Empty(t, applied, "apply leaving the resourceVersion unchanged is ignored")
+
+	tracker.reset()
+	tracker.recordApply(id, resourceVersionObj("2"))
+	applied, _ = tracker.actuated()
+	assert.Equal(t, map[object.ObjMetadata]bool{id: true}, applied, "apply changing the resourceVersion changes the object")
+
+	tracker.reset()
+	tracker.recordPrune(id)
+	applied, pruned = tracker.actuated()
+	assert.Empty(t, applied)
+	assert.Equal(t, map[object.ObjMetadata]struct{}{id: {}}, pruned)
+
+	tracker.reset()
+	tracker.recordApply(id, resourceVersionObj("2"))
+	applied, pruned = tracker.actuated()
+	assert.Equal(t, map[object.ObjMetadata]bool{id: false}, applied, "pruned objects are forgotten")
+	assert.Empty(t, pruned)
+}
+
+func resourceStatusItem(id object.ObjMetadata, actuationTime string) map[string]interface{} {
+	return map[string]interface{}{
+		"group":            id.GroupKind.Group,
+		"kind":             id.GroupKind.Kind,
+		"namespace":        id.Namespace,
+		"name":             id.Name,
+		actuationTimeField: actuationTime,
+	}
+}
+
+func objectStatus(id object.ObjMetadata, actuationStatus actuation.ActuationStatus) actuation.ObjectStatus {
+	return actuation.ObjectStatus{
+		ObjectReference: inventory.ObjectReferenceFromObjMetadata(id),
+		Strategy:        actuation.ActuationStrategyApply,
+		Actuation:       actuationStatus,
+		Reconcile:       actuation.ReconcilePending,
+	}
+}
+
+func prunedResourceItem(id object.ObjMetadata, pruneTime string) map[string]interface{} {
+	return map[string]interface{}{
+		"group":        id.GroupKind.Group,
+		"kind":         id.GroupKind.Kind,
+		"namespace":    id.Namespace,
+		"name":         id.Name,
+		pruneTimeField: pruneTime,
+	}
+}
+
+func resourceVersionObj(resourceVersion string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetResourceVersion(resourceVersion)
+	return obj
+}
@@ -21,9 +21,13 @@ import (
 
 	"github.com/GoogleContainerTools/kpt/pkg/live"
 	"golang.org/x/net/context"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
 	syncerreconcile "kpt.dev/configsync/pkg/syncer/reconcile"
@@ -60,6 +64,69 @@ func toUnstructured(objs []client.Object) ([]*unstructured.Unstructured, status.
 	return unstructureds, errs
 }
 
+// partitionUnestablishedCRs splits the resources into the resources to apply
+// and the custom resources to defer, because the CRD of their type is not yet
+// established. A custom resource is deferred if its type is not served by the
+// API server, and its CRD either exists without being established, or is only
+// declared in the resources. The returned map contains the name of the CRD
+// which blocks each deferred custom resource.
+func partitionUnestablishedCRs(ctx context.Context, c client.Client, mapper meta.RESTMapper, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, map[core.ID]string, error) {
+	declaredCRDs := make(map[schema.GroupKind]string)
+	for _, u := range resources {
+		if u.GroupVersionKind().GroupKind() != kinds.CustomResourceDefinition() {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(u.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(u.Object, "spec", "names", "kind")
+		declaredCRDs[schema.GroupKind{Group: group, Kind: kind}] = u.GetName()
+	}
+
+	var clusterCRDs map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition
+	var toApply []*unstructured.Unstructured
+	deferred := make(map[core.ID]string)
+	for _, u := range resources {
+		gvk := u.GroupVersionKind()
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); !meta.IsNoMatchError(err) {
+			toApply = append(toApply, u)
+			continue
+		}
+		// Only list the CRDs on the cluster if there are unknown types.
+		if clusterCRDs == nil {
+			crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+			if err := c.List(ctx, crdList); err != nil {
+				return nil, nil, err
+			}
+			clusterCRDs = make(map[schema.GroupKind]*apiextensionsv1.CustomResourceDefinition, len(crdList.Items))
+			for i, crd := range crdList.Items {
+				clusterCRDs[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = &crdList.Items[i]
+			}
+		}
+		if crd, found := clusterCRDs[gvk.GroupKind()]; found {
+			if crdIsEstablished(crd) {
+				toApply = append(toApply, u)
+			} else {
+				deferred[core.IDOf(u)] = crd.Name
+			}
+		} else if crdName, found := declaredCRDs[gvk.GroupKind()]; found {
+			deferred[core.IDOf(u)] = crdName
+		} else {
+			// Unknown types without a CRD are reported by the applier.
+			toApply = append(toApply, u)
+		}
+	}
+	return toApply, deferred, nil
+}
+
+// crdIsEstablished returns true if the given CRD has the Established condition.
+func crdIsEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			return condition.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // ObjMetaFromObject constructs an ObjMetadata representing the Object.
 func ObjMetaFromObject(obj client.Object) object.ObjMetadata {
 	return object.ObjMetadata{
@@ -69,6 +136,27 @@ func ObjMetaFromObject(obj client.Object) object.ObjMetadata {
 	}
 }
 
+// objMetasFromObjects constructs the ObjMetadataSet representing the Objects.
+func objMetasFromObjects(objs []client.Object) object.ObjMetadataSet {
+	ids := make(object.ObjMetadataSet, 0, len(objs))
+	for _, obj := range objs {
+		ids = append(ids, ObjMetaFromObject(obj))
+	}
+	return ids
+}
+
+// sourcePaths returns the paths of the files declaring the objects, from
+// their source-path annotation, by object ID.
+func sourcePaths(objs []client.Object) map[core.ID]string {
+	paths := make(map[core.ID]string, len(objs))
+	for _, obj := range objs {
+		if path := status.GetSourceAnnotation(obj); path != "" {
+			paths[core.IDOf(obj)] = path
+		}
+	}
+	return paths
+}
+
 func objMetaFromID(id core.ID) object.ObjMetadata {
 	return object.ObjMetadata{
 		Namespace: id.Namespace,
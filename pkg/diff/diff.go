@@ -18,6 +18,7 @@ package diff
 
 import (
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
@@ -212,6 +213,63 @@ func (d Diff) UnstructuredDeclared() (*unstructured.Unstructured, status.Error)
 	return reconcile.AsUnstructuredSanitized(d.Declared)
 }
 
+// HasDrifted returns true if any field of the declared object is missing from,
+// or differs in, the actual object. Fields only set on the actual object, such
+// as defaulted fields and the status, are ignored. The Config Sync metadata is
+// also ignored, because it is not declared in the source, and is never set on
+// objects which were only observed.
+func (d Diff) HasDrifted() (bool, status.Error) {
+	declared, err := d.UnstructuredDeclared()
+	if err != nil {
+		return false, err
+	}
+	actual, err := d.UnstructuredActual()
+	if err != nil {
+		return false, err
+	}
+	declared = declared.DeepCopy()
+	metadata.RemoveConfigSyncMetadata(declared)
+	return !isSubset(declared.Object, actual.Object), nil
+}
+
+// isSubset returns true if every field set in sub is set to the same value in
+// obj. Lists must have the same length, and their items are compared in order.
+// Empty maps and lists match unset fields, like the API server omits them.
+func isSubset(sub, obj interface{}) bool {
+	switch subValue := sub.(type) {
+	case map[string]interface{}:
+		if len(subValue) == 0 && obj == nil {
+			return true
+		}
+		objValue, ok := obj.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range subValue {
+			if !isSubset(v, objValue[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		if len(subValue) == 0 && obj == nil {
+			return true
+		}
+		objValue, ok := obj.([]interface{})
+		if !ok || len(subValue) != len(objValue) {
+			return false
+		}
+		for i := range subValue {
+			if !isSubset(subValue[i], objValue[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equality.Semantic.DeepEqual(sub, obj)
+	}
+}
+
 // ThreeWay does a three way diff and returns the FileObjectDiff list.
 // Compare between previous declared and new declared to decide the delete list.
 // Compare between the new declared and the actual states to decide the create and update.
@@ -575,3 +575,55 @@ func TestUnknown(t *testing.T) {
 		t.Errorf("Want empty diffs with unknown; got %v", diffs)
 	}
 }
+
+func TestHasDrifted(t *testing.T) {
+	testCases := []struct {
+		name     string
+		declared client.Object
+		actual   client.Object
+		want     bool
+	}{
+		{
+			name:     "same object",
+			declared: fake.NamespaceObject("hello", core.Label("team", "a")),
+			actual:   fake.NamespaceObject("hello", core.Label("team", "a")),
+			want:     false,
+		},
+		{
+			name:     "extra fields on the actual object",
+			declared: fake.NamespaceObject("hello", core.Label("team", "a")),
+			actual:   fake.NamespaceObject("hello", core.Label("team", "a"), core.Label("extra", "label")),
+			want:     false,
+		},
+		{
+			name:     "Config Sync metadata only declared",
+			declared: fake.NamespaceObject("hello", syncertest.ManagementEnabled),
+			actual:   fake.NamespaceObject("hello"),
+			want:     false,
+		},
+		{
+			name:     "declared field differs",
+			declared: fake.NamespaceObject("hello", core.Label("team", "a")),
+			actual:   fake.NamespaceObject("hello", core.Label("team", "b")),
+			want:     true,
+		},
+		{
+			name:     "declared field missing",
+			declared: fake.NamespaceObject("hello", core.Label("team", "a")),
+			actual:   fake.NamespaceObject("hello"),
+			want:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Diff{Declared: tc.declared, Actual: tc.actual}.HasDrifted()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("HasDrifted() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -59,6 +59,8 @@ type Hydrator struct {
 	ValuesYAML              string
 	ValuesFilePaths         []string
 	IncludeCRDs             string
+	APIVersions             []string
+	KubeVersion             string
 	HydrateRoot             string
 	Dest                    string
 	Auth                    configsync.AuthType
@@ -66,6 +68,8 @@ type Hydrator struct {
 	Password                string
 	ValuesFileApplyStrategy string
 	CACertFilePath          string
+	ClientCertFilePath      string
+	ClientKeyFilePath       string
 }
 
 func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string, error) {
@@ -101,6 +105,12 @@ func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string,
 	if includeCRDs {
 		args = append(args, "--include-crds")
 	}
+	for _, apiVersion := range h.APIVersions {
+		args = append(args, "--api-versions", apiVersion)
+	}
+	if h.KubeVersion != "" {
+		args = append(args, "--kube-version", h.KubeVersion)
+	}
 	args = append(args, "--output-dir", destDir)
 	return args, nil
 }
@@ -254,6 +264,9 @@ func (h *Hydrator) helm(ctx context.Context, args ...string) ([]byte, error) {
 	if h.CACertFilePath != "" {
 		allArgs = append(allArgs, "--ca-file", h.CACertFilePath)
 	}
+	if h.ClientCertFilePath != "" && h.ClientKeyFilePath != "" {
+		allArgs = append(allArgs, "--cert-file", h.ClientCertFilePath, "--key-file", h.ClientKeyFilePath)
+	}
 	out, err := exec.CommandContext(ctx, "helm", allArgs...).CombinedOutput()
 	if err != nil {
 		return out, errors.Wrapf(err, "invoking helm: %s", string(out))
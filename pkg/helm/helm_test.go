@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChartName = "test-chart"
+
+var testChartFiles = map[string]string{
+	"Chart.yaml": `apiVersion: v2
+name: test-chart
+version: 0.1.0
+`,
+	"crds/crontab.yaml": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: CronTab
+    plural: crontabs
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+`,
+	"templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  foo: bar
+`,
+}
+
+// writeTestChart writes a local chart with a CustomResourceDefinition in its
+// crds/ directory, and returns the path to the chart.
+func writeTestChart(t *testing.T) string {
+	t.Helper()
+	chartDir := filepath.Join(t.TempDir(), testChartName)
+	for name, content := range testChartFiles {
+		path := filepath.Join(chartDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return chartDir
+}
+
+func TestTemplateArgs_IncludeCRDs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		includeCRDs string
+		want        bool
+	}{
+		{name: "unset", includeCRDs: "", want: false},
+		{name: "false", includeCRDs: "false", want: false},
+		{name: "true", includeCRDs: "true", want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hydrator{
+				Chart:       testChartName,
+				Repo:        "https://example.com/charts",
+				ReleaseName: "my-release",
+				IncludeCRDs: tc.includeCRDs,
+			}
+			args, err := h.templateArgs(context.Background(), t.TempDir())
+			require.NoError(t, err)
+			if tc.want {
+				assert.Contains(t, args, "--include-crds")
+			} else {
+				assert.NotContains(t, args, "--include-crds")
+			}
+		})
+	}
+}
+
+func TestHelmTemplate_IncludeCRDs(t *testing.T) {
+	if _, err := exec.LookPath("helm"); err != nil {
+		t.Skip("helm is not installed")
+	}
+	chartDir := writeTestChart(t)
+
+	testCases := []struct {
+		name        string
+		includeCRDs string
+		wantCRDs    bool
+	}{
+		{name: "unset", includeCRDs: "", wantCRDs: false},
+		{name: "false", includeCRDs: "false", wantCRDs: false},
+		{name: "true", includeCRDs: "true", wantCRDs: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destDir := t.TempDir()
+			// A local chart is rendered without a repository.
+			h := &Hydrator{
+				Chart:       chartDir,
+				ReleaseName: "my-release",
+				IncludeCRDs: tc.includeCRDs,
+			}
+			args, err := h.templateArgs(context.Background(), destDir)
+			require.NoError(t, err)
+			out, err := h.helm(context.Background(), args...)
+			require.NoError(t, err, string(out))
+
+			// The templates are always rendered.
+			assert.FileExists(t, filepath.Join(destDir, testChartName, "templates", "configmap.yaml"))
+			// The CustomResourceDefinitions in the crds/ directory are only
+			// rendered with includeCRDs.
+			crdPath := filepath.Join(destDir, testChartName, "crds", "crontab.yaml")
+			if tc.wantCRDs {
+				assert.FileExists(t, crdPath)
+			} else {
+				assert.NoFileExists(t, crdPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// gitMetadata is the name of the git metadata file or directory, which is
+// excluded from the checksum.
+const gitMetadata = ".git"
+
+// ComputeChecksum returns the SHA-256 checksum of the directory tree rooted at
+// dir. It covers the relative path and the content of every file, and the
+// target of every symlink, so that two trees have the same checksum only if
+// they have the same files.
+func ComputeChecksum(dir string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Name() == gitMetadata && path != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(hash, "link\x00%s\x00%s\x00", filepath.ToSlash(rel), target)
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(hash, "file\x00%s\x00%d\x00", filepath.ToSlash(rel), info.Size()); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				klog.Warningf("unable to close %s: %v", path, err)
+			}
+		}()
+		_, err = io.Copy(hash, f)
+		return err
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to compute the checksum of %s", dir)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
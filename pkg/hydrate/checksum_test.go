@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeChecksum(t *testing.T) {
+	baseFiles := map[string]string{
+		"kustomization.yaml": kustomization,
+		"base/ns.yaml":       "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test-ns\n",
+	}
+
+	testCases := []struct {
+		name      string
+		files     map[string]string
+		wantEqual bool
+	}{
+		{
+			name:      "same files",
+			files:     baseFiles,
+			wantEqual: true,
+		},
+		{
+			name: "git metadata is ignored",
+			files: map[string]string{
+				"kustomization.yaml": kustomization,
+				"base/ns.yaml":       "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test-ns\n",
+				".git":               "gitdir: /repo/source/.git/worktrees/abcdef",
+			},
+			wantEqual: true,
+		},
+		{
+			name: "changed file content",
+			files: map[string]string{
+				"kustomization.yaml": kustomization,
+				"base/ns.yaml":       "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other-ns\n",
+			},
+		},
+		{
+			name: "renamed file",
+			files: map[string]string{
+				"kustomization.yaml":  kustomization,
+				"base/namespace.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test-ns\n",
+			},
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				"kustomization.yaml": kustomization,
+			},
+		},
+	}
+
+	want, err := ComputeChecksum(writeTree(t, baseFiles))
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ComputeChecksum(writeTree(t, tc.files))
+			require.NoError(t, err)
+			if tc.wantEqual {
+				assert.Equal(t, want, got)
+			} else {
+				assert.NotEqual(t, want, got)
+			}
+		})
+	}
+}
+
+// writeTree writes the files to a new temporary directory and returns its path.
+func writeTree(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
@@ -21,9 +21,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
@@ -42,8 +45,31 @@ const (
 	DoneFile = "done"
 	// ErrorFile is the file name of the hydration errors.
 	ErrorFile = "error.json"
+	// syncTimeoutMessage is the message git-sync, oci-sync and helm-sync
+	// report when a fetch takes longer than its timeout.
+	syncTimeoutMessage = "context deadline exceeded"
 )
 
+// gitRefNotFoundPattern matches the error git reports when fetching a branch
+// which does not exist in the remote repository, capturing the branch name.
+var gitRefNotFoundPattern = regexp.MustCompile(`couldn't find remote ref ([^\s"\\]+)`)
+
+// gitAuthFailurePattern matches the errors git reports when the credentials
+// are missing or rejected by the remote repository.
+var gitAuthFailurePattern = regexp.MustCompile(`(?i)authentication failed|could not read (username|password)|terminal prompts disabled|permission denied \(publickey|host key verification failed|the requested url returned error: 40[13]`)
+
+// gitHostUnreachablePattern matches the errors git reports when the host of the
+// remote repository cannot be reached.
+var gitHostUnreachablePattern = regexp.MustCompile(`(?i)could not resolve host|connection refused|connection timed out|no route to host|network is unreachable|failed to connect to`)
+
+// registryAuthFailurePattern matches the errors reported by the OCI and Helm
+// clients when the credentials are missing or rejected by the registry.
+var registryAuthFailurePattern = regexp.MustCompile(`(?i)unauthorized|forbidden|authentication required|access denied|access to the resource is denied`)
+
+// registryHostUnreachablePattern matches the errors reported by the OCI and
+// Helm clients when the registry cannot be reached.
+var registryHostUnreachablePattern = regexp.MustCompile(`(?i)no such host|connection refused|i/o timeout|no route to host|network is unreachable`)
+
 // Hydrator runs the hydration process.
 type Hydrator struct {
 	// DonePath is the absolute path to the done file under the /repo directory.
@@ -66,6 +92,36 @@ type Hydrator struct {
 	RehydratePeriod time.Duration
 	// ReconcilerName is the name of the reconciler.
 	ReconcilerName string
+	// KustomizeVersion is the version of the installed Kustomize, which is
+	// recorded in the done file.
+	KustomizeVersion string
+	// HelmVersion is the version of the installed Helm, which is recorded in
+	// the done file.
+	HelmVersion string
+
+	// renderedCommit is the most recent commit rendered successfully.
+	renderedCommit string
+	// renderedChecksum is the checksum of the source configs of renderedCommit,
+	// which is recorded in the done file.
+	renderedChecksum string
+	// renderedDuration is how long it took to render renderedCommit, which is
+	// recorded in the done file.
+	renderedDuration time.Duration
+}
+
+// DoneFileMetadata is the content of the done file, which records the rendered
+// commit and the versions of the tools used to render it.
+type DoneFileMetadata struct {
+	Commit           string `json:"commit"`
+	KustomizeVersion string `json:"kustomizeVersion,omitempty"`
+	HelmVersion      string `json:"helmVersion,omitempty"`
+	// SourceChecksum is the checksum of the source configs which were
+	// rendered, as computed by ComputeChecksum. It is only set if the
+	// rendering succeeded.
+	SourceChecksum string `json:"sourceChecksum,omitempty"`
+	// Duration is how long the rendering took. It is only set if the
+	// rendering succeeded.
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // Run runs the hydration process periodically.
@@ -116,6 +172,7 @@ func (h *Hydrator) Run(ctx context.Context) {
 
 // runHydrate runs `kustomize build` on the source configs.
 func (h *Hydrator) runHydrate(sourceCommit string, syncDir cmpath.Absolute) HydrationError {
+	start := time.Now()
 	newHydratedDir := h.HydratedRoot.Join(cmpath.RelativeOS(sourceCommit))
 	dest := newHydratedDir.Join(h.SyncDir).OSPath()
 
@@ -130,9 +187,19 @@ func (h *Hydrator) runHydrate(sourceCommit string, syncDir cmpath.Absolute) Hydr
 		return NewTransientError(fmt.Errorf("source commit changed while running Kustomize build, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
 	}
 
+	// Compute the checksum after rendering, because rendering might pull remote
+	// Helm charts into the source directory.
+	checksum, err := ComputeChecksum(syncDir.OSPath())
+	if err != nil {
+		return NewInternalError(err)
+	}
+
 	if err := updateSymlink(h.HydratedRoot.OSPath(), h.HydratedLink, newHydratedDir.OSPath()); err != nil {
 		return NewInternalError(errors.Wrapf(err, "unable to update the symbolic link to %s", newHydratedDir.OSPath()))
 	}
+	h.renderedCommit = sourceCommit
+	h.renderedChecksum = checksum
+	h.renderedDuration = time.Since(start)
 	klog.Infof("Successfully rendered %s for commit %s", syncDir.OSPath(), sourceCommit)
 	return nil
 }
@@ -251,11 +318,24 @@ func (h *Hydrator) complete(commit string, hydrationErr HydrationError) error {
 	if err != nil {
 		return err
 	}
+	metadata := DoneFileMetadata{
+		Commit:           commit,
+		KustomizeVersion: h.KustomizeVersion,
+		HelmVersion:      h.HelmVersion,
+	}
+	if hydrationErr == nil && h.renderedCommit == commit {
+		metadata.SourceChecksum = h.renderedChecksum
+		metadata.Duration = &metav1.Duration{Duration: h.renderedDuration}
+	}
+	content, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrapf(err, "unable to encode the done file: %s", h.DonePath.OSPath())
+	}
 	done, err := os.Create(h.DonePath.OSPath())
 	if err != nil {
 		return errors.Wrapf(err, "unable to create done file: %s", h.DonePath.OSPath())
 	}
-	if _, err = done.WriteString(commit); err != nil {
+	if _, err = done.Write(content); err != nil {
 		return errors.Wrapf(err, "unable to write to commit hash to the done file: %s", h.DonePath)
 	}
 	if err := done.Close(); err != nil {
@@ -269,17 +349,28 @@ func (h *Hydrator) complete(commit string, hydrationErr HydrationError) error {
 // If it fails to extract the commit hash for various errors, we only log a warning,
 // and wait for the next hydration loop to retry the hydration.
 func DoneCommit(donePath string) string {
+	return ReadDoneFile(donePath).Commit
+}
+
+// ReadDoneFile reads the metadata from the done file if exists.
+// A done file which only contains the commit hash, as written by older versions
+// of the hydration-controller, is also supported.
+// It returns empty metadata if the done file does not exist or fails to be read.
+func ReadDoneFile(donePath string) DoneFileMetadata {
+	var metadata DoneFileMetadata
 	if _, err := os.Stat(donePath); err == nil {
-		commit, err := os.ReadFile(donePath)
+		content, err := os.ReadFile(donePath)
 		if err != nil {
 			klog.Warningf("unable to read the done file %s: %v", donePath, err)
-			return ""
+			return metadata
+		}
+		if err := json.Unmarshal(content, &metadata); err != nil {
+			return DoneFileMetadata{Commit: string(content)}
 		}
-		return string(commit)
 	} else if !os.IsNotExist(err) {
 		klog.Warningf("unable to check the status of the done file %s: %v", donePath, err)
 	}
-	return ""
+	return metadata
 }
 
 // exportError writes the error content to the error file.
@@ -344,11 +435,59 @@ func SourceCommitAndDirWithRetry(backoff wait.Backoff, sourceType v1beta1.Source
 		commit, sourceDir, err = SourceCommitAndDir(sourceType, sourceRevDir, syncDir, reconcilerName)
 		return err
 	})
+	// Timeouts, authentication failures, unreachable hosts and missing
+	// branches have dedicated error codes, so that they can be told apart from
+	// other source errors.
+	var classifiedErr status.Error
+	if errors.As(err, &classifiedErr) {
+		return commit, sourceDir, classifiedErr
+	}
 	// If a retriable error can't be addressed with retry, it is identified as a
 	// source error, and will be exposed in the R*Sync status.
 	return commit, sourceDir, status.SourceError.Wrap(err).Build()
 }
 
+// classifyGitSyncError wraps the error reported by git-sync with a dedicated
+// error code if its cause is known, so that fetch failures can be told apart.
+// Otherwise, it returns the error as is.
+func classifyGitSyncError(content string, err error) error {
+	switch {
+	case strings.Contains(content, syncTimeoutMessage):
+		return status.SourceTimeoutError.Wrap(err).
+			Sprint("git-sync timed out fetching the source. Consider increasing spec.override.gitSyncTimeout").Build()
+	case gitAuthFailurePattern.MatchString(content):
+		return status.SourceAuthError.Wrap(err).
+			Sprint("AuthenticationFailed: git-sync failed to authenticate to the Git repository. Check spec.git.auth and spec.git.secretRef").Build()
+	case gitHostUnreachablePattern.MatchString(content):
+		return status.SourceUnreachableError.Wrap(err).
+			Sprint("HostUnreachable: git-sync failed to connect to the Git repository. Check spec.git.repo and the network connectivity").Build()
+	}
+	if match := gitRefNotFoundPattern.FindStringSubmatch(content); match != nil {
+		branch := strings.TrimPrefix(match[1], "refs/heads/")
+		return status.SourceBranchNotFoundError.Wrap(err).
+			Sprintf("BranchNotFound: branch %q does not exist in the Git repository. Check spec.git.branch", branch).Build()
+	}
+	return err
+}
+
+// classifyRegistryError wraps the error reported by oci-sync or helm-sync with
+// a dedicated error code if its cause is known, so that fetch failures can be
+// told apart. Otherwise, it returns the error as is.
+func classifyRegistryError(content, repoKind, authFields, repoField string, err error) error {
+	switch {
+	case strings.Contains(content, syncTimeoutMessage):
+		return status.SourceTimeoutError.Wrap(err).
+			Sprintf("timed out fetching from the %s", repoKind).Build()
+	case registryAuthFailurePattern.MatchString(content):
+		return status.SourceAuthError.Wrap(err).
+			Sprintf("AuthenticationFailed: failed to authenticate to the %s. Check %s", repoKind, authFields).Build()
+	case registryHostUnreachablePattern.MatchString(content):
+		return status.SourceUnreachableError.Wrap(err).
+			Sprintf("HostUnreachable: failed to connect to the %s. Check %s and the network connectivity", repoKind, repoField).Build()
+	}
+	return err
+}
+
 // SourceCommitAndDir returns the source hash (a git commit hash or an OCI image
 // digest or a helm chart version), the absolute path of the sync directory,
 // and source errors.
@@ -390,7 +529,16 @@ func SourceCommitAndDir(sourceType v1beta1.SourceType, sourceRevDir cmpath.Absol
 	case err == nil && len(content) != 0:
 		// The source error file exists, which indicates the *-sync container is
 		// ready, so return the error directly without retry.
-		return "", "", fmt.Errorf("error in the %s container: %s", containerName, string(content))
+		err := fmt.Errorf("error in the %s container: %s", containerName, string(content))
+		switch sourceType {
+		case v1beta1.GitSource:
+			return "", "", classifyGitSyncError(string(content), err)
+		case v1beta1.OciSource:
+			return "", "", classifyRegistryError(string(content), "OCI repository", "spec.oci.auth", "spec.oci.image", err)
+		case v1beta1.HelmSource:
+			return "", "", classifyRegistryError(string(content), "Helm repository", "spec.helm.auth and spec.helm.secretRef", "spec.helm.repo", err)
+		}
+		return "", "", err
 	default:
 		// The sourceRoot directory exists, but the source error file doesn't exist.
 		// It indicates that *-sync is ready, but no errors so far.
@@ -15,6 +15,7 @@
 package hydrate
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,11 +23,14 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	ft "kpt.dev/configsync/pkg/importer/filesystem/filesystemtest"
+	"kpt.dev/configsync/pkg/status"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -50,6 +54,7 @@ func TestSourceCommitAndDirWithRetry(t *testing.T) {
 		errFileContent       string
 		expectedSourceCommit string
 		expectedErrMsg       string
+		expectedErrCode      string
 	}{
 		{
 			name:                 "source root directory isn't created within the retry cap",
@@ -82,11 +87,36 @@ func TestSourceCommitAndDirWithRetry(t *testing.T) {
 			expectedErrMsg: "is empty. Please check git-sync logs for more info",
 		},
 		{
-			name:           "error file exists with non-empty content",
-			retryCap:       100 * time.Millisecond,
-			errFileExists:  true,
-			errFileContent: "git-sync error",
-			expectedErrMsg: "git-sync error",
+			name:            "error file exists with non-empty content",
+			retryCap:        100 * time.Millisecond,
+			errFileExists:   true,
+			errFileContent:  "git-sync error",
+			expectedErrMsg:  "git-sync error",
+			expectedErrCode: status.SourceErrorCode,
+		},
+		{
+			name:            "error file reports a git-sync timeout",
+			retryCap:        100 * time.Millisecond,
+			errFileExists:   true,
+			errFileContent:  "Run(git fetch): context deadline exceeded",
+			expectedErrMsg:  "context deadline exceeded",
+			expectedErrCode: status.SourceTimeoutErrorCode,
+		},
+		{
+			name:            "error file reports a nonexistent branch",
+			retryCap:        100 * time.Millisecond,
+			errFileExists:   true,
+			errFileContent:  `Run(git fetch --no-tags --depth 1 origin no-such-branch): exit status 128: { stdout: "", stderr: "fatal: couldn't find remote ref no-such-branch\n" }`,
+			expectedErrMsg:  `BranchNotFound: branch "no-such-branch" does not exist in the Git repository`,
+			expectedErrCode: status.SourceBranchNotFoundErrorCode,
+		},
+		{
+			name:            "error file reports an authentication failure",
+			retryCap:        100 * time.Millisecond,
+			errFileExists:   true,
+			errFileContent:  `Run(git fetch): exit status 128: { stdout: "", stderr: "fatal: Authentication failed for 'https://github.com/acme/repo/'\n" }`,
+			expectedErrMsg:  "AuthenticationFailed: git-sync failed to authenticate to the Git repository",
+			expectedErrCode: status.SourceAuthErrorCode,
 		},
 		{
 			name:           "sync directory doesn't exist",
@@ -181,8 +211,11 @@ func TestSourceCommitAndDirWithRetry(t *testing.T) {
 				assert.Equal(t, tc.expectedSourceCommit, srcCommit)
 				assert.Equal(t, filepath.Join(commitDir, syncDir), srcSyncDir.OSPath())
 			} else {
-				assert.NotNil(t, err)
+				require.NotNil(t, err)
 				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				if tc.expectedErrCode != "" {
+					assert.Equal(t, tc.expectedErrCode, err.Code())
+				}
 			}
 
 			// Block and wait for the goroutine to complete.
@@ -329,3 +362,219 @@ func TestComputeCommit(t *testing.T) {
 		})
 	}
 }
+
+func TestReadDoneFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		hydrator *Hydrator
+		want     DoneFileMetadata
+	}{
+		{
+			name: "done file with tool versions",
+			hydrator: &Hydrator{
+				KustomizeVersion: KustomizeVersion,
+				HelmVersion:      HelmVersion,
+			},
+			want: DoneFileMetadata{
+				Commit:           originCommit,
+				KustomizeVersion: KustomizeVersion,
+				HelmVersion:      HelmVersion,
+			},
+		},
+		{
+			name: "done file with the checksum and duration of the rendered commit",
+			hydrator: &Hydrator{
+				renderedCommit:   originCommit,
+				renderedChecksum: "checksum",
+				renderedDuration: 3 * time.Second,
+			},
+			want: DoneFileMetadata{
+				Commit:         originCommit,
+				SourceChecksum: "checksum",
+				Duration:       &metav1.Duration{Duration: 3 * time.Second},
+			},
+		},
+		{
+			name: "done file without the checksum of a different commit",
+			hydrator: &Hydrator{
+				renderedCommit:   differentCommit,
+				renderedChecksum: "checksum",
+			},
+			want: DoneFileMetadata{Commit: originCommit},
+		},
+		{
+			name:     "done file without tool versions",
+			hydrator: &Hydrator{},
+			want:     DoneFileMetadata{Commit: originCommit},
+		},
+		{
+			name:    "done file with only the commit",
+			content: originCommit,
+			want:    DoneFileMetadata{Commit: originCommit},
+		},
+		{
+			name: "missing done file",
+			want: DoneFileMetadata{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := ft.NewTestDir(t)
+			donePath := dir.Root().Join(cmpath.RelativeSlash(DoneFile))
+			hydratedRoot := dir.Root().Join(cmpath.RelativeSlash("hydrated"))
+			if tc.hydrator != nil {
+				tc.hydrator.DonePath = donePath
+				tc.hydrator.HydratedRoot = hydratedRoot
+				require.NoError(t, tc.hydrator.complete(originCommit, nil))
+			}
+			if tc.content != "" {
+				require.NoError(t, os.WriteFile(donePath.OSPath(), []byte(tc.content), 0644))
+			}
+			assert.Equal(t, tc.want, ReadDoneFile(donePath.OSPath()))
+			assert.Equal(t, tc.want.Commit, DoneCommit(donePath.OSPath()))
+		})
+	}
+}
+
+func TestClassifyGitSyncError(t *testing.T) {
+	testCases := []struct {
+		name            string
+		stderr          string
+		expectedErrCode string
+	}{
+		{
+			name:            "timeout",
+			stderr:          "Run(git fetch): context deadline exceeded",
+			expectedErrCode: status.SourceTimeoutErrorCode,
+		},
+		{
+			name:            "https authentication failure",
+			stderr:          "remote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/acme/repo/'",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "missing https credentials",
+			stderr:          "fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "rejected ssh key",
+			stderr:          "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "unknown ssh host key",
+			stderr:          "Host key verification failed.\nfatal: Could not read from remote repository.",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "access denied",
+			stderr:          "fatal: unable to access 'https://github.com/acme/repo/': The requested URL returned error: 403",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "unknown host",
+			stderr:          "fatal: unable to access 'https://gitlab.acme.com/repo/': Could not resolve host: gitlab.acme.com",
+			expectedErrCode: status.SourceUnreachableErrorCode,
+		},
+		{
+			name:            "unknown ssh host",
+			stderr:          "ssh: Could not resolve hostname gitlab.acme.com: Name or service not known",
+			expectedErrCode: status.SourceUnreachableErrorCode,
+		},
+		{
+			name:            "connection refused",
+			stderr:          "fatal: unable to access 'https://10.0.0.1/repo/': Failed to connect to 10.0.0.1 port 443: Connection refused",
+			expectedErrCode: status.SourceUnreachableErrorCode,
+		},
+		{
+			name:            "connection timed out",
+			stderr:          "ssh: connect to host gitlab.acme.com port 22: Connection timed out",
+			expectedErrCode: status.SourceUnreachableErrorCode,
+		},
+		{
+			name:            "branch not found",
+			stderr:          "fatal: couldn't find remote ref refs/heads/no-such-branch",
+			expectedErrCode: status.SourceBranchNotFoundErrorCode,
+		},
+		{
+			name:   "unknown error",
+			stderr: "fatal: not a git repository",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := classifyGitSyncError(tc.stderr, fmt.Errorf("error in the git-sync container: %s", tc.stderr))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.stderr)
+			var statusErr status.Error
+			if tc.expectedErrCode == "" {
+				assert.False(t, errors.As(err, &statusErr), "got unexpected status error %v", err)
+				return
+			}
+			require.True(t, errors.As(err, &statusErr), "got non-status error %v", err)
+			assert.Equal(t, tc.expectedErrCode, statusErr.Code())
+		})
+	}
+}
+
+func TestClassifyRegistryError(t *testing.T) {
+	testCases := []struct {
+		name            string
+		content         string
+		expectedErrCode string
+	}{
+		{
+			name:            "timeout",
+			content:         `failed to pull image us-docker.pkg.dev/acme/repo/app:v1: Get "https://us-docker.pkg.dev/v2/": context deadline exceeded`,
+			expectedErrCode: status.SourceTimeoutErrorCode,
+		},
+		{
+			name:            "oci authentication failure",
+			content:         "failed to pull image us-docker.pkg.dev/acme/repo/app:v1: GET https://us-docker.pkg.dev/v2/token: UNAUTHORIZED: authentication failed",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "oci access denied",
+			content:         "failed to pull image us-docker.pkg.dev/acme/repo/app:v1: DENIED: Permission \"artifactregistry.repositories.downloadArtifacts\" denied; requested access to the resource is denied",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "helm authentication failure",
+			content:         "failed to pull chart: failed to fetch https://charts.acme.com/index.yaml : 401 Unauthorized",
+			expectedErrCode: status.SourceAuthErrorCode,
+		},
+		{
+			name:            "unknown host",
+			content:         `failed to pull chart: Get "https://charts.acme.com/index.yaml": dial tcp: lookup charts.acme.com: no such host`,
+			expectedErrCode: status.SourceUnreachableErrorCode,
+		},
+		{
+			name:            "connection refused",
+			content:         `failed to pull image 10.0.0.1:5000/app:v1: Get "https://10.0.0.1:5000/v2/": dial tcp 10.0.0.1:5000: connect: connection refused`,
+			expectedErrCode: status.SourceUnreachableErrorCode,
+		},
+		{
+			name:    "unknown error",
+			content: "failed to pull image us-docker.pkg.dev/acme/repo/app:v1: MANIFEST_UNKNOWN: manifest unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := classifyRegistryError(tc.content, "OCI repository", "spec.oci.auth", "spec.oci.image", fmt.Errorf("error in the oci-sync container: %s", tc.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.content)
+			var statusErr status.Error
+			if tc.expectedErrCode == "" {
+				assert.False(t, errors.As(err, &statusErr), "got unexpected status error %v", err)
+				return
+			}
+			require.True(t, errors.As(err, &statusErr), "got non-status error %v", err)
+			assert.Equal(t, tc.expectedErrCode, statusErr.Code())
+		})
+	}
+}
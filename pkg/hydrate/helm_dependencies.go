@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// defaultChartHome is the default value of the `helmGlobals.chartHome`
+	// field, which is relative to the Kustomization root.
+	defaultChartHome = "charts"
+	// chartFile is the name of the file with the Helm chart metadata.
+	chartFile = "Chart.yaml"
+)
+
+// chartMetadata is the subset of the Helm chart metadata in Chart.yaml which
+// describes the chart dependencies.
+type chartMetadata struct {
+	Name         string            `yaml:"name"`
+	Dependencies []chartDependency `yaml:"dependencies"`
+}
+
+// chartDependency is a dependency of a Helm chart.
+type chartDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+// validateHelmDependencies checks that the dependencies of the local Helm
+// charts inflated by the Kustomizations under dir are resolvable.
+//
+// Helm renders a local chart only if its dependencies are vendored in the
+// charts/ directory of the chart, and fails deep in rendering otherwise.
+// Remote charts are skipped, because they are pulled with their dependencies.
+func validateHelmDependencies(dir string) error {
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() || !HasKustomization(fi.Name()) {
+			return nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "unable to read the Kustomization file %s", path)
+		}
+		var kt types.Kustomization
+		if err := yaml.Unmarshal(b, &kt); err != nil {
+			// Leave invalid Kustomizations to be reported by `kustomize build`.
+			return nil
+		}
+		chartHome := defaultChartHome
+		if kt.HelmGlobals != nil && kt.HelmGlobals.ChartHome != "" {
+			chartHome = kt.HelmGlobals.ChartHome
+		}
+		if !filepath.IsAbs(chartHome) {
+			chartHome = filepath.Join(filepath.Dir(path), chartHome)
+		}
+		for _, chart := range kt.HelmCharts {
+			if err := validateChartDependencies(localChartDir(chartHome, chart)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// localChartDir returns the directory of the local copy of the Helm chart, or
+// an empty string if the chart is not available locally.
+func localChartDir(chartHome string, chart types.HelmChart) string {
+	candidates := []string{filepath.Join(chartHome, chart.Name)}
+	if chart.Version != "" {
+		// Newer Kustomize versions store the chart under a versioned directory.
+		candidates = append([]string{filepath.Join(chartHome, fmt.Sprintf("%s-%s", chart.Name, chart.Version), chart.Name)}, candidates...)
+	}
+	for _, dir := range candidates {
+		if _, err := os.Stat(filepath.Join(dir, chartFile)); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// validateChartDependencies checks that the dependencies declared in the
+// Chart.yaml of the chart in chartDir are vendored in its charts/ directory.
+func validateChartDependencies(chartDir string) error {
+	if chartDir == "" {
+		return nil
+	}
+	b, err := os.ReadFile(filepath.Join(chartDir, chartFile))
+	if err != nil {
+		return errors.Wrapf(err, "unable to read the Helm chart metadata in %s", chartDir)
+	}
+	var chart chartMetadata
+	if err := yaml.Unmarshal(b, &chart); err != nil {
+		return errors.Wrapf(err, "unable to parse the Helm chart metadata in %s", chartDir)
+	}
+	vendored, err := os.ReadDir(filepath.Join(chartDir, "charts"))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "unable to read the dependencies of the Helm chart in %s", chartDir)
+	}
+	for _, dep := range chart.Dependencies {
+		if !hasVendoredChart(vendored, dep.Name) {
+			return errors.Errorf("unresolved dependency of Helm chart %q in %s: chart %q (version %q) from repository %q is missing in the charts/ directory. "+
+				"Run `helm dependency update` on the chart and commit the charts/ directory, or remove the local copy of the chart so that it is pulled with its dependencies",
+				chart.Name, chartDir, dep.Name, dep.Version, dep.Repository)
+		}
+	}
+	return nil
+}
+
+// hasVendoredChart returns true if the chart is vendored either as a
+// directory or as a packaged chart archive.
+func hasVendoredChart(vendored []os.DirEntry, name string) bool {
+	for _, entry := range vendored {
+		if entry.IsDir() && entry.Name() == name {
+			return true
+		}
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), name+"-") && strings.HasSuffix(entry.Name(), ".tgz") {
+			return true
+		}
+	}
+	return false
+}
@@ -152,6 +152,13 @@ func kustomizeBuild(input, output string, sendMetrics bool) HydrationError {
 		return NewInternalError(errors.Wrapf(err, "unable to make directory: %s", output))
 	}
 
+	// Report unresolvable Helm chart dependencies before rendering, because
+	// Helm fails with a cryptic error otherwise.
+	if err := validateHelmDependencies(input); err != nil {
+		mustDeleteOutput(err, output)
+		return NewActionableError(err)
+	}
+
 	// run kustomize build with the wrapper library
 	out, err := kmetrics.RunKustomizeBuild(context.Background(), sendMetrics, input, args...)
 	if err != nil {
@@ -202,6 +209,17 @@ func getVersion(tool string) (string, error) {
 	return version, nil
 }
 
+// ToolVersion returns the version of the installed tool, or an empty string if
+// the tool is not installed.
+func ToolVersion(tool string) string {
+	version, err := getVersion(tool)
+	if err != nil {
+		klog.Warningf("unable to detect the %s version: %v", tool, err)
+		return ""
+	}
+	return version
+}
+
 func validateKustomize() error {
 	version, err := getVersion(Kustomize)
 	if err != nil {
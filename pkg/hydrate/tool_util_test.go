@@ -16,7 +16,13 @@ package hydrate
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/status"
 )
 
 func TestValidateTool(t *testing.T) {
@@ -93,3 +99,84 @@ func TestNeedsKustomize(t *testing.T) {
 		})
 	}
 }
+
+func TestKustomizeBuildHelmDependencies(t *testing.T) {
+	chartWithDependency := `apiVersion: v2
+name: my-chart
+version: 1.0.0
+dependencies:
+- name: redis
+  version: 17.3.7
+  repository: https://charts.bitnami.com/bitnami
+`
+	testCases := []struct {
+		name        string
+		files       map[string]string
+		expectedErr string
+	}{
+		{
+			name: "local chart with a missing dependency",
+			files: map[string]string{
+				"kustomization.yaml":           "helmCharts:\n- name: my-chart\n  releaseName: my-release\n",
+				"charts/my-chart/Chart.yaml":   chartWithDependency,
+				"charts/my-chart/values.yaml":  "",
+				"charts/my-chart/charts/.keep": "",
+			},
+			expectedErr: `unresolved dependency of Helm chart "my-chart" in %[1]s/charts/my-chart: chart "redis" (version "17.3.7") from repository "https://charts.bitnami.com/bitnami" is missing in the charts/ directory. ` +
+				"Run `helm dependency update` on the chart and commit the charts/ directory, or remove the local copy of the chart so that it is pulled with its dependencies",
+		},
+		{
+			name: "local chart with a missing dependency in a custom chart home",
+			files: map[string]string{
+				"kustomization.yaml":                        "helmGlobals:\n  chartHome: vendor\nhelmCharts:\n- name: my-chart\n  version: 1.0.0\n",
+				"vendor/my-chart-1.0.0/my-chart/Chart.yaml": chartWithDependency,
+			},
+			expectedErr: `unresolved dependency of Helm chart "my-chart" in %[1]s/vendor/my-chart-1.0.0/my-chart: chart "redis" (version "17.3.7") from repository "https://charts.bitnami.com/bitnami" is missing in the charts/ directory. ` +
+				"Run `helm dependency update` on the chart and commit the charts/ directory, or remove the local copy of the chart so that it is pulled with its dependencies",
+		},
+		{
+			name: "local chart with a vendored dependency directory",
+			files: map[string]string{
+				"kustomization.yaml":                      "helmCharts:\n- name: my-chart\n",
+				"charts/my-chart/Chart.yaml":              chartWithDependency,
+				"charts/my-chart/charts/redis/Chart.yaml": "apiVersion: v2\nname: redis\nversion: 17.3.7\n",
+			},
+		},
+		{
+			name: "local chart with a packaged dependency",
+			files: map[string]string{
+				"kustomization.yaml":                      "helmCharts:\n- name: my-chart\n",
+				"charts/my-chart/Chart.yaml":              chartWithDependency,
+				"charts/my-chart/charts/redis-17.3.7.tgz": "",
+			},
+		},
+		{
+			name: "remote chart",
+			files: map[string]string{
+				"kustomization.yaml": "helmCharts:\n- name: my-chart\n  repo: https://example.com/charts\n  version: 1.0.0\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+
+			if tc.expectedErr == "" {
+				assert.NoError(t, validateHelmDependencies(dir))
+				return
+			}
+			// The dependencies are validated before running `kustomize build`.
+			output := filepath.Join(t.TempDir(), "hydrated")
+			err := kustomizeBuild(dir, output, false)
+			assert.Equal(t, NewActionableError(fmt.Errorf(tc.expectedErr, dir)).Error(), err.Error())
+			assert.Equal(t, status.ActionableHydrationErrorCode, err.Code())
+			assert.NoDirExists(t, output)
+		})
+	}
+}
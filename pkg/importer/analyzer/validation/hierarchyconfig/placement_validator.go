@@ -0,0 +1,35 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hierarchyconfig
+
+import (
+	"kpt.dev/configsync/pkg/api/configmanagement/v1/repo"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IllegalHierarchyConfigPlacementErrorCode is the error code for IllegalHierarchyConfigPlacementError
+const IllegalHierarchyConfigPlacementErrorCode = "1072"
+
+var illegalHierarchyConfigPlacementError = status.NewErrorBuilder(IllegalHierarchyConfigPlacementErrorCode)
+
+// IllegalHierarchyConfigPlacementError reports that a HierarchyConfig is
+// declared in a Namespace directory.
+func IllegalHierarchyConfigPlacementError(config client.Object) status.Error {
+	return illegalHierarchyConfigPlacementError.
+		Sprintf("HierarchyConfigs MUST be declared in `%s/` or in an abstract namespace directory, "+
+			"and MUST NOT be declared in a Namespace directory:", repo.SystemDir).
+		BuildWithResources(config)
+}
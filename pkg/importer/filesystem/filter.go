@@ -19,22 +19,35 @@ import (
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 )
 
-func isHierarchyFile(root cmpath.Absolute, file cmpath.Absolute) bool {
+// topLevelDir returns the first path element of file under root, or the empty
+// string if file is not under root.
+func topLevelDir(root cmpath.Absolute, file cmpath.Absolute) string {
 	fileSplits := file.Split()
 	rootSplits := root.Split()
 	if len(fileSplits) <= len(rootSplits) {
-		return false
+		return ""
 	}
 	for i := range rootSplits {
 		if fileSplits[i] != rootSplits[i] {
-			return false
+			return ""
 		}
 	}
+	return fileSplits[len(rootSplits)]
+}
+
+func isHierarchyFile(root cmpath.Absolute, file cmpath.Absolute) bool {
+	switch topLevelDir(root, file) {
+	case repo.SystemDir, repo.ClusterDir, repo.ClusterRegistryDir, repo.NamespacesDir:
+		return true
+	default:
+		return false
+	}
+}
 
-	return fileSplits[len(rootSplits)] == repo.SystemDir ||
-		fileSplits[len(rootSplits)] == repo.ClusterDir ||
-		fileSplits[len(rootSplits)] == repo.ClusterRegistryDir ||
-		fileSplits[len(rootSplits)] == repo.NamespacesDir
+// IsSystemFile returns true if the file is in the top-level system/ directory
+// of a hierarchical repository rooted at root.
+func IsSystemFile(root cmpath.Absolute, file cmpath.Absolute) bool {
+	return topLevelDir(root, file) == repo.SystemDir
 }
 
 // FilterHierarchyFiles filters out files that aren't in a top-level directory
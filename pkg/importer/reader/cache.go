@@ -0,0 +1,105 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reader
+
+import (
+	"crypto/sha256"
+	"path/filepath"
+	"sync"
+
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+// cacheKey identifies the contents of a file at a path relative to the root
+// directory, which stays the same across commits.
+type cacheKey struct {
+	path string
+	hash [sha256.Size]byte
+}
+
+// newCacheKey returns the cacheKey of the file, and whether the file can be
+// cached.
+func newCacheKey(rootDir, file cmpath.Absolute, contents []byte) (cacheKey, bool) {
+	rel, err := filepath.Rel(rootDir.OSPath(), file.OSPath())
+	if err != nil {
+		return cacheKey{}, false
+	}
+	return cacheKey{path: rel, hash: sha256.Sum256(contents)}, true
+}
+
+// Cache caches the FileObjects decoded and validated from each file, keyed by
+// the path and the content hash of the file, so that the files which did not
+// change between two commits are not decoded again.
+// It only keeps the files read by the most recent call to Read.
+// Cache is safe for concurrent use.
+type Cache struct {
+	mux sync.Mutex
+	// entries are the files cached by the previous call to Read.
+	entries map[cacheKey][]ast.FileObject
+	// used are the files read by the current call to Read.
+	used map[cacheKey][]ast.FileObject
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{
+		entries: make(map[cacheKey][]ast.FileObject),
+		used:    make(map[cacheKey][]ast.FileObject),
+	}
+}
+
+// get returns a copy of the cached FileObjects of the file, if found.
+func (c *Cache) get(key cacheKey) ([]ast.FileObject, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	objs, found := c.used[key]
+	if !found {
+		objs, found = c.entries[key]
+		if !found {
+			return nil, false
+		}
+		c.used[key] = objs
+	}
+	return deepCopy(objs), true
+}
+
+// add caches a copy of the FileObjects of the file, so that the caller can
+// modify the FileObjects.
+func (c *Cache) add(key cacheKey, objs []ast.FileObject) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.used[key] = deepCopy(objs)
+}
+
+// evictUnused evicts the files which were not read since the last call to
+// evictUnused.
+func (c *Cache) evictUnused() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.entries = c.used
+	c.used = make(map[cacheKey][]ast.FileObject, len(c.entries))
+}
+
+func deepCopy(objs []ast.FileObject) []ast.FileObject {
+	if objs == nil {
+		return nil
+	}
+	result := make([]ast.FileObject, len(objs))
+	for i := range objs {
+		result[i] = objs[i].DeepCopy()
+	}
+	return result
+}
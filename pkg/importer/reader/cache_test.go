@@ -0,0 +1,103 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+const (
+	roleFoo = `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: foo
+  namespace: foo
+`
+	roleBar = `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: bar
+  namespace: foo
+`
+)
+
+// writeCommit writes the files to the directory of a new commit, and returns
+// the FilePaths to read them.
+func writeCommit(t *testing.T, files map[string]string) FilePaths {
+	dir, err := cmpath.AbsoluteOS(t.TempDir())
+	require.NoError(t, err)
+	filePaths := FilePaths{RootDir: dir}
+	for name, content := range files {
+		path := dir.Join(cmpath.RelativeSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path.OSPath()), 0755))
+		require.NoError(t, os.WriteFile(path.OSPath(), []byte(content), 0644))
+		filePaths.Files = append(filePaths.Files, path)
+	}
+	return filePaths
+}
+
+func TestFileReader_Read_Cache(t *testing.T) {
+	cache := NewCache()
+	r := File{Cache: cache}
+
+	first := writeCommit(t, map[string]string{"foo.yaml": roleFoo, "bar.yaml": roleBar})
+	want, err := (&File{}).Read(first)
+	require.Nil(t, err)
+	got, err := r.Read(first)
+	require.Nil(t, err)
+	if diff := cmp.Diff(want, got, ast.CompareFileObject); diff != "" {
+		t.Errorf("Read() with an empty cache diff: %s", diff)
+	}
+	assert.Len(t, cache.entries, 2)
+
+	// Modifying the returned objects must not modify the cache.
+	for _, obj := range got {
+		obj.SetLabels(map[string]string{"modified": "true"})
+	}
+
+	// The unchanged file in a new commit is read from the cache, and the
+	// removed file is evicted.
+	second := writeCommit(t, map[string]string{"foo.yaml": roleFoo})
+	got, err = r.Read(second)
+	require.Nil(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "foo", got[0].GetName())
+	assert.Empty(t, got[0].GetLabels())
+	assert.Len(t, cache.entries, 1)
+
+	// The changed file is decoded again.
+	third := writeCommit(t, map[string]string{"foo.yaml": roleBar})
+	got, err = r.Read(third)
+	require.Nil(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "bar", got[0].GetName())
+	assert.Len(t, cache.entries, 1)
+
+	// Files with errors are not cached.
+	fourth := writeCommit(t, map[string]string{"invalid.yaml": "apiVersion: v1\nkind: ConfigMap\n"})
+	_, err = r.Read(fourth)
+	require.NotNil(t, err)
+	assert.Empty(t, cache.entries)
+}
@@ -32,26 +32,40 @@ import (
 )
 
 func parseFile(path string) ([]*unstructured.Unstructured, error) {
+	contents, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseContents(path, contents)
+}
+
+// readFile returns the contents of the file, or nil if the file is neither a
+// YAML nor a JSON file.
+func readFile(path string) ([]byte, error) {
 	if !filepath.IsAbs(path) {
 		return nil, errors.New("attempted to read relative path")
 	}
 
 	switch filepath.Ext(path) {
-	case ".yml", ".yaml":
+	case ".yml", ".yaml", ".json":
 		contents, err := os.ReadFile(path)
 		if err != nil {
 			klog.Errorf("Failed to read file declared in git from mounted filesystem: %s", path)
 			importer.Metrics.Violations.Inc()
 			return nil, err
 		}
+		return contents, nil
+	default:
+		return nil, nil
+	}
+}
+
+// parseContents parses the contents of the file based on its extension.
+func parseContents(path string, contents []byte) ([]*unstructured.Unstructured, error) {
+	switch filepath.Ext(path) {
+	case ".yml", ".yaml":
 		return parseYAMLFile(contents)
 	case ".json":
-		contents, err := os.ReadFile(path)
-		if err != nil {
-			klog.Errorf("Failed to read file declared in git from mounted filesystem: %s", path)
-			importer.Metrics.Violations.Inc()
-			return nil, err
-		}
 		return parseJSONFile(contents)
 	default:
 		return nil, nil
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -54,20 +55,69 @@ type FilePaths struct {
 }
 
 // File reads FileObjects from a filesystem.
-type File struct{}
+type File struct {
+	// Concurrency is the maximum number of files to read and decode at once.
+	// Files are read one at a time if it is less than 2.
+	Concurrency int
+	// Cache caches the FileObjects read from unchanged files across calls to
+	// Read. Files are always decoded if it is nil.
+	Cache *Cache
+}
 
 var _ Reader = &File{}
 
+// fileResult is the result of reading a single file.
+type fileResult struct {
+	objs []ast.FileObject
+	errs status.MultiError
+}
+
 func (r *File) Read(filePaths FilePaths) ([]ast.FileObject, status.MultiError) {
+	// Collect the results by the index of the file, so that the returned
+	// FileObjects are in the same order regardless of the concurrency.
+	results := make([]fileResult, len(filePaths.Files))
+	readFile := func(i int) {
+		objs, errs := r.read(filePaths.RootDir, filePaths.PolicyDir, filePaths.Files[i])
+		results[i] = fileResult{objs: objs, errs: errs}
+	}
+
+	if r.Concurrency < 2 {
+		for i := range filePaths.Files {
+			readFile(i)
+		}
+	} else {
+		indexes := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < r.Concurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range indexes {
+					readFile(i)
+				}
+			}()
+		}
+		for i := range filePaths.Files {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+	}
+
+	if r.Cache != nil {
+		// Evict the files which were not read, so that the cache only holds
+		// the current files.
+		r.Cache.evictUnused()
+	}
+
 	var objs []ast.FileObject
 	var errs status.MultiError
-	for _, f := range filePaths.Files {
-		newObjs, err := r.read(filePaths.RootDir, filePaths.PolicyDir, f)
-		if err != nil {
-			errs = status.Append(errs, err)
+	for _, result := range results {
+		if result.errs != nil {
+			errs = status.Append(errs, result.errs)
 			continue
 		}
-		objs = append(objs, newObjs...)
+		objs = append(objs, result.objs...)
 	}
 	if errs != nil {
 		return nil, errs
@@ -85,7 +135,23 @@ func (r *File) read(rootDir cmpath.Absolute, policyDir cmpath.Relative, file cmp
 		}
 	}
 
-	unstructureds, err := parseFile(file.OSPath())
+	contents, err := readFile(file.OSPath())
+	if err != nil {
+		return nil, status.PathWrapError(err, file.OSPath())
+	}
+
+	var key cacheKey
+	cacheable := false
+	if r.Cache != nil {
+		key, cacheable = newCacheKey(rootDir, file, contents)
+		if cacheable {
+			if objs, found := r.Cache.get(key); found {
+				return objs, nil
+			}
+		}
+	}
+
+	unstructureds, err := parseContents(file.OSPath(), contents)
 	if err != nil {
 		return nil, status.PathWrapError(err, file.OSPath())
 	}
@@ -100,6 +166,9 @@ func (r *File) read(rootDir cmpath.Absolute, policyDir cmpath.Relative, file cmp
 		fileObjects = append(fileObjects, newFileObjects...)
 	}
 
+	if errs == nil && cacheable {
+		r.Cache.add(key, fileObjects)
+	}
 	return fileObjects, errs
 }
 
@@ -20,6 +20,8 @@ import (
 	"path"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	ft "kpt.dev/configsync/pkg/importer/filesystem/filesystemtest"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/status"
@@ -228,3 +230,62 @@ workflow:
 		}
 	}
 }
+
+func TestFileReader_Read_Concurrency(t *testing.T) {
+	// Mirrors the scale of the RootSyncs with many source files.
+	const fileCount = 500
+	var opts []ft.TestDirOpt
+	var files []string
+	for i := 0; i < fileCount; i++ {
+		file := fmt.Sprintf("namespaces/foo/role%d.yaml", i)
+		opts = append(opts, ft.FileContents(file, fmt.Sprintf(`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: role%d
+  namespace: foo
+`, i)))
+		files = append(files, file)
+	}
+	invalidFiles := []string{"namespaces/foo/invalid1.yaml", "namespaces/foo/invalid2.yaml"}
+	for _, file := range invalidFiles {
+		opts = append(opts, ft.FileContents(file, "apiVersion: v1\nkind: ConfigMap\n"))
+	}
+	dir := ft.NewTestDir(t, opts...)
+
+	serial := reader.File{}
+	concurrent := reader.File{Concurrency: 8}
+
+	t.Run("valid files", func(t *testing.T) {
+		want, err := serial.Read(dir.FilePaths(files...))
+		if err != nil {
+			t.Fatalf("got serial Read() = %v, want nil", err)
+		}
+		if len(want) != fileCount {
+			t.Fatalf("got %d objects from serial Read(), want %d", len(want), fileCount)
+		}
+		got, err := concurrent.Read(dir.FilePaths(files...))
+		if err != nil {
+			t.Fatalf("got concurrent Read() = %v, want nil", err)
+		}
+		if diff := cmp.Diff(want, got, ast.CompareFileObject); diff != "" {
+			t.Errorf("concurrent Read() returned objects in a different order than serial Read(): %s", diff)
+		}
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		allFiles := append([]string{invalidFiles[0]}, files...)
+		allFiles = append(allFiles, invalidFiles[1])
+		_, want := serial.Read(dir.FilePaths(allFiles...))
+		if want == nil {
+			t.Fatal("got serial Read() = nil, want error")
+		}
+		objs, got := concurrent.Read(dir.FilePaths(allFiles...))
+		if objs != nil {
+			t.Errorf("got concurrent Read() = %v, want nil", objs)
+		}
+		if got == nil || got.Error() != want.Error() {
+			t.Errorf("got concurrent Read() error %v, want %v", got, want)
+		}
+	})
+}
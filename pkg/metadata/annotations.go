@@ -125,10 +125,36 @@ const (
 	// to disable updating the webhook configuration.
 	WebhookConfigurationUpdateDisabled = "disabled"
 
+	// ReconcileNowAnnotationKey is the annotation that requests a one-shot
+	// force-apply of a managed resource, without a new commit.
+	// The annotation is advisory: the reconciler re-applies the declared state
+	// of the resource on its next loop, even if no drift is detected, and then
+	// clears the annotation. The annotation value is ignored.
+	// This annotation is set by Config Sync users on a managed resource.
+	ReconcileNowAnnotationKey = configsync.ConfigSyncPrefix + "reconcile-now"
+
+	// SyncGenerationAnnotationKey is the annotation that indicates the
+	// metadata.generation of the RootSync or RepoSync which last applied the
+	// resource. It is only set if spec.override.annotateSyncGeneration is true.
+	// This annotation is set by Config Sync on a managed resource.
+	SyncGenerationAnnotationKey = configsync.ConfigSyncPrefix + "sync-generation"
+
+	// OrphanedByAnnotationKey is the annotation that indicates which RootSync
+	// or RepoSync intentionally left the resource on the cluster, when it
+	// pruned or deleted the resource with a policy preserving the resource.
+	// The value is the kind, namespace and name of the RootSync or RepoSync,
+	// formatted as `<kind>/<namespace>/<name>`.
+	// This annotation is set by Config Sync on a previously managed resource.
+	OrphanedByAnnotationKey = configsync.ConfigSyncPrefix + "orphaned-by"
+
 	// UnknownScopeAnnotationKey is the annotation that indicates the scope of a resource is unknown.
 	// This annotation is set by Config Sync on a managed resource whose scope is unknown.
 	UnknownScopeAnnotationKey = configsync.ConfigSyncPrefix + "unknown-scope"
 
+	// DeletionProtectionEnabled is the value for DeletionProtectionAnnotationKey
+	// to protect a resource from being pruned.
+	DeletionProtectionEnabled = "enabled"
+
 	// UnknownScopeAnnotationValue is the value for UnknownScopeAnnotationKey
 	// to indicate that the scope of a resource is unknown.
 	UnknownScopeAnnotationValue = "true"
@@ -138,6 +164,57 @@ const (
 	// resources when the RootSync/RepoSync object is deleted.
 	DeletionPropagationPolicyAnnotationKey = configsync.ConfigSyncPrefix + "deletion-propagation-policy"
 
+	// IgnoreMutationFieldsAnnotationKey is the annotation key listing the
+	// fields of a managed resource which Config Sync neither applies nor
+	// remediates, so that another controller can mutate them, for example the
+	// replicas of a Deployment scaled by an autoscaler.
+	// The value is a comma-separated list of field paths, such as
+	// `.spec.replicas,.spec.template.metadata.annotations`.
+	// The fields are removed from the declared resource before its declared
+	// fields are computed, so the admission webhook does not protect them, and
+	// Config Sync releases its server-side apply ownership of them. Fields no
+	// longer owned by any other manager are then removed by the next apply.
+	// This annotation is set by Config Sync users on a managed resource.
+	IgnoreMutationFieldsAnnotationKey = configsync.ConfigSyncPrefix + "ignore-mutation-fields"
+
+	// HandoffToAnnotationKey is the annotation key naming the RootSync which
+	// takes over the management of a resource. When the resource is removed
+	// from the source of its current RootSync or RepoSync, and declared in the
+	// source of the named RootSync, the current RootSync or RepoSync removes
+	// the resource from its inventory instead of pruning it, so that the
+	// named RootSync adopts the resource without deleting and recreating it.
+	// The annotation must be applied by the current RootSync or RepoSync
+	// before the resource is removed from its source.
+	// This annotation is set by Config Sync users on a managed resource.
+	HandoffToAnnotationKey = configsync.ConfigSyncPrefix + "handoff-to"
+
+	// DeletionProtectionAnnotationKey is the annotation key protecting a
+	// managed resource from being pruned when it is removed from the source.
+	// If set to `enabled`, the reconciler keeps the resource on the cluster
+	// and in its inventory, and reports a non-blocking error, until either
+	// the annotation is removed or the resource is declared again.
+	// Unlike the `client.lifecycle.config.k8s.io/deletion: detach` annotation,
+	// which Config Sync sets on the special and implicit Namespaces, the
+	// resource stays managed, and is pruned as soon as the annotation is removed.
+	// This annotation is set by Config Sync users on a managed resource.
+	DeletionProtectionAnnotationKey = configsync.ConfigSyncPrefix + "deletion-protection"
+
+	// PruneConfirmationAnnotationKey is the annotation key set on
+	// RootSync/RepoSync objects to confirm that the reconciler may prune more
+	// objects in one sync than spec.override.maxPruneCount allows.
+	// The value is the hash of the set of objects to prune, as reported in the
+	// sync error, so that the confirmation does not apply to a different set
+	// of objects to prune.
+	// This annotation is set by Config Sync users on a RootSync or RepoSync.
+	PruneConfirmationAnnotationKey = configsync.ConfigSyncPrefix + "confirm-prune"
+
+	// ResyncTokenAnnotationKey is the annotation key set on RootSync/RepoSync
+	// objects to force the reconciler to run a full resync.
+	// The reconciler resyncs once each time the value changes, so the same
+	// value does not trigger another resync.
+	// This annotation is set by Config Sync users on a RootSync or RepoSync.
+	ResyncTokenAnnotationKey = configsync.ConfigSyncPrefix + "resync-token"
+
 	// RequiresRenderingAnnotationKey is the annotation key set on
 	// RootSync/RepoSync objects to indicate whether the source of truth
 	// requires last mile hydration. The reconciler writes the value of this
@@ -216,6 +293,14 @@ const KustomizeOrigin = "config.kubernetes.io/origin"
 // FleetWorkloadIdentityCredentials is the key for the credentials file of the Fleet Workload Identity.
 const FleetWorkloadIdentityCredentials = "config.kubernetes.io/fleet-workload-identity"
 
+// FleetMembershipGeneration is the key for the generation of the Fleet
+// Membership used to build the Fleet Workload Identity credentials.
+const FleetMembershipGeneration = configsync.ConfigSyncPrefix + "fleet-membership-generation"
+
+// PostSyncVerificationCommit is the key for the commit verified by a
+// post-sync verification Job.
+const PostSyncVerificationCommit = configsync.ConfigSyncPrefix + "post-sync-verification-commit"
+
 // DeletionPropagationPolicy is the type used to identify value enums to use
 // with the deletion-propagation-policy annotation.
 type DeletionPropagationPolicy string
@@ -15,6 +15,7 @@
 package metadata
 
 import (
+	"fmt"
 	"strings"
 
 	"kpt.dev/configsync/pkg/api/configmanagement"
@@ -54,6 +55,9 @@ var sourceAnnotations = map[string]bool{
 	ResourceManagementKey:                  true,
 	LifecycleMutationAnnotation:            true,
 	DeletionPropagationPolicyAnnotationKey: true,
+	IgnoreMutationFieldsAnnotationKey:      true,
+	HandoffToAnnotationKey:                 true,
+	DeletionProtectionAnnotationKey:        true,
 }
 
 // IsSourceAnnotation returns true if the annotation is a ConfigSync source
@@ -109,6 +113,42 @@ func HasConfigSyncMetadata(obj client.Object) bool {
 	return false
 }
 
+// HasReconcileNow returns true if the given obj has the reconcile-now
+// annotation, which requests a one-shot force-apply of the object.
+func HasReconcileNow(obj client.Object) bool {
+	_, found := obj.GetAnnotations()[ReconcileNowAnnotationKey]
+	return found
+}
+
+// IgnoredMutationFields returns the field paths listed in the
+// ignore-mutation-fields annotation of the given obj, each split into its
+// fields. It returns an error if any of the paths is invalid.
+func IgnoredMutationFields(obj client.Object) ([][]string, error) {
+	value, found := obj.GetAnnotations()[IgnoreMutationFieldsAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+	var paths [][]string
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if !strings.HasPrefix(path, ".") {
+			return nil, fmt.Errorf("field path %q must start with %q", path, ".")
+		}
+		fields := strings.Split(strings.TrimPrefix(path, "."), ".")
+		for _, field := range fields {
+			if field == "" || strings.ContainsAny(field, "[]*") {
+				return nil, fmt.Errorf("field path %q must only contain non-empty field names, without list indices or wildcards", path)
+			}
+		}
+		switch fields[0] {
+		case "apiVersion", "kind", "metadata":
+			return nil, fmt.Errorf("field path %q must not be under %q", path, fields[0])
+		}
+		paths = append(paths, fields)
+	}
+	return paths, nil
+}
+
 // RemoveConfigSyncMetadata removes the Config Sync metadata, including both Config Sync
 // annotations and labels, from the given resource.
 // The only Config Sync metadata which will not be removed is `LifecycleMutationAnnotation`.
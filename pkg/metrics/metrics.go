@@ -29,6 +29,13 @@ var (
 		"The number of errors in the reconciler",
 		stats.UnitDimensionless)
 
+	// SourceFetchErrors metric measures the number of errors fetching the
+	// source of truth, by error code.
+	SourceFetchErrors = stats.Int64(
+		"source_fetch_errors",
+		"The number of errors fetching the source of truth",
+		stats.UnitDimensionless)
+
 	// PipelineError metric measures the error by components when syncing a commit.
 	// Definition here must exactly match the definition in the resource-group
 	// controller, or the Prometheus exporter will error. b/247516388
@@ -50,6 +57,34 @@ var (
 		"The duration of the parse-apply-watch loop in seconds",
 		stats.UnitSeconds)
 
+	// ReconcileCycles metric measures the number of completed cycles of the
+	// parse-apply-watch loop, whether or not they succeeded.
+	ReconcileCycles = stats.Int64(
+		"reconcile_cycles",
+		"The number of completed reconcile cycles",
+		stats.UnitDimensionless)
+
+	// SuccessfulReconcileCycles metric measures the number of cycles of the
+	// parse-apply-watch loop which completed without errors.
+	SuccessfulReconcileCycles = stats.Int64(
+		"successful_reconcile_cycles",
+		"The number of reconcile cycles which completed without errors",
+		stats.UnitDimensionless)
+
+	// Triggers metric measures the number of runs of the parse-apply-watch
+	// loop, by the trigger which started them and their result.
+	Triggers = stats.Int64(
+		"reconciler_triggers",
+		"The number of runs of the parse-apply-watch loop by trigger",
+		stats.UnitDimensionless)
+
+	// SyncStageDuration metric measures the latency of each stage of a sync:
+	// read, render, parse, apply, and wait.
+	SyncStageDuration = stats.Float64(
+		"sync_stage_duration_seconds",
+		"The duration of each stage of a sync in seconds",
+		stats.UnitSeconds)
+
 	// LastSync metric measures the timestamp of the latest Git sync.
 	LastSync = stats.Int64(
 		"last_sync_timestamp",
@@ -86,6 +121,12 @@ var (
 		"The duration of remediator reconciliation events",
 		stats.UnitSeconds)
 
+	// PrunedObjects metric measures the number of objects pruned by the applier.
+	PrunedObjects = stats.Int64(
+		"pruned_objects",
+		"The number of objects pruned by the applier",
+		stats.UnitDimensionless)
+
 	// LastApply metric measures the timestamp of the most recent applier apply event.
 	LastApply = stats.Int64(
 		"last_apply_timestamp",
@@ -98,6 +139,12 @@ var (
 		"The number of resource conflicts resulting from a mismatch between the cached resources and cluster resources",
 		stats.UnitDimensionless)
 
+	// ManagementConflicts metric measures the number of management conflicts between reconcilers.
+	ManagementConflicts = stats.Int64(
+		"management_conflicts",
+		"The number of management conflicts detected between reconcilers which declare the same resources",
+		stats.UnitDimensionless)
+
 	// InternalErrors metric measures the number of unexpected internal errors triggered by defensive checks in Config Sync.
 	InternalErrors = stats.Int64(
 		"internal_errors",
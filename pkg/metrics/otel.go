@@ -165,6 +165,7 @@ processors:
           - resource_fights_total
           - remediate_duration_seconds
           - resource_conflicts_total
+          - management_conflicts_total
           - internal_errors_total
           - kcc_resource_count
           - last_sync_timestamp
@@ -256,6 +257,13 @@ processors:
           - action: aggregate_labels
             label_set: []
             aggregation_type: max
+      - include: management_conflicts_total
+        action: update
+        new_name: management_conflicts_count
+        operations:
+          - action: aggregate_labels
+            label_set: [current_manager_scope, desired_manager_scope]
+            aggregation_type: max
       - include: internal_errors_total
         action: update
         new_name: internal_errors_count
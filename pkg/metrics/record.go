@@ -63,6 +63,16 @@ func RecordReconcilerErrors(ctx context.Context, component string, errs []v1beta
 	}
 }
 
+// RecordSourceFetchErrors produces a measurement for the SourceFetchErrors
+// view, for every code of the errors fetching the source of truth.
+func RecordSourceFetchErrors(ctx context.Context, errs []v1beta1.ConfigSyncError) {
+	errorCountByCode := status.CountSourceFetchErrorByCode(errs)
+	for _, code := range status.SourceFetchErrorCodes {
+		tagCtx, _ := tag.New(ctx, tag.Upsert(KeyErrorCode, code))
+		record(tagCtx, SourceFetchErrors.M(errorCountByCode[code]))
+	}
+}
+
 // RecordPipelineError produces a measurement for the PipelineError view
 func RecordPipelineError(ctx context.Context, reconcilerType, component string, errLen int) {
 	reconcilerName := os.Getenv(reconcilermanager.ReconcilerNameKey)
@@ -85,9 +95,60 @@ func RecordReconcileDuration(ctx context.Context, status string, startTime time.
 }
 
 // RecordParserDuration produces a measurement for the ParserDuration view.
+// The same duration is also recorded for the SyncStageDuration view, under the
+// stage that corresponds to the parser source.
 func RecordParserDuration(ctx context.Context, trigger, source, status string, startTime time.Time) {
+	duration := time.Since(startTime)
 	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyStatus, status), tag.Upsert(KeyTrigger, trigger), tag.Upsert(KeyParserSource, source))
-	measurement := ParserDuration.M(time.Since(startTime).Seconds())
+	measurement := ParserDuration.M(duration.Seconds())
+	record(tagCtx, measurement)
+	if stage, found := parserSourceStages[source]; found {
+		recordSyncStageDuration(ctx, stage, status, duration)
+	}
+}
+
+// parserSourceStages maps the parser sources recorded by RecordParserDuration
+// to the stages of the SyncStageDuration view.
+// The "update" source is not a stage, since it spans both the apply and the
+// wait stages, which the applier records separately.
+var parserSourceStages = map[string]string{
+	"read":  StageRead,
+	"parse": StageParse,
+}
+
+// RecordReconcileCycle produces measurements for the ReconcileCycles and
+// SuccessfulReconcileCycles views, at the completion of a reconcile cycle.
+func RecordReconcileCycle(ctx context.Context, succeeded bool) {
+	record(ctx, ReconcileCycles.M(1))
+	if succeeded {
+		record(ctx, SuccessfulReconcileCycles.M(1))
+	}
+}
+
+// RecordTrigger produces a measurement for the Triggers view, at the end of a
+// run of the parse-apply-watch loop started by the trigger. The status is
+// StatusSuccess or StatusError for runs completing a reconcile cycle, and
+// StatusSkipped otherwise.
+func RecordTrigger(ctx context.Context, trigger, status string) {
+	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyTrigger, trigger), tag.Upsert(KeyStatus, status))
+	record(tagCtx, Triggers.M(1))
+}
+
+// RecordSyncStageDuration produces a measurement for the SyncStageDuration view.
+func RecordSyncStageDuration(ctx context.Context, stage, status string, startTime time.Time) {
+	recordSyncStageDuration(ctx, stage, status, time.Since(startTime))
+}
+
+// RecordRenderingDuration produces a measurement for the SyncStageDuration
+// view, with the duration of a successful rendering, as measured by the
+// hydration-controller.
+func RecordRenderingDuration(ctx context.Context, duration time.Duration) {
+	recordSyncStageDuration(ctx, StageRender, StatusSuccess, duration)
+}
+
+func recordSyncStageDuration(ctx context.Context, stage, status string, duration time.Duration) {
+	tagCtx, _ := tag.New(ctx, tag.Upsert(KeySyncStage, stage), tag.Upsert(KeyStatus, status))
+	measurement := SyncStageDuration.M(duration.Seconds())
 	record(tagCtx, measurement)
 }
 
@@ -135,6 +196,14 @@ func RecordApplyDuration(ctx context.Context, status, commit string, startTime t
 	record(tagCtx, durationMeasurement, lastApplyMeasurement)
 }
 
+// RecordPrunedObjects produces a measurement for the PrunedObjects view.
+func RecordPrunedObjects(ctx context.Context, gvk string, numObjects int) {
+	tagCtx, _ := tag.New(ctx,
+		tag.Upsert(KeyType, gvk))
+	measurement := PrunedObjects.M(int64(numObjects))
+	record(tagCtx, measurement)
+}
+
 // RecordResourceFight produces measurements for the ResourceFights view.
 func RecordResourceFight(ctx context.Context, _ string) {
 	//tagCtx, _ := tag.New(ctx,
@@ -164,6 +233,16 @@ func RecordResourceConflict(ctx context.Context, commit string) {
 	record(tagCtx, measurement)
 }
 
+// RecordManagementConflict produces measurements for the ManagementConflicts view.
+func RecordManagementConflict(ctx context.Context, currentManagerScope, desiredManagerScope string) {
+	tagCtx, _ := tag.New(ctx,
+		tag.Upsert(KeyCurrentManagerScope, currentManagerScope),
+		tag.Upsert(KeyDesiredManagerScope, desiredManagerScope),
+	)
+	measurement := ManagementConflicts.M(1)
+	record(tagCtx, measurement)
+}
+
 // RecordInternalError produces measurements for the InternalErrors view.
 func RecordInternalError(ctx context.Context, source string) {
 	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyInternalErrorSource, source))
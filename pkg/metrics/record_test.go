@@ -0,0 +1,266 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/status"
+)
+
+func TestRecordSyncStageDuration(t *testing.T) {
+	testCases := []struct {
+		name      string
+		durations []time.Duration
+		// wantCountPerBucket is indexed by the stageDistributionBounds buckets,
+		// with the last entry counting durations beyond the largest bound.
+		wantCountPerBucket []int64
+	}{
+		{
+			name:               "sub-second durations",
+			durations:          []time.Duration{5 * time.Millisecond, 20 * time.Millisecond, 200 * time.Millisecond},
+			wantCountPerBucket: []int64{1, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:               "long durations",
+			durations:          []time.Duration{2 * time.Second, 2 * time.Second, 45 * time.Second, 20 * time.Minute, 2 * time.Hour},
+			wantCountPerBucket: []int64{0, 0, 0, 0, 0, 2, 0, 0, 1, 0, 0, 1, 0, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := view.Register(SyncStageDurationView); err != nil {
+				t.Fatal(err)
+			}
+			defer view.Unregister(SyncStageDurationView)
+
+			for _, d := range tc.durations {
+				recordSyncStageDuration(context.Background(), StageApply, StatusSuccess, d)
+			}
+
+			rows, err := view.RetrieveData(SyncStageDurationView.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != 1 {
+				t.Fatalf("expected 1 row, got %d: %v", len(rows), rows)
+			}
+			wantTags := []tag.Tag{{Key: KeySyncStage, Value: StageApply}, {Key: KeyStatus, Value: StatusSuccess}}
+			if diff := cmp.Diff(wantTags, rows[0].Tags, cmp.AllowUnexported(tag.Key{})); diff != "" {
+				t.Errorf("unexpected tags (-want, +got):\n%s", diff)
+			}
+			data, ok := rows[0].Data.(*view.DistributionData)
+			if !ok {
+				t.Fatalf("expected *view.DistributionData, got %T", rows[0].Data)
+			}
+			if data.Count != int64(len(tc.durations)) {
+				t.Errorf("expected count %d, got %d", len(tc.durations), data.Count)
+			}
+			if diff := cmp.Diff(tc.wantCountPerBucket, data.CountPerBucket); diff != "" {
+				t.Errorf("unexpected bucket counts (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordParserDuration_SyncStage(t *testing.T) {
+	if err := view.Register(SyncStageDurationView); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(SyncStageDurationView)
+
+	start := time.Now()
+	RecordParserDuration(context.Background(), "retry", "read", StatusSuccess, start)
+	RecordParserDuration(context.Background(), "retry", "parse", StatusError, start)
+	// The update spans the apply and the wait stages, so it is not a stage.
+	RecordParserDuration(context.Background(), "retry", "update", StatusSuccess, start)
+	RecordRenderingDuration(context.Background(), time.Second)
+
+	rows, err := view.RetrieveData(SyncStageDurationView.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, row := range rows {
+		var stage, status string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case KeySyncStage:
+				stage = tg.Value
+			case KeyStatus:
+				status = tg.Value
+			}
+		}
+		got[stage+"/"+status] = row.Data.(*view.DistributionData).Count
+	}
+	want := map[string]int64{
+		StageRead + "/" + StatusSuccess:   1,
+		StageRender + "/" + StatusSuccess: 1,
+		StageParse + "/" + StatusError:    1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected stage counts (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRecordReconcileCycle(t *testing.T) {
+	if err := view.Register(ReconcileCyclesView, SuccessfulReconcileCyclesView); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(ReconcileCyclesView, SuccessfulReconcileCyclesView)
+
+	countOf := func(v *view.View) int64 {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) == 0 {
+			return 0
+		}
+		return rows[0].Data.(*view.CountData).Value
+	}
+
+	steps := []struct {
+		succeeded      bool
+		wantTotal      int64
+		wantSuccessful int64
+	}{
+		{succeeded: true, wantTotal: 1, wantSuccessful: 1},
+		{succeeded: false, wantTotal: 2, wantSuccessful: 1},
+		{succeeded: false, wantTotal: 3, wantSuccessful: 1},
+		{succeeded: true, wantTotal: 4, wantSuccessful: 2},
+	}
+	for i, step := range steps {
+		RecordReconcileCycle(context.Background(), step.succeeded)
+		if got := countOf(ReconcileCyclesView); got != step.wantTotal {
+			t.Errorf("step %d: expected %d total cycles, got %d", i, step.wantTotal, got)
+		}
+		if got := countOf(SuccessfulReconcileCyclesView); got != step.wantSuccessful {
+			t.Errorf("step %d: expected %d successful cycles, got %d", i, step.wantSuccessful, got)
+		}
+	}
+}
+
+func TestRecordManagementConflict(t *testing.T) {
+	if err := view.Register(ManagementConflictsView); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(ManagementConflictsView)
+
+	RecordManagementConflict(context.Background(), "root", "namespace")
+	RecordManagementConflict(context.Background(), "root", "namespace")
+	RecordManagementConflict(context.Background(), "namespace", "root")
+
+	rows, err := view.RetrieveData(ManagementConflictsView.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, row := range rows {
+		var current, desired string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case KeyCurrentManagerScope:
+				current = tg.Value
+			case KeyDesiredManagerScope:
+				desired = tg.Value
+			}
+		}
+		got[current+"->"+desired] = row.Data.(*view.CountData).Value
+	}
+	want := map[string]int64{
+		"root->namespace": 2,
+		"namespace->root": 1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected conflict counts (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRecordSourceFetchErrors(t *testing.T) {
+	if err := view.Register(SourceFetchErrorsView); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(SourceFetchErrorsView)
+
+	RecordSourceFetchErrors(context.Background(), []v1beta1.ConfigSyncError{
+		{Code: status.SourceAuthErrorCode},
+		{Code: status.SourceAuthErrorCode},
+		{Code: status.SourceUnreachableErrorCode},
+		{Code: "2122"},
+		{Code: status.InternalErrorCode},
+	})
+
+	rows, err := view.RetrieveData(SourceFetchErrorsView.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == KeyErrorCode {
+				got[tg.Value] = row.Data.(*view.LastValueData).Value
+			}
+		}
+	}
+	want := map[string]float64{
+		status.SourceErrorCode:               0,
+		status.SourceTimeoutErrorCode:        0,
+		status.SourceBranchNotFoundErrorCode: 0,
+		status.SourceAuthErrorCode:           2,
+		status.SourceUnreachableErrorCode:    1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected source fetch error counts (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRecordPrunedObjects(t *testing.T) {
+	if err := view.Register(PrunedObjectsView); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(PrunedObjectsView)
+
+	RecordPrunedObjects(context.Background(), "/v1, Kind=ConfigMap", 3)
+	RecordPrunedObjects(context.Background(), "apps/v1, Kind=Deployment", 1)
+	RecordPrunedObjects(context.Background(), "/v1, Kind=ConfigMap", 2)
+
+	rows, err := view.RetrieveData(PrunedObjectsView.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == KeyType {
+				got[tg.Value] = row.Data.(*view.SumData).Value
+			}
+		}
+	}
+	want := map[string]float64{
+		"/v1, Kind=ConfigMap":      5,
+		"apps/v1, Kind=Deployment": 1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected pruned object counts (-want, +got):\n%s", diff)
+	}
+}
@@ -52,15 +52,22 @@ func RegisterReconcilerMetricsViews() error {
 	return view.Register(
 		APICallDurationView,
 		ReconcilerErrorsView,
+		SourceFetchErrorsView,
 		ParserDurationView,
+		ReconcileCyclesView,
+		SuccessfulReconcileCyclesView,
+		TriggersView,
+		SyncStageDurationView,
 		LastApplyTimestampView,
 		LastSyncTimestampView,
 		DeclaredResourcesView,
 		ApplyOperationsView,
 		ApplyDurationView,
+		PrunedObjectsView,
 		ResourceFightsView,
 		RemediateDurationView,
 		ResourceConflictsView,
+		ManagementConflictsView,
 		InternalErrorsView,
 		PipelineErrorView,
 	)
@@ -48,7 +48,12 @@ var (
 	// KeyErrorClass groups metrics by their error code.
 	KeyErrorClass, _ = tag.NewKey("errorclass")
 
-	// KeyStatus groups metrics by their status. Possible values: success, error.
+	// KeyErrorCode groups metrics by their error code. Possible values: the
+	// codes of the errors fetching the source, e.g. 2004.
+	KeyErrorCode, _ = tag.NewKey("errorcode")
+
+	// KeyStatus groups metrics by their status. Possible values: success, error,
+	// and skipped for the Triggers metric.
 	KeyStatus, _ = tag.NewKey("status")
 
 	// KeyInternalErrorSource groups the InternalError metrics by their source. Possible values: parser, differ, remediator.
@@ -57,9 +62,15 @@ var (
 	// KeyParserSource groups the metrics for the parser by their source. Possible values: read, parse, update.
 	KeyParserSource, _ = tag.NewKey("source")
 
+	// KeySyncStage groups the SyncStageDuration metrics by their stage. Possible values: read, render, parse, apply, wait.
+	KeySyncStage, _ = tag.NewKey("stage")
+
 	// KeyTrigger groups metrics by their trigger. Possible values: retry, watchUpdate, managementConflict, resync, reimport.
 	KeyTrigger, _ = tag.NewKey("trigger")
 
+	// KeyType groups metrics by the GroupVersionKind of their resources.
+	KeyType, _ = tag.NewKey("type")
+
 	// KeyCommit groups metrics by their git commit. Even though this tag has a high cardinality,
 	// it is only used by the `last_sync_timestamp` and `last_apply_timestamp` metrics.
 	// These are both aggregated as LastValue metrics so the number of recorded values will always be
@@ -72,6 +83,14 @@ var (
 
 	// KeyResourceType groups metrics by their resource types. Possible values: cpu, memory.
 	KeyResourceType, _ = tag.NewKey("resource")
+
+	// KeyCurrentManagerScope groups metrics by the scope of the reconciler
+	// which manages the conflicting resource. Possible values: root, namespace.
+	KeyCurrentManagerScope, _ = tag.NewKey("current_manager_scope")
+
+	// KeyDesiredManagerScope groups metrics by the scope of the reconciler
+	// which tries to manage the conflicting resource. Possible values: root, namespace.
+	KeyDesiredManagerScope, _ = tag.NewKey("desired_manager_scope")
 )
 
 // The following metric tag keys are available from the otel-collector
@@ -103,6 +122,9 @@ const (
 	StatusSuccess = "success"
 	// StatusError is the string value for the status key indicating failure/errors
 	StatusError = "error"
+	// StatusSkipped is the string value for the status key indicating that a
+	// run of the parse-apply-watch loop had nothing to do
+	StatusSkipped = "skipped"
 	// CommitNone is the string value for the commit key indicating that no
 	// commit has been synced.
 	CommitNone = "NONE"
@@ -110,6 +132,17 @@ const (
 	ApplierController = "applier"
 	// RemediatorController is the string value for the remediator controller in the multi-repo mode
 	RemediatorController = "remediator"
+	// StageRead is the string value for the stage key of reading the source
+	// files, once they are fetched by git-sync, oci-sync or helm-sync
+	StageRead = "read"
+	// StageRender is the string value for the stage key of rendering the source files
+	StageRender = "render"
+	// StageParse is the string value for the stage key of parsing and validating the source files
+	StageParse = "parse"
+	// StageApply is the string value for the stage key of applying the declared resources and pruning the others
+	StageApply = "apply"
+	// StageWait is the string value for the stage key of waiting for the applied resources to reconcile
+	StageWait = "wait"
 )
 
 // StatusTagKey returns a string representation of the error, if it exists, otherwise success.
@@ -25,6 +25,10 @@ var distributionBounds = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5,
 // longDistributionBounds defines the bounds for a histogram distribution meansuring long durations.
 var longDistributionBounds = []float64{1, 5, 10, 30, 60, 300, 600, 1200, 1800, 3600, 5400}
 
+// stageDistributionBounds defines the bounds for a histogram distribution
+// measuring sync stage durations, which range from milliseconds to an hour.
+var stageDistributionBounds = []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600}
+
 var (
 	// APICallDurationView aggregates the APICallDuration metric measurements.
 	APICallDurationView = &view.View{
@@ -44,6 +48,16 @@ var (
 		Aggregation: view.LastValue(),
 	}
 
+	// SourceFetchErrorsView aggregates the SourceFetchErrors metric
+	// measurements.
+	SourceFetchErrorsView = &view.View{
+		Name:        SourceFetchErrors.Name(),
+		Measure:     SourceFetchErrors,
+		Description: "The current number of errors fetching the source of truth in the RootSync and RepoSync reconcilers, by error code",
+		TagKeys:     []tag.Key{KeyErrorCode},
+		Aggregation: view.LastValue(),
+	}
+
 	// PipelineErrorView aggregates the PipelineError metric measurements.
 	// Definition here must exactly match the definition in the resource-group
 	// controller, or the Prometheus exporter will error. b/247516388
@@ -74,6 +88,41 @@ var (
 		Aggregation: view.Distribution(longDistributionBounds...),
 	}
 
+	// ReconcileCyclesView aggregates the ReconcileCycles metric measurements.
+	ReconcileCyclesView = &view.View{
+		Name:        ReconcileCycles.Name() + "_total",
+		Measure:     ReconcileCycles,
+		Description: "The total number of completed reconcile cycles",
+		Aggregation: view.Count(),
+	}
+
+	// SuccessfulReconcileCyclesView aggregates the SuccessfulReconcileCycles
+	// metric measurements.
+	SuccessfulReconcileCyclesView = &view.View{
+		Name:        SuccessfulReconcileCycles.Name() + "_total",
+		Measure:     SuccessfulReconcileCycles,
+		Description: "The total number of reconcile cycles which completed without errors",
+		Aggregation: view.Count(),
+	}
+
+	// TriggersView aggregates the Triggers metric measurements.
+	TriggersView = &view.View{
+		Name:        Triggers.Name() + "_total",
+		Measure:     Triggers,
+		Description: "The total number of runs of the parse-apply-watch loop by trigger and result",
+		TagKeys:     []tag.Key{KeyTrigger, KeyStatus},
+		Aggregation: view.Count(),
+	}
+
+	// SyncStageDurationView aggregates the SyncStageDuration metric measurements.
+	SyncStageDurationView = &view.View{
+		Name:        SyncStageDuration.Name(),
+		Measure:     SyncStageDuration,
+		Description: "The latency distribution of each stage of a sync",
+		TagKeys:     []tag.Key{KeySyncStage, KeyStatus},
+		Aggregation: view.Distribution(stageDistributionBounds...),
+	}
+
 	// LastSyncTimestampView aggregates the LastSyncTimestamp metric measurements.
 	LastSyncTimestampView = &view.View{
 		Name:        LastSync.Name(),
@@ -110,6 +159,14 @@ var (
 		Aggregation: view.Distribution(longDistributionBounds...),
 	}
 
+	// PrunedObjectsView aggregates the PrunedObjects metric measurements.
+	PrunedObjectsView = &view.View{
+		Name:        PrunedObjects.Name() + "_total",
+		Measure:     PrunedObjects,
+		Description: "The total number of objects pruned by the applier",
+		TagKeys:     []tag.Key{KeyType},
+		Aggregation: view.Sum(),
+	}
 	// LastApplyTimestampView aggregates the LastApplyTimestamp metric measurements.
 	LastApplyTimestampView = &view.View{
 		Name:        LastApply.Name(),
@@ -145,6 +202,15 @@ var (
 		Aggregation: view.Count(),
 	}
 
+	// ManagementConflictsView aggregates the ManagementConflicts metric measurements.
+	ManagementConflictsView = &view.View{
+		Name:        ManagementConflicts.Name() + "_total",
+		Measure:     ManagementConflicts,
+		Description: "The total number of management conflicts detected between reconcilers which declare the same resources",
+		TagKeys:     []tag.Key{KeyCurrentManagerScope, KeyDesiredManagerScope},
+		Aggregation: view.Count(),
+	}
+
 	// InternalErrorsView aggregates the InternalErrors metric measurements.
 	InternalErrorsView = &view.View{
 		Name:        InternalErrors.Name() + "_total",
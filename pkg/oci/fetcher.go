@@ -16,8 +16,10 @@ package oci
 
 import (
 	"archive/tar"
+	"crypto/tls"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -47,14 +49,35 @@ func authenticator(authType string, logger *utillog.Logger) (authenticator authn
 	return nil, nil
 }
 
+// ClientCertTransport returns an http.RoundTripper that presents the client
+// certificate and private key stored in the provided files for mutual TLS.
+// The key pair is loaded on every handshake, so that a rotated certificate is
+// picked up without restarting the process.
+func ClientCertTransport(certFile, keyFile string) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+			}
+			return &cert, nil
+		},
+	}
+	return transport
+}
+
 // FetchPackage fetches the package from the OCI repository and write it to the destination.
-func FetchPackage(ctx context.Context, logger *utillog.Logger, authType, imageName, ociRoot, rev string) error {
+// Additional remote options, such as a custom transport, are used when pulling the image.
+func FetchPackage(ctx context.Context, logger *utillog.Logger, authType, imageName, ociRoot, rev string, options ...remote.Option) error {
 	auth, err := authenticator(authType, logger)
 	if err != nil {
 		return fmt.Errorf("failed to get the authentication with type %q: %w", authType, err)
 	}
 
-	image, err := PullImage(imageName, remote.WithContext(ctx), remote.WithAuth(auth))
+	options = append([]remote.Option{remote.WithContext(ctx), remote.WithAuth(auth)}, options...)
+	image, err := PullImage(imageName, options...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,64 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateWithAdmission dry-run applies the objects through the admission
+// chain of the cluster, including the validating webhooks, like those of
+// OPA Gatekeeper, and reports each rejected object as a SourceError.
+//
+// Other errors, like a missing Namespace or CRD, are only logged, because the
+// object may be created by the same commit, and the applier reports them if
+// they persist.
+//
+// The objects are applied with the fieldManager of the applier, so that the
+// webhooks see the same requests as when the objects are applied.
+func validateWithAdmission(ctx context.Context, c client.Client, fieldManager string, objs []ast.FileObject) status.MultiError {
+	var errs status.MultiError
+	for _, obj := range objs {
+		u := obj.Unstructured.DeepCopy()
+		u.SetResourceVersion("")
+		err := c.Patch(ctx, u, client.Apply, client.DryRunAll,
+			client.ForceOwnership, client.FieldOwner(fieldManager))
+		switch {
+		case err == nil:
+		case apierrors.IsForbidden(err) || apierrors.IsInvalid(err):
+			errs = status.Append(errs, admissionRejectedError(obj, err))
+		default:
+			klog.V(3).Infof("Skipped validating %s with the admission chain: %v", core.GKNN(obj), err)
+		}
+	}
+	return errs
+}
+
+// admissionRejectedError reports that the admission chain of the cluster
+// rejected the object, when spec.override.validateWithAdmission is true.
+func admissionRejectedError(obj ast.FileObject, err error) status.Error {
+	return status.SourceError.
+		Sprintf("%s %q was rejected by the admission chain of the cluster, so the commit is not applied: %v. "+
+			"Fix the object, or unset spec.override.validateWithAdmission.",
+			obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err).
+		BuildWithResources(obj)
+}
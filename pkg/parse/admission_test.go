@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// admissionClient is a client whose patches fail with the error configured
+// for the name of the patched object, like an admission chain rejecting it.
+type admissionClient struct {
+	client.Client
+	errs    map[string]error
+	patched []string
+	// fieldManagers are the field managers of the patches.
+	fieldManagers []string
+}
+
+func (c *admissionClient) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	options := &client.PatchOptions{}
+	options.ApplyOptions(opts)
+	if patch != client.Apply || len(options.DryRun) != 1 || options.DryRun[0] != metav1.DryRunAll {
+		return apierrors.NewBadRequest("only dry-run apply patches are expected")
+	}
+	c.patched = append(c.patched, obj.GetName())
+	c.fieldManagers = append(c.fieldManagers, options.FieldManager)
+	return c.errs[obj.GetName()]
+}
+
+func TestValidateWithAdmission(t *testing.T) {
+	roleGR := schema.GroupResource{Group: kinds.Role().Group, Resource: "roles"}
+	deniedErr := apierrors.NewForbidden(roleGR, "denied",
+		errors.New(`admission webhook "validation.gatekeeper.sh" denied the request`))
+	invalidErr := apierrors.NewInvalid(kinds.Role().GroupKind(), "invalid", nil)
+	missingNamespaceErr := apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "foo")
+
+	allowed := fake.RoleAtPath("namespaces/foo/allowed.yaml", core.Name("allowed"), core.Namespace("foo"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/allowed.yaml"))
+	denied := fake.RoleAtPath("namespaces/foo/denied.yaml", core.Name("denied"), core.Namespace("foo"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/denied.yaml"))
+	invalid := fake.RoleAtPath("namespaces/foo/invalid.yaml", core.Name("invalid"), core.Namespace("foo"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/invalid.yaml"))
+	unknown := fake.RoleAtPath("namespaces/bar/unknown.yaml", core.Name("unknown"), core.Namespace("bar"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/bar/unknown.yaml"))
+
+	c := &admissionClient{
+		Client: syncertestfake.NewClient(t, core.Scheme),
+		errs: map[string]error{
+			"denied":  deniedErr,
+			"invalid": invalidErr,
+			"unknown": missingNamespaceErr,
+		},
+	}
+	errs := validateWithAdmission(context.Background(), c, "team-a-sync", []ast.FileObject{allowed, denied, invalid, unknown})
+
+	// Only the objects rejected by the admission chain are reported.
+	var want status.MultiError
+	want = status.Append(want, admissionRejectedError(denied, deniedErr))
+	want = status.Append(want, admissionRejectedError(invalid, invalidErr))
+	testutil.AssertEqual(t, want, errs)
+	assert.Equal(t, []string{"allowed", "denied", "invalid", "unknown"}, c.patched)
+	// The objects are validated with the configured field manager.
+	assert.Equal(t, []string{"team-a-sync", "team-a-sync", "team-a-sync", "team-a-sync"}, c.fieldManagers)
+	assert.Contains(t, errs.Error(), "namespaces/foo/denied.yaml")
+}
@@ -59,3 +59,13 @@ func addAnnotationsAndLabels(objs []ast.FileObject, scope declared.Scope, syncNa
 	}
 	return nil
 }
+
+// addSyncGenerationAnnotation annotates the objects with the generation of the
+// RootSync or RepoSync. The annotation is added after the declared fields are
+// encoded, so that it is not one of the declared fields, and a new generation
+// does not change the declared-fields annotation of every object.
+func addSyncGenerationAnnotation(objs []ast.FileObject, generation int64) {
+	for _, obj := range objs {
+		core.SetAnnotation(obj, metadata.SyncGenerationAnnotationKey, fmt.Sprint(generation))
+	}
+}
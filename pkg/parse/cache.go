@@ -20,6 +20,8 @@ import (
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
 )
@@ -47,6 +49,14 @@ type cacheForCommit struct {
 	// objsToApply contains the objects which will be sent to the applier to apply.
 	objsToApply []ast.FileObject
 
+	// implicitNamespaces contains the sorted names of the implicit Namespaces
+	// in objsToApply.
+	implicitNamespaces []string
+
+	// managedNamespaceCount is the number of distinct Namespaces of the
+	// namespace-scoped objects in objsToApply.
+	managedNamespaceCount int
+
 	// parserErrs includes the parser errors.
 	parserErrs status.MultiError
 
@@ -58,6 +68,10 @@ type cacheForCommit struct {
 	// declared resources.
 	applied bool
 
+	// applyErrs are the non-blocking errors of the last apply, which are
+	// reported until the declared resources are applied again.
+	applyErrs status.MultiError
+
 	// watchesUpdated indicates whether the remediator watches have been updated
 	// for the latest declared resources.
 	watchesUpdated bool
@@ -73,6 +87,8 @@ func (c *cacheForCommit) setParserResult(objs []ast.FileObject, parserErrs statu
 	knownScopeObjs, unknownScopeObjs := splitObjects(objs)
 	c.objsSkipped = unknownScopeObjs
 	c.objsToApply = knownScopeObjs
+	c.implicitNamespaces = implicitNamespaces(knownScopeObjs)
+	c.managedNamespaceCount = managedNamespaceCount(knownScopeObjs)
 	c.parserErrs = parserErrs
 	c.hasParserResult = true
 }
@@ -85,6 +101,32 @@ func (c *cacheForCommit) parserResultUpToDate() bool {
 	return c.hasParserResult && len(c.objsSkipped) == 0 && c.parserErrs == nil
 }
 
+// implicitNamespaces returns the sorted names of the implicit Namespaces in
+// `objs`. Implicit Namespaces are the only objects which are not read from a
+// file in the source, so they are identified by their empty source path.
+func implicitNamespaces(objs []ast.FileObject) []string {
+	var namespaces []string
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().GroupKind() == kinds.Namespace().GroupKind() && obj.Relative == cmpath.RelativeOS("") {
+			namespaces = append(namespaces, obj.GetName())
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// managedNamespaceCount returns the number of distinct Namespaces of the
+// namespace-scoped objects in `objs`.
+func managedNamespaceCount(objs []ast.FileObject) int {
+	namespaces := make(map[string]struct{})
+	for _, obj := range objs {
+		if ns := obj.GetNamespace(); ns != "" {
+			namespaces[ns] = struct{}{}
+		}
+	}
+	return len(namespaces)
+}
+
 // splitObjects splits `objs` into two groups: the objects whose scope is known, and the objects whose scope is unknown.
 func splitObjects(objs []ast.FileObject) ([]ast.FileObject, []ast.FileObject) {
 	var knownScopeObjs, unknownScopeObjs []ast.FileObject
@@ -0,0 +1,136 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// circuitBreakerMaxProbePeriod is the longest period between two probes of an
+// open circuit breaker.
+const circuitBreakerMaxProbePeriod = 10 * time.Minute
+
+// circuitBreaker pauses syncing after repeated syncs fail because the API
+// server is unavailable or overloaded, so that the reconciler does not add to
+// the load of a degraded API server. While open, one sync is allowed per probe
+// period to check whether the API server has recovered. The probe period
+// doubles after each failed probe, up to circuitBreakerMaxProbePeriod.
+//
+// A nil circuitBreaker is always closed.
+type circuitBreaker struct {
+	// threshold is the number of consecutive syncs failing with API server
+	// errors which opens the circuit breaker.
+	threshold int
+	// probePeriod is the initial period between two probes.
+	probePeriod time.Duration
+
+	// failures is the number of consecutive syncs failing with API server
+	// errors.
+	failures int
+	// open is true if syncing is paused.
+	open bool
+	// nextProbePeriod is the period before the probe after the next one.
+	nextProbePeriod time.Duration
+	// nextProbe is when the next sync is allowed while open.
+	nextProbe time.Time
+	// errs are the errors of the last sync, which are reported while open.
+	errs status.MultiError
+}
+
+// CircuitBreakerOptions configures the circuit breaker of the Parser.
+type CircuitBreakerOptions struct {
+	// Threshold is the number of consecutive syncs failing with API server
+	// errors after which the Parser pauses syncing, and only probes the API
+	// server every ProbePeriod, backing off exponentially. Zero disables the
+	// circuit breaker.
+	Threshold int
+	// ProbePeriod is the initial period between two probes while syncing is
+	// paused by the circuit breaker.
+	ProbePeriod time.Duration
+}
+
+// newCircuitBreaker returns a circuitBreaker which opens after
+// opts.Threshold consecutive syncs fail with API server errors, or nil if
+// opts.Threshold is not positive.
+func newCircuitBreaker(opts CircuitBreakerOptions) *circuitBreaker {
+	if opts.Threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		threshold:   opts.Threshold,
+		probePeriod: opts.ProbePeriod,
+	}
+}
+
+// isOpen returns whether syncing is paused.
+func (c *circuitBreaker) isOpen() bool {
+	return c != nil && c.open
+}
+
+// untilProbe returns how long to wait before the next sync is allowed, which
+// is zero if the circuit breaker is closed or a probe is due.
+func (c *circuitBreaker) untilProbe(now time.Time) time.Duration {
+	if !c.isOpen() || !now.Before(c.nextProbe) {
+		return 0
+	}
+	return c.nextProbe.Sub(now)
+}
+
+// record updates the circuit breaker with the errors of a sync.
+func (c *circuitBreaker) record(errs status.MultiError, now time.Time) {
+	if c == nil {
+		return
+	}
+	if !status.HasAPIServerUnavailableErrors(errs) {
+		if c.open {
+			klog.Infof("Circuit breaker closed: the API server has recovered")
+		}
+		c.failures = 0
+		c.open = false
+		c.errs = nil
+		return
+	}
+	c.failures++
+	c.errs = errs
+	switch {
+	case c.open:
+		c.nextProbe = now.Add(c.nextProbePeriod)
+		klog.Infof("Circuit breaker probe failed: the next probe is in %v", c.nextProbePeriod)
+		c.nextProbePeriod = min(2*c.nextProbePeriod, c.maxProbePeriod())
+	case c.failures >= c.threshold:
+		c.open = true
+		c.nextProbe = now.Add(c.probePeriod)
+		c.nextProbePeriod = min(2*c.probePeriod, c.maxProbePeriod())
+		klog.Warningf("Circuit breaker opened after %d consecutive syncs failed with API server errors: the next probe is in %v",
+			c.failures, c.probePeriod)
+	}
+}
+
+// message returns the message of the APIServerUnavailable condition.
+func (c *circuitBreaker) message() string {
+	if !c.isOpen() {
+		return ""
+	}
+	return fmt.Sprintf("Syncing is paused after %d consecutive syncs failed with API server errors, the next probe is at %s",
+		c.failures, c.nextProbe.UTC().Format(time.RFC3339))
+}
+
+func (c *circuitBreaker) maxProbePeriod() time.Duration {
+	return max(c.probePeriod, circuitBreakerMaxProbePeriod)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	unavailableErr := status.APIServerError(apierrors.NewServiceUnavailable("overloaded"), "failed to apply")
+	notFoundErr := status.APIServerError(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cm"), "failed to get")
+	cmID := core.ID{GroupKind: kinds.ConfigMap().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "cm"}}
+	applyUnavailableErr := applier.ErrorForResource(apierrors.NewTooManyRequests("overloaded", 1), cmID, "")
+	applyInvalidErr := applier.ErrorForResource(apierrors.NewBadRequest("invalid"), cmID, "")
+	now := time.Now()
+
+	c := newCircuitBreaker(CircuitBreakerOptions{Threshold: 2, ProbePeriod: time.Minute})
+	assert.False(t, c.isOpen())
+
+	// API server errors caused by the request do not count.
+	c.record(notFoundErr, now)
+	assert.False(t, c.isOpen())
+	assert.Equal(t, 0, c.failures)
+
+	// Neither do applier errors caused by the request.
+	c.record(applyInvalidErr, now)
+	assert.False(t, c.isOpen())
+	assert.Equal(t, 0, c.failures)
+
+	// The circuit breaker opens after threshold consecutive failures, whether
+	// they are returned by the API server or wrapped by the applier.
+	c.record(unavailableErr, now)
+	assert.False(t, c.isOpen())
+	c.record(applyUnavailableErr, now)
+	assert.True(t, c.isOpen())
+	assert.Equal(t, time.Minute, c.untilProbe(now))
+	assert.Equal(t, 30*time.Second, c.untilProbe(now.Add(30*time.Second)))
+	assert.Equal(t, time.Duration(0), c.untilProbe(now.Add(time.Minute)))
+	assert.Contains(t, c.message(), "after 2 consecutive syncs failed")
+
+	// The probe period doubles after each failed probe, up to the max.
+	now = now.Add(time.Minute)
+	c.record(unavailableErr, now)
+	assert.Equal(t, 2*time.Minute, c.untilProbe(now))
+	for i := 0; i < 5; i++ {
+		now = now.Add(c.untilProbe(now))
+		c.record(unavailableErr, now)
+	}
+	assert.Equal(t, circuitBreakerMaxProbePeriod, c.untilProbe(now))
+
+	// A successful probe closes the circuit breaker.
+	c.record(nil, now)
+	assert.False(t, c.isOpen())
+	assert.Equal(t, time.Duration(0), c.untilProbe(now))
+	assert.Empty(t, c.message())
+	assert.Nil(t, c.errs)
+
+	// The failures are counted again from zero.
+	c.record(unavailableErr, now)
+	assert.False(t, c.isOpen())
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	c := newCircuitBreaker(CircuitBreakerOptions{ProbePeriod: time.Minute})
+	assert.Nil(t, c)
+	c.record(status.APIServerError(errors.New("connection refused"), "failed to apply"), time.Now())
+	assert.False(t, c.isOpen())
+	assert.Equal(t, time.Duration(0), c.untilProbe(time.Now()))
+	assert.Empty(t, c.message())
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"strings"
+
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate"
+)
+
+const (
+	// ClusterNameToken is replaced with the cluster name in the string field
+	// values of the objects from the source, when enabled.
+	ClusterNameToken = "${CLUSTER_NAME}"
+
+	// escapedClusterNameToken is replaced with a literal ClusterNameToken.
+	escapedClusterNameToken = "$" + ClusterNameToken
+)
+
+// clusterNameVisitor returns a visitor which replaces ClusterNameToken with the
+// cluster name in the string field values of the objects. The apiVersion,
+// kind, metadata.name and metadata.namespace fields are left unchanged, since
+// they identify the object and have already been validated.
+func clusterNameVisitor(clusterName string) validate.VisitorFunc {
+	// At each position, the escaped token is checked first, so `$${CLUSTER_NAME}`
+	// becomes a literal `${CLUSTER_NAME}` instead of `$<cluster name>`.
+	replacer := strings.NewReplacer(
+		escapedClusterNameToken, ClusterNameToken,
+		ClusterNameToken, clusterName)
+	return func(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+		for _, obj := range objs {
+			for key, value := range obj.Object {
+				switch key {
+				case "apiVersion", "kind":
+					continue
+				case "metadata":
+					if metadata, ok := value.(map[string]interface{}); ok {
+						for field, fieldValue := range metadata {
+							if field == "name" || field == "namespace" {
+								continue
+							}
+							metadata[field] = substitute(fieldValue, replacer)
+						}
+					}
+				default:
+					obj.Object[key] = substitute(value, replacer)
+				}
+			}
+		}
+		return objs, nil
+	}
+}
+
+// substitute recursively applies the replacer to the string values in the
+// given unstructured value. Map keys are left unchanged.
+func substitute(value interface{}, replacer *strings.Replacer) interface{} {
+	switch v := value.(type) {
+	case string:
+		if strings.Contains(v, ClusterNameToken) {
+			return replacer.Replace(v)
+		}
+		return v
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = substitute(item, replacer)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = substitute(item, replacer)
+		}
+		return v
+	default:
+		return v
+	}
+}
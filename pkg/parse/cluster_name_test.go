@@ -0,0 +1,163 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/kinds"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"kpt.dev/configsync/pkg/testing/openapitest"
+)
+
+func TestClusterNameVisitor(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			name: "data values",
+			in: map[string]interface{}{
+				"region":  "${CLUSTER_NAME}",
+				"url":     "https://${CLUSTER_NAME}.example.com/${CLUSTER_NAME}",
+				"unset":   "CLUSTER_NAME",
+				"escaped": "$${CLUSTER_NAME}",
+				"mixed":   "$${CLUSTER_NAME}=${CLUSTER_NAME}",
+			},
+			want: map[string]interface{}{
+				"region":  "prod-1",
+				"url":     "https://prod-1.example.com/prod-1",
+				"unset":   "CLUSTER_NAME",
+				"escaped": "${CLUSTER_NAME}",
+				"mixed":   "${CLUSTER_NAME}=prod-1",
+			},
+		},
+		{
+			name: "nested values and lists",
+			in: map[string]interface{}{
+				"${CLUSTER_NAME}": []interface{}{"${CLUSTER_NAME}", int64(1), map[string]interface{}{"name": "${CLUSTER_NAME}"}},
+			},
+			want: map[string]interface{}{
+				"${CLUSTER_NAME}": []interface{}{"prod-1", int64(1), map[string]interface{}{"name": "prod-1"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := fake.ConfigMap(core.Name("cm-${CLUSTER_NAME}"), core.Namespace("foo"),
+				core.Label("cluster", "${CLUSTER_NAME}"))
+			require.NoError(t, unstructured.SetNestedField(obj.Object, tc.in, "data"))
+
+			got, errs := clusterNameVisitor("prod-1")([]ast.FileObject{obj})
+			require.Nil(t, errs)
+			require.Len(t, got, 1)
+			assert.Equal(t, tc.want, got[0].Object["data"])
+			assert.Equal(t, "prod-1", got[0].GetLabels()["cluster"])
+			// The identifying fields are left unchanged.
+			assert.Equal(t, "cm-${CLUSTER_NAME}", got[0].GetName())
+			assert.Equal(t, "foo", got[0].GetNamespace())
+			assert.Equal(t, kinds.ConfigMap(), got[0].GroupVersionKind())
+		})
+	}
+}
+
+func TestRoot_ParseSubstituteClusterName(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		substituteClusterName bool
+		clusterName           string
+		want                  string
+	}{
+		{
+			name:                  "substituted when enabled",
+			substituteClusterName: true,
+			clusterName:           "prod-1",
+			want:                  "prod-1",
+		},
+		{
+			name:        "unchanged when disabled",
+			clusterName: "prod-1",
+			want:        "${CLUSTER_NAME}",
+		},
+		{
+			name:                  "unchanged without a cluster name",
+			substituteClusterName: true,
+			want:                  "${CLUSTER_NAME}",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			converter, err := openapitest.ValueConverterForTest()
+			require.NoError(t, err)
+			cm := fake.ConfigMap(core.Name("cluster-info"), core.Namespace("foo"))
+			require.NoError(t, unstructured.SetNestedField(cm.Object, "${CLUSTER_NAME}", "data", "cluster"))
+
+			parser := &root{
+				Options: &Options{
+					Parser: &fakeParser{parse: []ast.FileObject{
+						fake.Namespace("namespaces/foo"),
+						cm,
+					}},
+					ClusterName:           tc.clusterName,
+					SubstituteClusterName: tc.substituteClusterName,
+					SyncName:              rootSyncName,
+					ReconcilerName:        rootReconcilerName,
+					Client:                syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+					DiscoveryInterface:    syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.ConfigMap()),
+					Converter:             converter,
+					Updater: Updater{
+						Scope:      declared.RootReconciler,
+						Resources:  &declared.Resources{},
+						Remediator: &noOpRemediator{},
+						Applier:    &fakeApplier{},
+					},
+					mux: &sync.Mutex{},
+				},
+				RootOptions: &RootOptions{
+					SourceFormat:      filesystem.SourceFormatUnstructured,
+					NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+				},
+			}
+			state := reconcilerState{}
+			require.NoError(t, parseAndUpdate(context.Background(), parser, triggerReimport, &state))
+
+			var found bool
+			for _, obj := range state.cache.objsToApply {
+				if obj.GetName() != "cluster-info" {
+					continue
+				}
+				found = true
+				value, _, err := unstructured.NestedString(obj.Object, "data", "cluster")
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, value)
+			}
+			assert.True(t, found, "expected the ConfigMap to be applied")
+		})
+	}
+}
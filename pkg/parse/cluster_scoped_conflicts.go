@@ -0,0 +1,126 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterScopedID identifies a cluster-scoped object in an inventory.
+type clusterScopedID struct {
+	schema.GroupKind
+	Name string
+}
+
+// clusterScopedConflicts returns a ManagementConflictError for each
+// cluster-scoped object declared by the RootSync, which is also declared by
+// another RootSync. The objects declared by the other RootSyncs are read from
+// their inventory ResourceGroups.
+func clusterScopedConflicts(ctx context.Context, c client.Client, syncName string, objs []ast.FileObject) status.MultiError {
+	rgList := &unstructured.UnstructuredList{}
+	rgList.SetGroupVersionKind(kinds.ResourceGroup().GroupVersion().WithKind(kinds.ResourceGroup().Kind + "List"))
+	if err := c.List(ctx, rgList, client.InNamespace(configmanagement.ControllerNamespace),
+		client.MatchingLabels{metadata.SyncKindLabel: configsync.RootSyncKind}); err != nil {
+		if meta.IsNoMatchError(err) {
+			// No inventory has been created yet.
+			return nil
+		}
+		return status.APIServerError(err, "failed to list the inventories of the other RootSyncs")
+	}
+
+	declaredBy := make(map[clusterScopedID]string)
+	for _, rg := range rgList.Items {
+		if rg.GetName() == syncName {
+			continue
+		}
+		resources, _, err := unstructured.NestedSlice(rg.Object, "spec", "resources")
+		if err != nil {
+			klog.Warningf("Skipping the malformed inventory of RootSync %q: %v", rg.GetName(), err)
+			continue
+		}
+		for _, r := range resources {
+			res, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if ns, _, _ := unstructured.NestedString(res, "namespace"); ns != "" {
+				continue
+			}
+			group, _, _ := unstructured.NestedString(res, "group")
+			kind, _, _ := unstructured.NestedString(res, "kind")
+			name, _, _ := unstructured.NestedString(res, "name")
+			declaredBy[clusterScopedID{GroupKind: schema.GroupKind{Group: group, Kind: kind}, Name: name}] = rg.GetName()
+		}
+	}
+
+	var errs status.MultiError
+	newManager := declared.ResourceManager(declared.RootReconciler, syncName)
+	for _, obj := range objs {
+		if obj.GetNamespace() != "" {
+			continue
+		}
+		id := clusterScopedID{GroupKind: obj.GetObjectKind().GroupVersionKind().GroupKind(), Name: obj.GetName()}
+		other, found := declaredBy[id]
+		if !found {
+			continue
+		}
+		handedOff, err := isHandedOffTo(ctx, c, obj, syncName)
+		if err != nil {
+			errs = status.Append(errs, err)
+			continue
+		}
+		if handedOff {
+			// The other RootSync releases the resource once it is removed
+			// from its source.
+			continue
+		}
+		currentManager := declared.ResourceManager(declared.RootReconciler, other)
+		errs = status.Append(errs, status.ManagementConflictErrorBuilder.
+			Sprintf("The cluster-scoped resource is also declared by the RootSync %q. "+
+				"Remove the declaration for this resource from either the current repository, or the repository synced by the RootSync %q.",
+				other, other).
+			BuildWithConflictingManagers(obj, newManager, currentManager))
+	}
+	return errs
+}
+
+// isHandedOffTo returns whether the handoff-to annotation of the resource on
+// the cluster names the RootSync.
+func isHandedOffTo(ctx context.Context, c client.Client, obj ast.FileObject, syncName string) (bool, status.Error) {
+	uObj := &unstructured.Unstructured{}
+	uObj.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), uObj); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, status.APIServerError(err, "failed to get the resource declared by another RootSync", obj)
+	}
+	return core.GetAnnotation(uObj, metadata.HandoffToAnnotationKey) == syncName, nil
+}
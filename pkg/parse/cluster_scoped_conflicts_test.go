@@ -0,0 +1,231 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	resourcegroupv1alpha1 "kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/resourcegroup"
+	"kpt.dev/configsync/pkg/status"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"kpt.dev/configsync/pkg/testing/openapitest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rootSyncInventory returns the inventory ResourceGroup of a RootSync which
+// declares the specified objects.
+func rootSyncInventory(t *testing.T, syncName string, objs ...ast.FileObject) *unstructured.Unstructured {
+	rg := resourcegroup.Unstructured(syncName, configmanagement.ControllerNamespace,
+		applier.InventoryID(syncName, configmanagement.ControllerNamespace))
+	core.SetLabel(rg, metadata.SyncKindLabel, configsync.RootSyncKind)
+	var resources []interface{}
+	for _, obj := range objs {
+		gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+		resources = append(resources, map[string]interface{}{
+			"group":     gk.Group,
+			"kind":      gk.Kind,
+			"namespace": obj.GetNamespace(),
+			"name":      obj.GetName(),
+		})
+	}
+	require.NoError(t, unstructured.SetNestedSlice(rg.Object, resources, "spec", "resources"))
+	return rg
+}
+
+func TestRoot_ParseClusterScopedConflicts(t *testing.T) {
+	const otherSyncName = "other-sync"
+	otherInventory := func(t *testing.T) *unstructured.Unstructured {
+		return rootSyncInventory(t, otherSyncName,
+			fake.ClusterRole(core.Name("shared")),
+			fake.Role(core.Name("shared"), core.Namespace("foo")))
+	}
+
+	testCases := []struct {
+		name          string
+		conflictCheck bool
+		inventories   func(t *testing.T) []*unstructured.Unstructured
+		clusterObjs   []client.Object
+		parsed        []ast.FileObject
+		wantConflicts []string
+	}{
+		{
+			name:          "cluster-scoped object declared by another RootSync",
+			conflictCheck: true,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return []*unstructured.Unstructured{otherInventory(t)}
+			},
+			parsed: []ast.FileObject{
+				fake.ClusterRole(core.Name("shared")),
+				fake.ClusterRole(core.Name("unique")),
+			},
+			wantConflicts: []string{"shared"},
+		},
+		{
+			name:          "cluster-scoped object handed off by another RootSync",
+			conflictCheck: true,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return []*unstructured.Unstructured{otherInventory(t)}
+			},
+			clusterObjs: []client.Object{
+				fake.ClusterRoleObject(core.Name("shared"),
+					core.Annotation(metadata.HandoffToAnnotationKey, rootSyncName)),
+			},
+			parsed: []ast.FileObject{
+				fake.ClusterRole(core.Name("shared")),
+			},
+		},
+		{
+			name:          "cluster-scoped object handed off to a third RootSync",
+			conflictCheck: true,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return []*unstructured.Unstructured{otherInventory(t)}
+			},
+			clusterObjs: []client.Object{
+				fake.ClusterRoleObject(core.Name("shared"),
+					core.Annotation(metadata.HandoffToAnnotationKey, "third-sync")),
+			},
+			parsed: []ast.FileObject{
+				fake.ClusterRole(core.Name("shared")),
+			},
+			wantConflicts: []string{"shared"},
+		},
+		{
+			name:          "namespaced object declared by another RootSync",
+			conflictCheck: true,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return []*unstructured.Unstructured{otherInventory(t)}
+			},
+			parsed: []ast.FileObject{
+				fake.Role(core.Name("shared"), core.Namespace("foo")),
+			},
+		},
+		{
+			name:          "cluster-scoped object only declared by the current RootSync",
+			conflictCheck: true,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return []*unstructured.Unstructured{
+					rootSyncInventory(t, rootSyncName, fake.ClusterRole(core.Name("shared"))),
+				}
+			},
+			parsed: []ast.FileObject{
+				fake.ClusterRole(core.Name("shared")),
+			},
+		},
+		{
+			name:          "no inventories",
+			conflictCheck: true,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return nil
+			},
+			parsed: []ast.FileObject{
+				fake.ClusterRole(core.Name("shared")),
+			},
+		},
+		{
+			name:          "conflict check disabled",
+			conflictCheck: false,
+			inventories: func(t *testing.T) []*unstructured.Unstructured {
+				return []*unstructured.Unstructured{otherInventory(t)}
+			},
+			parsed: []ast.FileObject{
+				fake.ClusterRole(core.Name("shared")),
+			},
+		},
+	}
+
+	converter, err := openapitest.ValueConverterForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The ResourceGroup type is not registered in core.Scheme.
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	require.NoError(t, resourcegroupv1alpha1.AddToScheme(scheme))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := syncertest.NewClient(t, scheme, fake.RootSyncObjectV1Beta1(rootSyncName))
+			for _, rg := range tc.inventories(t) {
+				require.NoError(t, fakeClient.Create(context.Background(), rg))
+			}
+			for _, obj := range tc.clusterObjs {
+				require.NoError(t, fakeClient.Create(context.Background(), obj))
+			}
+			parser := &root{
+				Options: &Options{
+					Parser:             &fakeParser{parse: tc.parsed},
+					SyncName:           rootSyncName,
+					ReconcilerName:     rootReconcilerName,
+					Client:             fakeClient,
+					DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role(), kinds.ClusterRole()),
+					Converter:          converter,
+					Updater: Updater{
+						Scope:      declared.RootReconciler,
+						Resources:  &declared.Resources{},
+						Remediator: &noOpRemediator{},
+						Applier:    &fakeApplier{},
+					},
+					mux: &sync.Mutex{},
+				},
+				RootOptions: &RootOptions{
+					SourceFormat:               filesystem.SourceFormatUnstructured,
+					NamespaceStrategy:          configsync.NamespaceStrategyImplicit,
+					ClusterScopedConflictCheck: tc.conflictCheck,
+				},
+			}
+			objs, errs := parser.parseSource(context.Background(), sourceState{})
+			if len(tc.wantConflicts) == 0 {
+				require.NoError(t, errs)
+				assert.NotEmpty(t, objs)
+				return
+			}
+			// Conflicts block the sync.
+			assert.Nil(t, objs)
+			require.True(t, status.HasBlockingErrors(errs))
+			var gotConflicts []string
+			for _, e := range errs.Errors() {
+				var conflictErr status.ManagementConflictError
+				require.ErrorAs(t, e, &conflictErr)
+				assert.Equal(t, status.ManagementConflictErrorCode, conflictErr.Code())
+				assert.Equal(t, declared.ResourceManager(declared.RootReconciler, otherSyncName), conflictErr.ConflictingManager())
+				gotConflicts = append(gotConflicts, conflictErr.ToCSE().Resources[0].Name)
+			}
+			assert.Equal(t, tc.wantConflicts, gotConflicts)
+		})
+	}
+}
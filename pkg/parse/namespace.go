@@ -21,16 +21,19 @@ import (
 	"sync"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reposync"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncstatus"
 	"kpt.dev/configsync/pkg/util/compare"
 	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
@@ -76,6 +79,9 @@ func (p *namespace) parseSource(ctx context.Context, state sourceState) ([]ast.F
 	if err != nil {
 		return nil, err
 	}
+	if len(state.dirs) > 0 {
+		objs = mergeSyncDirs(state.syncDir, state.dirs, objs, p.DirsCollisionPolicy)
+	}
 
 	options := validate.Options{
 		ClusterName:  p.ClusterName,
@@ -84,12 +90,23 @@ func (p *namespace) parseSource(ctx context.Context, state sourceState) ([]ast.F
 		PreviousCRDs: crds,
 		BuildScoper:  builder,
 		Converter:    p.Converter,
+		// The declared fields are only needed by the admission webhook.
+		SkipDeclaredFields: !p.WebhookEnabled,
 		// Namespaces and NamespaceSelectors should not be declared in a namespace repo.
 		// So disable the API call and dynamic mode of NamespaceSelector.
 		AllowAPICall:             false,
 		DynamicNSSelectorEnabled: false,
 	}
 	options = OptionsForScope(options, p.Scope)
+	if p.ObjectSelector != nil && !p.ObjectSelector.Empty() {
+		options.Visitors = append(options.Visitors, objectSelectorVisitor(p.ObjectSelector))
+	}
+	if p.SubstituteClusterName && p.ClusterName != "" {
+		options.Visitors = append(options.Visitors, clusterNameVisitor(p.ClusterName))
+	}
+	if len(p.RequiredMetadata) > 0 {
+		options.Visitors = append(options.Visitors, requiredMetadataVisitor(p.RequiredMetadata))
+	}
 
 	objs, err = validate.Unstructured(ctx, p.Client, objs, options)
 
@@ -103,6 +120,9 @@ func (p *namespace) parseSource(ctx context.Context, state sourceState) ([]ast.F
 		err = status.Append(err, status.InternalErrorf("unable to add annotations and labels: %v", e))
 		return nil, err
 	}
+	if p.AnnotateSyncGeneration {
+		addSyncGenerationAnnotation(objs, p.SyncGeneration)
+	}
 	return objs, err
 }
 
@@ -140,6 +160,7 @@ func (p *namespace) setSourceStatusWithRetries(ctx context.Context, newStatus so
 		errorSource = []v1beta1.ErrorSource{v1beta1.SourceError}
 	}
 	reposync.SetSyncing(&rs, continueSyncing, "Source", "Source", newStatus.commit, errorSource, rs.Status.Source.ErrorSummary, newStatus.lastUpdate)
+	setBlockingStatusSummary(&rs.Status.Status, rs.Status.Source.ErrorSummary, newStatus.commit)
 
 	// Avoid unnecessary status updates.
 	if !currentRS.Status.Source.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
@@ -149,6 +170,7 @@ func (p *namespace) setSourceStatusWithRetries(ctx context.Context, newStatus so
 
 	csErrs := status.ToCSE(newStatus.errs)
 	metrics.RecordReconcilerErrors(ctx, "source", csErrs)
+	metrics.RecordSourceFetchErrors(ctx, csErrs)
 	metrics.RecordPipelineError(ctx, configsync.RepoSyncName, "source", len(csErrs))
 	if len(csErrs) > 0 {
 		klog.Infof("New source errors for RepoSync %s/%s: %+v",
@@ -186,6 +208,15 @@ func (p *namespace) setRequiresRendering(ctx context.Context, renderingRequired
 	return p.Client.Patch(ctx, rs, client.MergeFrom(existing))
 }
 
+// resyncToken implements the Parser interface
+func (p *namespace) resyncToken(ctx context.Context) (string, error) {
+	rs := &v1beta1.RepoSync{}
+	if err := p.syncReader().Get(ctx, reposync.ObjectKey(p.Scope, p.SyncName), rs); err != nil {
+		return "", status.APIServerError(err, "failed to get RepoSync for Parser")
+	}
+	return core.GetAnnotation(rs, metadata.ResyncTokenAnnotationKey), nil
+}
+
 // setRenderingStatus implements the Parser interface
 func (p *namespace) setRenderingStatus(ctx context.Context, oldStatus, newStatus renderingStatus) error {
 	if oldStatus.equal(newStatus) {
@@ -217,6 +248,7 @@ func (p *namespace) setRenderingStatusWithRetires(ctx context.Context, newStatus
 		errorSource = []v1beta1.ErrorSource{v1beta1.RenderingError}
 	}
 	reposync.SetSyncing(&rs, continueSyncing, "Rendering", newStatus.message, newStatus.commit, errorSource, rs.Status.Rendering.ErrorSummary, newStatus.lastUpdate)
+	setBlockingStatusSummary(&rs.Status.Status, rs.Status.Rendering.ErrorSummary, newStatus.commit)
 
 	// Avoid unnecessary status updates.
 	if !currentRS.Status.Rendering.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
@@ -271,8 +303,10 @@ func (p *namespace) setSyncStatusWithRetries(ctx context.Context, newStatus sync
 
 	setSyncStatusFields(&rs.Status.Status, newStatus, denominator)
 
-	errorSources, errorSummary := summarizeErrors(rs.Status.Source, rs.Status.Sync)
-	if newStatus.syncing {
+	errorSources, errorSummary := syncstatus.SummarizeErrors(rs.Status.Source, rs.Status.Rendering, rs.Status.Sync)
+	if newStatus.paused {
+		reposync.SetSyncing(rs, false, "Paused", "Applying is paused", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
+	} else if newStatus.syncing {
 		reposync.SetSyncing(rs, true, "Sync", "Syncing", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
 	} else {
 		if errorSummary.TotalCount == 0 {
@@ -280,6 +314,12 @@ func (p *namespace) setSyncStatusWithRetries(ctx context.Context, newStatus sync
 		}
 		reposync.SetSyncing(rs, false, "Sync", "Sync Completed", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
 	}
+	if newStatus.apiServerUnavailable != "" {
+		reposync.SetAPIServerUnavailable(rs, "CircuitBreakerOpen", newStatus.apiServerUnavailable)
+	} else {
+		reposync.RemoveCondition(rs, v1beta1.RepoSyncAPIServerUnavailable)
+	}
+	setSyncStatusSummary(&rs.Status.Status, newStatus, errorSummary)
 
 	// Avoid unnecessary status updates.
 	if !currentRS.Status.Sync.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
@@ -331,3 +371,34 @@ func (p *namespace) Syncing() bool {
 func (p *namespace) K8sClient() client.Client {
 	return p.Client
 }
+
+// prependRepoSyncRemediatorStatus adds the conflict error detected by the remediator to the front of the sync errors of the RepoSync.
+func prependRepoSyncRemediatorStatus(ctx context.Context, client client.Client, syncScope declared.Scope, syncName string, conflictErrs []status.ManagementConflictError, denominator int) error {
+	if denominator <= 0 {
+		return fmt.Errorf("The denominator must be a positive number")
+	}
+
+	var rs v1beta1.RepoSync
+	if err := client.Get(ctx, reposync.ObjectKey(syncScope, syncName), &rs); err != nil {
+		return status.APIServerError(err, fmt.Sprintf("failed to get RepoSync: %s/%s", syncScope, syncName))
+	}
+
+	errs := prependConflictErrors(rs.Status.Sync.Errors, conflictErrs)
+	// No new errors, so no update
+	if errs == nil {
+		return nil
+	}
+	setSyncStatusErrors(&rs.Status.Status, errs, denominator)
+	setStatusSummary(&rs.Status.Status, configsync.SyncStateError, rs.Status.Sync.Commit)
+	rs.Status.Sync.LastUpdate = metav1.Now()
+
+	if err := client.Status().Update(ctx, &rs); err != nil {
+		// If the update failure was caused by the size of the RepoSync object, we would truncate the errors and retry.
+		if isRequestTooLargeError(err) {
+			klog.Infof("Failed to update RepoSync sync status (total error count: %d, denominator: %d): %s.", rs.Status.Sync.ErrorSummary.TotalCount, denominator, err)
+			return prependRepoSyncRemediatorStatus(ctx, client, syncScope, syncName, conflictErrs, denominator*2)
+		}
+		return status.APIServerError(err, "failed to update RepoSync sync status")
+	}
+	return nil
+}
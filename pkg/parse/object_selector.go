@@ -0,0 +1,64 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate"
+)
+
+// objectSelectorVisitor returns a visitor which disables the management of the
+// objects whose labels do not match the selector, so that they are neither
+// applied nor watched for drift. The applier ignores them, unless they were
+// applied before and no longer match, in which case they are removed from the
+// inventory and abandoned on the cluster, rather than pruned.
+//
+// Unselected Namespaces which contain selected objects are dropped instead, so
+// that the selected objects are not rejected for being in an unmanaged
+// Namespace.
+func objectSelectorVisitor(selector labels.Selector) validate.VisitorFunc {
+	return func(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+		selectedNamespaces := make(map[string]bool)
+		for _, obj := range objs {
+			if obj.GetNamespace() != "" && selector.Matches(labels.Set(obj.GetLabels())) {
+				selectedNamespaces[obj.GetNamespace()] = true
+			}
+		}
+		result := make([]ast.FileObject, 0, len(objs))
+		var ignored int
+		for _, obj := range objs {
+			if selector.Matches(labels.Set(obj.GetLabels())) {
+				result = append(result, obj)
+				continue
+			}
+			if obj.GetObjectKind().GroupVersionKind() == kinds.Namespace() && selectedNamespaces[obj.GetName()] {
+				continue
+			}
+			ignored++
+			core.SetAnnotation(obj, metadata.ResourceManagementKey, metadata.ResourceManagementDisabled)
+			result = append(result, obj)
+		}
+		if ignored > 0 {
+			klog.V(3).Infof("Ignoring %d objects which do not match the object selector %q", ignored, selector)
+		}
+		return result, nil
+	}
+}
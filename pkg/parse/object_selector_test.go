@@ -0,0 +1,189 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"kpt.dev/configsync/pkg/testing/openapitest"
+)
+
+func TestObjectSelectorVisitor(t *testing.T) {
+	prod := func() ast.FileObject {
+		return fake.Role(core.Name("prod"), core.Namespace("foo"), core.Label("env", "prod"))
+	}
+	dev := func() ast.FileObject {
+		return fake.Role(core.Name("dev"), core.Namespace("foo"), core.Label("env", "dev"))
+	}
+	unlabeled := func() ast.FileObject {
+		return fake.Role(core.Name("unlabeled"), core.Namespace("foo"))
+	}
+	namespace := func() ast.FileObject {
+		return fake.Namespace("namespaces/foo")
+	}
+
+	testCases := []struct {
+		name         string
+		selector     string
+		objs         []ast.FileObject
+		wantEnabled  []string
+		wantDisabled []string
+	}{
+		{
+			name:         "equality selector",
+			selector:     "env=prod",
+			objs:         []ast.FileObject{prod(), dev(), unlabeled()},
+			wantEnabled:  []string{"prod"},
+			wantDisabled: []string{"dev", "unlabeled"},
+		},
+		{
+			name:         "set-based selector",
+			selector:     "env in (prod, dev)",
+			objs:         []ast.FileObject{prod(), dev(), unlabeled()},
+			wantEnabled:  []string{"prod", "dev"},
+			wantDisabled: []string{"unlabeled"},
+		},
+		{
+			name:         "inequality selector matches unlabeled objects",
+			selector:     "env!=prod",
+			objs:         []ast.FileObject{prod(), dev(), unlabeled()},
+			wantEnabled:  []string{"dev", "unlabeled"},
+			wantDisabled: []string{"prod"},
+		},
+		{
+			name:         "exists selector",
+			selector:     "env",
+			objs:         []ast.FileObject{prod(), dev(), unlabeled()},
+			wantEnabled:  []string{"prod", "dev"},
+			wantDisabled: []string{"unlabeled"},
+		},
+		{
+			name:         "no match",
+			selector:     "env=staging",
+			objs:         []ast.FileObject{prod(), dev(), unlabeled()},
+			wantDisabled: []string{"prod", "dev", "unlabeled"},
+		},
+		{
+			name:        "unselected Namespace of selected objects is dropped",
+			selector:    "env=prod",
+			objs:        []ast.FileObject{namespace(), prod()},
+			wantEnabled: []string{"prod"},
+		},
+		{
+			name:         "unselected Namespace without selected objects is disabled",
+			selector:     "env=staging",
+			objs:         []ast.FileObject{namespace(), prod()},
+			wantDisabled: []string{"foo", "prod"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := labels.Parse(tc.selector)
+			require.NoError(t, err)
+			got, errs := objectSelectorVisitor(selector)(tc.objs)
+			require.Nil(t, errs)
+			var gotEnabled, gotDisabled []string
+			for _, obj := range got {
+				if core.GetAnnotation(obj, metadata.ResourceManagementKey) == metadata.ResourceManagementDisabled {
+					gotDisabled = append(gotDisabled, obj.GetName())
+				} else {
+					gotEnabled = append(gotEnabled, obj.GetName())
+				}
+			}
+			assert.Equal(t, tc.wantEnabled, gotEnabled)
+			assert.Equal(t, tc.wantDisabled, gotDisabled)
+		})
+	}
+}
+
+func TestRoot_ParseObjectSelector(t *testing.T) {
+	converter, err := openapitest.ValueConverterForTest()
+	require.NoError(t, err)
+	selector, err := labels.Parse("cluster=prod")
+	require.NoError(t, err)
+
+	parser := &root{
+		Options: &Options{
+			Parser: &fakeParser{parse: []ast.FileObject{
+				fake.RoleAtPath("foo/prod.yaml", core.Name("prod"), core.Namespace("foo"), core.Label("cluster", "prod")),
+				fake.RoleAtPath("bar/dev.yaml", core.Name("dev"), core.Namespace("bar"), core.Label("cluster", "dev")),
+				fake.ClusterRoleAtPath("cluster/unlabeled.yaml", core.Name("unlabeled")),
+			}},
+			SyncName:           rootSyncName,
+			ReconcilerName:     rootReconcilerName,
+			Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+			DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role(), kinds.ClusterRole()),
+			Converter:          converter,
+			ObjectSelector:     selector,
+			Updater: Updater{
+				Scope:      declared.RootReconciler,
+				Resources:  &declared.Resources{},
+				Remediator: &noOpRemediator{},
+				Applier:    &fakeApplier{},
+			},
+			mux: &sync.Mutex{},
+		},
+		RootOptions: &RootOptions{
+			SourceFormat:      filesystem.SourceFormatUnstructured,
+			NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+		},
+	}
+	state := reconcilerState{}
+	require.NoError(t, parseAndUpdate(context.Background(), parser, triggerReimport, &state))
+
+	// Only the selected Role is applied, with the implicit Namespace it needs.
+	// No implicit Namespace is added for the unselected Role.
+	// The unselected objects are passed to the applier with management
+	// disabled, so that they are abandoned instead of pruned if they were
+	// applied before.
+	var gotEnabled, gotDisabled []string
+	for _, obj := range state.cache.objsToApply {
+		if core.GetAnnotation(obj, metadata.ResourceManagementKey) == metadata.ResourceManagementDisabled {
+			gotDisabled = append(gotDisabled, core.GKNN(obj))
+		} else {
+			gotEnabled = append(gotEnabled, core.GKNN(obj))
+		}
+	}
+	sort.Strings(gotEnabled)
+	sort.Strings(gotDisabled)
+	wantEnabled := []string{
+		core.GKNN(fake.NamespaceObject("foo")),
+		core.GKNN(fake.RoleObject(core.Name("prod"), core.Namespace("foo"))),
+	}
+	sort.Strings(wantEnabled)
+	wantDisabled := []string{
+		core.GKNN(fake.RoleObject(core.Name("dev"), core.Namespace("bar"))),
+		core.GKNN(fake.ClusterRoleObject(core.Name("unlabeled"))),
+	}
+	sort.Strings(wantDisabled)
+	assert.Equal(t, wantEnabled, gotEnabled)
+	assert.Equal(t, wantDisabled, gotDisabled)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/util/discovery"
+)
+
+// OfflineOptions configures ParseOffline.
+type OfflineOptions struct {
+	// Scope is the scope of the reconciler to parse the source for. Defaults
+	// to the root reconciler. Namespace scopes always use the unstructured
+	// source format.
+	Scope declared.Scope
+
+	// SyncName is the name of the RootSync or RepoSync object.
+	SyncName string
+
+	// ClusterName is the name of the cluster used for cluster selection.
+	ClusterName string
+
+	// SourceFormat is the format of the source. Only used by the root
+	// reconciler scope.
+	SourceFormat filesystem.SourceFormat
+
+	// NamespaceStrategy is the strategy for Namespaces which are used by the
+	// declared objects but not declared themselves. Only used by the root
+	// reconciler scope with the unstructured source format. Since no cluster is
+	// available, every undeclared Namespace is treated as missing.
+	NamespaceStrategy configsync.NamespaceStrategy
+
+	// Commit is the commit to annotate the parsed objects with. Optional.
+	Commit string
+
+	// DiscoveryInterface reports the resource types available for scoping
+	// the declared objects. If unset, only the built-in Kubernetes types and
+	// the CRDs declared in the source are known.
+	DiscoveryInterface discovery.ServerResourcer
+
+	// Converter encodes the declared fields of the parsed objects. If unset,
+	// the declared fields are not computed.
+	Converter *declared.ValueConverter
+}
+
+// ParseOffline parses and validates the source in the given directory the
+// same way a reconciler does, without talking to a cluster. It returns the
+// objects the reconciler would apply, along with any errors. Objects are only
+// returned if none of the errors are blocking.
+//
+// This allows tools such as CI pipelines to validate a checked out source
+// before it is merged.
+func ParseOffline(ctx context.Context, dir cmpath.Absolute, opts OfflineOptions) ([]ast.FileObject, status.MultiError) {
+	files, err := listFiles(dir, map[string]bool{".git": true})
+	if err != nil {
+		return nil, status.PathWrapError(errors.Wrap(err, "listing files in the configs directory"), dir.OSPath())
+	}
+	state := sourceState{
+		commit:  opts.Commit,
+		syncDir: dir,
+		files:   files,
+	}
+
+	scope := opts.Scope
+	if scope == "" {
+		scope = declared.RootReconciler
+	}
+	dc := opts.DiscoveryInterface
+	if dc == nil {
+		dc = discovery.NoOpServerResourcer{}
+	}
+	options := &Options{
+		Parser:             filesystem.NewParser(&reader.File{}),
+		ClusterName:        opts.ClusterName,
+		SyncName:           opts.SyncName,
+		DiscoveryInterface: dc,
+		Converter:          opts.Converter,
+		WebhookEnabled:     opts.Converter != nil,
+		Files: Files{FileSource: FileSource{
+			SourceDir: dir,
+			SyncDir:   cmpath.RelativeOS(dir.OSPath()),
+		}},
+		Updater: Updater{
+			Scope:     scope,
+			Resources: &declared.Resources{},
+		},
+		mux: &sync.Mutex{},
+	}
+
+	var parser Parser
+	if scope == declared.RootReconciler {
+		sourceFormat := opts.SourceFormat
+		if sourceFormat == "" {
+			sourceFormat = filesystem.SourceFormatHierarchy
+		}
+		parser = &root{
+			Options: options,
+			RootOptions: &RootOptions{
+				SourceFormat:      sourceFormat,
+				NamespaceStrategy: opts.NamespaceStrategy,
+			},
+		}
+	} else {
+		parser = &namespace{Options: options}
+	}
+	return parser.parseSource(ctx, state)
+}
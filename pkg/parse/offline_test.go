@@ -0,0 +1,152 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/status"
+)
+
+const offlineNamespace = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: foo
+`
+
+const offlineRole = `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: reader
+  namespace: foo
+`
+
+const offlineRepo = `apiVersion: configmanagement.gke.io/v1
+kind: Repo
+metadata:
+  name: repo
+spec:
+  version: "1.0.0"
+`
+
+func TestParseOffline(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   map[string]string
+		opts    OfflineOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "hierarchy source",
+			files: map[string]string{
+				"system/repo.yaml":         offlineRepo,
+				"namespaces/foo/ns.yaml":   offlineNamespace,
+				"namespaces/foo/rbac.yaml": offlineRole,
+			},
+			opts: OfflineOptions{
+				SyncName:     configsync.RootSyncName,
+				SourceFormat: filesystem.SourceFormatHierarchy,
+			},
+			want: []string{"Namespace//foo", "Role/foo/reader"},
+		},
+		{
+			name: "unstructured source with implicit namespace",
+			files: map[string]string{
+				"rbac.yaml": offlineRole,
+			},
+			opts: OfflineOptions{
+				SyncName:          configsync.RootSyncName,
+				SourceFormat:      filesystem.SourceFormatUnstructured,
+				NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+			},
+			want: []string{"Namespace//foo", "Role/foo/reader"},
+		},
+		{
+			name: "unstructured source with explicit namespace strategy",
+			files: map[string]string{
+				"rbac.yaml": offlineRole,
+			},
+			opts: OfflineOptions{
+				SyncName:          configsync.RootSyncName,
+				SourceFormat:      filesystem.SourceFormatUnstructured,
+				NamespaceStrategy: configsync.NamespaceStrategyExplicit,
+			},
+			wantErr: status.SourceErrorCode,
+		},
+		{
+			name: "namespace scope",
+			files: map[string]string{
+				"rbac.yaml": offlineRole,
+			},
+			opts: OfflineOptions{
+				Scope:    declared.Scope("foo"),
+				SyncName: configsync.RepoSyncName,
+			},
+			want: []string{"Role/foo/reader"},
+		},
+		{
+			name: "namespace scope rejects other namespaces",
+			files: map[string]string{
+				"ns.yaml": offlineNamespace,
+			},
+			opts: OfflineOptions{
+				Scope:    declared.Scope("bar"),
+				SyncName: configsync.RepoSyncName,
+			},
+			wantErr: "1058",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for path, content := range tc.files {
+				path = filepath.Join(dir, path)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+			absDir, err := cmpath.AbsoluteOS(dir)
+			require.NoError(t, err)
+			absDir, err = absDir.EvalSymlinks()
+			require.NoError(t, err)
+
+			objs, errs := ParseOffline(context.Background(), absDir, tc.opts)
+			if tc.wantErr != "" {
+				require.Error(t, errs)
+				require.Contains(t, errs.Error(), "KNV"+tc.wantErr)
+				return
+			}
+			require.NoError(t, errs)
+			var got []string
+			for _, obj := range objs {
+				got = append(got, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetNamespace()+"/"+obj.GetName())
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
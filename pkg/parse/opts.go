@@ -19,6 +19,10 @@ import (
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem"
@@ -40,6 +44,11 @@ type Options struct {
 	// status.
 	Client client.Client
 
+	// SyncReader reads the RootSync or RepoSync object from the informer cache
+	// of the controller manager, so that polling the annotations set by users
+	// does not query the API server. Defaults to Client.
+	SyncReader client.Reader
+
 	// ReconcilerName is the name of the reconciler resources, such as service
 	// account, service, deployment and etc.
 	ReconcilerName string
@@ -47,10 +56,19 @@ type Options struct {
 	// SyncName is the name of the RootSync or RepoSync object.
 	SyncName string
 
+	// SyncGeneration is the generation of the RootSync or RepoSync object.
+	SyncGeneration int64
+
 	// PollingPeriod is the period of time between checking the filesystem for
 	// source updates to sync.
 	PollingPeriod time.Duration
 
+	// ApplyDebouncePeriod is the period of time to wait after detecting a new
+	// commit before parsing and applying it. The commits detected within the
+	// period are coalesced, and only the latest one is applied.
+	// Zero applies every commit immediately.
+	ApplyDebouncePeriod time.Duration
+
 	// ResyncPeriod is the period of time between forced re-sync from source
 	// (even without a new commit).
 	ResyncPeriod time.Duration
@@ -62,6 +80,14 @@ type Options struct {
 	// sync status, to account for management conflict errors from the Remediator.
 	StatusUpdatePeriod time.Duration
 
+	// RenderingTimeout is how long the Parser waits for the rendering of a new
+	// commit before reporting the rendering as failed. Zero waits indefinitely.
+	RenderingTimeout time.Duration
+
+	// CircuitBreaker configures pausing syncing after repeated API server
+	// errors.
+	CircuitBreaker CircuitBreakerOptions
+
 	// DiscoveryInterface is how the Parser learns what types are currently
 	// available on the cluster.
 	DiscoveryInterface discovery.ServerResourcer
@@ -77,6 +103,52 @@ type Options struct {
 	// running for this reconciler.
 	RenderingEnabled bool
 
+	// PauseApply indicates whether applying is paused. If true, the declared
+	// resources are tracked for drift, but not applied.
+	PauseApply bool
+
+	// ObserveOnly indicates whether the RootSync is in observe mode. If true,
+	// the declared resources are compared with the cluster, but never applied,
+	// and the admission webhook is not configured.
+	ObserveOnly bool
+
+	// WebhookEnabled indicates whether the Config Sync admission webhook is
+	// enabled, protecting the declared fields of the managed objects.
+	WebhookEnabled bool
+
+	// RequiredMetadata is the list of labels and annotations which every
+	// managed object declared in the source must have.
+	RequiredMetadata []v1beta1.RequiredMetadata
+
+	// AnnotateSyncGeneration indicates whether to annotate the managed objects
+	// with the SyncGeneration.
+	AnnotateSyncGeneration bool
+
+	// ValidateWithAdmission indicates whether to dry-run apply the parsed
+	// objects through the admission chain of the cluster before applying
+	// them, and to report the rejected objects as source errors.
+	ValidateWithAdmission bool
+
+	// FieldManager is the field manager name used to apply the managed
+	// objects with server-side apply.
+	FieldManager string
+
+	// ObjectSelector limits the parsed objects which are applied. The objects
+	// whose labels do not match are marked as management disabled while
+	// parsing, so they are neither applied nor pruned. The applier ignores
+	// them, unless they are still in the inventory.
+	// Nil or empty selects all the objects.
+	ObjectSelector labels.Selector
+
+	// SubstituteClusterName indicates whether to replace the `${CLUSTER_NAME}`
+	// token in the string field values of the parsed objects with
+	// ClusterName.
+	SubstituteClusterName bool
+
+	// Readiness reports whether the latest source commit is synced without
+	// blocking errors. Optional.
+	Readiness *Readiness
+
 	// Files lists Files in the source of truth.
 	Files
 	// Updater mutates the most-recently-seen versions of objects stored in memory.
@@ -99,12 +171,27 @@ type Parser interface {
 	K8sClient() client.Client
 	// setRequiresRendering sets the requires-rendering annotation on the RSync
 	setRequiresRendering(ctx context.Context, renderingRequired bool) error
+	// resyncToken returns the resync-token annotation on the RSync
+	resyncToken(ctx context.Context) (string, error)
 }
 
 func (o *Options) k8sClient() client.Client {
 	return o.Client
 }
 
+func (o *Options) syncReader() client.Reader {
+	if o.SyncReader != nil {
+		return o.SyncReader
+	}
+	return o.Client
+}
+
 func (o *Options) discoveryClient() discovery.ServerResourcer {
 	return o.DiscoveryInterface
 }
+
+// jitteredStatusUpdatePeriod returns the StatusUpdatePeriod with a random
+// jitter added, to avoid synchronized status updates across many reconcilers.
+func (o *Options) jitteredStatusUpdatePeriod() time.Duration {
+	return wait.Jitter(o.StatusUpdatePeriod, configsync.ReconcilerSyncStatusUpdateJitterFactor)
+}
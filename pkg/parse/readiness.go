@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// Readiness tracks whether the latest source commit is synced without
+// blocking errors, to report the readiness of the reconciler.
+// It is safe for concurrent use.
+type Readiness struct {
+	mux sync.RWMutex
+	err error
+}
+
+// NewReadiness returns a Readiness which is not ready until the first sync
+// completes.
+func NewReadiness() *Readiness {
+	return &Readiness{
+		err: errors.New("no source commit has been synced yet"),
+	}
+}
+
+// Check implements healthz.Checker. It returns an error unless the latest
+// source commit is synced without blocking errors.
+func (r *Readiness) Check(_ *http.Request) error {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return r.err
+}
+
+// update sets the readiness from the latest source status, and the source and
+// sync status of the reconciler state.
+func (r *Readiness) update(state *reconcilerState, latest sourceStatus) {
+	var err error
+	switch {
+	case latest.errs != nil:
+		err = errors.Errorf("failed to fetch the latest source commit: %s",
+			status.FormatSingleLine(latest.errs))
+	case state.syncStatus.commit != latest.commit:
+		err = errors.Errorf("latest source commit %q is not synced yet", latest.commit)
+	case state.syncStatus.syncing:
+		err = errors.Errorf("latest source commit %q is still syncing", latest.commit)
+	case state.sourceStatus.commit == latest.commit && status.HasBlockingErrors(state.sourceStatus.errs):
+		err = errors.Errorf("latest source commit %q has source errors: %s",
+			latest.commit, status.FormatSingleLine(state.sourceStatus.errs))
+	case status.HasBlockingErrors(state.syncStatus.errs):
+		err = errors.Errorf("latest source commit %q has sync errors: %s",
+			latest.commit, status.FormatSingleLine(state.syncStatus.errs))
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.err = err
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestReadiness(t *testing.T) {
+	testCases := []struct {
+		name      string
+		state     reconcilerState
+		latest    sourceStatus
+		wantReady bool
+	}{
+		{
+			name:   "source error",
+			latest: sourceStatus{errs: status.SourceError.Sprintf("source error").Build()},
+		},
+		{
+			name: "latest commit not synced",
+			state: reconcilerState{
+				syncStatus: syncStatus{commit: "abc"},
+			},
+			latest: sourceStatus{commit: "def"},
+		},
+		{
+			name: "latest commit still syncing",
+			state: reconcilerState{
+				syncStatus: syncStatus{commit: "abc", syncing: true},
+			},
+			latest: sourceStatus{commit: "abc"},
+		},
+		{
+			name: "latest commit has source errors",
+			state: reconcilerState{
+				sourceStatus: sourceStatus{commit: "abc", errs: status.SourceError.Sprintf("source error").Build()},
+				syncStatus:   syncStatus{commit: "abc"},
+			},
+			latest: sourceStatus{commit: "abc"},
+		},
+		{
+			name: "latest commit has blocking sync errors",
+			state: reconcilerState{
+				syncStatus: syncStatus{commit: "abc", errs: status.InternalError("internal error")},
+			},
+			latest: sourceStatus{commit: "abc"},
+		},
+		{
+			name: "latest commit has non-blocking sync errors",
+			state: reconcilerState{
+				syncStatus: syncStatus{commit: "abc", errs: status.UnknownObjectKindError(fake.NamespaceObject("foo"))},
+			},
+			latest:    sourceStatus{commit: "abc"},
+			wantReady: true,
+		},
+		{
+			name: "latest commit synced",
+			state: reconcilerState{
+				sourceStatus: sourceStatus{commit: "abc"},
+				syncStatus:   syncStatus{commit: "abc"},
+			},
+			latest:    sourceStatus{commit: "abc"},
+			wantReady: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			readiness := NewReadiness()
+			assert.Error(t, readiness.Check(nil), "not ready before the first sync")
+
+			readiness.update(&tc.state, tc.latest)
+			if tc.wantReady {
+				assert.NoError(t, readiness.Check(nil))
+			} else {
+				assert.Error(t, readiness.Check(nil))
+			}
+		})
+	}
+}
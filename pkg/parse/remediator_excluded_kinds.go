@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ParseRemediatorExcludedKinds parses a comma-separated list of `Kind.group`
+// resource types, like `Event.example.com`, or `Kind` for the core group,
+// which the remediator does not watch.
+func ParseRemediatorExcludedKinds(value string) (map[schema.GroupKind]struct{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+	excluded := make(map[schema.GroupKind]struct{})
+	for _, gkString := range strings.Split(value, ",") {
+		gk := schema.ParseGroupKind(gkString)
+		if gk.Kind == "" {
+			return nil, fmt.Errorf("invalid remediator excluded kind %q: must be formatted as <kind>.<group>", gkString)
+		}
+		excluded[gk] = struct{}{}
+	}
+	return excluded, nil
+}
+
+// withoutExcludedKinds returns the GVKs whose GroupKind is not excluded.
+func withoutExcludedKinds(gvks map[schema.GroupVersionKind]struct{}, excluded map[schema.GroupKind]struct{}) map[schema.GroupVersionKind]struct{} {
+	if len(excluded) == 0 {
+		return gvks
+	}
+	watched := make(map[schema.GroupVersionKind]struct{}, len(gvks))
+	for gvk := range gvks {
+		if _, found := excluded[gvk.GroupKind()]; !found {
+			watched[gvk] = struct{}{}
+		}
+	}
+	return watched
+}
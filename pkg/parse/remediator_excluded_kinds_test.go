@@ -0,0 +1,78 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/kinds"
+)
+
+func TestParseRemediatorExcludedKinds(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		want    map[schema.GroupKind]struct{}
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "core and named groups",
+			value: "ConfigMap,Event.example.com",
+			want: map[schema.GroupKind]struct{}{
+				{Kind: "ConfigMap"}:                   {},
+				{Group: "example.com", Kind: "Event"}: {},
+			},
+		},
+		{
+			name:    "missing kind",
+			value:   "ConfigMap,.example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRemediatorExcludedKinds(tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUpdater_WatchExcludesKinds(t *testing.T) {
+	eventGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Event"}
+	rem := &noOpRemediator{}
+	u := &Updater{
+		Remediator:              rem,
+		RemediatorExcludedKinds: map[schema.GroupKind]struct{}{eventGVK.GroupKind(): {}},
+	}
+
+	err := u.watch(context.Background(), map[schema.GroupVersionKind]struct{}{
+		kinds.ConfigMap(): {},
+		eventGVK:          {},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[schema.GroupVersionKind]struct{}{kinds.ConfigMap(): {}}, rem.watches)
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate"
+)
+
+// ParseRequiredMetadata parses a comma-separated list of `<type>:<key>` pairs,
+// where type is either "label" or "annotation", into the required labels and
+// annotations.
+func ParseRequiredMetadata(value string) ([]v1beta1.RequiredMetadata, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var required []v1beta1.RequiredMetadata
+	for _, pair := range strings.Split(value, ",") {
+		typ, key, found := strings.Cut(pair, ":")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid required metadata %q: must be formatted as <type>:<key>", pair)
+		}
+		switch t := configsync.RequiredMetadataType(typ); t {
+		case configsync.RequiredMetadataLabel, configsync.RequiredMetadataAnnotation:
+			required = append(required, v1beta1.RequiredMetadata{Key: key, Type: t})
+		default:
+			return nil, fmt.Errorf("invalid required metadata %q: type must be %q or %q",
+				pair, configsync.RequiredMetadataLabel, configsync.RequiredMetadataAnnotation)
+		}
+	}
+	return required, nil
+}
+
+// requiredMetadataVisitor returns a visitor which reports a SourceError for
+// each object missing one of the required labels or annotations.
+func requiredMetadataVisitor(required []v1beta1.RequiredMetadata) validate.VisitorFunc {
+	return func(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+		var errs status.MultiError
+		for _, obj := range objs {
+			for _, r := range required {
+				var found bool
+				switch r.Type {
+				case configsync.RequiredMetadataLabel:
+					_, found = obj.GetLabels()[r.Key]
+				case configsync.RequiredMetadataAnnotation:
+					_, found = obj.GetAnnotations()[r.Key]
+				}
+				if !found {
+					errs = status.Append(errs, missingRequiredMetadataError(obj, r))
+				}
+			}
+		}
+		return objs, errs
+	}
+}
+
+// missingRequiredMetadataError reports that the object is missing a label or
+// annotation required by spec.override.requiredMetadata.
+func missingRequiredMetadataError(obj ast.FileObject, required v1beta1.RequiredMetadata) status.Error {
+	return status.SourceError.
+		Sprintf("%s %q is missing the required %s %q. "+
+			"Add the %s to the object, or remove it from spec.override.requiredMetadata.",
+			obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), required.Type, required.Key, required.Type).
+		BuildWithResources(obj)
+}
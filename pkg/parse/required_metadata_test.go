@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+)
+
+func TestParseRequiredMetadata(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		want    []v1beta1.RequiredMetadata
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "labels and annotations",
+			value: "label:cost-center,annotation:example.com/owner",
+			want: []v1beta1.RequiredMetadata{
+				{Key: "cost-center", Type: configsync.RequiredMetadataLabel},
+				{Key: "example.com/owner", Type: configsync.RequiredMetadataAnnotation},
+			},
+		},
+		{
+			name:    "missing type",
+			value:   "cost-center",
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			value:   "label:",
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			value:   "field:cost-center",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRequiredMetadata(tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configmanagement/v1/repo"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
@@ -44,6 +45,8 @@ import (
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/rootsync"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/differ"
+	"kpt.dev/configsync/pkg/syncstatus"
 	"kpt.dev/configsync/pkg/util/compare"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
@@ -71,11 +74,36 @@ type RootOptions struct {
 	// reconciler.
 	NamespaceStrategy configsync.NamespaceStrategy
 
+	// AmbiguousSourceFormat indicates whether a source which signals a
+	// different source format than SourceFormat is reported as a source error,
+	// or only logged as a warning. Only AmbiguousSourceFormatError reports an
+	// error.
+	AmbiguousSourceFormat configsync.AmbiguousSourceFormatPolicy
+
+	// NamespaceMismatchPolicy indicates whether an object in a hierarchical
+	// source whose metadata.namespace differs from its directory is reported
+	// as a source error, or corrected to the namespace of its directory.
+	NamespaceMismatchPolicy configsync.NamespaceMismatchPolicy
+
 	// DynamicNSSelectorEnabled represents whether the NamespaceSelector's dynamic
 	// mode is enabled. If it is enabled, NamespaceSelector will also select
 	// resources matching the on-cluster Namespaces.
 	DynamicNSSelectorEnabled bool
 
+	// DynamicNamespaceSelector represents whether NamespaceSelectors which do
+	// not set a mode use the dynamic mode. The objects selected dynamically are
+	// expanded into the selected Namespaces at parse time, so the declared
+	// resources always reflect the Namespaces matched by the last parse. When
+	// the Namespace controller detects a Namespace being selected or unselected,
+	// it schedules a new parse, which updates the declared resources so the
+	// applier creates or prunes the objects in that Namespace.
+	DynamicNamespaceSelector bool
+
+	// ClusterScopedConflictCheck indicates whether to reject the cluster-scoped
+	// objects which are also declared by another RootSync, according to the
+	// inventories of the other RootSyncs.
+	ClusterScopedConflictCheck bool
+
 	// NSControllerState stores whether the Namespace Controller schedules a sync
 	// event for the reconciler thread, along with the cached NamespaceSelector
 	// and selected namespaces.
@@ -95,8 +123,18 @@ func (p *root) options() *Options {
 
 // parseSource implements the Parser interface
 func (p *root) parseSource(ctx context.Context, state sourceState) ([]ast.FileObject, status.MultiError) {
+	if err := ambiguousSourceFormatError(p.SourceFormat, state.syncDir, state.files); err != nil {
+		if p.AmbiguousSourceFormat == configsync.AmbiguousSourceFormatError {
+			return nil, err
+		}
+		klog.Warning(err)
+	}
+
 	wantFiles := state.files
 	if p.SourceFormat == filesystem.SourceFormatHierarchy {
+		if len(state.dirs) > 0 {
+			return nil, status.SourceError.Sprintf("syncing multiple directories is not supported with the %s source format", filesystem.SourceFormatHierarchy).Build()
+		}
 		// We're using hierarchical mode for the root repository, so ignore files
 		// outside of the allowed directories.
 		wantFiles = filesystem.FilterHierarchyFiles(state.syncDir, wantFiles)
@@ -119,6 +157,9 @@ func (p *root) parseSource(ctx context.Context, state sourceState) ([]ast.FileOb
 	if err != nil {
 		return nil, err
 	}
+	if len(state.dirs) > 0 {
+		objs = mergeSyncDirs(state.syncDir, state.dirs, objs, p.DirsCollisionPolicy)
+	}
 
 	options := validate.Options{
 		ClusterName:  p.ClusterName,
@@ -127,16 +168,37 @@ func (p *root) parseSource(ctx context.Context, state sourceState) ([]ast.FileOb
 		PreviousCRDs: crds,
 		BuildScoper:  builder,
 		Converter:    p.Converter,
-		// Enable API call so NamespaceSelector can talk to k8s-api-server.
-		AllowAPICall:             true,
+		// The declared fields are only needed by the admission webhook.
+		SkipDeclaredFields: !p.WebhookEnabled,
+		// Enable API call so NamespaceSelector can talk to k8s-api-server,
+		// unless parsing offline without a client.
+		AllowAPICall:             p.Client != nil,
 		DynamicNSSelectorEnabled: p.DynamicNSSelectorEnabled,
+		DynamicNamespaceSelector: p.DynamicNamespaceSelector,
 		NSControllerState:        p.NSControllerState,
+		NamespaceMismatchPolicy:  p.NamespaceMismatchPolicy,
 	}
 	options = OptionsForScope(options, p.Scope)
+	if p.ObjectSelector != nil && !p.ObjectSelector.Empty() {
+		// Disable the unselected objects before checking the required
+		// metadata, and before implicit Namespaces are added for the selected
+		// ones.
+		options.Visitors = append(options.Visitors, objectSelectorVisitor(p.ObjectSelector))
+	}
+	if p.SubstituteClusterName && p.ClusterName != "" {
+		options.Visitors = append(options.Visitors, clusterNameVisitor(p.ClusterName))
+	}
+	if len(p.RequiredMetadata) > 0 {
+		// Validate the declared objects before implicit Namespaces are added.
+		options.Visitors = append(options.Visitors, requiredMetadataVisitor(p.RequiredMetadata))
+	}
 
 	if p.SourceFormat == filesystem.SourceFormatUnstructured {
-		if p.NamespaceStrategy == configsync.NamespaceStrategyImplicit {
+		switch p.NamespaceStrategy {
+		case configsync.NamespaceStrategyImplicit:
 			options.Visitors = append(options.Visitors, p.addImplicitNamespaces)
+		case configsync.NamespaceStrategyExplicit:
+			options.Visitors = append(options.Visitors, p.validateExplicitNamespaces)
 		}
 		objs, err = validate.Unstructured(ctx, p.Client, objs, options)
 	} else {
@@ -153,6 +215,15 @@ func (p *root) parseSource(ctx context.Context, state sourceState) ([]ast.FileOb
 		err = status.Append(err, status.InternalErrorf("unable to add annotations and labels: %v", e))
 		return nil, err
 	}
+	if p.AnnotateSyncGeneration {
+		addSyncGenerationAnnotation(objs, p.SyncGeneration)
+	}
+	if p.ClusterScopedConflictCheck {
+		if e := clusterScopedConflicts(ctx, p.Client, p.SyncName, objs); e != nil {
+			err = status.Append(err, e)
+			return nil, err
+		}
+	}
 	return objs, err
 }
 
@@ -183,6 +254,7 @@ func (p *root) setSourceStatusWithRetries(ctx context.Context, newStatus sourceS
 		errorSource = []v1beta1.ErrorSource{v1beta1.SourceError}
 	}
 	rootsync.SetSyncing(&rs, continueSyncing, "Source", "Source", newStatus.commit, errorSource, rs.Status.Source.ErrorSummary, newStatus.lastUpdate)
+	setBlockingStatusSummary(&rs.Status.Status, rs.Status.Source.ErrorSummary, newStatus.commit)
 
 	// Avoid unnecessary status updates.
 	if !currentRS.Status.Source.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
@@ -192,6 +264,7 @@ func (p *root) setSourceStatusWithRetries(ctx context.Context, newStatus sourceS
 
 	csErrs := status.ToCSE(newStatus.errs)
 	metrics.RecordReconcilerErrors(ctx, "source", csErrs)
+	metrics.RecordSourceFetchErrors(ctx, csErrs)
 	metrics.RecordPipelineError(ctx, configsync.RootSyncName, "source", len(csErrs))
 	if len(csErrs) > 0 {
 		klog.Infof("New source errors for RootSync %s/%s: %+v",
@@ -251,6 +324,17 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, p Parser, newStatus sou
 	source.Errors = cse[0 : len(cse)/denominator]
 	source.ErrorSummary = errorSummary
 	source.LastUpdate = newStatus.lastUpdate
+	// Report the health of the configured source separately, so that it stays
+	// clear which source is failing if more sources are supported.
+	source.Sources = []v1beta1.SourceHealth{
+		{
+			SourceType:   p.options().SourceType,
+			Commit:       source.Commit,
+			LastUpdate:   source.LastUpdate,
+			Errors:       source.Errors,
+			ErrorSummary: source.ErrorSummary,
+		},
+	}
 }
 
 func (p *root) setRequiresRendering(ctx context.Context, renderingRequired bool) error {
@@ -268,6 +352,15 @@ func (p *root) setRequiresRendering(ctx context.Context, renderingRequired bool)
 	return p.Client.Patch(ctx, rs, client.MergeFrom(existing))
 }
 
+// resyncToken implements the Parser interface
+func (p *root) resyncToken(ctx context.Context) (string, error) {
+	rs := &v1beta1.RootSync{}
+	if err := p.syncReader().Get(ctx, rootsync.ObjectKey(p.SyncName), rs); err != nil {
+		return "", status.APIServerError(err, "failed to get RootSync for Parser")
+	}
+	return core.GetAnnotation(rs, metadata.ResyncTokenAnnotationKey), nil
+}
+
 // setRenderingStatus implements the Parser interface
 func (p *root) setRenderingStatus(ctx context.Context, oldStatus, newStatus renderingStatus) error {
 	if oldStatus.equal(newStatus) {
@@ -299,6 +392,7 @@ func (p *root) setRenderingStatusWithRetires(ctx context.Context, newStatus rend
 		errorSource = []v1beta1.ErrorSource{v1beta1.RenderingError}
 	}
 	rootsync.SetSyncing(&rs, continueSyncing, "Rendering", newStatus.message, newStatus.commit, errorSource, rs.Status.Rendering.ErrorSummary, newStatus.lastUpdate)
+	setBlockingStatusSummary(&rs.Status.Status, rs.Status.Rendering.ErrorSummary, newStatus.commit)
 
 	// Avoid unnecessary status updates.
 	if !currentRS.Status.Rendering.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
@@ -360,6 +454,11 @@ func setRenderingStatusFields(rendering *v1beta1.RenderingStatus, p Parser, newS
 		rendering.Oci = nil
 	}
 	rendering.Message = newStatus.message
+	rendering.KustomizeVersion = newStatus.kustomizeVersion
+	rendering.HelmVersion = newStatus.helmVersion
+	rendering.Tool = newStatus.tool
+	rendering.SourceChecksum = newStatus.sourceChecksum
+	rendering.Duration = newStatus.duration
 	errorSummary := &v1beta1.ErrorSummary{
 		TotalCount: len(cse),
 		Truncated:  denominator != 1,
@@ -392,15 +491,28 @@ func (p *root) setSyncStatusWithRetries(ctx context.Context, newStatus syncStatu
 
 	setSyncStatusFields(&rs.Status.Status, newStatus, denominator)
 
-	errorSources, errorSummary := summarizeErrors(rs.Status.Source, rs.Status.Sync)
-	if newStatus.syncing {
+	errorSources, errorSummary := syncstatus.SummarizeErrors(rs.Status.Source, rs.Status.Rendering, rs.Status.Sync)
+	if newStatus.paused {
+		rootsync.SetSyncing(rs, false, "Paused", "Applying is paused", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
+	} else if newStatus.observing {
+		rootsync.SetSyncing(rs, newStatus.syncing, "Observe", "Observing drift without applying", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
+	} else if newStatus.syncing {
 		rootsync.SetSyncing(rs, true, "Sync", "Syncing", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
+	} else if newStatus.pendingCount > 0 {
+		rootsync.SetSyncing(rs, true, "Pending", pendingMessage(newStatus.pendingCount),
+			rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
 	} else {
 		if errorSummary.TotalCount == 0 {
 			rs.Status.LastSyncedCommit = rs.Status.Sync.Commit
 		}
 		rootsync.SetSyncing(rs, false, "Sync", "Sync Completed", rs.Status.Sync.Commit, errorSources, errorSummary, rs.Status.Sync.LastUpdate)
 	}
+	if newStatus.apiServerUnavailable != "" {
+		rootsync.SetAPIServerUnavailable(rs, "CircuitBreakerOpen", newStatus.apiServerUnavailable)
+	} else {
+		rootsync.RemoveCondition(rs, v1beta1.RootSyncAPIServerUnavailable)
+	}
+	setSyncStatusSummary(&rs.Status.Status, newStatus, errorSummary)
 
 	// Avoid unnecessary status updates.
 	if !currentRS.Status.Sync.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
@@ -437,10 +549,18 @@ func (p *root) setSyncStatusWithRetries(ctx context.Context, newStatus syncStatu
 
 func setSyncStatusFields(syncStatus *v1beta1.Status, newStatus syncStatus, denominator int) {
 	cse := status.ToCSE(newStatus.errs)
-	syncStatus.Sync.Commit = newStatus.commit
-	syncStatus.Sync.Git = syncStatus.Source.Git
-	syncStatus.Sync.Oci = syncStatus.Source.Oci
-	syncStatus.Sync.Helm = syncStatus.Source.Helm
+	syncStatus.WebhookEnforcing = newStatus.webhookEnforcing
+	// While applying is paused, the new commit is not applied, so keep
+	// reporting the last applied commit and its source.
+	if !newStatus.paused {
+		syncStatus.Sync.Commit = newStatus.commit
+		syncStatus.Sync.Git = syncStatus.Source.Git
+		syncStatus.Sync.Oci = syncStatus.Source.Oci
+		syncStatus.Sync.Helm = syncStatus.Source.Helm
+	}
+	syncStatus.Sync.ImplicitNamespaces = newStatus.implicitNamespaces
+	syncStatus.Sync.ManagedNamespaceCount = newStatus.managedNamespaceCount
+	syncStatus.Sync.FrequentlyEdited = newStatus.frequentlyEdited
 	setSyncStatusErrors(syncStatus, cse, denominator)
 	syncStatus.Sync.LastUpdate = newStatus.lastUpdate
 }
@@ -453,28 +573,58 @@ func setSyncStatusErrors(syncStatus *v1beta1.Status, cse []v1beta1.ConfigSyncErr
 	syncStatus.Sync.Errors = cse[0 : len(cse)/denominator]
 }
 
-// summarizeErrors summarizes the errors from `sourceStatus` and `syncStatus`, and returns an ErrorSource slice and an ErrorSummary.
-func summarizeErrors(sourceStatus v1beta1.SourceStatus, syncStatus v1beta1.SyncStatus) ([]v1beta1.ErrorSource, *v1beta1.ErrorSummary) {
-	var errorSources []v1beta1.ErrorSource
-	if len(sourceStatus.Errors) > 0 {
-		errorSources = append(errorSources, v1beta1.SourceError)
-	}
-	if len(syncStatus.Errors) > 0 {
-		errorSources = append(errorSources, v1beta1.SyncError)
+// setSyncStatusSummary sets the status.summary for a sync status update.
+func setSyncStatusSummary(syncStatus *v1beta1.Status, newStatus syncStatus, errorSummary *v1beta1.ErrorSummary) {
+	var state configsync.SyncState
+	switch {
+	case newStatus.paused:
+		state = configsync.SyncStatePaused
+	case newStatus.observing:
+		state = configsync.SyncStateObserving
+	case newStatus.syncing, newStatus.pendingCount > 0:
+		// Objects pending until their CRD is established are still syncing.
+		state = configsync.SyncStateSyncing
+	case errorSummary.TotalCount > 0:
+		state = configsync.SyncStateError
+	default:
+		state = configsync.SyncStateSynced
+	}
+	setStatusSummary(syncStatus, state, syncStatus.Sync.Commit)
+	if state != configsync.SyncStatePaused && state != configsync.SyncStateSyncing {
+		// The sync completed, with or without errors.
+		syncStatus.Summary.LastSyncTime = syncStatus.Sync.LastUpdate
 	}
+}
 
-	errorSummary := &v1beta1.ErrorSummary{}
-	for _, summary := range []*v1beta1.ErrorSummary{sourceStatus.ErrorSummary, syncStatus.ErrorSummary} {
-		if summary == nil {
-			continue
-		}
-		errorSummary.TotalCount += summary.TotalCount
-		errorSummary.ErrorCountAfterTruncation += summary.ErrorCountAfterTruncation
-		if summary.Truncated {
-			errorSummary.Truncated = true
-		}
+// setBlockingStatusSummary sets the status.summary for a source or rendering
+// status update. Source and rendering errors block the sync, otherwise the
+// reconciler carries on syncing.
+func setBlockingStatusSummary(syncStatus *v1beta1.Status, errorSummary *v1beta1.ErrorSummary, commit string) {
+	state := configsync.SyncStateSyncing
+	if errorSummary != nil && errorSummary.TotalCount > 0 {
+		state = configsync.SyncStateError
 	}
-	return errorSources, errorSummary
+	if commit == "" {
+		commit = syncStatus.Sync.Commit
+	}
+	setStatusSummary(syncStatus, state, commit)
+}
+
+// setStatusSummary sets the status.summary from the source, rendering and sync
+// status. It is called on every status update, so that the summary never falls
+// behind the detailed status. The lastSyncTime is carried over.
+func setStatusSummary(syncStatus *v1beta1.Status, state configsync.SyncState, commit string) {
+	_, errorSummary := syncstatus.SummarizeErrors(syncStatus.Source, syncStatus.Rendering, syncStatus.Sync)
+	summary := &v1beta1.StatusSummary{
+		Version:    v1beta1.StatusSummaryVersion,
+		State:      state,
+		Commit:     commit,
+		ErrorCount: errorSummary.TotalCount,
+	}
+	if syncStatus.Summary != nil {
+		summary.LastSyncTime = syncStatus.Summary.LastSyncTime
+	}
+	syncStatus.Summary = summary
 }
 
 // addImplicitNamespaces hydrates the given FileObjects by injecting implicit
@@ -490,7 +640,9 @@ func (p *root) addImplicitNamespaces(objs []ast.FileObject) ([]ast.FileObject, s
 	for _, o := range objs {
 		if o.GetObjectKind().GroupVersionKind().GroupKind() == kinds.Namespace().GroupKind() {
 			namespaces[o.GetName()] = true
-		} else if o.GetNamespace() != "" && !namespaces[o.GetNamespace()] {
+		} else if o.GetNamespace() != "" && !namespaces[o.GetNamespace()] && !differ.ManagementDisabled(o) {
+			// Objects with management disabled are not applied, so they do
+			// not need their Namespace.
 			// If unset, this ensures the key exists and is false.
 			// Otherwise it has no impact.
 			namespaces[o.GetNamespace()] = false
@@ -504,7 +656,7 @@ func (p *root) addImplicitNamespaces(objs []ast.FileObject) ([]ast.FileObject, s
 			continue
 		}
 		existingNs := &corev1.Namespace{}
-		err := p.Client.Get(context.Background(), types.NamespacedName{Name: ns}, existingNs)
+		err := p.getNamespace(ns, existingNs)
 		if err != nil && !apierrors.IsNotFound(err) {
 			errs = status.Append(errs, errors.Wrapf(err, "unable to check the existence of the implicit namespace %q", ns))
 			continue
@@ -540,6 +692,102 @@ func (p *root) addImplicitNamespaces(objs []ast.FileObject) ([]ast.FileObject, s
 	return objs, errs
 }
 
+// validateExplicitNamespaces returns an error for each Namespace which is used
+// by an object's metadata namespace field but is neither declared in the list
+// nor present on the cluster. It is used in place of addImplicitNamespaces when
+// the NamespaceStrategy is explicit.
+func (p *root) validateExplicitNamespaces(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+	var errs status.MultiError
+	declaredNamespaces := make(map[string]bool)
+	// undeclared tracks the objects in each Namespace, in the order the
+	// Namespaces were first seen, so the errors are deterministic.
+	var namespaces []string
+	undeclared := make(map[string][]client.Object)
+
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind().GroupKind() == kinds.Namespace().GroupKind() {
+			declaredNamespaces[o.GetName()] = true
+		} else if ns := o.GetNamespace(); ns != "" {
+			if _, found := undeclared[ns]; !found {
+				namespaces = append(namespaces, ns)
+			}
+			undeclared[ns] = append(undeclared[ns], o)
+		}
+	}
+
+	for _, ns := range namespaces {
+		if declaredNamespaces[ns] || ns == configsync.ControllerNamespace {
+			continue
+		}
+		err := p.getNamespace(ns, &corev1.Namespace{})
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			errs = status.Append(errs, errors.Wrapf(err, "unable to check the existence of the namespace %q", ns))
+			continue
+		}
+		errs = status.Append(errs, undeclaredNamespaceError(ns, undeclared[ns]...))
+	}
+
+	return objs, errs
+}
+
+// getNamespace gets the Namespace with the given name from the cluster. When
+// parsing offline without a client, every Namespace is reported as not found.
+func (p *root) getNamespace(name string, ns *corev1.Namespace) error {
+	if p.Client == nil {
+		return apierrors.NewNotFound(corev1.Resource("namespaces"), name)
+	}
+	return p.Client.Get(context.Background(), types.NamespacedName{Name: name}, ns)
+}
+
+// undeclaredNamespaceError reports that a Namespace used by the given
+// resources is not declared when the NamespaceStrategy is explicit.
+func undeclaredNamespaceError(namespace string, resources ...client.Object) status.Error {
+	return status.SourceError.
+		Sprintf("Namespace %q is not declared in the source and does not exist on the cluster. "+
+			"Implicit Namespace creation is disabled because spec.override.namespaceStrategy is %q. "+
+			"Either declare Namespace %q in the source, or set spec.override.namespaceStrategy to %q.",
+			namespace, configsync.NamespaceStrategyExplicit, namespace, configsync.NamespaceStrategyImplicit).
+		BuildWithResources(resources...)
+}
+
+// ambiguousSourceFormatError returns a SourceError if the files in the sync
+// directory signal a different source format than the configured one:
+//   - an unstructured source with a top-level system/ directory, or
+//   - a hierarchical source with files, none of which are in the top-level
+//     system/, cluster/, clusterregistry/ or namespaces/ directories.
+func ambiguousSourceFormatError(format filesystem.SourceFormat, syncDir cmpath.Absolute, files []cmpath.Absolute) status.Error {
+	var signal string
+	var otherFormat filesystem.SourceFormat
+	switch format {
+	case filesystem.SourceFormatUnstructured:
+		for _, f := range files {
+			if filesystem.IsSystemFile(syncDir, f) {
+				signal = fmt.Sprintf("contains the file %q in the top-level %s/ directory", f.OSPath(), repo.SystemDir)
+				otherFormat = filesystem.SourceFormatHierarchy
+				break
+			}
+		}
+	case filesystem.SourceFormatHierarchy:
+		if len(files) > 0 && len(filesystem.FilterHierarchyFiles(syncDir, files)) == 0 {
+			signal = fmt.Sprintf("has no files in the top-level %s/, %s/, %s/ or %s/ directories",
+				repo.SystemDir, repo.ClusterDir, repo.ClusterRegistryDir, repo.NamespacesDir)
+			otherFormat = filesystem.SourceFormatUnstructured
+		}
+	}
+	if signal == "" {
+		return nil
+	}
+	return status.SourceError.
+		Sprintf("The sync directory %q %s, which is the layout of a %q source, but spec.sourceFormat is %q. "+
+			"Either restructure the source, or set spec.sourceFormat to %q. "+
+			"To sync the source while migrating between source formats, set spec.override.ambiguousSourceFormat to %q or unset it.",
+			syncDir.OSPath(), signal, otherFormat, format, otherFormat, configsync.AmbiguousSourceFormatWarn).
+		Build()
+}
+
 // SyncErrors returns all the sync errors, including remediator errors,
 // validation errors, applier errors, and watch update errors.
 // SyncErrors implements the Parser interface
@@ -569,12 +817,35 @@ func prependRootSyncRemediatorStatus(ctx context.Context, client client.Client,
 		return status.APIServerError(err, "failed to get RootSync: "+syncName)
 	}
 
+	errs := prependConflictErrors(rs.Status.Sync.Errors, conflictErrs)
+	// No new errors, so no update
+	if errs == nil {
+		return nil
+	}
+	setSyncStatusErrors(&rs.Status.Status, errs, denominator)
+	rs.Status.Sync.LastUpdate = metav1.Now()
+	setStatusSummary(&rs.Status.Status, configsync.SyncStateError, rs.Status.Sync.Commit)
+
+	if err := client.Status().Update(ctx, &rs); err != nil {
+		// If the update failure was caused by the size of the RootSync object, we would truncate the errors and retry.
+		if isRequestTooLargeError(err) {
+			klog.Infof("Failed to update RootSync sync status (total error count: %d, denominator: %d): %s.", rs.Status.Sync.ErrorSummary.TotalCount, denominator, err)
+			return prependRootSyncRemediatorStatus(ctx, client, syncName, conflictErrs, denominator*2)
+		}
+		return status.APIServerError(err, "failed to update RootSync sync status")
+	}
+	return nil
+}
+
+// prependConflictErrors returns the conflict errors which are not yet in the
+// sync errors, followed by the sync errors, or nil if there are no new errors.
+func prependConflictErrors(syncErrs []v1beta1.ConfigSyncError, conflictErrs []status.ManagementConflictError) []v1beta1.ConfigSyncError {
 	var errs []v1beta1.ConfigSyncError
 	for _, conflictErr := range conflictErrs {
 		conflictCSEError := conflictErr.ToCSE()
 		conflictPairCSEError := conflictErr.CurrentManagerError().ToCSE()
 		errorFound := false
-		for _, e := range rs.Status.Sync.Errors {
+		for _, e := range syncErrs {
 			// Dedup the same remediator conflict error.
 			if e.Code == status.ManagementConflictErrorCode && (e.ErrorMessage == conflictCSEError.ErrorMessage || e.ErrorMessage == conflictPairCSEError.ErrorMessage) {
 				errorFound = true
@@ -585,26 +856,11 @@ func prependRootSyncRemediatorStatus(ctx context.Context, client client.Client,
 			errs = append(errs, conflictCSEError)
 		}
 	}
-
-	// No new errors, so no update
 	if len(errs) == 0 {
 		return nil
 	}
-
 	// Add the remeditor conflict errors before other sync errors for more visibility.
-	errs = append(errs, rs.Status.Sync.Errors...)
-	setSyncStatusErrors(&rs.Status.Status, errs, denominator)
-	rs.Status.Sync.LastUpdate = metav1.Now()
-
-	if err := client.Status().Update(ctx, &rs); err != nil {
-		// If the update failure was caused by the size of the RootSync object, we would truncate the errors and retry.
-		if isRequestTooLargeError(err) {
-			klog.Infof("Failed to update RootSync sync status (total error count: %d, denominator: %d): %s.", rs.Status.Sync.ErrorSummary.TotalCount, denominator, err)
-			return prependRootSyncRemediatorStatus(ctx, client, syncName, conflictErrs, denominator*2)
-		}
-		return status.APIServerError(err, "failed to update RootSync sync status")
-	}
-	return nil
+	return append(errs, syncErrs...)
 }
 
 // sourceRev will display the source version,
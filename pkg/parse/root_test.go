@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -26,7 +27,10 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
 	"k8s.io/utils/pointer"
 	"kpt.dev/configsync/pkg/api/configmanagement"
@@ -38,11 +42,14 @@ import (
 	"kpt.dev/configsync/pkg/diff/difftest"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/rootsync"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/fake"
 	"kpt.dev/configsync/pkg/testing/openapitest"
@@ -58,15 +65,26 @@ const (
 	rootSyncName       = "my-rs"
 	rootReconcilerName = "root-reconciler-my-rs"
 	nilGitContext      = `{"repo":""}`
+	sourceCommit       = "1234567"
 )
 
 type noOpRemediator struct {
 	needsUpdate bool
+	// watches are the GVKs of the last UpdateWatches call.
+	watches map[schema.GroupVersionKind]struct{}
+	// paused is whether the last lifecycle call was Pause.
+	paused bool
+	// frequentlyEdited are the update frequencies returned by FrequentlyEdited.
+	frequentlyEdited []fight.UpdateFrequency
 }
 
-func (r *noOpRemediator) Pause() {}
+func (r *noOpRemediator) Pause() {
+	r.paused = true
+}
 
-func (r *noOpRemediator) Resume() {}
+func (r *noOpRemediator) Resume() {
+	r.paused = false
+}
 
 func (r *noOpRemediator) ConflictErrors() []status.ManagementConflictError {
 	return nil
@@ -76,6 +94,10 @@ func (r *noOpRemediator) FightErrors() []status.Error {
 	return nil
 }
 
+func (r *noOpRemediator) FrequentlyEdited() []fight.UpdateFrequency {
+	return r.frequentlyEdited
+}
+
 func (r *noOpRemediator) NeedsUpdate() bool {
 	return r.needsUpdate
 }
@@ -84,8 +106,9 @@ func (r *noOpRemediator) ManagementConflict() bool {
 	return false
 }
 
-func (r *noOpRemediator) UpdateWatches(_ context.Context, _ map[schema.GroupVersionKind]struct{}) status.MultiError {
+func (r *noOpRemediator) UpdateWatches(_ context.Context, gvks map[schema.GroupVersionKind]struct{}) status.MultiError {
 	r.needsUpdate = false
+	r.watches = gvks
 	return nil
 }
 
@@ -106,12 +129,17 @@ func gitSpec(repo string, auth configsync.AuthType) core.MetaMutator {
 
 func TestRoot_Parse(t *testing.T) {
 	testCases := []struct {
-		name              string
-		format            filesystem.SourceFormat
-		namespaceStrategy configsync.NamespaceStrategy
-		existingObjects   []client.Object
-		parsed            []ast.FileObject
-		want              []ast.FileObject
+		name                   string
+		format                 filesystem.SourceFormat
+		namespaceStrategy      configsync.NamespaceStrategy
+		requiredMetadata       []v1beta1.RequiredMetadata
+		syncGeneration         int64
+		annotateSyncGeneration bool
+		existingObjects        []client.Object
+		parsed                 []ast.FileObject
+		want                   []ast.FileObject
+		wantImplicitNs         []string
+		wantErr                error
 	}{
 		{
 			name:   "no objects",
@@ -132,7 +160,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_foo"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -144,17 +172,19 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
 				),
 			},
+			wantImplicitNs: []string{"foo"},
 		},
 		{
-			name:              "no implicit namespace if namespaceStrategy is explicit",
+			name:              "no implicit namespace if namespaceStrategy is explicit and namespace exists",
 			format:            filesystem.SourceFormatUnstructured,
 			namespaceStrategy: configsync.NamespaceStrategyExplicit,
+			existingObjects:   []client.Object{fake.NamespaceObject("foo")},
 			parsed: []ast.FileObject{
 				fake.Role(core.Namespace("foo")),
 			},
@@ -166,13 +196,98 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
+					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
+					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
+					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
+				),
+			},
+		},
+		{
+			name:              "error if namespaceStrategy is explicit and namespace is missing",
+			format:            filesystem.SourceFormatUnstructured,
+			namespaceStrategy: configsync.NamespaceStrategyExplicit,
+			parsed: []ast.FileObject{
+				fake.Role(core.Namespace("foo")),
+			},
+			wantErr: undeclaredNamespaceError("foo", fake.Role(core.Namespace("foo"))),
+		},
+		{
+			name:              "objects with required metadata",
+			format:            filesystem.SourceFormatUnstructured,
+			namespaceStrategy: configsync.NamespaceStrategyExplicit,
+			requiredMetadata: []v1beta1.RequiredMetadata{
+				{Key: "cost-center", Type: configsync.RequiredMetadataLabel},
+				{Key: "example.com/owner", Type: configsync.RequiredMetadataAnnotation},
+			},
+			existingObjects: []client.Object{fake.NamespaceObject("foo")},
+			parsed: []ast.FileObject{
+				fake.Role(core.Namespace("foo"),
+					core.Label("cost-center", "123"),
+					core.Annotation("example.com/owner", "team-a")),
+			},
+			want: []ast.FileObject{
+				fake.Role(core.Namespace("foo"),
+					core.Label("cost-center", "123"),
+					core.Annotation("example.com/owner", "team-a"),
+					core.Label(metadata.ManagedByKey, metadata.ManagedByValue),
+					core.Label(metadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(metadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{"f:example.com/owner":{}},"f:labels":{"f:cost-center":{}}},"f:rules":{}}`),
+					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
+					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+					core.Annotation(metadata.GitContextKey, nilGitContext),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
 				),
 			},
 		},
+		{
+			name:              "error if objects are missing required metadata",
+			format:            filesystem.SourceFormatUnstructured,
+			namespaceStrategy: configsync.NamespaceStrategyExplicit,
+			requiredMetadata: []v1beta1.RequiredMetadata{
+				{Key: "cost-center", Type: configsync.RequiredMetadataLabel},
+				{Key: "example.com/owner", Type: configsync.RequiredMetadataAnnotation},
+			},
+			existingObjects: []client.Object{fake.NamespaceObject("foo")},
+			parsed: []ast.FileObject{
+				fake.Role(core.Namespace("foo"),
+					core.Label("cost-center", "123")),
+			},
+			wantErr: missingRequiredMetadataError(
+				fake.Role(core.Namespace("foo"), core.Label("cost-center", "123")),
+				v1beta1.RequiredMetadata{Key: "example.com/owner", Type: configsync.RequiredMetadataAnnotation}),
+		},
+		{
+			// The sync-generation annotation is not a declared field, so the
+			// declared-fields annotation does not drift with the generation.
+			name:                   "objects annotated with the sync generation",
+			format:                 filesystem.SourceFormatUnstructured,
+			namespaceStrategy:      configsync.NamespaceStrategyExplicit,
+			syncGeneration:         5,
+			annotateSyncGeneration: true,
+			existingObjects:        []client.Object{fake.NamespaceObject("foo")},
+			parsed: []ast.FileObject{
+				fake.Role(core.Namespace("foo")),
+			},
+			want: []ast.FileObject{
+				fake.Role(core.Namespace("foo"),
+					core.Label(metadata.ManagedByKey, metadata.ManagedByValue),
+					core.Label(metadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(metadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:rules":{}}`),
+					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
+					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+					core.Annotation(metadata.GitContextKey, nilGitContext),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
+					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
+					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
+					core.Annotation(metadata.SyncGenerationAnnotationKey, "5"),
+					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
+				),
+			},
+		},
 		{
 			name:              "implicit namespace if unstructured, present and self-managed",
 			format:            filesystem.SourceFormatUnstructured,
@@ -182,7 +297,7 @@ func TestRoot_Parse(t *testing.T) {
 				core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 				core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 				core.Annotation(metadata.GitContextKey, nilGitContext),
-				core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+				core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 				core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 				core.Annotation(metadata.ResourceIDKey, "_namespace_foo"),
 				difftest.ManagedBy(declared.RootReconciler, rootSyncName))},
@@ -197,7 +312,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_foo"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -209,12 +324,13 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
 				),
 			},
+			wantImplicitNs: []string{"foo"},
 		},
 		{
 			name:              "no implicit namespace if unstructured, present, but managed by others",
@@ -225,7 +341,7 @@ func TestRoot_Parse(t *testing.T) {
 				core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 				core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 				core.Annotation(metadata.GitContextKey, nilGitContext),
-				core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+				core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 				core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 				core.Annotation(metadata.ResourceIDKey, "_namespace_foo"),
 				difftest.ManagedBy(declared.RootReconciler, "other-root-sync"))},
@@ -240,7 +356,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -263,7 +379,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -286,7 +402,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, fmt.Sprintf("namespaces/%s/test.yaml", configsync.ControllerNamespace)),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "configsync.gke.io_rootsync_config-management-system_test"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -309,7 +425,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_bar"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -321,7 +437,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_bar_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -333,12 +449,13 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/configmap.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_configmap_bar_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
 				),
 			},
+			wantImplicitNs: []string{"bar"},
 		},
 		{
 			name:              "multiple implicit namespaces",
@@ -352,7 +469,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_baz"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName)),
@@ -372,7 +489,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -384,7 +501,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_bar"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -396,7 +513,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_bar_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -408,7 +525,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/configmap.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_configmap_bar_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -420,7 +537,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_baz"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -432,7 +549,7 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_baz_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -444,12 +561,13 @@ func TestRoot_Parse(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/configmap.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_configmap_baz_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
 				),
 			},
+			wantImplicitNs: []string{"bar", "baz"},
 		},
 	}
 
@@ -462,12 +580,16 @@ func TestRoot_Parse(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			parser := &root{
 				Options: &Options{
-					Parser:             &fakeParser{parse: tc.parsed},
-					SyncName:           rootSyncName,
-					ReconcilerName:     rootReconcilerName,
-					Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
-					DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
-					Converter:          converter,
+					Parser:                 &fakeParser{parse: tc.parsed},
+					SyncName:               rootSyncName,
+					ReconcilerName:         rootReconcilerName,
+					Client:                 syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+					DiscoveryInterface:     syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+					Converter:              converter,
+					WebhookEnabled:         true,
+					RequiredMetadata:       tc.requiredMetadata,
+					SyncGeneration:         tc.syncGeneration,
+					AnnotateSyncGeneration: tc.annotateSyncGeneration,
 					Updater: Updater{
 						Scope:      declared.RootReconciler,
 						Resources:  &declared.Resources{},
@@ -486,18 +608,414 @@ func TestRoot_Parse(t *testing.T) {
 					t.Fatal(err)
 				}
 			}
-			state := reconcilerState{}
-			if err := parseAndUpdate(context.Background(), parser, triggerReimport, &state); err != nil {
-				t.Fatal(err)
-			}
+			state := reconcilerState{cache: cacheForCommit{source: sourceState{commit: sourceCommit}}}
+			err := parseAndUpdate(context.Background(), parser, triggerReimport, &state)
+			testutil.AssertEqual(t, tc.wantErr, err, "expected error to match")
 
 			if diff := cmp.Diff(tc.want, state.cache.objsToApply, cmpopts.EquateEmpty(), ast.CompareFileObject, cmpopts.SortSlices(sortObjects)); diff != "" {
 				t.Error(diff)
 			}
+			if diff := cmp.Diff(tc.wantImplicitNs, state.cache.implicitNamespaces, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("implicit namespaces diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestRoot_Parse_WebhookDisabled verifies that the declared fields are not
+// computed when the admission webhook is disabled.
+func TestRoot_Parse_WebhookDisabled(t *testing.T) {
+	converter, err := openapitest.ValueConverterForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := &root{
+		Options: &Options{
+			Parser: &fakeParser{parse: []ast.FileObject{
+				fake.Namespace("namespaces/foo"),
+				fake.Role(core.Namespace("foo")),
+			}},
+			SyncName:           rootSyncName,
+			ReconcilerName:     rootReconcilerName,
+			Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+			DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+			Converter:          converter,
+			WebhookEnabled:     false,
+			Updater: Updater{
+				Scope:      declared.RootReconciler,
+				Resources:  &declared.Resources{},
+				Remediator: &noOpRemediator{},
+				Applier:    &fakeApplier{},
+			},
+			mux: &sync.Mutex{},
+		},
+		RootOptions: &RootOptions{
+			SourceFormat: filesystem.SourceFormatUnstructured,
+		},
+	}
+	state := reconcilerState{}
+	if err := parseAndUpdate(context.Background(), parser, triggerReimport, &state); err != nil {
+		t.Fatal(err)
+	}
+	if len(state.cache.objsToApply) != 2 {
+		t.Fatalf("expected 2 objects to apply, got %d", len(state.cache.objsToApply))
+	}
+	for _, obj := range state.cache.objsToApply {
+		if _, found := obj.GetAnnotations()[metadata.DeclaredFieldsKey]; found {
+			t.Errorf("expected no %s annotation on %s", metadata.DeclaredFieldsKey, core.GKNN(obj))
+		}
+	}
+}
+
+func TestRoot_ParseAmbiguousSourceFormat(t *testing.T) {
+	syncDir := cmpath.Absolute("/repo")
+	systemFiles := []cmpath.Absolute{
+		syncDir.Join(cmpath.RelativeSlash("system/repo.yaml")),
+		syncDir.Join(cmpath.RelativeSlash("namespaces/foo/namespace.yaml")),
+	}
+	flatFiles := []cmpath.Absolute{
+		syncDir.Join(cmpath.RelativeSlash("repo.yaml")),
+		syncDir.Join(cmpath.RelativeSlash("namespace.yaml")),
+	}
+	testCases := []struct {
+		name                  string
+		format                filesystem.SourceFormat
+		ambiguousSourceFormat configsync.AmbiguousSourceFormatPolicy
+		files                 []cmpath.Absolute
+		parsed                []ast.FileObject
+		wantErr               status.MultiError
+	}{
+		{
+			name:                  "unstructured without system directory",
+			format:                filesystem.SourceFormatUnstructured,
+			ambiguousSourceFormat: configsync.AmbiguousSourceFormatError,
+			files:                 flatFiles,
+			parsed:                []ast.FileObject{fake.Namespace("foo")},
+		},
+		{
+			name:                  "unstructured with system directory in error mode",
+			format:                filesystem.SourceFormatUnstructured,
+			ambiguousSourceFormat: configsync.AmbiguousSourceFormatError,
+			files:                 systemFiles,
+			parsed:                []ast.FileObject{fake.Namespace("foo")},
+			wantErr:               ambiguousSourceFormatError(filesystem.SourceFormatUnstructured, syncDir, systemFiles),
+		},
+		{
+			name:                  "unstructured with system directory in warn mode",
+			format:                filesystem.SourceFormatUnstructured,
+			ambiguousSourceFormat: configsync.AmbiguousSourceFormatWarn,
+			files:                 systemFiles,
+			parsed:                []ast.FileObject{fake.Namespace("foo")},
+		},
+		{
+			name:   "unstructured with system directory by default",
+			format: filesystem.SourceFormatUnstructured,
+			files:  systemFiles,
+			parsed: []ast.FileObject{fake.Namespace("foo")},
+		},
+		{
+			name:                  "hierarchy with hierarchy directories",
+			format:                filesystem.SourceFormatHierarchy,
+			ambiguousSourceFormat: configsync.AmbiguousSourceFormatError,
+			files:                 systemFiles,
+			parsed:                []ast.FileObject{fake.Repo(), fake.Namespace("namespaces/foo")},
+		},
+		{
+			name:                  "hierarchy without hierarchy directories in error mode",
+			format:                filesystem.SourceFormatHierarchy,
+			ambiguousSourceFormat: configsync.AmbiguousSourceFormatError,
+			files:                 flatFiles,
+			parsed:                []ast.FileObject{fake.Repo(), fake.Namespace("namespaces/foo")},
+			wantErr:               ambiguousSourceFormatError(filesystem.SourceFormatHierarchy, syncDir, flatFiles),
+		},
+		{
+			name:                  "hierarchy without hierarchy directories in warn mode",
+			format:                filesystem.SourceFormatHierarchy,
+			ambiguousSourceFormat: configsync.AmbiguousSourceFormatWarn,
+			files:                 flatFiles,
+			parsed:                []ast.FileObject{fake.Repo(), fake.Namespace("namespaces/foo")},
+		},
+		{
+			name:   "hierarchy without hierarchy directories by default",
+			format: filesystem.SourceFormatHierarchy,
+			files:  flatFiles,
+			parsed: []ast.FileObject{fake.Repo(), fake.Namespace("namespaces/foo")},
+		},
+	}
+
+	converter, err := openapitest.ValueConverterForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := &root{
+				Options: &Options{
+					Parser:             &fakeParser{parse: tc.parsed},
+					SyncName:           rootSyncName,
+					ReconcilerName:     rootReconcilerName,
+					Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+					DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+					Converter:          converter,
+					Updater: Updater{
+						Scope:      declared.RootReconciler,
+						Resources:  &declared.Resources{},
+						Remediator: &noOpRemediator{},
+						Applier:    &fakeApplier{},
+					},
+					mux: &sync.Mutex{},
+				},
+				RootOptions: &RootOptions{
+					SourceFormat:          tc.format,
+					NamespaceStrategy:     configsync.NamespaceStrategyImplicit,
+					AmbiguousSourceFormat: tc.ambiguousSourceFormat,
+				},
+			}
+			objs, err := parser.parseSource(context.Background(), sourceState{syncDir: syncDir, files: tc.files})
+			testutil.AssertEqual(t, tc.wantErr, err, "expected error to match")
+			if tc.wantErr == nil && len(objs) == 0 {
+				t.Errorf("expected the source to be parsed, got no objects")
+			}
 		})
 	}
 }
 
+// TestRoot_ParseAndUpdatePaused verifies that the declared resources are not
+// applied while applying is paused, and are applied once applying resumes.
+func TestRoot_ParseAndUpdatePaused(t *testing.T) {
+	commit := "abc123"
+	converter, err := openapitest.ValueConverterForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeApp := &fakeApplier{}
+	parser := &root{
+		Options: &Options{
+			Parser:             &fakeParser{parse: []ast.FileObject{fake.Namespace("namespaces/foo")}},
+			SyncName:           rootSyncName,
+			ReconcilerName:     rootReconcilerName,
+			Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+			DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+			Converter:          converter,
+			PauseApply:         true,
+			Updater: Updater{
+				Scope:      declared.RootReconciler,
+				Resources:  &declared.Resources{},
+				Remediator: &noOpRemediator{},
+				Applier:    fakeApp,
+			},
+			mux: &sync.Mutex{},
+		},
+		RootOptions: &RootOptions{
+			SourceFormat:      filesystem.SourceFormatUnstructured,
+			NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+		},
+	}
+
+	state := &reconcilerState{cache: cacheForCommit{source: sourceState{commit: commit}}}
+	if err := parseAndUpdate(context.Background(), parser, triggerReimport, state); err != nil {
+		t.Fatalf("unexpected error while paused: %v", err)
+	}
+	if len(fakeApp.got) != 0 {
+		t.Errorf("expected no objects to be applied while paused, got %d", len(fakeApp.got))
+	}
+	declaredObjs, _ := parser.Resources.DeclaredObjects()
+	if len(declaredObjs) != 1 {
+		t.Errorf("expected 1 declared object while paused, got %d", len(declaredObjs))
+	}
+	rs := &v1beta1.RootSync{}
+	if err := parser.Client.Get(context.Background(), rootsync.ObjectKey(rootSyncName), rs); err != nil {
+		t.Fatal(err)
+	}
+	cond := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncSyncing)
+	if cond == nil || cond.Reason != "Paused" {
+		t.Errorf("expected the Syncing condition to have the Paused reason, got %+v", cond)
+	}
+	if rs.Status.LastSyncedCommit != "" {
+		t.Errorf("expected no last synced commit while paused, got %q", rs.Status.LastSyncedCommit)
+	}
+	if rs.Status.Sync.Commit != "" {
+		t.Errorf("expected no sync commit while paused, got %q", rs.Status.Sync.Commit)
+	}
+
+	// Resuming restarts the reconciler, which starts with an empty state.
+	parser.PauseApply = false
+	parser.Parser = &fakeParser{parse: []ast.FileObject{fake.Namespace("namespaces/foo")}}
+	state = &reconcilerState{cache: cacheForCommit{source: sourceState{commit: commit}}}
+	if err := parseAndUpdate(context.Background(), parser, triggerReimport, state); err != nil {
+		t.Fatalf("unexpected error after resuming: %v", err)
+	}
+	if len(fakeApp.got) != 1 {
+		t.Errorf("expected 1 object to be applied after resuming, got %d", len(fakeApp.got))
+	}
+	if err := parser.Client.Get(context.Background(), rootsync.ObjectKey(rootSyncName), rs); err != nil {
+		t.Fatal(err)
+	}
+	cond = rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncSyncing)
+	if cond == nil || cond.Reason != "Sync" {
+		t.Errorf("expected the Syncing condition to have the Sync reason, got %+v", cond)
+	}
+	if rs.Status.LastSyncedCommit != commit {
+		t.Errorf("expected last synced commit %q after resuming, got %q", commit, rs.Status.LastSyncedCommit)
+	}
+	if rs.Status.Sync.Commit != commit {
+		t.Errorf("expected sync commit %q after resuming, got %q", commit, rs.Status.Sync.Commit)
+	}
+}
+
+// TestRoot_Parse_SyncToken verifies that the objects are annotated with the
+// source commit, and that the annotation is not one of the declared fields, so
+// a new commit does not change the declared fields of unchanged objects.
+func TestRoot_Parse_SyncToken(t *testing.T) {
+	converter, err := openapitest.ValueConverterForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := &root{
+		Options: &Options{
+			SyncName:           rootSyncName,
+			ReconcilerName:     rootReconcilerName,
+			Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+			DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+			Converter:          converter,
+			WebhookEnabled:     true,
+			Updater: Updater{
+				Scope:      declared.RootReconciler,
+				Resources:  &declared.Resources{},
+				Remediator: &noOpRemediator{},
+				Applier:    &fakeApplier{},
+			},
+			mux: &sync.Mutex{},
+		},
+		RootOptions: &RootOptions{
+			SourceFormat:      filesystem.SourceFormatUnstructured,
+			NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+		},
+	}
+
+	var declaredFields []string
+	for _, commit := range []string{"abc123", "def456"} {
+		// The parsed objects are annotated in place, so parse new ones.
+		parser.Parser = &fakeParser{parse: []ast.FileObject{fake.Role(core.Namespace("foo"))}}
+		state := reconcilerState{cache: cacheForCommit{source: sourceState{commit: commit}}}
+		if err := parseAndUpdate(context.Background(), parser, triggerReimport, &state); err != nil {
+			t.Fatal(err)
+		}
+		var obj ast.FileObject
+		for _, o := range state.cache.objsToApply {
+			if o.GetObjectKind().GroupVersionKind() == kinds.Role() {
+				obj = o
+			}
+		}
+		if obj.Unstructured == nil {
+			t.Fatalf("expected the Role to be applied, got %v", state.cache.objsToApply)
+		}
+		if got := core.GetAnnotation(obj, metadata.SyncTokenAnnotationKey); got != commit {
+			t.Errorf("expected %s annotation %q, got %q", metadata.SyncTokenAnnotationKey, commit, got)
+		}
+		declaredFields = append(declaredFields, core.GetAnnotation(obj, metadata.DeclaredFieldsKey))
+	}
+	if declaredFields[0] != declaredFields[1] {
+		t.Errorf("expected the declared fields to be unchanged by a new commit, got %q and %q", declaredFields[0], declaredFields[1])
+	}
+}
+
+func TestRoot_ParseAndUpdateCircuitBreaker(t *testing.T) {
+	commit := "abc123"
+	converter, err := openapitest.ValueConverterForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unavailableErr := applier.ErrorForResource(apierrors.NewServiceUnavailable("overloaded"), core.IDOf(fake.Namespace("namespaces/foo")), "")
+	fakeApp := &fakeApplier{errors: []status.Error{unavailableErr}}
+	fakeRemediator := &noOpRemediator{}
+	parser := &root{
+		Options: &Options{
+			Parser:             &fakeParser{parse: []ast.FileObject{fake.Namespace("namespaces/foo")}},
+			SyncName:           rootSyncName,
+			ReconcilerName:     rootReconcilerName,
+			Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+			DiscoveryInterface: syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+			Converter:          converter,
+			Updater: Updater{
+				Scope:      declared.RootReconciler,
+				Resources:  &declared.Resources{},
+				Remediator: fakeRemediator,
+				Applier:    fakeApp,
+			},
+			mux: &sync.Mutex{},
+		},
+		RootOptions: &RootOptions{
+			SourceFormat:      filesystem.SourceFormatUnstructured,
+			NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+		},
+	}
+	getCondition := func() *v1beta1.RootSyncCondition {
+		rs := &v1beta1.RootSync{}
+		if err := parser.Client.Get(context.Background(), rootsync.ObjectKey(rootSyncName), rs); err != nil {
+			t.Fatal(err)
+		}
+		return rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncAPIServerUnavailable)
+	}
+
+	state := &reconcilerState{
+		cache:   cacheForCommit{source: sourceState{commit: commit}},
+		breaker: newCircuitBreaker(CircuitBreakerOptions{Threshold: 2, ProbePeriod: time.Hour}),
+	}
+	// The first failure does not open the circuit breaker.
+	if err := parseAndUpdate(context.Background(), parser, triggerReimport, state); err == nil {
+		t.Fatal("expected an error from the applier")
+	}
+	if state.breaker.isOpen() {
+		t.Error("expected the circuit breaker to be closed after one failure")
+	}
+	if cond := getCondition(); cond != nil {
+		t.Errorf("expected no APIServerUnavailable condition, got %+v", cond)
+	}
+
+	// The second failure opens the circuit breaker.
+	if err := parseAndUpdate(context.Background(), parser, triggerReimport, state); err == nil {
+		t.Fatal("expected an error from the applier")
+	}
+	if !state.breaker.isOpen() {
+		t.Fatal("expected the circuit breaker to be open after two failures")
+	}
+	if cond := getCondition(); cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "CircuitBreakerOpen" {
+		t.Errorf("expected a True APIServerUnavailable condition, got %+v", cond)
+	}
+	if !fakeRemediator.paused {
+		t.Error("expected the remediator to be paused while the circuit breaker is open")
+	}
+
+	// The updater is skipped until the next probe, and the last errors are
+	// reported.
+	fakeApp.errors = nil
+	err = parseAndUpdate(context.Background(), parser, triggerReimport, state)
+	testutil.AssertEqual(t, status.Append(nil, unavailableErr), err, "expected the last errors while the circuit breaker is open")
+	if len(fakeApp.got) != 0 {
+		t.Errorf("expected no objects to be applied while the circuit breaker is open, got %d", len(fakeApp.got))
+	}
+
+	// A successful probe closes the circuit breaker.
+	state.breaker.nextProbe = time.Now()
+	if err := parseAndUpdate(context.Background(), parser, triggerReimport, state); err != nil {
+		t.Fatalf("unexpected error from the probe: %v", err)
+	}
+	if state.breaker.isOpen() {
+		t.Error("expected the circuit breaker to be closed after a successful probe")
+	}
+	if fakeRemediator.paused {
+		t.Error("expected the remediator to be resumed after a successful probe")
+	}
+	if len(fakeApp.got) != 1 {
+		t.Errorf("expected 1 object to be applied after the probe, got %d", len(fakeApp.got))
+	}
+	if cond := getCondition(); cond != nil {
+		t.Errorf("expected the APIServerUnavailable condition to be removed, got %+v", cond)
+	}
+}
+
 func fakeCRD(opts ...core.MetaMutator) ast.FileObject {
 	crd := fake.CustomResourceDefinitionV1Object(opts...)
 	crd.Spec.Group = "acme.com"
@@ -560,7 +1078,61 @@ func fakeParseError(err error, gvks ...schema.GroupVersionKind) error {
 	return status.APIServerError(&discovery.ErrGroupDiscoveryFailed{Groups: groups}, "API discovery failed")
 }
 
+// TestRoot_SetSyncStatus_FrequentlyEdited verifies that the objects the
+// remediator reports as frequently edited are written to the RootSync's
+// status.sync.frequentlyEdited, with their frequencies rounded.
+func TestRoot_SetSyncStatus_FrequentlyEdited(t *testing.T) {
+	fakeRemediator := &noOpRemediator{}
+	parser := &root{
+		Options: &Options{
+			SyncName:       rootSyncName,
+			ReconcilerName: rootReconcilerName,
+			Client:         syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
+			Updater: Updater{
+				Scope:      declared.RootReconciler,
+				Resources:  &declared.Resources{},
+				Remediator: fakeRemediator,
+				Applier:    &fakeApplier{},
+			},
+			mux: &sync.Mutex{},
+		},
+		RootOptions: &RootOptions{},
+	}
+	getFrequentlyEdited := func() []v1beta1.FrequentlyEditedObject {
+		rs := &v1beta1.RootSync{}
+		if err := parser.Client.Get(context.Background(), rootsync.ObjectKey(rootSyncName), rs); err != nil {
+			t.Fatal(err)
+		}
+		return rs.Status.Sync.FrequentlyEdited
+	}
+	state := &reconcilerState{cache: cacheForCommit{source: sourceState{commit: "abc123"}}}
+
+	fakeRemediator.frequentlyEdited = []fight.UpdateFrequency{
+		{ID: core.IDOf(fake.RoleObject(core.Name("admin"), core.Namespace("foo"))), PerMinute: 12.6},
+		{ID: core.IDOf(fake.Namespace("namespaces/bar")), PerMinute: 4.2},
+	}
+	if err := setSyncStatus(context.Background(), parser, state, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []v1beta1.FrequentlyEditedObject{
+		{Group: "rbac.authorization.k8s.io", Kind: "Role", Namespace: "foo", Name: "admin", EditsPerMinute: 13},
+		{Kind: "Namespace", Name: "bar", EditsPerMinute: 4},
+	}
+	testutil.AssertEqual(t, want, getFrequentlyEdited(), "unexpected status.sync.frequentlyEdited")
+
+	// Once the edits stop, the objects are removed from the status.
+	fakeRemediator.frequentlyEdited = nil
+	if err := setSyncStatus(context.Background(), parser, state, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, []v1beta1.FrequentlyEditedObject(nil), getFrequentlyEdited(), "expected no frequently edited objects")
+}
+
 func TestRoot_Parse_Discovery(t *testing.T) {
+	// Retry the transient discovery failures without waiting.
+	defer func(backoff wait.Backoff) { discoveryutil.DiscoveryRetryBackoff = backoff }(discoveryutil.DiscoveryRetryBackoff)
+	discoveryutil.DiscoveryRetryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
 	testCases := []struct {
 		name            string
 		parsed          []ast.FileObject
@@ -618,7 +1190,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_foo"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -630,7 +1202,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -655,7 +1227,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "_namespace_foo"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -667,7 +1239,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_foo_default-name"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName),
@@ -679,7 +1251,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "cluster/crd.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "apiextensions.k8s.io_customresourcedefinition_anvils.acme.com"),
 					difftest.ManagedBy(declared.RootReconciler, rootSyncName)),
@@ -693,7 +1265,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/obj.yaml"),
 					core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
 					core.Annotation(metadata.GitContextKey, nilGitContext),
-					core.Annotation(metadata.SyncTokenAnnotationKey, ""),
+					core.Annotation(metadata.SyncTokenAnnotationKey, sourceCommit),
 					core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootSyncName, configmanagement.ControllerNamespace)),
 					core.Annotation(metadata.ResourceIDKey, "acme.com_anvil_foo_deploy"),
 				),
@@ -716,6 +1288,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					Client:             syncertest.NewClient(t, core.Scheme, fake.RootSyncObjectV1Beta1(rootSyncName)),
 					DiscoveryInterface: tc.discoveryClient,
 					Converter:          converter,
+					WebhookEnabled:     true,
 					Updater: Updater{
 						Scope:      declared.RootReconciler,
 						Resources:  &declared.Resources{},
@@ -729,7 +1302,7 @@ func TestRoot_Parse_Discovery(t *testing.T) {
 					NamespaceStrategy: configsync.NamespaceStrategyImplicit,
 				},
 			}
-			state := reconcilerState{}
+			state := reconcilerState{cache: cacheForCommit{source: sourceState{commit: sourceCommit}}}
 			err := parseAndUpdate(context.Background(), parser, triggerReimport, &state)
 			testutil.AssertEqual(t, tc.expectedError, err, "expected error to match")
 
@@ -807,11 +1380,7 @@ func TestRoot_SourceReconcilerErrorsMetricValidation(t *testing.T) {
 			parseErrors: []status.Error{
 				status.SourceError.Sprintf("source error").Build(),
 			},
-			wantMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-			},
+			wantMetrics: reconcilerErrorsRows("source", map[string]float64{"2xxx": 1}),
 		},
 		{
 			name: "multiple reconciler errors in source component",
@@ -819,11 +1388,7 @@ func TestRoot_SourceReconcilerErrorsMetricValidation(t *testing.T) {
 				status.SourceError.Sprintf("source error").Build(),
 				status.InternalError("internal error"),
 			},
-			wantMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-			},
+			wantMetrics: reconcilerErrorsRows("source", map[string]float64{"2xxx": 1, "9xxx": 1}),
 		},
 	}
 
@@ -870,14 +1435,9 @@ func TestRoot_SourceAndSyncReconcilerErrorsMetricValidation(t *testing.T) {
 			applyErrors: []status.Error{
 				applier.Error(errors.New("sync error")),
 			},
-			wantMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-			},
+			wantMetrics: append(
+				reconcilerErrorsRows("source", map[string]float64{}),
+				reconcilerErrorsRows("sync", map[string]float64{"2xxx": 1})...),
 		},
 		{
 			name: "multiple reconciler errors in sync component",
@@ -885,14 +1445,9 @@ func TestRoot_SourceAndSyncReconcilerErrorsMetricValidation(t *testing.T) {
 				applier.Error(errors.New("sync error")),
 				status.InternalError("internal error"),
 			},
-			wantMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-			},
+			wantMetrics: append(
+				reconcilerErrorsRows("source", map[string]float64{}),
+				reconcilerErrorsRows("sync", map[string]float64{"2xxx": 1, "9xxx": 1})...),
 		},
 	}
 
@@ -930,6 +1485,19 @@ func TestRoot_SourceAndSyncReconcilerErrorsMetricValidation(t *testing.T) {
 	}
 }
 
+// reconcilerErrorsRows returns the ReconcilerErrors metric rows of the
+// component for every errorclass, with the given counts by errorclass.
+func reconcilerErrorsRows(component string, counts map[string]float64) []*view.Row {
+	var rows []*view.Row
+	for _, errorclass := range []string{"1xxx", "2xxx", "9xxx"} {
+		rows = append(rows, &view.Row{
+			Data: &view.LastValueData{Value: counts[errorclass]},
+			Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: component}, {Key: metrics.KeyErrorClass, Value: errorclass}},
+		})
+	}
+	return rows
+}
+
 func sortObjects(left, right client.Object) bool {
 	leftID := core.IDOf(left)
 	rightID := core.IDOf(right)
@@ -960,12 +1528,46 @@ func (p *fakeParser) ReadClusterNamesFromSelector(_ reader.FilePaths) ([]string,
 	return nil, nil
 }
 
+func TestUpdate_DeletionProtectedObjects(t *testing.T) {
+	protectedErr := status.DeletionProtectedError(fake.RoleObject(core.Name("protected"), core.Namespace("foo")))
+	applier := &fakeApplier{errors: []status.Error{protectedErr}}
+	updater := &Updater{
+		Scope:      declared.RootReconciler,
+		Resources:  &declared.Resources{},
+		Remediator: &noOpRemediator{},
+		Applier:    applier,
+	}
+	cache := &cacheForCommit{}
+
+	errs := updater.Update(context.Background(), cache)
+	testutil.AssertEqual(t, status.Append(nil, protectedErr), errs, "expected error to match")
+	// Objects protected from deletion do not need the apply to be retried.
+	testutil.AssertEqual(t, true, cache.applied, "expected the commit to be applied")
+	testutil.AssertEqual(t, 1, applier.calls, "expected one apply")
+
+	// The protected objects are still reported, without applying again.
+	errs = updater.Update(context.Background(), cache)
+	testutil.AssertEqual(t, status.Append(nil, protectedErr), errs, "expected error to match")
+	testutil.AssertEqual(t, 1, applier.calls, "expected no more apply")
+
+	// Other non-blocking apply errors are retried.
+	applier.errors = []status.Error{protectedErr, status.UnknownObjectKindError(fake.RoleObject())}
+	cache = &cacheForCommit{}
+	updater.Update(context.Background(), cache)
+	testutil.AssertEqual(t, false, cache.applied, "expected the commit to not be applied")
+}
+
 type fakeApplier struct {
 	got    []client.Object
 	errors []status.Error
+	// pending are the objects deferred by every Apply call.
+	pending []core.ID
+	// calls is the number of Apply calls.
+	calls int
 }
 
 func (a *fakeApplier) Apply(_ context.Context, objs []client.Object) (map[schema.GroupVersionKind]struct{}, status.MultiError) {
+	a.calls++
 	if a.errors == nil {
 		a.got = objs
 		gvks := make(map[schema.GroupVersionKind]struct{})
@@ -989,246 +1591,100 @@ func (a *fakeApplier) Errors() status.MultiError {
 	return errs
 }
 
+func (a *fakeApplier) Pending() []core.ID {
+	return a.pending
+}
+
 func (a *fakeApplier) Syncing() bool {
 	return false
 }
 
-func TestSummarizeErrors(t *testing.T) {
+func TestSetSourceStatusFields(t *testing.T) {
+	now := metav1.Now()
+	sourceErr := status.SourceError.Sprint("error in the git-sync container").Build()
 	testCases := []struct {
-		name                 string
-		sourceStatus         v1beta1.SourceStatus
-		syncStatus           v1beta1.SyncStatus
-		expectedErrorSources []v1beta1.ErrorSource
-		expectedErrorSummary *v1beta1.ErrorSummary
+		name       string
+		sourceType v1beta1.SourceType
+		commit     string
+		errs       status.MultiError
+		want       []v1beta1.SourceHealth
 	}{
 		{
-			name:                 "both sourceStatus and syncStatus are empty",
-			sourceStatus:         v1beta1.SourceStatus{},
-			syncStatus:           v1beta1.SyncStatus{},
-			expectedErrorSources: nil,
-			expectedErrorSummary: &v1beta1.ErrorSummary{},
-		},
-		{
-			name: "sourceStatus is not empty (no trucation), syncStatus is empty",
-			sourceStatus: v1beta1.SourceStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "1021", ErrorMessage: "1021-error-message"},
-					{Code: "1022", ErrorMessage: "1022-error-message"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                2,
-					Truncated:                 false,
-					ErrorCountAfterTruncation: 2,
+			name:       "healthy git source",
+			sourceType: v1beta1.GitSource,
+			commit:     "abc123",
+			want: []v1beta1.SourceHealth{
+				{
+					SourceType:   v1beta1.GitSource,
+					Commit:       "abc123",
+					LastUpdate:   now,
+					ErrorSummary: &v1beta1.ErrorSummary{},
 				},
 			},
-			syncStatus:           v1beta1.SyncStatus{},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                2,
-				Truncated:                 false,
-				ErrorCountAfterTruncation: 2,
-			},
 		},
 		{
-			name: "sourceStatus is not empty and trucates errors, syncStatus is empty",
-			sourceStatus: v1beta1.SourceStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "1021", ErrorMessage: "1021-error-message"},
-					{Code: "1022", ErrorMessage: "1022-error-message"},
+			name:       "failing git source",
+			sourceType: v1beta1.GitSource,
+			commit:     "abc123",
+			errs:       sourceErr,
+			want: []v1beta1.SourceHealth{
+				{
+					SourceType:   v1beta1.GitSource,
+					Commit:       "abc123",
+					LastUpdate:   now,
+					Errors:       status.ToCSE(sourceErr),
+					ErrorSummary: &v1beta1.ErrorSummary{TotalCount: 1, ErrorCountAfterTruncation: 1},
 				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                100,
-					Truncated:                 true,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			syncStatus:           v1beta1.SyncStatus{},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                100,
-				Truncated:                 true,
-				ErrorCountAfterTruncation: 2,
 			},
 		},
 		{
-			name:         "sourceStatus is empty, syncStatus is not empty (no trucation)",
-			sourceStatus: v1beta1.SourceStatus{},
-			syncStatus: v1beta1.SyncStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "2009", ErrorMessage: "apiserver error"},
-					{Code: "2009", ErrorMessage: "webhook error"},
+			name:       "healthy oci source",
+			sourceType: v1beta1.OciSource,
+			commit:     "sha256:abc123",
+			want: []v1beta1.SourceHealth{
+				{
+					SourceType:   v1beta1.OciSource,
+					Commit:       "sha256:abc123",
+					LastUpdate:   now,
+					ErrorSummary: &v1beta1.ErrorSummary{},
 				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                2,
-					Truncated:                 false,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SyncError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                2,
-				Truncated:                 false,
-				ErrorCountAfterTruncation: 2,
 			},
 		},
 		{
-			name:         "sourceStatus is empty, syncStatus is not empty and trucates errors",
-			sourceStatus: v1beta1.SourceStatus{},
-			syncStatus: v1beta1.SyncStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "2009", ErrorMessage: "apiserver error"},
-					{Code: "2009", ErrorMessage: "webhook error"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                100,
-					Truncated:                 true,
-					ErrorCountAfterTruncation: 2,
+			name:       "failing helm source",
+			sourceType: v1beta1.HelmSource,
+			commit:     "1.0.0",
+			errs:       sourceErr,
+			want: []v1beta1.SourceHealth{
+				{
+					SourceType:   v1beta1.HelmSource,
+					Commit:       "1.0.0",
+					LastUpdate:   now,
+					Errors:       status.ToCSE(sourceErr),
+					ErrorSummary: &v1beta1.ErrorSummary{TotalCount: 1, ErrorCountAfterTruncation: 1},
 				},
 			},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SyncError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                100,
-				Truncated:                 true,
-				ErrorCountAfterTruncation: 2,
-			},
-		},
-		{
-			name: "neither sourceStatus nor syncStatus is empty or trucates errors",
-			sourceStatus: v1beta1.SourceStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "1021", ErrorMessage: "1021-error-message"},
-					{Code: "1022", ErrorMessage: "1022-error-message"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                2,
-					Truncated:                 false,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			syncStatus: v1beta1.SyncStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "2009", ErrorMessage: "apiserver error"},
-					{Code: "2009", ErrorMessage: "webhook error"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                2,
-					Truncated:                 false,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                4,
-				Truncated:                 false,
-				ErrorCountAfterTruncation: 4,
-			},
-		},
-		{
-			name: "neither sourceStatus nor syncStatus is empty, sourceStatus trucates errors",
-			sourceStatus: v1beta1.SourceStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "1021", ErrorMessage: "1021-error-message"},
-					{Code: "1022", ErrorMessage: "1022-error-message"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                100,
-					Truncated:                 true,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			syncStatus: v1beta1.SyncStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "2009", ErrorMessage: "apiserver error"},
-					{Code: "2009", ErrorMessage: "webhook error"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                2,
-					Truncated:                 false,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                102,
-				Truncated:                 true,
-				ErrorCountAfterTruncation: 4,
-			},
-		},
-		{
-			name: "neither sourceStatus nor syncStatus is empty, syncStatus trucates errors",
-			sourceStatus: v1beta1.SourceStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "1021", ErrorMessage: "1021-error-message"},
-					{Code: "1022", ErrorMessage: "1022-error-message"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                2,
-					Truncated:                 false,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			syncStatus: v1beta1.SyncStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "2009", ErrorMessage: "apiserver error"},
-					{Code: "2009", ErrorMessage: "webhook error"},
-				},
-
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                100,
-					Truncated:                 true,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                102,
-				Truncated:                 true,
-				ErrorCountAfterTruncation: 4,
-			},
-		},
-		{
-			name: "neither sourceStatus nor syncStatus is empty, both trucates errors",
-			sourceStatus: v1beta1.SourceStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "1021", ErrorMessage: "1021-error-message"},
-					{Code: "1022", ErrorMessage: "1022-error-message"},
-				},
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                100,
-					Truncated:                 true,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			syncStatus: v1beta1.SyncStatus{
-				Errors: []v1beta1.ConfigSyncError{
-					{Code: "2009", ErrorMessage: "apiserver error"},
-					{Code: "2009", ErrorMessage: "webhook error"},
-				},
-
-				ErrorSummary: &v1beta1.ErrorSummary{
-					TotalCount:                100,
-					Truncated:                 true,
-					ErrorCountAfterTruncation: 2,
-				},
-			},
-			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
-			expectedErrorSummary: &v1beta1.ErrorSummary{
-				TotalCount:                200,
-				Truncated:                 true,
-				ErrorCountAfterTruncation: 4,
-			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			gotErrorSources, gotErrorSummary := summarizeErrors(tc.sourceStatus, tc.syncStatus)
-			if diff := cmp.Diff(tc.expectedErrorSources, gotErrorSources); diff != "" {
-				t.Errorf("summarizeErrors() got %v, expected %v", gotErrorSources, tc.expectedErrorSources)
-			}
-			if diff := cmp.Diff(tc.expectedErrorSummary, gotErrorSummary); diff != "" {
-				t.Errorf("summarizeErrors() got %v, expected %v", gotErrorSummary, tc.expectedErrorSummary)
+			parser := &root{
+				Options: &Options{
+					Files: Files{FileSource: FileSource{
+						SourceType: tc.sourceType,
+						SourceRepo: "example.com/repo",
+						SyncDir:    cmpath.RelativeSlash("configs"),
+					}},
+				},
 			}
+			source := &v1beta1.SourceStatus{}
+			setSourceStatusFields(source, parser, sourceStatus{
+				commit:     tc.commit,
+				errs:       tc.errs,
+				lastUpdate: now,
+			}, defaultDenominator)
+			testutil.AssertEqual(t, tc.want, source.Sources, "unexpected per-source health")
 		})
 	}
 }
@@ -22,16 +22,20 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/discovery"
 	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -66,6 +70,10 @@ const (
 	RenderingNotRequired string = "Rendering not required but is currently enabled"
 )
 
+// renderingToolNone is the rendering tool reported when the configs are not
+// rendered.
+const renderingToolNone = "none"
+
 // Run keeps checking whether a parse-apply-watch loop is necessary and starts a loop if needed.
 func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.State) {
 	opts := p.options()
@@ -81,7 +89,7 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 	retryTimer := time.NewTimer(opts.RetryPeriod)
 	defer retryTimer.Stop()
 
-	statusUpdateTimer := time.NewTimer(opts.StatusUpdatePeriod)
+	statusUpdateTimer := time.NewTimer(opts.jitteredStatusUpdatePeriod())
 	defer statusUpdateTimer.Stop()
 
 	nsEventPeriod := time.Second
@@ -92,6 +100,7 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 		backoff:     defaultBackoff(),
 		retryTimer:  retryTimer,
 		retryPeriod: opts.RetryPeriod,
+		breaker:     newCircuitBreaker(opts.CircuitBreaker),
 	}
 	for {
 		select {
@@ -113,18 +122,28 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 			resyncTimer.Reset(opts.ResyncPeriod) // Schedule resync attempt
 			// we should not reset retryTimer under this `case` since it is not aware of the
 			// state of backoff retry.
-			statusUpdateTimer.Reset(opts.StatusUpdatePeriod) // Schedule status update attempt
+			statusUpdateTimer.Reset(opts.jitteredStatusUpdatePeriod()) // Schedule status update attempt
 
 		// Re-import declared resources from the filesystem (from git-sync).
 		// If the reconciler is in the process of reconciling a given commit, the re-import won't
 		// happen until the ongoing reconciliation is done.
 		case <-runTimer.C:
-			run(ctx, p, triggerReimport, state)
+			if resyncTokenChanged(ctx, p, state) {
+				klog.Infof("The %s annotation changed to %q, it is time for a force-resync",
+					metadata.ResyncTokenAnnotationKey, state.resyncToken)
+				// Reset the whole cache to make sure the source files are read
+				// again and all the steps of a parse-apply-watch loop will run.
+				state.resetCache()
+				run(ctx, p, triggerResync, state)
+				resyncTimer.Reset(opts.ResyncPeriod) // Schedule resync attempt
+			} else {
+				run(ctx, p, triggerReimport, state)
+			}
 
 			runTimer.Reset(opts.PollingPeriod) // Schedule re-import attempt
 			// we should not reset retryTimer under this `case` since it is not aware of the
 			// state of backoff retry.
-			statusUpdateTimer.Reset(opts.StatusUpdatePeriod) // Schedule status update attempt
+			statusUpdateTimer.Reset(opts.jitteredStatusUpdatePeriod()) // Schedule status update attempt
 
 		// Retry if there was an error, conflict, or any watches need to be updated.
 		case <-retryTimer.C:
@@ -149,6 +168,19 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 				retryTimer.Reset(opts.RetryPeriod)
 				continue
 			}
+			if state.breaker.isOpen() {
+				// While the circuit breaker is open, retries are replaced by
+				// probes, which do not count towards the retry limit.
+				if wait := state.breaker.untilProbe(time.Now()); wait > 0 {
+					retryTimer.Reset(wait)
+					continue
+				}
+				klog.Infof("a probe is triggered to check whether the API server has recovered (trigger type: %v)", trigger)
+				run(ctx, p, trigger, state)
+				retryTimer.Reset(max(state.breaker.untilProbe(time.Now()), opts.RetryPeriod))
+				statusUpdateTimer.Reset(opts.jitteredStatusUpdatePeriod()) // Schedule status update attempt
+				continue
+			}
 			if state.backoff.Steps == 0 {
 				klog.Infof("Retry limit (%v) has been reached", retryLimit)
 				// Don't reset retryTimer if retry limit has been reached.
@@ -166,7 +198,7 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 			// Reset retryTimer after `run` to make sure `retryDuration` happens between the end of one execution
 			// of `run` and the start of the next execution.
 			retryTimer.Reset(retryDuration)
-			statusUpdateTimer.Reset(opts.StatusUpdatePeriod) // Schedule status update attempt
+			statusUpdateTimer.Reset(opts.jitteredStatusUpdatePeriod()) // Schedule status update attempt
 
 		// Update the sync status to report management conflicts (from the remediator).
 		case <-statusUpdateTimer.C:
@@ -182,7 +214,7 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 				}
 			}
 
-			statusUpdateTimer.Reset(opts.StatusUpdatePeriod) // Schedule status update attempt
+			statusUpdateTimer.Reset(opts.jitteredStatusUpdatePeriod()) // Schedule status update attempt
 			// we should not reset retryTimer under this `case` since it is not aware of the
 			// state of backoff retry.
 
@@ -209,12 +241,49 @@ func Run(ctx context.Context, p Parser, nsControllerState *namespacecontroller.S
 	}
 }
 
+// resyncTokenChanged returns true if the resync-token annotation on the RSync
+// changed since it was last observed. The first observed value, including an
+// empty one, only records the token, since the reconciler always runs a full
+// sync after it starts.
+func resyncTokenChanged(ctx context.Context, p Parser, state *reconcilerState) bool {
+	token, err := p.resyncToken(ctx)
+	if err != nil {
+		klog.Warningf("Failed to read the %s annotation: %v", metadata.ResyncTokenAnnotationKey, err)
+		return false
+	}
+	if !state.resyncTokenObserved {
+		state.resyncToken = token
+		state.resyncTokenObserved = true
+		return false
+	}
+	if token == state.resyncToken {
+		return false
+	}
+	state.resyncToken = token
+	return true
+}
+
+// run runs a reconcile cycle. Cycles which complete, by either checkpointing or
+// invalidating the reconciler state, are recorded in the reconcile cycle
+// metrics. Cycles waiting for rendering, or skipped because the source did not
+// change, are not recorded. Every run is recorded in the trigger metrics, with
+// the result of its cycle, or as skipped.
 func run(ctx context.Context, p Parser, trigger string, state *reconcilerState) {
+	result := metrics.StatusSkipped
+	defer func() { metrics.RecordTrigger(ctx, trigger, result) }()
+
 	var syncDir cmpath.Absolute
 	gs := sourceStatus{}
 	// pull the source commit and directory with retries within 5 minutes.
 	gs.commit, syncDir, gs.errs = hydrate.SourceCommitAndDirWithRetry(util.SourceRetryBackoff, p.options().SourceType, p.options().SourceDir, p.options().SyncDir, p.options().ReconcilerName)
 
+	// Report not ready while a new commit is synced, and update the readiness
+	// again once this cycle completes.
+	if readiness := p.options().Readiness; readiness != nil {
+		readiness.update(state, gs)
+		defer readiness.update(state, gs)
+	}
+
 	// If failed to fetch the source commit and directory, set `.status.source` to fail early.
 	// Otherwise, set `.status.rendering` before `.status.source` because the parser needs to
 	// read and parse the configs after rendering is done and there might have errors.
@@ -230,6 +299,7 @@ func run(ctx context.Context, p Parser, trigger string, state *reconcilerState)
 			}
 		}
 		state.invalidate(status.Append(gs.errs, setSourceStatusErr))
+		result = recordReconcileCycle(ctx, false)
 		return
 	}
 
@@ -243,6 +313,23 @@ func run(ctx context.Context, p Parser, trigger string, state *reconcilerState)
 		doneFilePath := p.options().RepoRoot.Join(cmpath.RelativeSlash(hydrate.DoneFile)).OSPath()
 		_, err := os.Stat(doneFilePath)
 		if os.IsNotExist(err) || (err == nil && hydrate.DoneCommit(doneFilePath) != gs.commit) {
+			renderingTimeout := p.options().RenderingTimeout
+			if renderingTimeout > 0 && time.Since(state.renderingStartTime(gs.commit)) > renderingTimeout {
+				rs.message = RenderingFailed
+				rs.lastUpdate = metav1.Now()
+				rs.errs = status.RenderingTimeoutError.Sprintf(
+					"rendering of commit %q did not complete within %v, check the logs of the %s container",
+					gs.commit, renderingTimeout, reconcilermanager.HydrationController).Build()
+				klog.V(3).Infof("Updating rendering status (before read): %#v", rs)
+				setRenderingStatusErr := p.setRenderingStatus(ctx, state.renderingStatus, rs)
+				if setRenderingStatusErr == nil {
+					state.renderingStatus = rs
+					state.syncingConditionLastUpdate = rs.lastUpdate
+				}
+				state.invalidate(status.Append(rs.errs, setRenderingStatusErr))
+				result = recordReconcileCycle(ctx, false)
+				return
+			}
 			rs.message = RenderingInProgress
 			rs.lastUpdate = metav1.Now()
 			klog.V(3).Infof("Updating rendering status (before read): %#v", rs)
@@ -254,6 +341,7 @@ func run(ctx context.Context, p Parser, trigger string, state *reconcilerState)
 			} else {
 				var m status.MultiError
 				state.invalidate(status.Append(m, setRenderingStatusErr))
+				result = recordReconcileCycle(ctx, false)
 			}
 			return
 		}
@@ -268,6 +356,7 @@ func run(ctx context.Context, p Parser, trigger string, state *reconcilerState)
 				state.syncingConditionLastUpdate = rs.lastUpdate
 			}
 			state.invalidate(status.Append(rs.errs, setRenderingStatusErr))
+			result = recordReconcileCycle(ctx, false)
 			return
 		}
 	}
@@ -281,6 +370,7 @@ func run(ctx context.Context, p Parser, trigger string, state *reconcilerState)
 	}
 	if errs := read(ctx, p, trigger, state, ps); errs != nil {
 		state.invalidate(errs)
+		result = recordReconcileCycle(ctx, false)
 		return
 	}
 
@@ -292,22 +382,50 @@ func run(ctx context.Context, p Parser, trigger string, state *reconcilerState)
 		state.retryTimer.Reset(state.retryPeriod)
 	}
 
+	// Coalesce the commits detected within the debounce period, and apply
+	// the latest one once it elapses, even if no new commit is detected then.
+	debounced := state.debouncing()
+	if wait := state.debounceWait(p.options().ApplyDebouncePeriod, newSyncDir != oldSyncDir); wait > 0 {
+		klog.Infof("Waiting %v for more commits before applying commit %s", wait.Round(time.Second), gs.commit)
+		return
+	}
+
 	// The parse-apply-watch sequence will be skipped if the trigger type is `triggerReimport` and
 	// there is no new source changes. The reasons are:
 	//   * If a former parse-apply-watch sequence for syncDir succeeded, there is no need to run the sequence again;
 	//   * If all the former parse-apply-watch sequences for syncDir failed, the next retry will call the sequence.
-	if trigger == triggerReimport && oldSyncDir == newSyncDir {
+	if trigger == triggerReimport && oldSyncDir == newSyncDir && !debounced {
 		return
 	}
 
 	errs := parseAndUpdate(ctx, p, trigger, state)
 	if errs != nil {
 		state.invalidate(errs)
+		result = recordReconcileCycle(ctx, false)
+		return
+	}
+
+	// Objects deferred until their CRD is established are not errors, but
+	// the apply is not complete until they are applied.
+	if pending := p.options().Applier.Pending(); len(pending) > 0 {
+		state.deferPending(len(pending))
+		result = recordReconcileCycle(ctx, true)
 		return
 	}
 
 	// Only checkpoint the state after *everything* succeeded, including status update.
 	state.checkpoint()
+	result = recordReconcileCycle(ctx, true)
+}
+
+// recordReconcileCycle records a completed reconcile cycle, and returns the
+// status of the run which completed it for the trigger metrics.
+func recordReconcileCycle(ctx context.Context, succeeded bool) string {
+	metrics.RecordReconcileCycle(ctx, succeeded)
+	if succeeded {
+		return metrics.StatusSuccess
+	}
+	return metrics.StatusError
 }
 
 // read reads config files from source if no rendering is needed, or from hydrated output if rendering is done.
@@ -363,8 +481,14 @@ func parseHydrationState(p Parser, srcState sourceState, hydrationStatus renderi
 	options := p.options()
 	if !options.RenderingEnabled {
 		hydrationStatus.message = RenderingSkipped
+		hydrationStatus.tool = renderingToolNone
+		if options.SourceType == v1beta1.HelmSource {
+			// helm-sync renders the chart before the reconciler reads it.
+			hydrationStatus.tool = hydrate.Helm
+		}
 		return srcState, hydrationStatus
 	}
+	hydrationStatus.tool = hydrate.Kustomize
 	// Check if the hydratedRoot directory exists.
 	// If exists, read the hydrated directory. Otherwise, fail.
 	absHydratedRoot, err := cmpath.AbsoluteOS(options.HydratedRoot)
@@ -376,6 +500,14 @@ func parseHydrationState(p Parser, srcState sourceState, hydrationStatus renderi
 
 	var hydrationErr hydrate.HydrationError
 	if _, err := os.Stat(absHydratedRoot.OSPath()); err == nil {
+		// Record the versions of the tools which rendered the commit.
+		doneFile := hydrate.ReadDoneFile(options.RepoRoot.Join(cmpath.RelativeSlash(hydrate.DoneFile)).OSPath())
+		if doneFile.Commit == srcState.commit {
+			hydrationStatus.kustomizeVersion = doneFile.KustomizeVersion
+			hydrationStatus.helmVersion = doneFile.HelmVersion
+			hydrationStatus.sourceChecksum = doneFile.SourceChecksum
+			hydrationStatus.duration = doneFile.Duration
+		}
 		// pull the hydrated commit and directory with retries within 1 minute.
 		srcState, hydrationErr = options.readHydratedDirWithRetry(util.HydratedRetryBackoff, absHydratedRoot, options.ReconcilerName, srcState)
 		if hydrationErr != nil {
@@ -391,6 +523,7 @@ func parseHydrationState(p Parser, srcState sourceState, hydrationStatus renderi
 	} else {
 		// Source of truth does not require hydration, but hydration-controller is running
 		hydrationStatus.message = RenderingNotRequired
+		hydrationStatus.tool = renderingToolNone
 		hydrationStatus.requiresRendering = false
 		err := hydrate.NewTransientError(fmt.Errorf("sync source contains only wet configs and hydration-controller is running"))
 		hydrationStatus.errs = status.HydrationError(err.Code(), err)
@@ -399,6 +532,29 @@ func parseHydrationState(p Parser, srcState sourceState, hydrationStatus renderi
 	return srcState, hydrationStatus
 }
 
+// verifyRenderedSource verifies that the hydrated configs of the commit were
+// rendered from the source configs in sourceDir, by comparing the checksum of
+// sourceDir with the checksum recorded in the done file by the
+// hydration-controller. It catches stale or partial renderings.
+func verifyRenderedSource(options *Options, commit string, sourceDir cmpath.Absolute) status.Error {
+	doneFile := hydrate.ReadDoneFile(options.RepoRoot.Join(cmpath.RelativeSlash(hydrate.DoneFile)).OSPath())
+	if doneFile.Commit != commit || doneFile.SourceChecksum == "" {
+		// The done file was not written for this commit, or was written by a
+		// hydration-controller which does not record the checksum.
+		return nil
+	}
+	checksum, err := hydrate.ComputeChecksum(sourceDir.OSPath())
+	if err != nil {
+		return status.InternalHydrationError(err, "unable to verify the hydrated configs of commit %q", commit)
+	}
+	if checksum != doneFile.SourceChecksum {
+		return status.RenderingChecksumMismatchError.Sprintf(
+			"the hydrated configs of commit %q were not rendered from the source configs in %s: got checksum %s, want %s. Check the logs of the %s container",
+			commit, sourceDir.OSPath(), doneFile.SourceChecksum, checksum, reconcilermanager.HydrationController).Build()
+	}
+	return nil
+}
+
 // readFromSource reads the source or hydrated configs, checks whether the sourceState in
 // the cache is up-to-date. If the cache is not up-to-date, reads all the source or hydrated files.
 // readFromSource returns the rendering status and source status.
@@ -414,6 +570,7 @@ func readFromSource(ctx context.Context, p Parser, trigger string, recState *rec
 		commit: srcState.commit,
 	}
 
+	sourceSyncDir := srcState.syncDir
 	srcState, hydrationStatus = parseHydrationState(p, srcState, hydrationStatus)
 	if hydrationStatus.errs != nil {
 		return hydrationStatus, srcStatus
@@ -423,6 +580,22 @@ func readFromSource(ctx context.Context, p Parser, trigger string, recState *rec
 		return hydrationStatus, srcStatus
 	}
 
+	// Record the rendering of each new commit once, with the duration
+	// measured by the hydration-controller.
+	if hydrationStatus.message == RenderingSucceeded && hydrationStatus.duration != nil {
+		metrics.RecordRenderingDuration(ctx, hydrationStatus.duration.Duration)
+	}
+
+	if hydrationStatus.message == RenderingSucceeded {
+		// Only verify new hydrated configs, because verifying reads all the
+		// source files.
+		if err := verifyRenderedSource(options, srcState.commit, sourceSyncDir); err != nil {
+			hydrationStatus.message = RenderingFailed
+			hydrationStatus.errs = err
+			return hydrationStatus, srcStatus
+		}
+	}
+
 	// Read all the files under srcState.syncDir
 	srcStatus.errs = options.readConfigFiles(&srcState)
 
@@ -458,10 +631,23 @@ func parseSource(ctx context.Context, p Parser, trigger string, state *reconcile
 
 	start := time.Now()
 	objs, sourceErrs := p.parseSource(ctx, state.cache.source)
+	if p.options().ValidateWithAdmission && !status.HasBlockingErrors(sourceErrs) {
+		sourceErrs = status.Append(sourceErrs, validateWithAdmission(ctx, p.options().k8sClient(), p.options().FieldManager, objs))
+	}
 	metrics.RecordParserDuration(ctx, trigger, "parse", metrics.StatusTagKey(sourceErrs), start)
 	state.cache.setParserResult(objs, sourceErrs)
 
-	if !status.HasBlockingErrors(sourceErrs) {
+	// Objects of an unknown type may be backed by a CRD which was added after
+	// the API resources were discovered, so discover them again on the next
+	// parse instead of waiting for the discovery cache to expire. The root
+	// reconcilers also reset the cache when a CRD changes.
+	if sourceErrs != nil || len(state.cache.objsSkipped) > 0 {
+		discovery.Reset(p.options().discoveryClient())
+	}
+
+	// The admission webhook only protects the objects managed by Config Sync,
+	// and the objects observed in observe mode are not managed.
+	if !status.HasBlockingErrors(sourceErrs) && !p.options().ObserveOnly {
 		err := webhookconfiguration.Update(ctx, p.options().k8sClient(), p.options().discoveryClient(), objs)
 		if err != nil {
 			// Don't block if updating the admission webhook fails.
@@ -503,6 +689,26 @@ func parseAndUpdate(ctx context.Context, p Parser, trigger string, state *reconc
 		return sourceErrs
 	}
 
+	if p.options().PauseApply {
+		// Skip the updater, but keep the remediator watching the declared
+		// resources, so that drift is still reported.
+		klog.V(3).Info("Updater skipped: applying is paused")
+		syncErrs := p.options().Observe(ctx, &state.cache)
+
+		klog.V(3).Info("Updating sync status (paused)")
+		if err := setSyncStatus(ctx, p, state, false, syncErrs); err != nil {
+			syncErrs = status.Append(syncErrs, err)
+		}
+		return status.Append(sourceErrs, syncErrs)
+	}
+
+	if wait := state.breaker.untilProbe(time.Now()); wait > 0 {
+		// Skip the updater, to not add to the load of the API server, and
+		// report the errors of the last sync until the next probe.
+		klog.V(3).Infof("Updater skipped: the circuit breaker is open, the next probe is in %v", wait)
+		return status.Append(sourceErrs, state.breaker.errs)
+	}
+
 	// Create a new context with its cancellation function.
 	ctxForUpdateSyncStatus, cancel := context.WithCancel(context.Background())
 
@@ -513,6 +719,12 @@ func parseAndUpdate(ctx context.Context, p Parser, trigger string, state *reconc
 	syncErrs := p.options().Update(ctx, &state.cache)
 	metrics.RecordParserDuration(ctx, trigger, "update", metrics.StatusTagKey(syncErrs), start)
 	klog.V(3).Info("Updater stopped")
+	state.breaker.record(syncErrs, time.Now())
+	if state.breaker.isOpen() {
+		// Stop remediating drift too, until a probe succeeds and the Updater
+		// resumes the remediator, to not add to the load of the API server.
+		p.options().Remediator.Pause()
+	}
 
 	// This is to terminate `updateSyncStatusPeriodically`.
 	cancel()
@@ -531,10 +743,18 @@ func parseAndUpdate(ctx context.Context, p Parser, trigger string, state *reconc
 func setSyncStatus(ctx context.Context, p Parser, state *reconcilerState, syncing bool, syncErrs status.MultiError) error {
 	// Update the RSync status, if necessary
 	newSyncStatus := syncStatus{
-		syncing:    syncing,
-		commit:     state.cache.source.commit,
-		errs:       syncErrs,
-		lastUpdate: metav1.Now(),
+		syncing:               syncing,
+		paused:                p.options().PauseApply,
+		observing:             p.options().ObserveOnly,
+		webhookEnforcing:      p.options().WebhookEnabled,
+		apiServerUnavailable:  state.breaker.message(),
+		commit:                state.cache.source.commit,
+		errs:                  syncErrs,
+		implicitNamespaces:    state.cache.implicitNamespaces,
+		managedNamespaceCount: state.cache.managedNamespaceCount,
+		frequentlyEdited:      frequentlyEditedObjects(p.options().Remediator.FrequentlyEdited()),
+		pendingCount:          len(p.options().Applier.Pending()),
+		lastUpdate:            metav1.Now(),
 	}
 	if state.needToSetSyncStatus(newSyncStatus) {
 		if err := p.SetSyncStatus(ctx, newSyncStatus); err != nil {
@@ -553,8 +773,13 @@ func setSyncStatus(ctx context.Context, p Parser, state *reconcilerState, syncin
 			}
 		}
 	}
-	// Report conflict errors to the remote manager, if it's a RootSync.
-	if err := reportRootSyncConflicts(ctx, p.K8sClient(), conflictErrs); err != nil {
+	for _, conflictErr := range state.newConflicts(conflictErrs) {
+		metrics.RecordManagementConflict(ctx,
+			managerReconcilerType(conflictErr.ConflictingManager()),
+			managerReconcilerType(conflictErr.NewManager()))
+	}
+	// Report conflict errors to the remote manager.
+	if err := reportConflicts(ctx, p.K8sClient(), conflictErrs); err != nil {
 		return errors.Wrapf(err, "failed to report remote conflicts")
 	}
 	return nil
@@ -564,8 +789,7 @@ func setSyncStatus(ctx context.Context, p Parser, state *reconcilerState, syncin
 // cancellation function of the context is called.
 func updateSyncStatusPeriodically(ctx context.Context, p Parser, state *reconcilerState) {
 	klog.V(3).Info("Periodic sync status updates starting...")
-	updatePeriod := p.options().StatusUpdatePeriod
-	updateTimer := time.NewTimer(updatePeriod)
+	updateTimer := time.NewTimer(p.options().jitteredStatusUpdatePeriod())
 	defer updateTimer.Stop()
 	for {
 		select {
@@ -580,14 +804,25 @@ func updateSyncStatusPeriodically(ctx context.Context, p Parser, state *reconcil
 				klog.Warningf("failed to update sync status: %v", err)
 			}
 
-			updateTimer.Reset(updatePeriod) // Schedule status update attempt
+			updateTimer.Reset(p.options().jitteredStatusUpdatePeriod()) // Schedule status update attempt
 		}
 	}
 }
 
-// reportRootSyncConflicts reports conflicts to the RootSync that manages the
-// conflicting resources.
-func reportRootSyncConflicts(ctx context.Context, k8sClient client.Client, conflictErrs []status.ManagementConflictError) error {
+// managerReconcilerType returns the type of the reconciler identified by the
+// manager annotation value: "root" or "namespace". Unlike the manager scope,
+// which is the namespace of a RepoSync, the type has a bounded cardinality, so
+// it is safe to use as a metric tag.
+func managerReconcilerType(manager string) string {
+	if scope, _ := declared.ManagerScopeAndName(manager); scope == declared.RootReconciler {
+		return "root"
+	}
+	return "namespace"
+}
+
+// reportConflicts reports conflicts to the RootSync or RepoSync that manages
+// the conflicting resources.
+func reportConflicts(ctx context.Context, k8sClient client.Client, conflictErrs []status.ManagementConflictError) error {
 	if len(conflictErrs) == 0 {
 		return nil
 	}
@@ -601,7 +836,8 @@ func reportRootSyncConflicts(ctx context.Context, k8sClient client.Client, confl
 	for conflictingManager, conflictErrors := range conflictingManagerErrors {
 		scope, name := declared.ManagerScopeAndName(conflictingManager)
 		if scope == declared.RootReconciler {
-			// RootSync applier uses PolicyAdoptAll.
+			// RootSync applier uses PolicyAdoptAll, unless its conflictPolicy
+			// is adoptIfNoInventory.
 			// So it may fight, if the webhook is disabled.
 			// Report the conflict to the other RootSync to make it easier to detect.
 			klog.Infof("Detected conflict with RootSync manager %q", conflictingManager)
@@ -611,7 +847,20 @@ func reportRootSyncConflicts(ctx context.Context, k8sClient client.Client, confl
 		} else {
 			// RepoSync applier uses PolicyAdoptIfNoInventory.
 			// So it won't fight, even if the webhook is disabled.
+			// Report the conflict to the RepoSync anyway, so that the namespace
+			// owners can see it without access to the RootSyncs.
 			klog.Infof("Detected conflict with RepoSync manager %q", conflictingManager)
+			if err := prependRepoSyncRemediatorStatus(ctx, k8sClient, scope, name, conflictErrors, defaultDenominator); err != nil {
+				// Namespace reconcilers may only update the RepoSyncs in their own
+				// namespace, and the root reconcilers may be bound to a custom
+				// ClusterRole which excludes the RepoSyncs.
+				// Reporting to the RepoSync is best effort in that case.
+				if apierrors.IsForbidden(errors.Cause(err)) {
+					klog.Warningf("Not permitted to report conflicts to RepoSync %s/%s: %v", scope, name, err)
+					continue
+				}
+				return errors.Wrapf(err, "failed to update RepoSync %s/%s to prepend remediator conflicts", scope, name)
+			}
 		}
 	}
 	return nil
@@ -16,6 +16,7 @@ package parse
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,6 +26,11 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -37,6 +43,9 @@ import (
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/reposync"
 	"kpt.dev/configsync/pkg/rootsync"
 	"kpt.dev/configsync/pkg/status"
 	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
@@ -44,6 +53,7 @@ import (
 	"kpt.dev/configsync/pkg/testing/openapitest"
 	"kpt.dev/configsync/pkg/util"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -98,6 +108,66 @@ func writeFile(rootDir, file, content string) error {
 	return os.WriteFile(errFile, []byte(content), 0644)
 }
 
+func TestJitteredStatusUpdatePeriod(t *testing.T) {
+	opts := &Options{StatusUpdatePeriod: 30 * time.Second}
+	maxPeriod := time.Duration(float64(opts.StatusUpdatePeriod) * (1 + configsync.ReconcilerSyncStatusUpdateJitterFactor))
+	for i := 0; i < 100; i++ {
+		period := opts.jitteredStatusUpdatePeriod()
+		assert.GreaterOrEqual(t, period, opts.StatusUpdatePeriod)
+		assert.LessOrEqual(t, period, maxPeriod)
+	}
+}
+
+func TestVerifyRenderedSource(t *testing.T) {
+	const commit = "abcd123"
+	repoRoot := t.TempDir()
+	sourceDir := filepath.Join(repoRoot, "source", commit)
+	require.NoError(t, os.MkdirAll(sourceDir, os.ModePerm))
+	require.NoError(t, writeFile(sourceDir, "kustomization.yaml", "namespace: foo"))
+	checksum, err := hydrate.ComputeChecksum(sourceDir)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		doneFile hydrate.DoneFileMetadata
+		wantErr  bool
+	}{
+		{
+			name:     "matching checksum",
+			doneFile: hydrate.DoneFileMetadata{Commit: commit, SourceChecksum: checksum},
+		},
+		{
+			name:     "mismatching checksum",
+			doneFile: hydrate.DoneFileMetadata{Commit: commit, SourceChecksum: "stale"},
+			wantErr:  true,
+		},
+		{
+			name:     "no checksum",
+			doneFile: hydrate.DoneFileMetadata{Commit: commit},
+		},
+		{
+			name:     "checksum of a different commit",
+			doneFile: hydrate.DoneFileMetadata{Commit: "efgh456", SourceChecksum: "stale"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := json.Marshal(tc.doneFile)
+			require.NoError(t, err)
+			require.NoError(t, writeFile(repoRoot, hydrate.DoneFile, string(content)))
+			opts := &Options{Files: Files{FileSource: FileSource{RepoRoot: cmpath.Absolute(repoRoot)}}}
+			verifyErr := verifyRenderedSource(opts, commit, cmpath.Absolute(sourceDir))
+			if tc.wantErr {
+				require.NotNil(t, verifyErr)
+				assert.Equal(t, status.RenderingChecksumMismatchErrorCode, verifyErr.Code())
+			} else {
+				assert.Nil(t, verifyErr)
+			}
+		})
+	}
+}
+
 func TestSplitObjects(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -167,37 +237,53 @@ func TestRun(t *testing.T) {
 		commit                     string
 		renderingEnabled           bool
 		hasKustomization           bool
+		sourceFiles                map[string]string
+		webhookEnabled             bool
+		renderingTimeout           time.Duration
+		renderingElapsed           time.Duration
 		hydratedRootExist          bool
 		retryCap                   time.Duration
 		srcRootCreateLatency       time.Duration
 		sourceError                string
 		hydratedError              string
 		hydrationDone              bool
+		kustomizeVersion           string
+		renderingDuration          time.Duration
+		pending                    []core.ID
 		needRetry                  bool
 		expectedMsg                string
 		expectedErrorSourceRefs    []v1beta1.ErrorSource
 		expectedErrors             string
 		expectedStateSourceErrs    status.MultiError
 		expectedStateRenderingErrs status.MultiError
+		expectedManagedNsCount     int
+		expectedWebhookEnforcing   bool
+		expectedRenderingTool      string
+		expectedSummaryState       configsync.SyncState
+		expectedSummaryErrorCount  int
 	}{
 		{
-			id:                      "0",
-			name:                    "source commit directory isn't created within the retry cap",
-			retryCap:                5 * time.Millisecond,
-			srcRootCreateLatency:    10 * time.Millisecond,
-			needRetry:               true,
-			expectedMsg:             "Source",
-			expectedErrorSourceRefs: []v1beta1.ErrorSource{v1beta1.SourceError},
-			expectedErrors:          fmt.Sprintf("1 error(s)\n\n\n[1] KNV2004: failed to check the status of the source root directory \"%s/0/source\": stat %s/0/source: no such file or directory\n\nFor more information, see https://g.co/cloud/acm-errors#knv2004\n", tempDir, tempDir),
-			expectedStateSourceErrs: status.SourceError.Sprintf("KNV2004: failed to check the status of the source root directory \"%s/0/source\": stat %s/0/source: no such file or directory\n\nFor more information, see https://g.co/cloud/acm-errors#knv2004\n", tempDir, tempDir).Build(),
+			id:                        "0",
+			name:                      "source commit directory isn't created within the retry cap",
+			retryCap:                  5 * time.Millisecond,
+			srcRootCreateLatency:      10 * time.Millisecond,
+			needRetry:                 true,
+			expectedMsg:               "Source",
+			expectedErrorSourceRefs:   []v1beta1.ErrorSource{v1beta1.SourceError},
+			expectedErrors:            fmt.Sprintf("1 error(s)\n\n\n[1] KNV2004: failed to check the status of the source root directory \"%s/0/source\": stat %s/0/source: no such file or directory\n\nFor more information, see https://g.co/cloud/acm-errors#knv2004\n", tempDir, tempDir),
+			expectedStateSourceErrs:   status.SourceError.Sprintf("KNV2004: failed to check the status of the source root directory \"%s/0/source\": stat %s/0/source: no such file or directory\n\nFor more information, see https://g.co/cloud/acm-errors#knv2004\n", tempDir, tempDir).Build(),
+			expectedSummaryState:      configsync.SyncStateError,
+			expectedSummaryErrorCount: 1,
 		},
 		{
-			id:                   "1",
-			name:                 "source commit directory created within the retry cap",
-			retryCap:             100 * time.Millisecond,
-			srcRootCreateLatency: 5 * time.Millisecond,
-			needRetry:            false,
-			expectedMsg:          "Sync Completed",
+			id:                    "1",
+			name:                  "source commit directory created within the retry cap",
+			retryCap:              100 * time.Millisecond,
+			srcRootCreateLatency:  5 * time.Millisecond,
+			needRetry:             false,
+			expectedMsg:           "Sync Completed",
+			expectedRenderingTool: "none",
+			expectedSummaryState:  configsync.SyncStateSynced,
 		},
 		{
 			id:                      "2",
@@ -207,16 +293,19 @@ func TestRun(t *testing.T) {
 			expectedErrors:          "1 error(s)\n\n\n[1] KNV2004: error in the git-sync container: git sync permission issue\n\nFor more information, see https://g.co/cloud/acm-errors#knv2004\n",
 			expectedStateSourceErrs: status.SourceError.Sprint("error in the git-sync container: git sync permission issue").Build(),
 			// source error is exposed to the RootSync status
-			expectedMsg:             "Source",
-			expectedErrorSourceRefs: []v1beta1.ErrorSource{v1beta1.SourceError},
+			expectedMsg:               "Source",
+			expectedErrorSourceRefs:   []v1beta1.ErrorSource{v1beta1.SourceError},
+			expectedSummaryState:      configsync.SyncStateError,
+			expectedSummaryErrorCount: 1,
 		},
 		{
-			id:                "3",
-			name:              "rendering in progress",
-			renderingEnabled:  true,
-			hydratedRootExist: true,
-			needRetry:         true,
-			expectedMsg:       "Rendering is still in progress",
+			id:                   "3",
+			name:                 "rendering in progress",
+			renderingEnabled:     true,
+			hydratedRootExist:    true,
+			needRetry:            true,
+			expectedMsg:          "Rendering is still in progress",
+			expectedSummaryState: configsync.SyncStateSyncing,
 		},
 		{
 			id:                         "4",
@@ -231,25 +320,29 @@ func TestRun(t *testing.T) {
 			expectedErrors:             "1 error(s)\n\n\n[1] KNV1068: rendering error\n\nFor more information, see https://g.co/cloud/acm-errors#knv1068\n",
 			expectedStateRenderingErrs: status.HydrationError(status.ActionableHydrationErrorCode, fmt.Errorf("rendering error")),
 			// rendering error is exposed to the RootSync status
-			expectedErrorSourceRefs: []v1beta1.ErrorSource{v1beta1.RenderingError},
+			expectedErrorSourceRefs:   []v1beta1.ErrorSource{v1beta1.RenderingError},
+			expectedSummaryState:      configsync.SyncStateError,
+			expectedSummaryErrorCount: 1,
 		},
 		{
-			id:                "5",
-			name:              "successful read",
-			renderingEnabled:  true,
-			hasKustomization:  true,
-			hydratedRootExist: true,
-			hydrationDone:     true,
-			needRetry:         false,
-			expectedMsg:       "Sync Completed",
+			id:                   "5",
+			name:                 "successful read",
+			renderingEnabled:     true,
+			hasKustomization:     true,
+			hydratedRootExist:    true,
+			hydrationDone:        true,
+			needRetry:            false,
+			expectedMsg:          "Sync Completed",
+			expectedSummaryState: configsync.SyncStateSynced,
 		},
 		{
-			id:                "6",
-			name:              "successful read without hydration",
-			hydratedRootExist: false,
-			hydrationDone:     false,
-			needRetry:         false,
-			expectedMsg:       "Sync Completed",
+			id:                   "6",
+			name:                 "successful read without hydration",
+			hydratedRootExist:    false,
+			hydrationDone:        false,
+			needRetry:            false,
+			expectedMsg:          "Sync Completed",
+			expectedSummaryState: configsync.SyncStateSynced,
 		},
 		{
 			id:                         "7",
@@ -263,6 +356,8 @@ func TestRun(t *testing.T) {
 			expectedErrorSourceRefs:    []v1beta1.ErrorSource{v1beta1.RenderingError},
 			expectedErrors:             "1 error(s)\n\n\n[1] KNV2016: sync source contains only wet configs and hydration-controller is running\n\nFor more information, see https://g.co/cloud/acm-errors#knv2016\n",
 			expectedStateRenderingErrs: status.HydrationError(status.TransientErrorCode, fmt.Errorf("sync source contains only wet configs and hydration-controller is running")),
+			expectedSummaryState:       configsync.SyncStateError,
+			expectedSummaryErrorCount:  1,
 		},
 		{
 			id:                         "8",
@@ -276,6 +371,96 @@ func TestRun(t *testing.T) {
 			expectedErrorSourceRefs:    []v1beta1.ErrorSource{v1beta1.RenderingError},
 			expectedErrors:             "1 error(s)\n\n\n[1] KNV2016: sync source contains dry configs and hydration-controller is not running\n\nFor more information, see https://g.co/cloud/acm-errors#knv2016\n",
 			expectedStateRenderingErrs: status.HydrationError(status.TransientErrorCode, fmt.Errorf("sync source contains dry configs and hydration-controller is not running")),
+			expectedSummaryState:       configsync.SyncStateError,
+			expectedSummaryErrorCount:  1,
+		},
+		{
+			id:                "9",
+			name:              "successful sync of objects in multiple namespaces",
+			hydratedRootExist: false,
+			hydrationDone:     false,
+			sourceFiles: map[string]string{
+				"ns-foo.yaml":          "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n",
+				"role-foo.yaml":        "apiVersion: rbac.authorization.k8s.io/v1\nkind: Role\nmetadata:\n  name: reader\n  namespace: foo\n",
+				"role-bar-reader.yaml": "apiVersion: rbac.authorization.k8s.io/v1\nkind: Role\nmetadata:\n  name: reader\n  namespace: bar\n",
+				"role-bar-writer.yaml": "apiVersion: rbac.authorization.k8s.io/v1\nkind: Role\nmetadata:\n  name: writer\n  namespace: bar\n",
+			},
+			needRetry:              false,
+			expectedMsg:            "Sync Completed",
+			expectedManagedNsCount: 2,
+			expectedSummaryState:   configsync.SyncStateSynced,
+		},
+		{
+			id:                       "10",
+			name:                     "successful sync with the admission webhook enabled",
+			webhookEnabled:           true,
+			needRetry:                false,
+			expectedMsg:              "Sync Completed",
+			expectedWebhookEnforcing: true,
+			expectedSummaryState:     configsync.SyncStateSynced,
+		},
+		{
+			id:                      "11",
+			name:                    "rendering timed out",
+			renderingEnabled:        true,
+			renderingTimeout:        time.Minute,
+			renderingElapsed:        time.Hour,
+			hydratedRootExist:       true,
+			needRetry:               true,
+			expectedMsg:             "Rendering failed",
+			expectedErrors:          "1 error(s)\n\n\n[1] KNV2020: rendering of commit \"abcd123\" did not complete within 1m0s, check the logs of the hydration-controller container\n\nFor more information, see https://g.co/cloud/acm-errors#knv2020\n",
+			expectedErrorSourceRefs: []v1beta1.ErrorSource{v1beta1.RenderingError},
+			expectedStateRenderingErrs: status.RenderingTimeoutError.Sprintf(
+				"rendering of commit %q did not complete within %v, check the logs of the %s container",
+				"abcd123", time.Minute, reconcilermanager.HydrationController).Build(),
+			expectedSummaryState:      configsync.SyncStateError,
+			expectedSummaryErrorCount: 1,
+		},
+		{
+			id:                   "12",
+			name:                 "rendering in progress within the timeout",
+			renderingEnabled:     true,
+			renderingTimeout:     time.Hour,
+			renderingElapsed:     time.Minute,
+			hydratedRootExist:    true,
+			needRetry:            true,
+			expectedMsg:          "Rendering is still in progress",
+			expectedSummaryState: configsync.SyncStateSyncing,
+		},
+		{
+			id:                    "13",
+			name:                  "successful read with the rendering tool versions",
+			renderingEnabled:      true,
+			hasKustomization:      true,
+			hydratedRootExist:     true,
+			hydrationDone:         true,
+			kustomizeVersion:      hydrate.KustomizeVersion,
+			renderingDuration:     3 * time.Second,
+			needRetry:             false,
+			expectedMsg:           "Sync Completed",
+			expectedRenderingTool: hydrate.Kustomize,
+			expectedSummaryState:  configsync.SyncStateSynced,
+		},
+		{
+			id:                    "14",
+			name:                  "objects pending until their CRD is established",
+			pending:               []core.ID{{GroupKind: kinds.Anvil().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "anvil"}}},
+			needRetry:             true,
+			expectedMsg:           "1 object is pending until its CustomResourceDefinition is established",
+			expectedRenderingTool: "none",
+			expectedSummaryState:  configsync.SyncStateSyncing,
+		},
+		{
+			id:   "15",
+			name: "multiple objects pending until their CRD is established",
+			pending: []core.ID{
+				{GroupKind: kinds.Anvil().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "anvil"}},
+				{GroupKind: kinds.Anvil().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "anvil-2"}},
+			},
+			needRetry:             true,
+			expectedMsg:           "2 objects are pending until their CustomResourceDefinition is established",
+			expectedRenderingTool: "none",
+			expectedSummaryState:  configsync.SyncStateSyncing,
 		},
 	}
 
@@ -325,6 +510,12 @@ func TestRun(t *testing.T) {
 							}
 						}
 
+						for file, content := range tc.sourceFiles {
+							if err = writeFile(sourceDir, file, content); err != nil {
+								return fmt.Errorf("failed to write source file: %v", err)
+							}
+						}
+
 						if tc.sourceError != "" {
 							if err = writeFile(sourceRoot, hydrate.ErrorFile, tc.sourceError); err != nil {
 								return fmt.Errorf("failed to write source error file: %v", err)
@@ -336,7 +527,19 @@ func TestRun(t *testing.T) {
 							}
 						}
 						if tc.hydrationDone {
-							if err = writeFile(rootDir, hydrate.DoneFile, sourceCommit); err != nil {
+							doneFileContent := sourceCommit
+							if tc.kustomizeVersion != "" {
+								doneFile := hydrate.DoneFileMetadata{Commit: sourceCommit, KustomizeVersion: tc.kustomizeVersion}
+								if tc.renderingDuration > 0 {
+									doneFile.Duration = &metav1.Duration{Duration: tc.renderingDuration}
+								}
+								content, err := json.Marshal(doneFile)
+								if err != nil {
+									return fmt.Errorf("failed to encode done file: %v", err)
+								}
+								doneFileContent = string(content)
+							}
+							if err = writeFile(rootDir, hydrate.DoneFile, doneFileContent); err != nil {
 								return fmt.Errorf("failed to write done file: %v", err)
 							}
 						}
@@ -363,11 +566,19 @@ func TestRun(t *testing.T) {
 				SourceBranch: "main",
 			}
 			parser := newParser(t, fs, tc.renderingEnabled)
+			parser.options().Applier = &fakeApplier{pending: tc.pending}
+			parser.options().WebhookEnabled = tc.webhookEnabled
+			parser.options().RenderingTimeout = tc.renderingTimeout
 			state := &reconcilerState{
 				backoff:     defaultBackoff(),
 				retryTimer:  time.NewTimer(configsync.DefaultReconcilerRetryPeriod),
 				retryPeriod: configsync.DefaultReconcilerRetryPeriod,
 			}
+			if tc.renderingElapsed > 0 {
+				// Simulate the commit waiting for rendering for some time.
+				state.renderingCommit = sourceCommit
+				state.renderingStart = time.Now().Add(-tc.renderingElapsed)
+			}
 			t.Logf("start running test at %v", time.Now())
 			run(context.Background(), parser, triggerReimport, state)
 
@@ -388,7 +599,30 @@ func TestRun(t *testing.T) {
 			expectedRSSourceErrs := status.ToCSE(state.sourceStatus.errs)
 			expectedRSRenderingErrs := status.ToCSE(state.renderingStatus.errs)
 			testutil.AssertEqual(t, expectedRSSourceErrs, rs.Status.Source.Errors, "[%s] unexpected source errors in RootSync return", tc.name)
+			for _, source := range rs.Status.Source.Sources {
+				testutil.AssertEqual(t, v1beta1.GitSource, source.SourceType, "[%s] unexpected source type in RootSync return", tc.name)
+				testutil.AssertEqual(t, expectedRSSourceErrs, source.Errors, "[%s] unexpected per-source errors in RootSync return", tc.name)
+			}
 			testutil.AssertEqual(t, expectedRSRenderingErrs, rs.Status.Rendering.Errors, "[%s] unexpected rendering errors in RootSync return", tc.name)
+			testutil.AssertEqual(t, tc.kustomizeVersion, rs.Status.Rendering.KustomizeVersion, "[%s] unexpected kustomize version in RootSync return", tc.name)
+			if tc.expectedRenderingTool != "" {
+				testutil.AssertEqual(t, tc.expectedRenderingTool, rs.Status.Rendering.Tool, "[%s] unexpected rendering tool in RootSync return", tc.name)
+			}
+			if tc.renderingDuration > 0 {
+				testutil.AssertEqual(t, &metav1.Duration{Duration: tc.renderingDuration}, rs.Status.Rendering.Duration, "[%s] unexpected rendering duration in RootSync return", tc.name)
+			}
+			testutil.AssertEqual(t, tc.expectedManagedNsCount, rs.Status.Sync.ManagedNamespaceCount, "[%s] unexpected managed namespace count in RootSync return", tc.name)
+			testutil.AssertEqual(t, tc.expectedWebhookEnforcing, rs.Status.WebhookEnforcing, "[%s] unexpected webhook enforcing in RootSync return", tc.name)
+			require.NotNil(t, rs.Status.Summary, "[%s] missing summary in RootSync return", tc.name)
+			testutil.AssertEqual(t, v1beta1.StatusSummaryVersion, rs.Status.Summary.Version, "[%s] unexpected summary version in RootSync return", tc.name)
+			testutil.AssertEqual(t, tc.expectedSummaryState, rs.Status.Summary.State, "[%s] unexpected summary state in RootSync return", tc.name)
+			testutil.AssertEqual(t, tc.expectedSummaryErrorCount, rs.Status.Summary.ErrorCount, "[%s] unexpected summary error count in RootSync return", tc.name)
+			expectedSummaryCommit := sourceCommit
+			if tc.expectedStateSourceErrs != nil {
+				// The commit is unknown when the source cannot be read.
+				expectedSummaryCommit = ""
+			}
+			testutil.AssertEqual(t, expectedSummaryCommit, rs.Status.Summary.Commit, "[%s] unexpected summary commit in RootSync return", tc.name)
 
 			for _, c := range rs.Status.Conditions {
 				if c.Type == v1beta1.RootSyncSyncing {
@@ -402,3 +636,208 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+// forbiddenClient fails to get any object with a Forbidden error.
+type forbiddenClient struct {
+	client.Client
+}
+
+func (c *forbiddenClient) Get(_ context.Context, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return apierrors.NewForbidden(schema.GroupResource{Group: configsync.GroupName, Resource: "reposyncs"}, key.Name, fmt.Errorf("forbidden"))
+}
+
+func TestRun_TriggerMetrics(t *testing.T) {
+	require.NoError(t, view.Register(metrics.TriggersView))
+	defer view.Unregister(metrics.TriggersView)
+
+	rootDir := t.TempDir()
+	sourceRoot := filepath.Join(rootDir, "source")
+	sourceDir := filepath.Join(sourceRoot, symLink)
+	require.NoError(t, createRootDir(sourceRoot, "abcd123"))
+	require.NoError(t, writeFile(sourceDir, "ns-foo.yaml", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n"))
+
+	parser := newParser(t, FileSource{
+		SourceDir:  cmpath.Absolute(sourceDir),
+		RepoRoot:   cmpath.Absolute(rootDir),
+		SourceType: v1beta1.GitSource,
+	}, false)
+	state := &reconcilerState{
+		backoff:     defaultBackoff(),
+		retryTimer:  time.NewTimer(configsync.DefaultReconcilerRetryPeriod),
+		retryPeriod: configsync.DefaultReconcilerRetryPeriod,
+	}
+
+	// The first reimport syncs the commit, the second one has nothing to do,
+	// and a resync syncs the same commit again.
+	run(context.Background(), parser, triggerReimport, state)
+	run(context.Background(), parser, triggerReimport, state)
+	state.resetPartialCache()
+	run(context.Background(), parser, triggerResync, state)
+
+	rows, err := view.RetrieveData(metrics.TriggersView.Name)
+	require.NoError(t, err)
+	got := make(map[string]int64)
+	for _, row := range rows {
+		var trigger, result string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case metrics.KeyTrigger:
+				trigger = tg.Value
+			case metrics.KeyStatus:
+				result = tg.Value
+			}
+		}
+		got[trigger+"/"+result] = row.Data.(*view.CountData).Value
+	}
+	want := map[string]int64{
+		triggerReimport + "/" + metrics.StatusSuccess: 1,
+		triggerReimport + "/" + metrics.StatusSkipped: 1,
+		triggerResync + "/" + metrics.StatusSuccess:   1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected trigger counts (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRun_ApplyDebounce(t *testing.T) {
+	rootDir := t.TempDir()
+	sourceRoot := filepath.Join(rootDir, "source")
+	sourceDir := filepath.Join(sourceRoot, symLink)
+	// pushCommit creates the commit directory and points the symlink to it,
+	// like git-sync does for a new commit.
+	pushCommit := func(commit string) {
+		commitDir := filepath.Join(sourceRoot, commit)
+		require.NoError(t, os.MkdirAll(commitDir, os.ModePerm))
+		require.NoError(t, writeFile(commitDir, "ns-foo.yaml", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n"))
+		_ = os.Remove(sourceDir)
+		require.NoError(t, os.Symlink(commitDir, sourceDir))
+	}
+
+	parser := newParser(t, FileSource{
+		SourceDir:  cmpath.Absolute(sourceDir),
+		RepoRoot:   cmpath.Absolute(rootDir),
+		SourceType: v1beta1.GitSource,
+	}, false)
+	debouncePeriod := 200 * time.Millisecond
+	parser.options().ApplyDebouncePeriod = debouncePeriod
+	state := &reconcilerState{
+		backoff:     defaultBackoff(),
+		retryTimer:  time.NewTimer(configsync.DefaultReconcilerRetryPeriod),
+		retryPeriod: configsync.DefaultReconcilerRetryPeriod,
+	}
+
+	// The first commit is applied immediately.
+	pushCommit("aaaaaaa")
+	run(context.Background(), parser, triggerReimport, state)
+	assert.Equal(t, "aaaaaaa", state.syncStatus.commit)
+	assert.False(t, state.debouncing())
+
+	// The commits pushed within the debounce period are not applied.
+	debounceStart := time.Now()
+	pushCommit("bbbbbbb")
+	run(context.Background(), parser, triggerReimport, state)
+	pushCommit("ccccccc")
+	run(context.Background(), parser, triggerReimport, state)
+	require.Less(t, time.Since(debounceStart), debouncePeriod, "test too slow to check the debounce period")
+	assert.Equal(t, "aaaaaaa", state.syncStatus.commit)
+	assert.True(t, state.debouncing())
+
+	// Once the period elapses, only the latest commit is applied, even though
+	// no new commit is detected.
+	time.Sleep(debouncePeriod)
+	run(context.Background(), parser, triggerReimport, state)
+	assert.Equal(t, "ccccccc", state.syncStatus.commit)
+	assert.False(t, state.debouncing())
+}
+
+func TestResyncTokenChanged(t *testing.T) {
+	ctx := context.Background()
+	parser := newParser(t, FileSource{}, false)
+	state := &reconcilerState{}
+	setToken := func(token string) {
+		rs := &v1beta1.RootSync{}
+		require.NoError(t, parser.K8sClient().Get(ctx, rootsync.ObjectKey(rootSyncName), rs))
+		core.SetAnnotation(rs, metadata.ResyncTokenAnnotationKey, token)
+		require.NoError(t, parser.K8sClient().Update(ctx, rs))
+	}
+
+	// The first observed token is only recorded.
+	setToken("1")
+	assert.False(t, resyncTokenChanged(ctx, parser, state))
+	assert.Equal(t, "1", state.resyncToken)
+
+	// A new token forces a resync once.
+	setToken("2")
+	assert.True(t, resyncTokenChanged(ctx, parser, state))
+	assert.Equal(t, "2", state.resyncToken)
+	assert.False(t, resyncTokenChanged(ctx, parser, state))
+
+	// Setting the same token again does not force a resync.
+	setToken("2")
+	assert.False(t, resyncTokenChanged(ctx, parser, state))
+
+	// Removing the token is a change, too.
+	rs := &v1beta1.RootSync{}
+	require.NoError(t, parser.K8sClient().Get(ctx, rootsync.ObjectKey(rootSyncName), rs))
+	core.RemoveAnnotations(rs, metadata.ResyncTokenAnnotationKey)
+	require.NoError(t, parser.K8sClient().Update(ctx, rs))
+	assert.True(t, resyncTokenChanged(ctx, parser, state))
+	assert.Equal(t, "", state.resyncToken)
+
+	// The token is read with the SyncReader, if set.
+	cachedRS := fake.RootSyncObjectV1Beta1(rootSyncName)
+	core.SetAnnotation(cachedRS, metadata.ResyncTokenAnnotationKey, "3")
+	parser.options().SyncReader = syncerFake.NewClient(t, core.Scheme, cachedRS)
+	assert.True(t, resyncTokenChanged(ctx, parser, state))
+	assert.Equal(t, "3", state.resyncToken)
+}
+
+func TestReconcilerStateNewConflicts(t *testing.T) {
+	rootManager := declared.ResourceManager(declared.RootReconciler, rootSyncName)
+	repoManager := declared.ResourceManager("bookstore", "repo-sync")
+	cm1 := fake.ConfigMapObject(core.Namespace("bookstore"), core.Name("cm1"),
+		core.Annotation(metadata.ResourceManagerKey, repoManager))
+	cm2 := fake.ConfigMapObject(core.Namespace("bookstore"), core.Name("cm2"),
+		core.Annotation(metadata.ResourceManagerKey, repoManager))
+	conflict1 := status.ManagementConflictErrorWrap(cm1, rootManager)
+	conflict2 := status.ManagementConflictErrorWrap(cm2, rootManager)
+
+	state := &reconcilerState{}
+	assert.Equal(t, []status.ManagementConflictError{conflict1}, state.newConflicts([]status.ManagementConflictError{conflict1}))
+	// A conflict is not new while it persists.
+	assert.Empty(t, state.newConflicts([]status.ManagementConflictError{conflict1}))
+	assert.Equal(t, []status.ManagementConflictError{conflict2}, state.newConflicts([]status.ManagementConflictError{conflict1, conflict2}))
+	// A conflict is new again after it was resolved.
+	assert.Empty(t, state.newConflicts(nil))
+	assert.Equal(t, []status.ManagementConflictError{conflict1}, state.newConflicts([]status.ManagementConflictError{conflict1}))
+
+	assert.Equal(t, "root", managerReconcilerType(rootManager))
+	assert.Equal(t, "namespace", managerReconcilerType(repoManager))
+}
+
+func TestReportConflicts_RepoSync(t *testing.T) {
+	repoSyncScope := declared.Scope("bookstore")
+	repoSyncName := "repo-sync"
+	obj := fake.ConfigMapObject(core.Namespace(string(repoSyncScope)), core.Name("cm"),
+		core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(repoSyncScope, repoSyncName)))
+	conflictErr := status.ManagementConflictErrorWrap(obj, declared.ResourceManager(declared.RootReconciler, rootSyncName))
+
+	fakeClient := syncerFake.NewClient(t, core.Scheme, fake.RepoSyncObjectV1Beta1(string(repoSyncScope), repoSyncName))
+	err := reportConflicts(context.Background(), fakeClient, []status.ManagementConflictError{conflictErr})
+	require.NoError(t, err)
+
+	rs := &v1beta1.RepoSync{}
+	require.NoError(t, fakeClient.Get(context.Background(), reposync.ObjectKey(repoSyncScope, repoSyncName), rs))
+	expectedErrs := []v1beta1.ConfigSyncError{conflictErr.ConflictingManagerError().ToCSE()}
+	testutil.AssertEqual(t, expectedErrs, rs.Status.Sync.Errors)
+
+	// The same conflict is only reported once.
+	err = reportConflicts(context.Background(), fakeClient, []status.ManagementConflictError{conflictErr})
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(context.Background(), reposync.ObjectKey(repoSyncScope, repoSyncName), rs))
+	testutil.AssertEqual(t, expectedErrs, rs.Status.Sync.Errors)
+
+	// Reporting to a RepoSync without permission is skipped.
+	err = reportConflicts(context.Background(), &forbiddenClient{Client: fakeClient}, []status.ManagementConflictError{conflictErr})
+	assert.NoError(t, err)
+}
@@ -45,6 +45,12 @@ type FileSource struct {
 	HydratedLink string
 	// SyncDir is the path to the directory of policies within the source repository.
 	SyncDir cmpath.Relative
+	// SyncDirs is the ordered list of paths to the directories of policies
+	// within the source repository, which are parsed as a union. Optional.
+	SyncDirs []cmpath.Relative
+	// DirsCollisionPolicy indicates how an object declared in more than one of
+	// the SyncDirs is handled.
+	DirsCollisionPolicy configsync.DirsCollisionPolicy
 	// SourceType is the type of the source repository, must be git or oci.
 	SourceType v1beta1.SourceType
 	// SourceRepo is the source repo to sync.
@@ -73,6 +79,10 @@ type sourceState struct {
 	syncDir cmpath.Absolute
 	// files is the list of all observed files in the sync directory (recursively).
 	files []cmpath.Absolute
+	// dirs is the list of files observed in each of the sync directories, if
+	// more than one directory is synced. In that case, syncDir is the root of
+	// the sync directories and files is the union of their files.
+	dirs []syncDirFiles
 }
 
 // readConfigFiles reads all the files under state.syncDir and sets state.files.
@@ -92,10 +102,20 @@ func (o *Files) readConfigFiles(state *sourceState) status.Error {
 	}
 
 	var fileList []cmpath.Absolute
-	var err error
-	fileList, err = listFiles(syncDir, map[string]bool{".git": true})
-	if err != nil {
-		return status.PathWrapError(errors.Wrap(err, "listing files in the configs directory"), syncDir.OSPath())
+	var dirs []syncDirFiles
+	if len(o.SyncDirs) > 0 {
+		var dirsErr status.Error
+		dirs, dirsErr = listSyncDirs(syncDir, o.SyncDirs)
+		if dirsErr != nil {
+			return dirsErr
+		}
+		fileList = unionFiles(dirs)
+	} else {
+		var err error
+		fileList, err = listFiles(syncDir, map[string]bool{".git": true})
+		if err != nil {
+			return status.PathWrapError(errors.Wrap(err, "listing files in the configs directory"), syncDir.OSPath())
+		}
 	}
 
 	newCommit, err := hydrate.ComputeCommit(o.SourceDir)
@@ -106,6 +126,7 @@ func (o *Files) readConfigFiles(state *sourceState) status.Error {
 	}
 
 	state.files = fileList
+	state.dirs = dirs
 	return nil
 }
 
@@ -15,12 +15,17 @@
 package parse
 
 import (
+	"fmt"
+	"math"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 )
 
 type sourceStatus struct {
@@ -41,21 +46,71 @@ type renderingStatus struct {
 	// requiresRendering indicates whether the sync source has dry configs
 	// only used internally (not surfaced on RSync status)
 	requiresRendering bool
+	// kustomizeVersion and helmVersion are the versions of the tools used by
+	// the hydration-controller to render the commit.
+	kustomizeVersion string
+	helmVersion      string
+	// tool is the tool which rendered the commit.
+	tool string
+	// sourceChecksum and duration are the checksum of the rendered source
+	// configs and how long the rendering took, as recorded by the
+	// hydration-controller.
+	sourceChecksum string
+	duration       *metav1.Duration
 }
 
 func (rs renderingStatus) equal(other renderingStatus) bool {
-	return rs.commit == other.commit && rs.message == other.message && status.DeepEqual(rs.errs, other.errs)
+	return rs.commit == other.commit && rs.message == other.message && status.DeepEqual(rs.errs, other.errs) &&
+		rs.kustomizeVersion == other.kustomizeVersion && rs.helmVersion == other.helmVersion &&
+		rs.tool == other.tool && rs.sourceChecksum == other.sourceChecksum && equality.Semantic.DeepEqual(rs.duration, other.duration)
 }
 
 type syncStatus struct {
-	syncing    bool
-	commit     string
-	errs       status.MultiError
-	lastUpdate metav1.Time
+	syncing          bool
+	paused           bool
+	observing        bool
+	webhookEnforcing bool
+	// apiServerUnavailable is the message of the APIServerUnavailable
+	// condition, which is set while the circuit breaker is open.
+	apiServerUnavailable  string
+	commit                string
+	errs                  status.MultiError
+	implicitNamespaces    []string
+	managedNamespaceCount int
+	frequentlyEdited      []v1beta1.FrequentlyEditedObject
+	// pendingCount is the number of objects whose apply is deferred until
+	// their CustomResourceDefinition is established.
+	pendingCount int
+	lastUpdate   metav1.Time
 }
 
 func (gs syncStatus) equal(other syncStatus) bool {
-	return gs.syncing == other.syncing && gs.commit == other.commit && status.DeepEqual(gs.errs, other.errs)
+	return gs.syncing == other.syncing && gs.paused == other.paused && gs.observing == other.observing && gs.webhookEnforcing == other.webhookEnforcing &&
+		gs.apiServerUnavailable == other.apiServerUnavailable && gs.commit == other.commit && status.DeepEqual(gs.errs, other.errs) &&
+		equality.Semantic.DeepEqual(gs.implicitNamespaces, other.implicitNamespaces) &&
+		gs.managedNamespaceCount == other.managedNamespaceCount &&
+		gs.pendingCount == other.pendingCount &&
+		equality.Semantic.DeepEqual(gs.frequentlyEdited, other.frequentlyEdited)
+}
+
+// frequentlyEditedObjects converts the update frequencies of the frequently
+// edited objects to their representation in the sync status.
+// The frequencies are rounded to limit status updates as they decay.
+func frequentlyEditedObjects(freqs []fight.UpdateFrequency) []v1beta1.FrequentlyEditedObject {
+	if len(freqs) == 0 {
+		return nil
+	}
+	objs := make([]v1beta1.FrequentlyEditedObject, len(freqs))
+	for i, freq := range freqs {
+		objs[i] = v1beta1.FrequentlyEditedObject{
+			Group:          freq.ID.Group,
+			Kind:           freq.ID.Kind,
+			Namespace:      freq.ID.Namespace,
+			Name:           freq.ID.Name,
+			EditsPerMinute: int(math.Round(freq.PerMinute)),
+		}
+	}
+	return objs
 }
 
 type reconcilerState struct {
@@ -86,6 +141,32 @@ type reconcilerState struct {
 	retryTimer *time.Timer
 
 	retryPeriod time.Duration
+
+	// breaker pauses syncing after repeated API server errors. Optional.
+	breaker *circuitBreaker
+
+	// renderingCommit is the most recent commit observed waiting for rendering.
+	renderingCommit string
+
+	// renderingStart is when renderingCommit was first observed waiting for
+	// rendering.
+	renderingStart time.Time
+
+	// debounceStart is when the first of the new commits waiting to be
+	// applied was detected, or zero if no commit is waiting.
+	debounceStart time.Time
+
+	// resyncToken is the most recent value of the resync-token annotation
+	// observed on the RootSync/RepoSync.
+	resyncToken string
+
+	// resyncTokenObserved is true once resyncToken has been read from the
+	// RootSync/RepoSync.
+	resyncTokenObserved bool
+
+	// conflicts is the set of management conflicts observed by the most recent
+	// sync status update, keyed by error message.
+	conflicts map[string]struct{}
 }
 
 // retryLimit defines the maximal number of retries allowed on a given commit.
@@ -139,6 +220,61 @@ func (s *reconcilerState) invalidate(errs status.MultiError) {
 	s.cache.needToRetry = true
 }
 
+// deferPending keeps the reconciler retrying, without reporting any error,
+// while some objects are pending until their CRD is established. The state
+// is not checkpointed, so that the next retry applies the pending objects.
+func (s *reconcilerState) deferPending(pendingCount int) {
+	klog.Infof("Retrying the apply: %s", pendingMessage(pendingCount))
+	s.lastApplied = ""
+	s.cache.needToRetry = true
+}
+
+// pendingMessage describes the objects pending until their CRD is
+// established.
+func pendingMessage(pendingCount int) string {
+	if pendingCount == 1 {
+		return "1 object is pending until its CustomResourceDefinition is established"
+	}
+	return fmt.Sprintf("%d objects are pending until their CustomResourceDefinition is established", pendingCount)
+}
+
+// renderingStartTime returns when the commit was first observed waiting for
+// rendering, which is used to measure the rendering timeout.
+func (s *reconcilerState) renderingStartTime(commit string) time.Time {
+	if s.renderingCommit != commit {
+		s.renderingCommit = commit
+		s.renderingStart = time.Now()
+	}
+	return s.renderingStart
+}
+
+// debounceWait returns how long to wait for more commits before applying the
+// latest one. The debounce period starts when the first commit after the last
+// synced one is detected, and newSource reports whether this run detected a
+// new commit. The first commit synced by the reconciler is never delayed.
+func (s *reconcilerState) debounceWait(period time.Duration, newSource bool) time.Duration {
+	if period <= 0 {
+		return 0
+	}
+	if newSource && s.debounceStart.IsZero() && s.syncStatus.commit != "" {
+		s.debounceStart = time.Now()
+	}
+	if s.debounceStart.IsZero() {
+		return 0
+	}
+	if wait := period - time.Since(s.debounceStart); wait > 0 {
+		return wait
+	}
+	s.debounceStart = time.Time{}
+	return 0
+}
+
+// debouncing returns whether a commit is waiting for the debounce period to
+// elapse before it is applied.
+func (s *reconcilerState) debouncing() bool {
+	return !s.debounceStart.IsZero()
+}
+
 // resetCache resets the whole cache.
 //
 // resetCache is called when a new source commit is detected.
@@ -161,6 +297,23 @@ func (s *reconcilerState) resetPartialCache() {
 	s.cache.needToRetry = needToRetry
 }
 
+// newConflicts returns the conflicts which were not observed by the previous
+// call, and remembers the current conflicts, so that a conflict is only counted
+// once, when it is first detected, instead of on every status update.
+func (s *reconcilerState) newConflicts(conflictErrs []status.ManagementConflictError) []status.ManagementConflictError {
+	var newErrs []status.ManagementConflictError
+	conflicts := make(map[string]struct{}, len(conflictErrs))
+	for _, conflictErr := range conflictErrs {
+		key := conflictErr.Error()
+		if _, found := s.conflicts[key]; !found {
+			newErrs = append(newErrs, conflictErr)
+		}
+		conflicts[key] = struct{}{}
+	}
+	s.conflicts = conflicts
+	return newErrs
+}
+
 // needToSetSourceStatus returns true if `p.setSourceStatus` should be called.
 func (s *reconcilerState) needToSetSourceStatus(newStatus sourceStatus) bool {
 	// Update if not initialized
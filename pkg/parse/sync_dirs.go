@@ -0,0 +1,116 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// syncDirFiles contains the files listed in one of the sync directories.
+type syncDirFiles struct {
+	// dir is the path of the sync directory relative to the source root.
+	dir cmpath.Relative
+	// files is the list of all observed files in the sync directory (recursively).
+	files []cmpath.Absolute
+}
+
+// listSyncDirs lists the files in each of the sync directories under root, in
+// order. It returns an error if a sync directory doesn't exist, or resolves to
+// a path outside of root.
+func listSyncDirs(root cmpath.Absolute, dirs []cmpath.Relative) ([]syncDirFiles, status.Error) {
+	var result []syncDirFiles
+	for _, dir := range dirs {
+		absDir, err := root.Join(dir).EvalSymlinks()
+		if err != nil {
+			return nil, status.PathWrapError(fmt.Errorf("evaluating the sync directory %q: %w", dir.SlashPath(), err), root.OSPath())
+		}
+		rel, err := filepath.Rel(root.OSPath(), absDir.OSPath())
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, status.PathWrapError(fmt.Errorf("sync directory %q is outside of the source repository", dir.SlashPath()), absDir.OSPath())
+		}
+		files, err := listFiles(absDir, map[string]bool{".git": true})
+		if err != nil {
+			return nil, status.PathWrapError(fmt.Errorf("listing files in the sync directory %q: %w", dir.SlashPath(), err), absDir.OSPath())
+		}
+		result = append(result, syncDirFiles{dir: dir, files: files})
+	}
+	return result, nil
+}
+
+// unionFiles returns the files in all the sync directories, in order and
+// without duplicates.
+func unionFiles(dirs []syncDirFiles) []cmpath.Absolute {
+	var result []cmpath.Absolute
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		for _, f := range dir.files {
+			if seen[f.OSPath()] {
+				continue
+			}
+			seen[f.OSPath()] = true
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// mergeSyncDirs merges the objects parsed from the files of the sync
+// directories under root into an ordered union.
+//
+// With the override policy, an object declared in a later sync directory
+// replaces any object with the same ID declared in an earlier one. With the
+// error policy, all the objects are kept, so that the collisions are reported
+// by the duplicate name validation.
+func mergeSyncDirs(root cmpath.Absolute, dirs []syncDirFiles, objs []ast.FileObject, policy configsync.DirsCollisionPolicy) []ast.FileObject {
+	if policy == configsync.DirsCollisionError {
+		return objs
+	}
+	// A file which is in more than one sync directory belongs to the last one.
+	dirIndex := make(map[string]int)
+	for i, dir := range dirs {
+		for _, f := range dir.files {
+			dirIndex[f.OSPath()] = i
+		}
+	}
+	indexOf := func(obj ast.FileObject) int {
+		return dirIndex[root.Join(obj.Relative).OSPath()]
+	}
+
+	lastIndex := make(map[core.ID]int)
+	for _, obj := range objs {
+		id := core.IDOf(obj)
+		if i := indexOf(obj); i > lastIndex[id] {
+			lastIndex[id] = i
+		}
+	}
+
+	var result []ast.FileObject
+	for _, obj := range objs {
+		// Objects with the same ID in the same sync directory are kept, so
+		// that they are reported as duplicates.
+		if indexOf(obj) == lastIndex[core.IDOf(obj)] {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestListSyncDirs(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	for _, dir := range []string{"base", "overlays/prod"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "cm.yaml"), []byte("{}"), 0644))
+	}
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+	absRoot, err := cmpath.AbsoluteOS(root)
+	require.NoError(t, err)
+	absRoot, err = absRoot.EvalSymlinks()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		dirs      []cmpath.Relative
+		wantFiles []string
+		wantErr   bool
+	}{
+		{
+			name:      "lists the files in order",
+			dirs:      []cmpath.Relative{cmpath.RelativeSlash("overlays/prod"), cmpath.RelativeSlash("base")},
+			wantFiles: []string{"overlays/prod/cm.yaml", "base/cm.yaml"},
+		},
+		{
+			name:      "nested directories are listed once",
+			dirs:      []cmpath.Relative{cmpath.RelativeSlash("overlays"), cmpath.RelativeSlash("overlays/prod")},
+			wantFiles: []string{"overlays/prod/cm.yaml"},
+		},
+		{
+			name:    "missing directory",
+			dirs:    []cmpath.Relative{cmpath.RelativeSlash("missing")},
+			wantErr: true,
+		},
+		{
+			name:    "directory escapes the source root",
+			dirs:    []cmpath.Relative{cmpath.RelativeSlash("base"), cmpath.RelativeSlash("escape")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dirs, err := listSyncDirs(absRoot, tc.dirs)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var gotFiles []string
+			for _, f := range unionFiles(dirs) {
+				rel, err := filepath.Rel(absRoot.OSPath(), f.OSPath())
+				require.NoError(t, err)
+				gotFiles = append(gotFiles, filepath.ToSlash(rel))
+			}
+			if diff := cmp.Diff(tc.wantFiles, gotFiles); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestMergeSyncDirs(t *testing.T) {
+	root, err := cmpath.AbsoluteSlash("/repo")
+	require.NoError(t, err)
+	dirs := []syncDirFiles{
+		{
+			dir:   cmpath.RelativeSlash("base"),
+			files: []cmpath.Absolute{root.Join(cmpath.RelativeSlash("base/cm.yaml")), root.Join(cmpath.RelativeSlash("base/role.yaml"))},
+		},
+		{
+			dir:   cmpath.RelativeSlash("overlays/prod"),
+			files: []cmpath.Absolute{root.Join(cmpath.RelativeSlash("overlays/prod/cm.yaml"))},
+		},
+	}
+	baseCM := fake.FileObject(fake.ConfigMapObject(core.Name("cm"), core.Namespace("foo"), core.Label("env", "base")), "base/cm.yaml")
+	baseRole := fake.RoleAtPath("base/role.yaml", core.Name("role"), core.Namespace("foo"))
+	prodCM := fake.FileObject(fake.ConfigMapObject(core.Name("cm"), core.Namespace("foo"), core.Label("env", "prod")), "overlays/prod/cm.yaml")
+	prodCMDuplicate := fake.FileObject(fake.ConfigMapObject(core.Name("cm"), core.Namespace("foo")), "overlays/prod/cm.yaml")
+
+	testCases := []struct {
+		name   string
+		objs   []ast.FileObject
+		policy configsync.DirsCollisionPolicy
+		want   []ast.FileObject
+	}{
+		{
+			name:   "later directory overrides earlier directory",
+			objs:   []ast.FileObject{baseCM, baseRole, prodCM},
+			policy: configsync.DirsCollisionOverride,
+			want:   []ast.FileObject{baseRole, prodCM},
+		},
+		{
+			name:   "duplicates in the same directory are kept",
+			objs:   []ast.FileObject{baseCM, prodCM, prodCMDuplicate},
+			policy: configsync.DirsCollisionOverride,
+			want:   []ast.FileObject{prodCM, prodCMDuplicate},
+		},
+		{
+			name:   "collisions are kept with the error policy",
+			objs:   []ast.FileObject{baseCM, baseRole, prodCM},
+			policy: configsync.DirsCollisionError,
+			want:   []ast.FileObject{baseCM, baseRole, prodCM},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mergeSyncDirs(root, dirs, tc.objs, tc.policy)
+			if diff := cmp.Diff(tc.want, got, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -45,6 +45,10 @@ type Updater struct {
 	// Applier is a bulk client for applying a set of desired resource objects and
 	// tracking them in a ResourceGroup inventory.
 	Applier applier.Applier
+	// RemediatorExcludedKinds are the resource types which the remediator does
+	// not watch, so drift on their objects is not corrected automatically.
+	// The objects are still applied by the Applier.
+	RemediatorExcludedKinds map[schema.GroupKind]struct{}
 
 	errorMux       sync.RWMutex
 	validationErrs status.MultiError
@@ -190,12 +194,17 @@ func (u *Updater) update(ctx context.Context, cache *cacheForCommit) status.Mult
 	if !cache.applied {
 		declaredObjs, _ := u.Resources.DeclaredObjects()
 		_, err := u.apply(ctx, declaredObjs, cache.source.commit)
-		if err != nil {
+		if status.HasBlockingErrors(err) {
 			return err
 		}
-		// Only mark the commit as applied if there were no (non-blocking) parse errors.
-		// This ensures the apply will be retried until parsing fully succeeds.
-		if cache.parserErrs == nil {
+		// Non-blocking apply errors, like objects protected from deletion, are
+		// reported without blocking the watches and the remediator.
+		cache.applyErrs = err
+		// Only mark the commit as applied if there were no (non-blocking) parse
+		// errors, apply errors which a retry may resolve, or objects deferred
+		// until their CRD is established. This ensures the apply will be
+		// retried until parsing and applying fully succeed.
+		if cache.parserErrs == nil && !hasRetriableApplyErrors(err) && len(u.Applier.Pending()) == 0 {
 			cache.applied = true
 		}
 	}
@@ -221,6 +230,67 @@ func (u *Updater) update(ctx context.Context, cache *cacheForCommit) status.Mult
 	// otherwise the objects may be updated in the wrong order (dependencies).
 	u.Remediator.Resume()
 
+	return cache.applyErrs
+}
+
+// hasRetriableApplyErrors returns whether the apply errors may be resolved by
+// applying again. Objects protected from deletion stay protected until their
+// annotation is removed, so they do not need a retry.
+func hasRetriableApplyErrors(errs status.MultiError) bool {
+	if errs == nil {
+		return false
+	}
+	for _, err := range errs.Errors() {
+		if err.Code() != status.DeletionProtectedErrorCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Observe updates the declared resources and the remediator watches without
+// applying the declared resources, so that the remediator can report drift
+// while applying is paused.
+//
+// Like Update, any errors returned will be prepended with any known conflict
+// and fight errors from the remediator.
+func (u *Updater) Observe(ctx context.Context, cache *cacheForCommit) status.MultiError {
+	u.updateMux.Lock()
+	defer u.updateMux.Unlock()
+
+	observeErrs := u.observe(ctx, cache)
+
+	// Prepend current conflict and fight errors
+	var errs status.MultiError
+	errs = status.Append(errs, u.conflictErrors())
+	errs = status.Append(errs, u.fightErrors())
+	errs = status.Append(errs, observeErrs)
+	return errs
+}
+
+// observe performs most of the work for `Observe`.
+func (u *Updater) observe(ctx context.Context, cache *cacheForCommit) status.MultiError {
+	if !cache.declaredResourcesUpdated {
+		objs := filesystem.AsCoreObjects(cache.objsToApply)
+		_, err := u.declare(ctx, objs, cache.source.commit)
+		if err != nil {
+			return err
+		}
+		if cache.parserErrs == nil {
+			cache.declaredResourcesUpdated = true
+		}
+	}
+
+	if !cache.watchesUpdated {
+		declaredGVKs, _ := u.Resources.DeclaredGVKs()
+		err := u.watch(ctx, declaredGVKs)
+		if err != nil {
+			return err
+		}
+		if cache.parserErrs == nil {
+			cache.watchesUpdated = true
+		}
+	}
 	return nil
 }
 
@@ -253,7 +323,7 @@ func (u *Updater) apply(ctx context.Context, objs []client.Object, commit string
 // ones.
 func (u *Updater) watch(ctx context.Context, gvks map[schema.GroupVersionKind]struct{}) status.MultiError {
 	klog.V(1).Info("Remediator watches updating...")
-	watchErrs := u.Remediator.UpdateWatches(ctx, gvks)
+	watchErrs := u.Remediator.UpdateWatches(ctx, withoutExcludedKinds(gvks, u.RemediatorExcludedKinds))
 	u.setWatchErrs(watchErrs)
 	if watchErrs != nil {
 		klog.Warningf("Failed to update resource watches: %v", watchErrs)
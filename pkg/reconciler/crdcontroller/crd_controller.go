@@ -0,0 +1,60 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crdcontroller
+
+import (
+	"context"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/util/discovery"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// CRDController is a controller that watches for CustomResourceDefinition
+// events and resets the API discovery cache of the parser, so that the types
+// added or removed by the CRDs are discovered on the next parse, instead of
+// when the cache expires.
+type CRDController struct {
+	serverResourcer discovery.ServerResourcer
+}
+
+// New instantiates the CRD controller.
+func New(sr discovery.ServerResourcer) *CRDController {
+	return &CRDController{
+		serverResourcer: sr,
+	}
+}
+
+// Reconcile resets the API discovery cache when a CRD changes.
+func (cc *CRDController) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+	klog.V(3).Infof("The CustomResourceDefinition %s changed", req.Name)
+	discovery.Reset(cc.serverResourcer)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers the CRD Controller.
+func (cc *CRDController) SetupWithManager(mgr ctrl.Manager) error {
+	// Only the metadata is watched, since the resource version changes when
+	// the CRD becomes established too.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("CRDController").
+		For(&apiextensionsv1.CustomResourceDefinition{},
+			builder.OnlyMetadata,
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Complete(cc)
+}
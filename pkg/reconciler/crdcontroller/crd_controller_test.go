@@ -0,0 +1,56 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crdcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/util/discovery"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// countingServerResourcer counts the discoveries.
+type countingServerResourcer struct {
+	discoveries int
+}
+
+func (c *countingServerResourcer) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	c.discoveries++
+	return nil, nil, nil
+}
+
+func TestCRDControllerResetsDiscoveryCache(t *testing.T) {
+	delegate := &countingServerResourcer{}
+	cached := discovery.NewCachedServerResourcer(delegate, time.Hour)
+	cc := New(cached)
+
+	_, _, err := cached.ServerGroupsAndResources()
+	require.NoError(t, err)
+	_, _, err = cached.ServerGroupsAndResources()
+	require.NoError(t, err)
+	assert.Equal(t, 1, delegate.discoveries, "discoveries within the TTL")
+
+	_, err = cc.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "anvils.acme.com"}})
+	require.NoError(t, err)
+	_, _, err = cached.ServerGroupsAndResources()
+	require.NoError(t, err)
+	assert.Equal(t, 2, delegate.discoveries, "discoveries after a CRD changed")
+}
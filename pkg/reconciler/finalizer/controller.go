@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/metadata"
@@ -48,8 +49,14 @@ import (
 // Use `configsync.gke.io/deletion-propagation-policy: Orphan` or remove the
 // annotation to disable deletion propagation (default behavior).
 //
+// `spec.deletionPropagationPolicy` takes precedence over the annotation.
+// Unlike the annotation, `spec.deletionPropagationPolicy: Orphan` injects the
+// finalizer too, so that the reconciler removes the Config Sync metadata from
+// the managed objects, which only the managing reconciler is allowed to do
+// when the admission webhook is enabled.
+//
 // The `configsync.gke.io/reconciler` finalizer is used to block deletion until
-// all the managed objects can be deleted.
+// all the managed objects can be deleted or orphaned.
 type Controller struct {
 	SyncScope declared.Scope
 	SyncName  string
@@ -141,10 +148,19 @@ func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reco
 }
 
 // reconcileFinalizer adds or removes the `configsync.gke.io/reconciler`
-// finalizer, depending on the existence and value of the
-// `configsync.gke.io/deletion-propagation-policy` annotation.
+// finalizer, depending on the value of `spec.deletionPropagationPolicy`, or
+// the existence and value of the `configsync.gke.io/deletion-propagation-policy`
+// annotation if the field is unset.
 func (c *Controller) reconcileFinalizer(ctx context.Context, obj client.Object) error {
 	policyStr, found := obj.GetAnnotations()[metadata.DeletionPropagationPolicyAnnotationKey]
+	if specPolicy := specDeletionPropagationPolicy(obj); specPolicy != "" {
+		if specPolicy == configsync.DeletionPropagationPolicyOrphan {
+			// The finalizer orphans the managed objects.
+			_, err := c.Finalizer.AddFinalizer(ctx, obj)
+			return err
+		}
+		policyStr, found = string(specPolicy), true
+	}
 	policy := metadata.DeletionPropagationPolicy(policyStr)
 	if !found {
 		// Orphan is the default policy
@@ -166,3 +182,16 @@ func (c *Controller) reconcileFinalizer(ctx context.Context, obj client.Object)
 	}
 	return nil
 }
+
+// specDeletionPropagationPolicy returns the `spec.deletionPropagationPolicy`
+// of the RootSync or RepoSync.
+func specDeletionPropagationPolicy(obj client.Object) configsync.DeletionPropagationPolicy {
+	switch rs := obj.(type) {
+	case *v1beta1.RootSync:
+		return rs.Spec.DeletionPropagationPolicy
+	case *v1beta1.RepoSync:
+		return rs.Spec.DeletionPropagationPolicy
+	default:
+		return ""
+	}
+}
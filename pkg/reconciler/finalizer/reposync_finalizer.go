@@ -19,6 +19,7 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/reposync"
@@ -47,7 +48,7 @@ type RepoSyncFinalizer struct {
 // - Stop other controllers
 // - Wait for other controllers to stop
 // - Sets the Finalizing condition
-// - Uses the Destroyer to delete managed objects
+// - Uses the Destroyer to delete or orphan managed objects
 // - Removes the Finalizing condition
 // - Removes the Finalizer (unblocking deletion)
 //
@@ -173,10 +174,15 @@ func (f *RepoSyncFinalizer) removeFinalizingCondition(ctx context.Context, syncO
 	return updated, nil
 }
 
-// deleteManagedObjects uses the destroyer to delete managed objects and then
-// updates the ReconcilerFinalizerFailure condition on the specified object.
+// deleteManagedObjects uses the destroyer to delete managed objects, or to
+// orphan them if `spec.deletionPropagationPolicy` is Orphan, and then updates
+// the ReconcilerFinalizerFailure condition on the specified object.
 func (f *RepoSyncFinalizer) deleteManagedObjects(ctx context.Context, syncObj *v1beta1.RepoSync) error {
-	destroyErrs := f.Destroyer.Destroy(ctx)
+	destroy := f.Destroyer.Destroy
+	if syncObj.Spec.DeletionPropagationPolicy == configsync.DeletionPropagationPolicyOrphan {
+		destroy = f.Destroyer.Orphan
+	}
+	destroyErrs := destroy(ctx)
 	// Update the FinalizerFailure condition whether the destroy succeeded or failed
 	if _, updateErr := f.updateFailureCondition(ctx, syncObj, destroyErrs); updateErr != nil {
 		updateErr = errors.Wrap(updateErr, "updating FinalizerFailure condition")
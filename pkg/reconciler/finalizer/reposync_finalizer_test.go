@@ -26,6 +26,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
@@ -322,6 +323,36 @@ func TestRepoSyncFinalize(t *testing.T) {
 	}
 }
 
+func TestRepoSyncFinalize_Orphan(t *testing.T) {
+	rsync := yamlToTypedObject(t, repoSync1Yaml).(*v1beta1.RepoSync)
+	rsync.Spec.DeletionPropagationPolicy = configsync.DeletionPropagationPolicyOrphan
+	rsync.SetFinalizers([]string{
+		metadata.ReconcilerFinalizer,
+	})
+	fakeClient := fake.NewClient(t, scheme, rsync)
+
+	continueCh := make(chan struct{})
+	close(continueCh)
+	fakeDestroyer := newFakeDestroyer(nil, func(context.Context) status.MultiError {
+		t.Error("unexpected call to Destroy: managed objects must be orphaned")
+		return nil
+	})
+	finalizer := &RepoSyncFinalizer{
+		Destroyer:          fakeDestroyer,
+		Client:             fakeClient,
+		StopControllers:    func() {},
+		ControllersStopped: continueCh,
+	}
+
+	err := finalizer.Finalize(context.Background(), rsync)
+	require.NoError(t, err)
+	assert.True(t, fakeDestroyer.orphaned, "expected the managed objects to be orphaned")
+
+	got := &v1beta1.RepoSync{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rsync), got))
+	assert.Empty(t, got.GetFinalizers())
+}
+
 func TestRepoSyncAddFinalizer(t *testing.T) {
 	repoSync1 := yamlToTypedObject(t, repoSync1Yaml).(*v1beta1.RepoSync)
 
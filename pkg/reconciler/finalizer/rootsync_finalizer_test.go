@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/core"
@@ -330,6 +331,36 @@ func TestRootSyncFinalize(t *testing.T) {
 	}
 }
 
+func TestRootSyncFinalize_Orphan(t *testing.T) {
+	rsync := yamlToTypedObject(t, rootSync1Yaml).(*v1beta1.RootSync)
+	rsync.Spec.DeletionPropagationPolicy = configsync.DeletionPropagationPolicyOrphan
+	rsync.SetFinalizers([]string{
+		metadata.ReconcilerFinalizer,
+	})
+	fakeClient := fake.NewClient(t, scheme, rsync)
+
+	continueCh := make(chan struct{})
+	close(continueCh)
+	fakeDestroyer := newFakeDestroyer(nil, func(context.Context) status.MultiError {
+		t.Error("unexpected call to Destroy: managed objects must be orphaned")
+		return nil
+	})
+	finalizer := &RootSyncFinalizer{
+		Destroyer:          fakeDestroyer,
+		Client:             fakeClient,
+		StopControllers:    func() {},
+		ControllersStopped: continueCh,
+	}
+
+	err := finalizer.Finalize(context.Background(), rsync)
+	require.NoError(t, err)
+	assert.True(t, fakeDestroyer.orphaned, "expected the managed objects to be orphaned")
+
+	got := &v1beta1.RootSync{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rsync), got))
+	assert.Empty(t, got.GetFinalizers())
+}
+
 func TestRootSyncAddFinalizer(t *testing.T) {
 	rootSync1 := yamlToTypedObject(t, rootSync1Yaml).(*v1beta1.RootSync)
 
@@ -639,6 +670,7 @@ func updateToRemoveFinalizers(ctx context.Context, fakeClient *fake.Client, obj
 type fakeDestroyer struct {
 	errs        status.MultiError
 	destroyFunc func(context.Context) status.MultiError
+	orphaned    bool
 }
 
 var _ applier.Destroyer = &fakeDestroyer{}
@@ -657,6 +689,11 @@ func (d *fakeDestroyer) Destroy(ctx context.Context) status.MultiError {
 	return d.errs
 }
 
+func (d *fakeDestroyer) Orphan(_ context.Context) status.MultiError {
+	d.orphaned = true
+	return d.errs
+}
+
 func (d *fakeDestroyer) Errors() status.MultiError {
 	return d.errs
 }
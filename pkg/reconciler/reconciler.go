@@ -16,12 +16,15 @@ package reconciler
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/klogr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
@@ -32,14 +35,18 @@ import (
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/parse"
+	"kpt.dev/configsync/pkg/reconciler/crdcontroller"
 	"kpt.dev/configsync/pkg/reconciler/finalizer"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/remediator"
+	remediatorreconcile "kpt.dev/configsync/pkg/remediator/reconcile"
 	"kpt.dev/configsync/pkg/remediator/watch"
 	syncerclient "kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/syncer/metrics"
 	"kpt.dev/configsync/pkg/syncer/reconcile"
 	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
+	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
+	"kpt.dev/configsync/pkg/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -54,10 +61,67 @@ type Options struct {
 	// Resource at which the reconciler will log warnings about too many updates
 	// to the resource.
 	FightDetectionThreshold float64
+	// FrequentEditThreshold is the rate of manual edits per minute to a managed
+	// object at which the object is reported as frequently edited in the sync
+	// status. Zero disables the reporting.
+	FrequentEditThreshold float64
+	// WatchJitterFactor is the maximum fraction by which the remediator watch
+	// timeouts and retry delays are randomly extended, to spread the watch
+	// restarts of many reconcilers over time.
+	WatchJitterFactor float64
 	// NumWorkers is the number of concurrent remediator workers to run at once.
 	// Each worker pulls resources off of the work queue and remediates them one
 	// at a time.
 	NumWorkers int
+	// ReadConcurrency is the number of source files to read and decode at once.
+	ReadConcurrency int
+	// DiscoveryCacheTTL is how long the API resources discovered by the parser
+	// are reused across parses. Zero discovers them on every parse.
+	DiscoveryCacheTTL time.Duration
+	// MinRemediationInterval is the minimum period of time between two
+	// corrections of the same object by the remediator.
+	MinRemediationInterval time.Duration
+	// ApplyCallTimeout is the timeout of each individual apply, patch, or
+	// delete call made by the applier and the remediator. Zero means no
+	// per-call timeout.
+	ApplyCallTimeout time.Duration
+	// FieldManager is the field manager name used by the applier and the
+	// remediator to apply the managed objects with server-side apply.
+	FieldManager string
+	// RequiredMetadata is the list of labels and annotations which every
+	// managed object declared in the source must have.
+	RequiredMetadata []v1beta1.RequiredMetadata
+	// AnnotateSyncGeneration indicates whether to annotate the managed objects
+	// with the SyncGeneration.
+	AnnotateSyncGeneration bool
+	// PauseApply indicates whether to pause applying the resources from the
+	// source. If true, the remediator only reports drifted objects.
+	PauseApply bool
+	// MaxPruneCount is the maximum number of objects to prune in one sync
+	// without confirmation. Zero prunes any number of objects.
+	MaxPruneCount int
+	// ValidateWithAdmission indicates whether to dry-run apply the objects
+	// from the source through the admission chain before applying them.
+	ValidateWithAdmission bool
+	// RemediatorExcludedKinds are the resource types which the remediator does
+	// not watch for drift.
+	RemediatorExcludedKinds map[schema.GroupKind]struct{}
+	// ObjectSelector limits the objects from the source which are applied.
+	// Nil or empty selects all the objects.
+	ObjectSelector labels.Selector
+	// SubstituteClusterName indicates whether to replace the `${CLUSTER_NAME}`
+	// token in the objects from the source with ClusterName.
+	SubstituteClusterName bool
+	// WebhookEnabled indicates whether the Config Sync admission webhook is
+	// enabled, protecting the declared fields of the managed objects.
+	WebhookEnabled bool
+	// LeaderElection indicates whether to use leader election, so that only
+	// one of the reconciler replicas is active at a time.
+	LeaderElection bool
+	// HealthProbePort is the port of the readiness endpoint, which reports
+	// ready once the latest source commit is synced without blocking errors.
+	// Zero disables the endpoint.
+	HealthProbePort int
 	// ReconcilerScope is the scope of resources which the reconciler will manage.
 	// Currently this can either be a namespace or the root scope which allows a
 	// cluster admin to manage the entire cluster.
@@ -67,6 +131,8 @@ type Options struct {
 	ReconcilerScope declared.Scope
 	// SyncName is the name of the RootSync or RepoSync object.
 	SyncName string
+	// SyncGeneration is the generation of the RootSync or RepoSync object.
+	SyncGeneration int64
 	// ReconcilerName is the name of the Reconciler Deployment.
 	ReconcilerName string
 	// ResyncPeriod is the period of time between forced re-sync from source (even
@@ -75,12 +141,21 @@ type Options struct {
 	// PollingPeriod is the period of time between checking the filesystem for
 	// source updates to sync.
 	PollingPeriod time.Duration
+	// ApplyDebouncePeriod is the period of time to wait after detecting a new
+	// commit before applying it, so that rapid commits are coalesced.
+	ApplyDebouncePeriod time.Duration
 	// RetryPeriod is the period of time between checking the filesystem for
 	// source updates to sync, after an error.
 	RetryPeriod time.Duration
 	// StatusUpdatePeriod is how long the parser waits between updates of the
 	// sync status, to account for management conflict errors from the remediator.
 	StatusUpdatePeriod time.Duration
+	// RenderingTimeout is how long the parser waits for the rendering of a new
+	// commit before reporting the rendering as failed. Zero waits indefinitely.
+	RenderingTimeout time.Duration
+	// CircuitBreaker configures pausing syncing after repeated API server
+	// errors.
+	CircuitBreaker parse.CircuitBreakerOptions
 	// SourceRoot is the absolute path to the source repository.
 	// Usually contains a symlink that must be resolved every time before parsing.
 	SourceRoot cmpath.Absolute
@@ -102,12 +177,26 @@ type Options struct {
 	SourceType v1beta1.SourceType
 	// SyncDir is the relative path to the configurations in the source.
 	SyncDir cmpath.Relative
+	// SyncDirs is the ordered list of relative paths to the configurations in
+	// the source, synced as a union. If set, SyncDir is the root of the source.
+	SyncDirs []cmpath.Relative
+	// DirsCollisionPolicy indicates how an object declared in more than one of
+	// the SyncDirs is handled.
+	DirsCollisionPolicy configsync.DirsCollisionPolicy
 	// StatusMode controls the kpt applier to inject the actuation status data or not
 	StatusMode string
 	// ReconcileTimeout controls the reconcile/prune Timeout in kpt applier
 	ReconcileTimeout string
 	// APIServerTimeout is the client-side timeout used for talking to the API server
 	APIServerTimeout string
+	// ClientQPS is the client-side throttling QPS used for talking to the API
+	// server. Zero keeps the default, which is unlimited if the API server has
+	// flow control enabled. Raising it speeds up large syncs, but increases the
+	// load on the API server.
+	ClientQPS float32
+	// ClientBurst is the client-side throttling burst used with ClientQPS.
+	// Zero keeps the default, which is twice ClientQPS.
+	ClientBurst int
 	// RenderingEnabled indicates whether the reconciler Pod is currently running
 	// with the hydration-controller.
 	RenderingEnabled bool
@@ -127,6 +216,28 @@ type RootOptions struct {
 	SourceFormat filesystem.SourceFormat
 	// NamespaceStrategy indicates the NamespaceStrategy used by this reconciler.
 	NamespaceStrategy configsync.NamespaceStrategy
+	// AmbiguousSourceFormat indicates how this reconciler handles a source
+	// which signals a different source format than SourceFormat.
+	AmbiguousSourceFormat configsync.AmbiguousSourceFormatPolicy
+	// NamespaceMismatchPolicy indicates how this reconciler handles an object
+	// whose metadata.namespace differs from its directory.
+	NamespaceMismatchPolicy configsync.NamespaceMismatchPolicy
+	// ConflictPolicy indicates which objects already managed by another
+	// RootSync or RepoSync this reconciler may adopt.
+	ConflictPolicy configsync.ConflictPolicy
+	// SyncMode indicates whether the reconciler applies the resources from the
+	// source, or only observes them. In observe mode, the reconciler never
+	// writes to the managed resources.
+	SyncMode configsync.SyncMode
+	// DynamicNamespaceSelector indicates whether NamespaceSelectors which do
+	// not set a mode use the dynamic mode.
+	DynamicNamespaceSelector bool
+	// DeferUnestablishedCRs indicates whether the applier defers applying
+	// custom resources whose CRD is not yet established until a later sync.
+	DeferUnestablishedCRs bool
+	// ClusterScopedConflictCheck indicates whether to reject the cluster-scoped
+	// objects which are also declared by another RootSync.
+	ClusterScopedConflictCheck bool
 }
 
 // Run configures and starts the various components of a reconciler process.
@@ -134,14 +245,7 @@ func Run(opts Options) {
 	fight.SetFightThreshold(opts.FightDetectionThreshold)
 
 	// Get a config to talk to the apiserver.
-	apiServerTimeout, err := time.ParseDuration(opts.APIServerTimeout)
-	if err != nil {
-		klog.Fatalf("Error parsing applier reconcile/prune task timeout: %v", err)
-	}
-	if apiServerTimeout <= 0 {
-		klog.Fatalf("Invalid apiServerTimeout: %v, timeout should be positive", apiServerTimeout)
-	}
-	cfg, err := restconfig.NewRestConfig(apiServerTimeout)
+	cfg, err := newRestConfig(opts)
 	if err != nil {
 		klog.Fatalf("Error creating rest config: %v", err)
 	}
@@ -150,6 +254,11 @@ func Run(opts Options) {
 	if err != nil {
 		klog.Fatalf("Error creating config flags from rest config: %v", err)
 	}
+	// Bound each write of the applier by the per-call timeout.
+	wrapConfigFn := configFlags.WrapConfigFn
+	configFlags.WrapConfigFn = func(c *rest.Config) *rest.Config {
+		return syncerclient.CallTimeoutConfig(wrapConfigFn(c), opts.ApplyCallTimeout)
+	}
 
 	discoveryClient, err := configFlags.ToDiscoveryClient()
 	if err != nil {
@@ -171,9 +280,22 @@ func Run(opts Options) {
 		klog.Fatalf("failed to create client: %v", err)
 	}
 
+	// In observe mode, the declared resources are only compared with the
+	// cluster, and the remediator only reports drift. The clients of the
+	// applier and the remediator also reject every write, so that the
+	// observed resources can never be mutated.
+	observe := opts.RootOptions != nil && opts.RootOptions.SyncMode == configsync.SyncModeObserve
+	managedClient := cl
+	applierCfg := syncerclient.CallTimeoutConfig(cfg, opts.ApplyCallTimeout)
+	if observe {
+		managedClient = syncerclient.NewReadOnly(cl)
+		applierCfg = syncerclient.ReadOnlyConfig(applierCfg)
+	}
+
 	// Configure the Applier.
-	genericClient := syncerclient.New(cl, metrics.APICallDuration)
-	baseApplier, err := reconcile.NewApplierForMultiRepo(cfg, genericClient)
+	genericClient := syncerclient.New(managedClient, metrics.APICallDuration)
+	genericClient.CallTimeout = opts.ApplyCallTimeout
+	baseApplier, err := reconcile.NewApplierForMultiRepo(applierCfg, genericClient, opts.FieldManager)
 	if err != nil {
 		klog.Fatalf("Instantiating Applier: %v", err)
 	}
@@ -185,15 +307,25 @@ func Run(opts Options) {
 	if reconcileTimeout < 0 {
 		klog.Fatalf("Invalid reconcileTimeout: %v, timeout should not be negative", reconcileTimeout)
 	}
-	clientSet, err := applier.NewClientSet(cl, configFlags, opts.StatusMode)
+	clientSet, err := applier.NewClientSet(cl, configFlags, opts.StatusMode, opts.FieldManager)
 	if err != nil {
 		klog.Fatalf("Error creating clients: %v", err)
 	}
-	supervisor, err := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout)
+	supervisorOpts := applier.Options{
+		MaxPruneCount:  opts.MaxPruneCount,
+		ObjectSelector: opts.ObjectSelector,
+	}
+	if opts.RootOptions != nil {
+		supervisorOpts.DeferUnestablishedCRs = opts.RootOptions.DeferUnestablishedCRs
+		supervisorOpts.ConflictPolicy = opts.RootOptions.ConflictPolicy
+	}
+	supervisor, err := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout, supervisorOpts)
 	if err != nil {
 		klog.Fatalf("Error creating applier: %v", err)
 	}
-
+	if opts.SubstituteClusterName && opts.ClusterName == "" {
+		klog.Warningf("The cluster name is not set, so the %s token is not substituted", parse.ClusterNameToken)
+	}
 	// Configure the Remediator.
 	decls := &declared.Resources{}
 
@@ -205,11 +337,29 @@ func Run(opts Options) {
 		klog.Fatalf("Error creating rest config for the remediator: %v", err)
 	}
 
-	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, cfgForWatch, baseApplier, decls, opts.NumWorkers)
+	driftMode := remediatorreconcile.CorrectDrift
+	switch {
+	case observe:
+		driftMode = remediatorreconcile.ReportObservedDrift
+	case opts.PauseApply:
+		driftMode = remediatorreconcile.ReportPausedDrift
+	}
+	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, cfgForWatch, baseApplier, decls, opts.NumWorkers, opts.MinRemediationInterval, driftMode, opts.FrequentEditThreshold, opts.WatchJitterFactor)
 	if err != nil {
 		klog.Fatalf("Instantiating Remediator: %v", err)
 	}
 
+	var rsApplier applier.Applier = supervisor
+	if observe {
+		// The observer reports the drift along with the remediator.
+		rsApplier = applier.NewObserver(managedClient, rem.FightHandler())
+	}
+
+	var serverResourcer utildiscovery.ServerResourcer = discoveryClient
+	if opts.DiscoveryCacheTTL > 0 {
+		serverResourcer = utildiscovery.NewCachedServerResourcer(discoveryClient, opts.DiscoveryCacheTTL)
+	}
+
 	converter, err := declared.NewValueConverter(discoveryClient)
 	if err != nil {
 		klog.Fatalf("Instantiating converter: %v", err)
@@ -218,45 +368,66 @@ func Run(opts Options) {
 	// Configure the Parser.
 	var parser parse.Parser
 	fs := parse.FileSource{
-		SourceDir:    opts.SourceRoot,
-		RepoRoot:     opts.RepoRoot,
-		HydratedRoot: opts.HydratedRoot,
-		HydratedLink: opts.HydratedLink,
-		SyncDir:      opts.SyncDir,
-		SourceType:   opts.SourceType,
-		SourceRepo:   opts.SourceRepo,
-		SourceBranch: opts.SourceBranch,
-		SourceRev:    opts.SourceRev,
+		SourceDir:           opts.SourceRoot,
+		RepoRoot:            opts.RepoRoot,
+		HydratedRoot:        opts.HydratedRoot,
+		HydratedLink:        opts.HydratedLink,
+		SyncDir:             opts.SyncDir,
+		SyncDirs:            opts.SyncDirs,
+		DirsCollisionPolicy: opts.DirsCollisionPolicy,
+		SourceType:          opts.SourceType,
+		SourceRepo:          opts.SourceRepo,
+		SourceBranch:        opts.SourceBranch,
+		SourceRev:           opts.SourceRev,
 	}
 
 	parseOpts := &parse.Options{
-		Parser:             filesystem.NewParser(&reader.File{}),
-		ClusterName:        opts.ClusterName,
-		Client:             cl,
-		ReconcilerName:     opts.ReconcilerName,
-		SyncName:           opts.SyncName,
-		PollingPeriod:      opts.PollingPeriod,
-		ResyncPeriod:       opts.ResyncPeriod,
-		RetryPeriod:        opts.RetryPeriod,
-		StatusUpdatePeriod: opts.StatusUpdatePeriod,
-		DiscoveryInterface: discoveryClient,
-		Converter:          converter,
-		RenderingEnabled:   opts.RenderingEnabled,
-		Files:              parse.Files{FileSource: fs},
+		Parser:                 filesystem.NewParser(&reader.File{Concurrency: opts.ReadConcurrency, Cache: reader.NewCache()}),
+		ClusterName:            opts.ClusterName,
+		Client:                 cl,
+		ReconcilerName:         opts.ReconcilerName,
+		SyncName:               opts.SyncName,
+		SyncGeneration:         opts.SyncGeneration,
+		PollingPeriod:          opts.PollingPeriod,
+		ApplyDebouncePeriod:    opts.ApplyDebouncePeriod,
+		ResyncPeriod:           opts.ResyncPeriod,
+		RetryPeriod:            opts.RetryPeriod,
+		StatusUpdatePeriod:     opts.StatusUpdatePeriod,
+		RenderingTimeout:       opts.RenderingTimeout,
+		CircuitBreaker:         opts.CircuitBreaker,
+		DiscoveryInterface:     serverResourcer,
+		Converter:              converter,
+		RenderingEnabled:       opts.RenderingEnabled,
+		PauseApply:             opts.PauseApply,
+		ObserveOnly:            observe,
+		WebhookEnabled:         opts.WebhookEnabled,
+		RequiredMetadata:       opts.RequiredMetadata,
+		AnnotateSyncGeneration: opts.AnnotateSyncGeneration,
+		ValidateWithAdmission:  opts.ValidateWithAdmission,
+		FieldManager:           opts.FieldManager,
+		ObjectSelector:         opts.ObjectSelector,
+		SubstituteClusterName:  opts.SubstituteClusterName,
+		Readiness:              parse.NewReadiness(),
+		Files:                  parse.Files{FileSource: fs},
 		Updater: parse.Updater{
-			Scope:      opts.ReconcilerScope,
-			Resources:  decls,
-			Applier:    supervisor,
-			Remediator: rem,
+			Scope:                   opts.ReconcilerScope,
+			Resources:               decls,
+			Applier:                 rsApplier,
+			Remediator:              rem,
+			RemediatorExcludedKinds: opts.RemediatorExcludedKinds,
 		},
 	}
 	nsControllerState := namespacecontroller.NewState()
 	if opts.ReconcilerScope == declared.RootReconciler {
 		rootOpts := &parse.RootOptions{
-			SourceFormat:             opts.SourceFormat,
-			NamespaceStrategy:        opts.NamespaceStrategy,
-			DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
-			NSControllerState:        nsControllerState,
+			SourceFormat:               opts.SourceFormat,
+			NamespaceStrategy:          opts.NamespaceStrategy,
+			AmbiguousSourceFormat:      opts.AmbiguousSourceFormat,
+			NamespaceMismatchPolicy:    opts.NamespaceMismatchPolicy,
+			DynamicNSSelectorEnabled:   opts.DynamicNSSelectorEnabled,
+			DynamicNamespaceSelector:   opts.DynamicNamespaceSelector,
+			ClusterScopedConflictCheck: opts.ClusterScopedConflictCheck,
+			NSControllerState:          nsControllerState,
 		}
 		parser = parse.NewRootRunner(parseOpts, rootOpts)
 	} else {
@@ -270,7 +441,6 @@ func Run(opts Options) {
 	signalCtx := signals.SetupSignalHandler()
 
 	// Create the ControllerManager
-	ctrl.SetLogger(klogr.New())
 	mgrOptions := ctrl.Options{
 		Scheme: core.Scheme,
 		MapperProvider: func(c *rest.Config) (meta.RESTMapper, error) {
@@ -280,6 +450,9 @@ func Run(opts Options) {
 			return signalCtx
 		},
 	}
+	if opts.HealthProbePort > 0 {
+		mgrOptions.HealthProbeBindAddress = fmt.Sprintf(":%d", opts.HealthProbePort)
+	}
 	// For Namespaced Reconcilers, set the default namespace to watch.
 	// Otherwise, all namespaced informers will watch at the cluster-scope.
 	// This prevents Namespaced Reconcilers from needing cluster-scoped read
@@ -287,10 +460,28 @@ func Run(opts Options) {
 	if opts.ReconcilerScope != declared.RootReconciler {
 		mgrOptions.Namespace = string(opts.ReconcilerScope)
 	}
+	// With multiple reconciler replicas, only the leader runs the controllers.
+	// The Lease is named after the reconciler, in the config-management-system
+	// namespace, where the reconciler-manager grants the reconciler permission
+	// to manage only that Lease.
+	if opts.LeaderElection {
+		mgrOptions.LeaderElection = true
+		mgrOptions.LeaderElectionID = opts.ReconcilerName
+		mgrOptions.LeaderElectionNamespace = configsync.ControllerNamespace
+		mgrOptions.LeaderElectionReleaseOnCancel = true
+	}
 	mgr, err := ctrl.NewManager(cfgForWatch, mgrOptions)
 	if err != nil {
 		klog.Fatalf("Instantiating Controller Manager: %v", err)
 	}
+	// Poll the annotations of the RootSync/RepoSync from the informer cache,
+	// which is shared with the Finalizer Controller.
+	parseOpts.SyncReader = mgr.GetCache()
+	if opts.HealthProbePort > 0 {
+		if err := mgr.AddReadyzCheck("sync", parseOpts.Readiness.Check); err != nil {
+			klog.Fatalf("Registering the readiness check: %v", err)
+		}
+	}
 
 	// This cancelFunc will be used by the Finalizer to stop all the other
 	// controllers (Parser & Remediator).
@@ -303,22 +494,26 @@ func Run(opts Options) {
 	// The caching client built by the controller-manager doesn't update
 	// the GET cache on UPDATE/PATCH. So we need to use the non-caching client
 	// for the finalizer, which does GET/LIST after UPDATE/PATCH.
-	f := finalizer.New(opts.ReconcilerScope, supervisor, cl, // non-caching client
-		stopControllers, continueChanForFinalizer)
-
-	// Create the Finalizer Controller
-	finalizerController := &finalizer.Controller{
-		SyncScope: opts.ReconcilerScope,
-		SyncName:  opts.SyncName,
-		Client:    mgr.GetClient(), // caching client
-		Scheme:    mgr.GetScheme(),
-		Mapper:    mgr.GetRESTMapper(),
-		Finalizer: f,
-	}
+	// In observe mode, the finalizer is not registered, because the observed
+	// objects are not managed, and must never be deleted.
+	if !observe {
+		f := finalizer.New(opts.ReconcilerScope, supervisor, cl, // non-caching client
+			stopControllers, continueChanForFinalizer)
+
+		// Create the Finalizer Controller
+		finalizerController := &finalizer.Controller{
+			SyncScope: opts.ReconcilerScope,
+			SyncName:  opts.SyncName,
+			Client:    mgr.GetClient(), // caching client
+			Scheme:    mgr.GetScheme(),
+			Mapper:    mgr.GetRESTMapper(),
+			Finalizer: f,
+		}
 
-	// Register the Finalizer Controller
-	if err := finalizerController.SetupWithManager(mgr); err != nil {
-		klog.Fatalf("Instantiating Finalizer: %v", err)
+		// Register the Finalizer Controller
+		if err := finalizerController.SetupWithManager(mgr); err != nil {
+			klog.Fatalf("Instantiating Finalizer: %v", err)
+		}
 	}
 
 	// Only create and register the Namespace Controller when the flag is enabled.
@@ -334,9 +529,24 @@ func Run(opts Options) {
 		}
 	}
 
+	// With the API discovery cached, reset the cache when the CRDs change, so
+	// that the parser discovers new types promptly. Only the root reconcilers
+	// are permitted to watch CRDs. The namespace reconcilers reset the cache
+	// when parsing finds an unknown type instead.
+	if _, isCached := serverResourcer.(*utildiscovery.CachedServerResourcer); isCached && opts.ReconcilerScope == declared.RootReconciler {
+		crdController := crdcontroller.New(serverResourcer)
+
+		// Register the CRD Controller
+		// The controller will stop when the controller-manager shuts down.
+		if err := crdController.SetupWithManager(mgr); err != nil {
+			klog.Fatalf("Instantiating CRD Controller: %v", err)
+		}
+	}
+
 	klog.Info("Starting ControllerManager")
 	// TODO: Once everything is using the controller-manager, move mgr.Start to the top level.
 	doneChanForManager := make(chan struct{})
+	var mgrErr error
 	go func() {
 		defer func() {
 			// If the manager returned, there was either an error or a term/kill
@@ -344,15 +554,37 @@ func Run(opts Options) {
 			stopControllers()
 			close(doneChanForManager) // Signal thread completion
 		}()
-		err := mgr.Start(signalCtx) // blocks on signalCtx.Done()
-		if err != nil {
-			klog.Errorf("Starting ControllerManager: %v", err)
+		mgrErr = mgr.Start(signalCtx) // blocks on signalCtx.Done()
+		if mgrErr != nil {
+			klog.Errorf("Starting ControllerManager: %v", mgrErr)
 			// klog.Fatalf calls os.Exit, which doesn't trigger defer funcs.
 			// So we're using klog.Error instead, for now.
 			// TODO: Once this is top-level, just call klog.Fatalf
 		}
 	}()
 
+	if opts.LeaderElection {
+		klog.Info("Waiting for leader election")
+		select {
+		case <-mgr.Elected():
+			klog.Info("Elected as leader")
+		case <-ctx.Done():
+		}
+	}
+
+	// Take over from the reconcilers of the same or older versions, and stop
+	// applying if a reconciler of a newer version takes over later, e.g.
+	// during a rollout of Config Sync.
+	marker := newVersionMarker(cl, opts.ReconcilerScope, opts.SyncName, version.VERSION)
+	if err := marker.ClaimWhenAvailable(ctx); err != nil {
+		klog.Warningf("Failed to claim the reconciler version marker: %v", err)
+	}
+	var superseded atomic.Bool
+	go marker.Run(ctx, func() {
+		superseded.Store(true)
+		stopControllers()
+	})
+
 	klog.Info("Starting Remediator")
 	// TODO: Convert the Remediator to use the controller-manager framework.
 	doneChanForRemediator := rem.Start(ctx) // non-blocking
@@ -366,14 +598,75 @@ func Run(opts Options) {
 	<-doneChanForRemediator
 	klog.Info("Remediator exited")
 
+	if superseded.Load() {
+		// Stay idle, instead of restarting, until the reconciler of the newer
+		// version releases the marker, e.g. when the rollout of Config Sync is
+		// rolled back. The Finalizer is left to the reconciler of the newer
+		// version.
+		err := marker.ClaimWhenAvailable(signalCtx)
+		if signalCtx.Err() != nil {
+			klog.Infof("%s: exiting", SupersededByNewerVersion)
+			return
+		}
+		// Restart to resume applying.
+		if err != nil {
+			klog.Fatalf("%s: failed to claim the reconciler version marker, restarting: %v", SupersededByNewerVersion, err)
+		}
+		klog.Fatalf("%s: reconciler version marker released, restarting", SupersededByNewerVersion)
+	}
+
 	// Unblock the Finalizer to destroy managed resources, if needed.
 	close(continueChanForFinalizer)
 	// Wait for ControllerManager to exit
 	<-doneChanForManager
 	klog.Info("Finalizer exited")
 
+	if opts.LeaderElection && mgrErr != nil {
+		// Restart to rejoin the leader election, e.g. after losing the lease,
+		// instead of waiting on standby without running any controllers.
+		klog.Fatalf("ControllerManager exited: %v", mgrErr)
+	}
+
 	// Wait for exit signal, if not already received.
 	// This avoids unnecessary restarts after the finalizer has completed.
 	<-signalCtx.Done()
 	klog.Info("All controllers exited")
+
+	releaseCtx, cancel := context.WithTimeout(context.Background(), versionMarkerPeriod)
+	defer cancel()
+	if err := marker.Release(releaseCtx); err != nil {
+		klog.Warningf("Failed to release the reconciler version marker: %v", err)
+	}
+}
+
+// newRestConfig returns the config to talk to the API server, with the
+// client-side timeout set from the API server timeout of the RSync, which
+// defaults to restconfig.DefaultTimeout when not overridden, and the
+// client-side throttling set from ClientQPS and ClientBurst, if specified.
+func newRestConfig(opts Options) (*rest.Config, error) {
+	timeout, err := time.ParseDuration(opts.APIServerTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("parsing API server timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("invalid API server timeout: %v, timeout should be positive", timeout)
+	}
+	if opts.ClientQPS < 0 || opts.ClientBurst < 0 {
+		return nil, fmt.Errorf("invalid client QPS %v (burst: %d), QPS and burst should not be negative", opts.ClientQPS, opts.ClientBurst)
+	}
+	cfg, err := restconfig.NewRestConfig(timeout)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ClientQPS > 0 {
+		// Enable client-side throttling, even if the API server has flow
+		// control enabled.
+		cfg.QPS = opts.ClientQPS
+		cfg.Burst = opts.ClientBurst
+		if cfg.Burst == 0 {
+			cfg.Burst = int(2 * opts.ClientQPS)
+		}
+		klog.Infof("Client-side throttling QPS set to %.0f (burst: %d)", cfg.QPS, cfg.Burst)
+	}
+	return cfg, nil
 }
@@ -0,0 +1,142 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/client/restconfig"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`
+
+func TestNewRestConfig(t *testing.T) {
+	kubeConfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeConfigPath, []byte(testKubeConfig), 0600))
+	t.Setenv("KUBECONFIG", kubeConfigPath)
+
+	testCases := []struct {
+		name            string
+		override        *metav1.Duration
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "default timeout",
+			expectedTimeout: restconfig.DefaultTimeout,
+		},
+		{
+			name:            "overridden timeout",
+			override:        &metav1.Duration{Duration: 40 * time.Second},
+			expectedTimeout: 40 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The reconciler-manager passes the override to the reconciler
+			// as the API server timeout.
+			cfg, err := newRestConfig(Options{APIServerTimeout: v1beta1.GetAPIServerTimeout(tc.override)})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTimeout, cfg.Timeout)
+		})
+	}
+}
+
+func TestNewRestConfig_InvalidTimeout(t *testing.T) {
+	for _, timeout := range []string{"", "abc", "0s", "-1s"} {
+		_, err := newRestConfig(Options{APIServerTimeout: timeout})
+		assert.Error(t, err, "timeout %q", timeout)
+	}
+}
+
+func TestNewRestConfig_ClientQPS(t *testing.T) {
+	kubeConfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeConfigPath, []byte(testKubeConfig), 0600))
+	t.Setenv("KUBECONFIG", kubeConfigPath)
+
+	testCases := []struct {
+		name          string
+		qps           float32
+		burst         int
+		expectedQPS   float32
+		expectedBurst int
+		expectedErr   bool
+	}{
+		{
+			name:          "qps and burst",
+			qps:           100,
+			burst:         150,
+			expectedQPS:   100,
+			expectedBurst: 150,
+		},
+		{
+			name:          "qps with the default burst",
+			qps:           100,
+			expectedQPS:   100,
+			expectedBurst: 200,
+		},
+		{
+			name:        "negative qps",
+			qps:         -1,
+			expectedErr: true,
+		},
+		{
+			name:        "negative burst",
+			qps:         100,
+			burst:       -1,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := newRestConfig(Options{
+				APIServerTimeout: restconfig.DefaultTimeout.String(),
+				ClientQPS:        tc.qps,
+				ClientBurst:      tc.burst,
+			})
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQPS, cfg.QPS)
+			assert.Equal(t, tc.expectedBurst, cfg.Burst)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/util/mutate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SupersededByNewerVersion is the reason logged by a reconciler which stopped
+// applying, because a reconciler of a newer Config Sync version has taken over
+// syncing the same RootSync or RepoSync.
+const SupersededByNewerVersion = "SupersededByNewerVersion"
+
+// errSupersededByNewerVersion is returned by Claim when the marker is held by
+// a reconciler of a newer version.
+var errSupersededByNewerVersion = errors.New("the reconciler version marker is held by a newer version")
+
+// versionMarkerPeriod is how often the reconciler checks whether a reconciler
+// of another version has taken over.
+const versionMarkerPeriod = 5 * time.Second
+
+// versionMarker coordinates the reconcilers of different Config Sync versions
+// during a rollout of Config Sync, to prevent split-brain applies.
+//
+// The status.reconcilerVersion field of the RootSync or RepoSync is used as
+// the marker. A reconciler claims the marker when it starts, unless it is held
+// by a reconciler of a newer minor version, and reconcilers of older minor
+// versions stop applying once they detect the change. A reconciler releases
+// the marker when it shuts down, so that reconcilers of older versions can
+// take over during a rollback of Config Sync. If the reconciler of the newer
+// version exits without releasing the marker, clearing the status field lets
+// the reconcilers of older versions take over.
+type versionMarker struct {
+	client   client.Client
+	scope    declared.Scope
+	syncName string
+	version  string
+	period   time.Duration
+}
+
+// newVersionMarker returns a versionMarker for the RootSync or RepoSync
+// synced by a reconciler of the specified version.
+func newVersionMarker(c client.Client, scope declared.Scope, syncName, version string) *versionMarker {
+	return &versionMarker{
+		client:   c,
+		scope:    scope,
+		syncName: syncName,
+		version:  version,
+		period:   versionMarkerPeriod,
+	}
+}
+
+// newSyncObject returns a new RootSync or RepoSync with name and namespace
+// set, and a pointer to its version marker.
+func (m *versionMarker) newSyncObject() (client.Object, *string) {
+	if m.scope == declared.RootReconciler {
+		rs := &v1beta1.RootSync{}
+		rs.Name = m.syncName
+		rs.Namespace = configsync.ControllerNamespace
+		return rs, &rs.Status.ReconcilerVersion
+	}
+	rs := &v1beta1.RepoSync{}
+	rs.Name = m.syncName
+	rs.Namespace = string(m.scope)
+	return rs, &rs.Status.ReconcilerVersion
+}
+
+// Claim records the version of this reconciler in the marker, taking over
+// from the reconcilers of the same or older versions. It returns
+// errSupersededByNewerVersion, without changing the marker, if the marker is
+// held by a reconciler of a newer version.
+func (m *versionMarker) Claim(ctx context.Context) error {
+	obj, marker := m.newSyncObject()
+	if err := m.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return err
+	}
+	_, err := mutate.Status(ctx, m.client, obj, func() error {
+		if m.supersededBy(*marker) {
+			return errSupersededByNewerVersion
+		}
+		if *marker == m.version {
+			return &mutate.NoUpdateError{}
+		}
+		*marker = m.version
+		return nil
+	})
+	return err
+}
+
+// ClaimWhenAvailable claims the marker, waiting while it is held by a
+// reconciler of a newer version, until the marker is released or the context
+// is done.
+func (m *versionMarker) ClaimWhenAvailable(ctx context.Context) error {
+	logged := false
+	for {
+		err := m.Claim(ctx)
+		if !errors.Is(err, errSupersededByNewerVersion) {
+			return err
+		}
+		if !logged {
+			klog.Warningf("%s: waiting for a reconciler of a newer version to release the marker", SupersededByNewerVersion)
+			logged = true
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.period):
+		}
+	}
+}
+
+// Release clears the marker, if it is held by the version of this reconciler,
+// so that the reconcilers of older versions can claim it.
+func (m *versionMarker) Release(ctx context.Context) error {
+	obj, marker := m.newSyncObject()
+	if err := m.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	_, err := mutate.Status(ctx, m.client, obj, func() error {
+		if *marker != m.version {
+			return &mutate.NoUpdateError{}
+		}
+		*marker = ""
+		return nil
+	})
+	return client.IgnoreNotFound(err)
+}
+
+// current returns the version which currently holds the marker.
+func (m *versionMarker) current(ctx context.Context) (string, error) {
+	obj, marker := m.newSyncObject()
+	if err := m.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return "", err
+	}
+	return *marker, nil
+}
+
+// supersededBy returns whether the specified version holding the marker is a
+// newer minor version than the version of this reconciler. Patch versions are
+// ignored. Versions which are not semantic versions, like those of
+// development builds, are only compared for equality.
+func (m *versionMarker) supersededBy(version string) bool {
+	if version == "" || version == m.version {
+		return false
+	}
+	current, err := utilversion.ParseGeneric(m.version)
+	if err != nil {
+		return true
+	}
+	other, err := utilversion.ParseGeneric(version)
+	if err != nil {
+		return true
+	}
+	if other.Major() != current.Major() {
+		return other.Major() > current.Major()
+	}
+	return other.Minor() > current.Minor()
+}
+
+// Run checks the marker periodically, until the context is done or a
+// reconciler of a newer version has claimed the marker. In the latter case,
+// onSuperseded is called to stop applying.
+func (m *versionMarker) Run(ctx context.Context, onSuperseded func()) {
+	ticker := time.NewTicker(m.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		version, err := m.current(ctx)
+		if err != nil {
+			klog.Warningf("Failed to check the reconciler version marker: %v", err)
+			continue
+		}
+		if m.supersededBy(version) {
+			klog.Warningf("%s: reconciler version %q has taken over from version %q; stopping the Parser and Remediator",
+				SupersededByNewerVersion, version, m.version)
+			onSuperseded()
+			return
+		}
+	}
+}
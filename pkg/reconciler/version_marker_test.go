@@ -0,0 +1,189 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestVersionMarkerTakeover(t *testing.T) {
+	testCases := []struct {
+		name      string
+		scope     declared.Scope
+		syncName  string
+		newClient func() *syncerFake.Client
+	}{
+		{
+			name:     "RootSync",
+			scope:    declared.RootReconciler,
+			syncName: configsync.RootSyncName,
+			newClient: func() *syncerFake.Client {
+				return syncerFake.NewClient(t, core.Scheme,
+					fake.RootSyncObjectV1Beta1(configsync.RootSyncName))
+			},
+		},
+		{
+			name:     "RepoSync",
+			scope:    "bookstore",
+			syncName: configsync.RepoSyncName,
+			newClient: func() *syncerFake.Client {
+				return syncerFake.NewClient(t, core.Scheme,
+					fake.RepoSyncObjectV1Beta1("bookstore", configsync.RepoSyncName))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			fakeClient := tc.newClient()
+
+			oldMarker := newVersionMarker(fakeClient, tc.scope, tc.syncName, "v1.16.0")
+			oldMarker.period = 10 * time.Millisecond
+			require.NoError(t, oldMarker.Claim(ctx))
+			version, err := oldMarker.current(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "v1.16.0", version)
+
+			superseded := make(chan struct{})
+			doneCh := make(chan struct{})
+			go func() {
+				defer close(doneCh)
+				oldMarker.Run(ctx, func() { close(superseded) })
+			}()
+
+			// The old reconciler keeps applying while it holds the marker.
+			select {
+			case <-superseded:
+				t.Fatal("old reconciler superseded before the takeover")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			// A reconciler of a newer patch version does not supersede the old
+			// reconciler, and a reconciler of an older version cannot claim
+			// the marker.
+			require.NoError(t, newVersionMarker(fakeClient, tc.scope, tc.syncName, "v1.16.1").Claim(ctx))
+			err = newVersionMarker(fakeClient, tc.scope, tc.syncName, "v1.15.0").Claim(ctx)
+			require.ErrorIs(t, err, errSupersededByNewerVersion)
+			version, err = oldMarker.current(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "v1.16.1", version)
+			select {
+			case <-superseded:
+				t.Fatal("old reconciler superseded by a patch or older version")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			// A reconciler of a newer version takes over.
+			newMarker := newVersionMarker(fakeClient, tc.scope, tc.syncName, "v1.17.0")
+			require.NoError(t, newMarker.Claim(ctx))
+			version, err = newMarker.current(ctx)
+			require.NoError(t, err)
+			require.Equal(t, "v1.17.0", version)
+
+			select {
+			case <-superseded:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the old reconciler to be superseded")
+			}
+			<-doneCh
+		})
+	}
+}
+
+func TestVersionMarkerRestartAfterSuperseded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fakeClient := syncerFake.NewClient(t, core.Scheme,
+		fake.RootSyncObjectV1Beta1(configsync.RootSyncName))
+
+	oldMarker := newVersionMarker(fakeClient, declared.RootReconciler, configsync.RootSyncName, "v1.16.0")
+	oldMarker.period = 10 * time.Millisecond
+	require.NoError(t, oldMarker.Claim(ctx))
+	newMarker := newVersionMarker(fakeClient, declared.RootReconciler, configsync.RootSyncName, "v1.17.0")
+	require.NoError(t, newMarker.Claim(ctx))
+
+	// The restarted old reconciler does not take the marker back from the
+	// newer one.
+	err := oldMarker.Claim(ctx)
+	require.ErrorIs(t, err, errSupersededByNewerVersion)
+	version, err := oldMarker.current(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "v1.17.0", version)
+
+	// It stays idle until the newer reconciler releases the marker.
+	claimed := make(chan error)
+	go func() {
+		claimed <- oldMarker.ClaimWhenAvailable(ctx)
+	}()
+	select {
+	case err := <-claimed:
+		t.Fatalf("old reconciler claimed the marker before it was released: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, newMarker.Release(ctx))
+	select {
+	case err := <-claimed:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the old reconciler to claim the marker")
+	}
+	version, err = oldMarker.current(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "v1.16.0", version)
+
+	// Releasing a marker held by another version is a no-op.
+	require.NoError(t, newMarker.Release(ctx))
+	version, err = oldMarker.current(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "v1.16.0", version)
+}
+
+func TestVersionMarkerSupersededBy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		version string
+		marker  string
+		want    bool
+	}{
+		{name: "unclaimed", version: "v1.16.0", marker: "", want: false},
+		{name: "same version", version: "v1.16.0", marker: "v1.16.0", want: false},
+		{name: "newer patch version", version: "v1.16.0", marker: "v1.16.2", want: false},
+		{name: "newer minor version", version: "v1.16.3", marker: "v1.17.0", want: true},
+		{name: "newer major version", version: "v1.16.0", marker: "v2.0.0", want: true},
+		{name: "older minor version", version: "v1.17.0", marker: "v1.16.0", want: false},
+		{name: "older major version", version: "v2.0.0", marker: "v1.17.0", want: false},
+		{name: "minor version compared numerically", version: "v1.9.0", marker: "v1.10.0", want: true},
+		{name: "development build", version: "UNKNOWN", marker: "v1.17.0", want: true},
+		{name: "same development build", version: "UNKNOWN", marker: "UNKNOWN", want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newVersionMarker(nil, declared.RootReconciler, configsync.RootSyncName, tc.version)
+			require.Equal(t, tc.want, m.supersededBy(tc.marker))
+		})
+	}
+}
@@ -53,6 +53,15 @@ const (
 	// read by the hydration controller and the reconciler.
 	SyncDirKey = "SYNC_DIR"
 
+	// SyncDirsKey is the OS env variable key for the comma-separated list of
+	// sync directories read by the reconciler, when more than one directory
+	// is synced.
+	SyncDirsKey = "SYNC_DIRS"
+
+	// DirsCollisionPolicy tells the reconciler container how to handle an
+	// object declared in more than one of the sync directories.
+	DirsCollisionPolicy = "DIRS_COLLISION_POLICY"
+
 	// GitSync is the name of the git-sync container in reconciler pods.
 	GitSync = "git-sync"
 
@@ -81,6 +90,93 @@ const (
 	// APIServerTimeout is to control the client-side timeout when talking to the API server
 	APIServerTimeout = "API_SERVER_TIMEOUT"
 
+	// ClientQPS is to control the client-side throttling QPS when talking to
+	// the API server.
+	ClientQPS = "CLIENT_QPS"
+
+	// ClientBurst is to control the client-side throttling burst when talking
+	// to the API server.
+	ClientBurst = "CLIENT_BURST"
+
+	// MinRemediationInterval is to control the minimum interval between two
+	// corrections of the same object by the remediator.
+	MinRemediationInterval = "MIN_REMEDIATION_INTERVAL"
+
+	// ApplyCallTimeout is to control the timeout of each individual apply,
+	// patch, or delete call made by the reconciler.
+	ApplyCallTimeout = "APPLY_CALL_TIMEOUT"
+
+	// ResyncPeriod is to control the period of time between forced re-syncs
+	// from the source.
+	ResyncPeriod = "RESYNC_PERIOD"
+
+	// StatusUpdatePeriod is to control the period of time between the periodic
+	// updates of the sync status by the reconciler.
+	StatusUpdatePeriod = "STATUS_UPDATE_PERIOD"
+
+	// RenderingTimeout is to control how long the reconciler waits for the
+	// rendering of a new commit before reporting the rendering as failed.
+	RenderingTimeout = "RENDERING_TIMEOUT"
+
+	// CircuitBreakerThreshold is to control the number of consecutive syncs
+	// failing because the API server is unavailable after which the reconciler
+	// stops syncing until a periodic probe succeeds.
+	CircuitBreakerThreshold = "CIRCUIT_BREAKER_THRESHOLD"
+
+	// CircuitBreakerProbePeriod is to control the initial period between two
+	// probes while the circuit breaker is open.
+	CircuitBreakerProbePeriod = "CIRCUIT_BREAKER_PROBE_PERIOD"
+
+	// FieldManager is to control the field manager name used by the
+	// reconciler to apply the managed objects.
+	FieldManager = "FIELD_MANAGER"
+
+	// RequiredMetadata tells the reconciler container which labels and
+	// annotations every managed object must have, as a comma-separated list
+	// of `<type>:<key>` pairs.
+	RequiredMetadata = "REQUIRED_METADATA"
+
+	// AnnotateSyncGeneration tells the reconciler container whether to
+	// annotate the managed objects with the generation of the RootSync or
+	// RepoSync.
+	AnnotateSyncGeneration = "ANNOTATE_SYNC_GENERATION"
+
+	// DeferUnestablishedCRs tells the reconciler container whether to defer
+	// applying custom resources whose CRD is not yet established.
+	DeferUnestablishedCRs = "DEFER_UNESTABLISHED_CRS"
+
+	// PauseApply tells the reconciler container whether to pause applying the
+	// resources from the source.
+	PauseApply = "PAUSE_APPLY"
+
+	// MaxPruneCount tells the reconciler container the maximum number of
+	// objects to prune in one sync without confirmation.
+	MaxPruneCount = "MAX_PRUNE_COUNT"
+
+	// ValidateWithAdmission tells the reconciler container whether to validate
+	// the objects from the source with the admission chain before applying.
+	ValidateWithAdmission = "VALIDATE_WITH_ADMISSION"
+
+	// RemediatorExcludedKinds tells the reconciler container which resource
+	// types not to watch for drift, as a comma-separated list of `Kind.group`.
+	RemediatorExcludedKinds = "REMEDIATOR_EXCLUDED_KINDS"
+
+	// ObjectSelector tells the reconciler container which objects from the
+	// source to apply, as a label selector string.
+	ObjectSelector = "OBJECT_SELECTOR"
+
+	// SubstituteClusterName tells the reconciler container whether to replace
+	// the `${CLUSTER_NAME}` token in the objects from the source.
+	SubstituteClusterName = "SUBSTITUTE_CLUSTER_NAME"
+
+	// ApplyDebouncePeriod tells the reconciler container how long to wait
+	// after detecting a new commit before applying it.
+	ApplyDebouncePeriod = "APPLY_DEBOUNCE_PERIOD"
+
+	// LeaderElection tells the reconciler container whether to use leader
+	// election, so that only one of the reconciler replicas is active.
+	LeaderElection = "LEADER_ELECTION"
+
 	// StatusMode is to control if the kpt applier needs to inject the actuation data
 	// into the ResourceGroup object.
 	StatusMode = "STATUS_MODE"
@@ -93,10 +189,38 @@ const (
 	// use
 	NamespaceStrategy = "NAMESPACE_STRATEGY"
 
+	// AmbiguousSourceFormat tells the reconciler container how to handle a
+	// source which signals a different source format than the configured one.
+	AmbiguousSourceFormat = "AMBIGUOUS_SOURCE_FORMAT"
+
+	// NamespaceMismatchPolicy tells the reconciler container how to handle an
+	// object whose metadata.namespace differs from its directory.
+	NamespaceMismatchPolicy = "NAMESPACE_MISMATCH_POLICY"
+
+	// ConflictPolicy tells the reconciler container which objects already
+	// managed by another RootSync or RepoSync it may adopt.
+	ConflictPolicy = "CONFLICT_POLICY"
+
+	// SyncMode tells the reconciler container whether to apply the resources
+	// from the source, or only observe them.
+	SyncMode = "SYNC_MODE"
+
 	// DynamicNSSelectorEnabled tells the reconciler container whether the dynamic
 	// mode is enabled in NamespaceSelectors, which requires a Namespace controller
 	// to be running.
 	DynamicNSSelectorEnabled = "DYNAMIC_NS_SELECTOR_ENABLED"
+
+	// WebhookEnabled tells the reconciler container whether the Config Sync
+	// admission webhook is enabled.
+	WebhookEnabled = "WEBHOOK_ENABLED"
+
+	// DynamicNamespaceSelector tells the reconciler container whether
+	// NamespaceSelectors which do not set a mode use the dynamic mode.
+	DynamicNamespaceSelector = "DYNAMIC_NAMESPACE_SELECTOR"
+
+	// ClusterScopedConflictCheck tells the reconciler container whether to
+	// reject the cluster-scoped objects also declared by another RootSync.
+	ClusterScopedConflictCheck = "CLUSTER_SCOPED_CONFLICT_CHECK"
 )
 
 const (
@@ -137,6 +261,14 @@ const (
 	// This variable is consumed by the underlying crypto library:
 	// - https://pkg.go.dev/crypto/x509#SystemCertPool
 	OciCACert = "SSL_CERT_FILE"
+
+	// OciClientCert is the OS env variable key for the OCI client certificate
+	// file path used for mutual TLS.
+	OciClientCert = "OCI_SYNC_CLIENT_CERT"
+
+	// OciClientKey is the OS env variable key for the OCI client private key
+	// file path used for mutual TLS.
+	OciClientKey = "OCI_SYNC_CLIENT_KEY"
 )
 
 const (
@@ -169,6 +301,14 @@ const (
 	//HelmIncludeCRDs is the OS env variable key for whether to include CRDs in helm rendering output.
 	HelmIncludeCRDs = "HELM_INCLUDE_CRDS"
 
+	// HelmAPIVersions is the OS env variable key for a comma-separated list of
+	// the Kubernetes API versions used for Capabilities.APIVersions in helm rendering.
+	HelmAPIVersions = "HELM_API_VERSIONS"
+
+	// HelmKubeVersion is the OS env variable key for the Kubernetes version used
+	// for Capabilities.KubeVersion in helm rendering.
+	HelmKubeVersion = "HELM_KUBE_VERSION"
+
 	//HelmAuthType is the OS env variable key for Helm sync auth type.
 	HelmAuthType = "HELM_AUTH_TYPE"
 
@@ -177,4 +317,12 @@ const (
 
 	// HelmCACert is the OS env variable key for the Helm sync CA cert file path.
 	HelmCACert = "HELM_CA_CERT"
+
+	// HelmClientCert is the OS env variable key for the Helm sync client
+	// certificate file path used for mutual TLS.
+	HelmClientCert = "HELM_CLIENT_CERT"
+
+	// HelmClientKey is the OS env variable key for the Helm sync client
+	// private key file path used for mutual TLS.
+	HelmClientKey = "HELM_CLIENT_KEY"
 )
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -146,6 +147,24 @@ func (r *reconcilerBase) deleteServiceAccount(ctx context.Context, reconcilerRef
 	return r.cleanup(ctx, sa)
 }
 
+// deleteLeaderElectionRBAC deletes the Role and RoleBinding which allow the
+// reconciler to manage its leader election Lease, if they exist.
+func (r *reconcilerBase) deleteLeaderElectionRBAC(ctx context.Context, reconcilerRef types.NamespacedName) error {
+	key := client.ObjectKey{Namespace: reconcilerRef.Namespace, Name: leaderElectionRBACName(reconcilerRef)}
+	for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+		if err := r.client.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := r.cleanup(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *RepoSyncReconciler) deleteSharedRoleBinding(ctx context.Context, reconcilerRef, rsRef types.NamespacedName) error {
 	rbKey := client.ObjectKey{Namespace: rsRef.Namespace, Name: RepoSyncBaseClusterRoleName}
 	rb := &rbacv1.RoleBinding{}
@@ -230,6 +249,23 @@ func (r *RepoSyncReconciler) deleteHelmConfigMapCopies(ctx context.Context, rsRe
 	return nil
 }
 
+func (r *RootSyncReconciler) deletePostSyncVerificationJob(ctx context.Context, reconcilerRef types.NamespacedName) error {
+	job := &batchv1.Job{}
+	key := client.ObjectKey{
+		Namespace: reconcilerRef.Namespace,
+		Name:      postSyncVerificationJobName(reconcilerRef.Name),
+	}
+	// Most RootSyncs do not use post-sync verification. So use Get to avoid
+	// waiting for the deletion of a Job which does not exist.
+	if err := r.client.Get(ctx, key, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return NewObjectOperationErrorWithKey(err, job, OperationDelete, key)
+	}
+	return r.cleanup(ctx, job)
+}
+
 func (r *reconcilerBase) deleteDeployment(ctx context.Context, reconcilerRef types.NamespacedName) error {
 	d := &appsv1.Deployment{}
 	d.Name = reconcilerRef.Name
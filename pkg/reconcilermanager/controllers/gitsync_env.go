@@ -21,6 +21,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
 )
@@ -42,6 +43,8 @@ const (
 	GitSyncDepth = "GITSYNC_DEPTH"
 	// gitSyncPeriod represents the environment variable key for specifying the sync interval duration.
 	gitSyncPeriod = "GITSYNC_PERIOD"
+	// gitSyncTimeout represents the environment variable key for specifying the timeout of a single sync.
+	gitSyncTimeout = "GITSYNC_SYNC_TIMEOUT"
 
 	// gitSyncSSH represents the environment variable key for specifying the SSH key to use.
 	gitSyncSSH = "GITSYNC_SSH"
@@ -53,9 +56,16 @@ const (
 
 	// GitSSLCAInfo represents the environment variable key for SSL certificates.
 	GitSSLCAInfo = "GIT_SSL_CAINFO"
+	// GitSSLCert represents the environment variable key for the client certificate.
+	GitSSLCert = "GIT_SSL_CERT"
+	// GitSSLKey represents the environment variable key for the client private key.
+	GitSSLKey = "GIT_SSL_KEY"
 
 	// GitSyncKnownHosts represents the environment variable key for GIT_KNOWN_HOSTS.
 	GitSyncKnownHosts = "GITSYNC_SSH_KNOWN_HOSTS"
+	// GitSyncKnownHostsFile represents the environment variable key for the
+	// path to the known_hosts file.
+	GitSyncKnownHostsFile = "GITSYNC_SSH_KNOWN_HOSTS_FILE"
 	// GitSSLNoVerify represents the environment variable key for GIT_SSL_NO_VERIFY.
 	GitSSLNoVerify = "GIT_SSL_NO_VERIFY"
 
@@ -88,14 +98,20 @@ type options struct {
 	secretType configsync.AuthType
 	// proxy used to connect to the repo.
 	proxy string
+	// noProxy lists the hosts that bypass the proxy.
+	noProxy string
 	// period is the time duration between consecutive syncs.
 	period time.Duration
 	// depth is the number of git commits to sync.
 	depth *int64
+	// syncTimeout is the timeout of a single sync.
+	syncTimeout *metav1.Duration
 	// noSSLVerify specifies whether to skip the SSL certificate verification in Git.
 	noSSLVerify bool
 	// caCertSecretRef specifies the name of a secret containing a CA certificate
 	caCertSecretRef string
+	// clientCertSecretRef specifies the name of a secret containing a client certificate and key
+	clientCertSecretRef string
 	// knownHost specifies whether known_hosts configuration is included
 	knownHost bool
 }
@@ -161,6 +177,10 @@ func useCACert(caCertSecretRef string) bool {
 	return caCertSecretRef != ""
 }
 
+func useClientCert(clientCertSecretRef string) bool {
+	return clientCertSecretRef != ""
+}
+
 func gitSyncEnvs(_ context.Context, opts options) []corev1.EnvVar {
 	var result []corev1.EnvVar
 	result = append(result, corev1.EnvVar{
@@ -192,6 +212,15 @@ func gitSyncEnvs(_ context.Context, opts options) []corev1.EnvVar {
 			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
 		})
 	}
+	if useClientCert(opts.clientCertSecretRef) {
+		result = append(result, corev1.EnvVar{
+			Name:  GitSSLCert,
+			Value: fmt.Sprintf("%s/%s", ClientCertPath, ClientCertSecretCertKey),
+		}, corev1.EnvVar{
+			Name:  GitSSLKey,
+			Value: fmt.Sprintf("%s/%s", ClientCertPath, ClientCertSecretKeyKey),
+		})
+	}
 	if opts.depth != nil && *opts.depth >= 0 {
 		// git-sync would do a shallow clone if *opts.depth > 0;
 		// git-sync would do a full clone if *opts.depth == 0.
@@ -225,6 +254,12 @@ func gitSyncEnvs(_ context.Context, opts options) []corev1.EnvVar {
 		Name:  gitSyncPeriod,
 		Value: opts.period.String(),
 	})
+	if opts.syncTimeout != nil {
+		result = append(result, corev1.EnvVar{
+			Name:  gitSyncTimeout,
+			Value: opts.syncTimeout.Duration.String(),
+		})
+	}
 	// We can't use default values in git-sync because of the breaking change: https://github.com/kubernetes/git-sync/issues/841.
 	// For backward compatibility, we set gitSyncRef to branch when ref is HEAD.
 	// If ref is HEAD or empty,
@@ -268,6 +303,14 @@ func gitSyncEnvs(_ context.Context, opts options) []corev1.EnvVar {
 				Value: opts.proxy,
 			})
 		}
+		// The proxy may also come from the https_proxy key of the Secret, so
+		// NO_PROXY is set whenever it is declared.
+		if opts.noProxy != "" {
+			result = append(result, corev1.EnvVar{
+				Name:  noProxyEnv,
+				Value: opts.noProxy,
+			})
+		}
 	default:
 		// TODO b/168553377 Return error while setting up gitSyncData.
 		klog.Errorf("Unrecognized secret type %s", opts.secretType)
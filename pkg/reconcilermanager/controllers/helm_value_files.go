@@ -86,16 +86,16 @@ func (r *RepoSyncReconciler) getReconcilerHelmConfigMapRefs(rs *v1beta1.RepoSync
 	return cmsCMRefs
 }
 
-// upsertHelmConfigMaps creates or updates the helm values file ConfigMaps
-// in the config-management-system namespace using existing ConfigMaps in the
-// RepoSync namespace.
-// Since SourceType or ValuesFileRefs may have changed, we also need to delete
-// ConfigMap copies for this RepoSync that are no longer used.
-func (r *RepoSyncReconciler) upsertHelmConfigMaps(ctx context.Context, rs *v1beta1.RepoSync, labelMap map[string]string) error {
+// upsertConfigMapCopies creates or updates the helm values file and
+// known_hosts ConfigMaps in the config-management-system namespace using
+// existing ConfigMaps in the RepoSync namespace.
+// Since SourceType, ValuesFileRefs or KnownHostsConfigMapRef may have changed,
+// we also need to delete ConfigMap copies for this RepoSync that are no longer
+// used.
+func (r *RepoSyncReconciler) upsertConfigMapCopies(ctx context.Context, rs *v1beta1.RepoSync, labelMap map[string]string) error {
 	rsRef := client.ObjectKeyFromObject(rs)
-	var cmNamesToKeep map[string]struct{}
+	cmNamesToKeep := make(map[string]struct{})
 	if rs.Spec.SourceType == string(v1beta1.HelmSource) && rs.Spec.Helm != nil {
-		cmNamesToKeep = make(map[string]struct{}, len(rs.Spec.Helm.ValuesFileRefs))
 		for _, vfRef := range rs.Spec.Helm.ValuesFileRefs {
 			userCMRef := types.NamespacedName{
 				Namespace: rsRef.Namespace,
@@ -113,6 +113,23 @@ func (r *RepoSyncReconciler) upsertHelmConfigMaps(ctx context.Context, rs *v1bet
 
 		}
 	}
+	if rs.Spec.SourceType == string(v1beta1.GitSource) {
+		if name := knownHostsConfigMapName(rs.Spec.Git); name != "" {
+			userCMRef := types.NamespacedName{
+				Namespace: rsRef.Namespace,
+				Name:      name,
+			}
+			copyCMRef := getHelmConfigMapCopyRef(userCMRef.Name, rsRef)
+			cmNamesToKeep[copyCMRef.Name] = struct{}{}
+			userCM, err := r.getUserHelmConfigMap(ctx, userCMRef)
+			if err != nil {
+				return errors.Wrapf(err, "user config map required for known_hosts: %s", userCMRef)
+			}
+			if _, err = r.upsertHelmConfigMap(ctx, copyCMRef, rsRef, userCM, labelMap); err != nil {
+				return err
+			}
+		}
+	}
 	return r.deleteHelmConfigMapCopies(ctx, rsRef, cmNamesToKeep)
 }
 
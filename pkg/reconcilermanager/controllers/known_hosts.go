@@ -0,0 +1,106 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/validate/raw/validate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KnownHostsVolume is the volume name of the known_hosts ConfigMap.
+const KnownHostsVolume = "known-hosts"
+
+// KnownHostsPath is the path where the known_hosts ConfigMap is mounted.
+const KnownHostsPath = "/etc/known-hosts"
+
+// knownHostsConfigMapName returns the name of the user ConfigMap which
+// contains the known_hosts, or an empty string if the known_hosts are not
+// read from a ConfigMap.
+func knownHostsConfigMapName(git *v1beta1.Git) string {
+	if git == nil || git.Auth != configsync.AuthSSH || git.KnownHostsConfigMapRef == nil {
+		return ""
+	}
+	return git.KnownHostsConfigMapRef.Name
+}
+
+// getReconcilerKnownHostsConfigMapRef returns the KnownHostsConfigMapRef with
+// the associated data key, or nil if the known_hosts are not read from a
+// ConfigMap.
+func (r *RootSyncReconciler) getReconcilerKnownHostsConfigMapRef(rs *v1beta1.RootSync) *v1beta1.KnownHostsConfigMapRef {
+	name := knownHostsConfigMapName(rs.Spec.Git)
+	if name == "" {
+		return nil
+	}
+	return &v1beta1.KnownHostsConfigMapRef{
+		Name:    name,
+		DataKey: validate.KnownHostsDataKeyOrDefault(rs.Spec.Git.KnownHostsConfigMapRef.DataKey),
+	}
+}
+
+// getReconcilerKnownHostsConfigMapRef returns the KnownHostsConfigMapRef with
+// the name of the known_hosts ConfigMap copy in the config-management-system
+// namespace with the associated data key, or nil if the known_hosts are not
+// read from a ConfigMap.
+func (r *RepoSyncReconciler) getReconcilerKnownHostsConfigMapRef(rs *v1beta1.RepoSync) *v1beta1.KnownHostsConfigMapRef {
+	name := knownHostsConfigMapName(rs.Spec.Git)
+	if name == "" {
+		return nil
+	}
+	return &v1beta1.KnownHostsConfigMapRef{
+		Name:    getHelmConfigMapCopyRef(name, client.ObjectKeyFromObject(rs)).Name,
+		DataKey: validate.KnownHostsDataKeyOrDefault(rs.Spec.Git.KnownHostsConfigMapRef.DataKey),
+	}
+}
+
+// mountKnownHostsConfigMap mounts the known_hosts from the referenced ConfigMap
+// as a file in the git-sync container.
+func mountKnownHostsConfigMap(templateSpec *corev1.PodSpec, c *corev1.Container, ref *v1beta1.KnownHostsConfigMapRef) {
+	if ref == nil {
+		return
+	}
+	templateSpec.Volumes = append(templateSpec.Volumes, corev1.Volume{
+		Name: KnownHostsVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: ref.Name,
+				},
+				Items: []corev1.KeyToPath{{
+					Key:  ref.DataKey,
+					Path: KnownHostsKey,
+				}},
+				// Like the helm values files, the ConfigMap may be deleted
+				// before the RSync, so the mount is optional and the
+				// ConfigMap is validated elsewhere.
+				Optional: pointer.Bool(true),
+			},
+		},
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      KnownHostsVolume,
+		MountPath: KnownHostsPath,
+		ReadOnly:  true,
+	})
+	c.Env = append(c.Env, corev1.EnvVar{
+		Name:  GitSyncKnownHostsFile,
+		Value: filepath.Join(KnownHostsPath, KnownHostsKey),
+	})
+}
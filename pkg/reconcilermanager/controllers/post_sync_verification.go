@@ -0,0 +1,149 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/rootsync"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// postSyncVerificationJobName returns the name of the post-sync verification
+// Job of a root reconciler.
+func postSyncVerificationJobName(reconcilerName string) string {
+	return ReconcilerResourceName(reconcilerName, "post-sync-verification")
+}
+
+// postSyncVerificationCommit returns the commit to verify, which is the commit
+// of the last sync if it completed without errors, or empty otherwise.
+func postSyncVerificationCommit(rs *v1beta1.RootSync) string {
+	cond := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncSyncing)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "Sync" ||
+		!rootsync.ConditionHasNoErrors(*cond) {
+		return ""
+	}
+	return cond.Commit
+}
+
+// validatePostSyncVerification rejects a Job template which runs as a
+// ServiceAccount other than the default one of the config-management-system
+// namespace, since the ServiceAccounts of the reconcilers live in the same
+// namespace and have much broader permissions.
+func validatePostSyncVerification(verification *v1beta1.PostSyncVerification) error {
+	if verification == nil {
+		return nil
+	}
+	podSpec := verification.JobTemplate.Spec.Template.Spec
+	if podSpec.ServiceAccountName != "" || podSpec.DeprecatedServiceAccount != "" {
+		return fmt.Errorf("spec.override.postSyncVerification.jobTemplate.spec.template.spec.serviceAccountName: must not be set, the Job runs as the default ServiceAccount")
+	}
+	return nil
+}
+
+// managePostSyncVerification creates the post-sync verification Job for the
+// last successfully synced commit, replacing the Job of a previously synced
+// commit, and returns the current Job.
+// The Job is deleted if post-sync verification is disabled.
+func (r *RootSyncReconciler) managePostSyncVerification(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync, labelMap map[string]string) (*batchv1.Job, error) {
+	jobRef := types.NamespacedName{
+		Namespace: reconcilerRef.Namespace,
+		Name:      postSyncVerificationJobName(reconcilerRef.Name),
+	}
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, jobRef, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, NewObjectOperationErrorWithKey(err, job, OperationGet, jobRef)
+		}
+		job = nil
+	}
+
+	if rs.Spec.Override == nil || rs.Spec.Override.PostSyncVerification == nil {
+		if job != nil {
+			return nil, r.cleanup(ctx, job)
+		}
+		return nil, nil
+	}
+
+	commit := postSyncVerificationCommit(rs)
+	if commit == "" {
+		// Keep the Job of the previously synced commit, if any.
+		return job, nil
+	}
+	if job != nil {
+		if core.GetAnnotation(job, metadata.PostSyncVerificationCommit) == commit {
+			return job, nil
+		}
+		// Jobs are immutable, so replace the Job of the previous commit.
+		if err := r.cleanup(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	template := rs.Spec.Override.PostSyncVerification.JobTemplate.DeepCopy()
+	job = &batchv1.Job{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+	job.Name = jobRef.Name
+	job.Namespace = jobRef.Namespace
+	core.AddLabels(job, labelMap)
+	core.SetAnnotation(job, metadata.PostSyncVerificationCommit, commit)
+	if err := r.client.Create(ctx, job); err != nil {
+		return nil, NewObjectOperationError(err, job, OperationCreate)
+	}
+	r.logger(ctx).Info("Managed object upsert successful",
+		logFieldObjectRef, jobRef.String(),
+		logFieldObjectKind, "Job",
+		logFieldOperation, OperationCreate)
+	return job, nil
+}
+
+// setPostSyncVerificationCondition sets the PostSyncVerificationFailed
+// condition from the outcome of the post-sync verification Job.
+// The condition is left unchanged while the Job is running.
+func setPostSyncVerificationCondition(rs *v1beta1.RootSync, job *batchv1.Job) {
+	if rs.Spec.Override == nil || rs.Spec.Override.PostSyncVerification == nil {
+		rootsync.RemoveCondition(rs, v1beta1.RootSyncPostSyncVerificationFailed)
+		return
+	}
+	if job == nil {
+		return
+	}
+	jobRef := client.ObjectKeyFromObject(job)
+	commit := core.GetAnnotation(job, metadata.PostSyncVerificationCommit)
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobFailed:
+			rootsync.SetPostSyncVerificationFailed(rs, true, "JobFailed",
+				fmt.Sprintf("Job (%s) failed: %s", jobRef, cond.Message), commit)
+		case batchv1.JobComplete:
+			rootsync.SetPostSyncVerificationFailed(rs, false, "JobComplete",
+				fmt.Sprintf("Job (%s) completed", jobRef), commit)
+		}
+	}
+}
@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -33,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -43,9 +47,14 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/mutate"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
+	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 const (
@@ -62,6 +71,16 @@ const (
 	logFieldResourceVersion = "resourceVersion"
 )
 
+const (
+	// DefaultStatusConflictThreshold is the default number of conflicts
+	// updating the status of an RSync within the DefaultStatusConflictWindow
+	// which are reported as a StatusContention condition.
+	DefaultStatusConflictThreshold = 10
+	// DefaultStatusConflictWindow is the default period of time over which the
+	// status update conflicts are counted.
+	DefaultStatusConflictWindow = time.Minute
+)
+
 // The fields in reconcilerManagerAllowList are the fields that reconciler manager
 // allows users or other controllers to modify on the reconciler Deployment.
 var reconcilerManagerAllowList = []string{
@@ -96,10 +115,38 @@ type reconcilerBase struct {
 	membership              *hubv1.Membership
 	knownHostExist          bool
 
+	// statusConflicts detects the conflict storms when updating the status of
+	// the RSync objects.
+	statusConflicts *mutate.ConflictDetector
+
 	// syncKind is the kind of the sync object: RootSync or RepoSync.
 	syncKind string
 }
 
+// updateMembership caches the Fleet Membership used to build the Fleet Workload
+// Identity credentials, and returns whether it changed since the last update.
+// A changed Membership spec, or a re-created Membership, invalidates the
+// credentials injected into the reconciler Deployments.
+func (r *reconcilerBase) updateMembership(m *hubv1.Membership) bool {
+	cached := r.membership
+	r.membership = m
+	switch {
+	case cached == nil && m == nil:
+		return false
+	case cached == nil:
+		klog.Infof("Fleet Membership found with generation %d, injecting Fleet Workload Identity credentials", m.Generation)
+		return true
+	case m == nil:
+		return true
+	case cached.UID == m.UID && cached.Generation == m.Generation:
+		return false
+	default:
+		klog.Infof("Fleet Membership changed from generation %d to %d, refreshing Fleet Workload Identity credentials",
+			cached.Generation, m.Generation)
+		return true
+	}
+}
+
 func (r *reconcilerBase) serviceAccountSubject(reconcilerRef types.NamespacedName) rbacv1.Subject {
 	return newSubject(reconcilerRef.Name, reconcilerRef.Namespace, kinds.ServiceAccount().Kind)
 }
@@ -146,6 +193,69 @@ func (r *reconcilerBase) upsertServiceAccount(
 	return childSARef, nil
 }
 
+// leaderElectionRBACName returns the name of the Role and RoleBinding which
+// allow the reconciler to manage its leader election Lease.
+func leaderElectionRBACName(reconcilerRef types.NamespacedName) string {
+	return ReconcilerResourceName(reconcilerRef.Name, "leader-election")
+}
+
+// upsertLeaderElectionRBAC creates or updates the Role and RoleBinding which
+// allow the reconciler to manage its leader election Lease, named after the
+// reconciler, in the reconciler namespace. Creating a Lease cannot be limited
+// by name, so it is limited to the reconciler namespace instead.
+func (r *reconcilerBase) upsertLeaderElectionRBAC(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string) error {
+	name := leaderElectionRBACName(reconcilerRef)
+	role := &rbacv1.Role{}
+	role.Name = name
+	role.Namespace = reconcilerRef.Namespace
+	op, err := CreateOrUpdate(ctx, r.client, role, func() error {
+		core.AddLabels(role, labelMap)
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{"coordination.k8s.io"},
+				Resources:     []string{"leases"},
+				ResourceNames: []string{reconcilerRef.Name},
+				Verbs:         []string{"get", "update"},
+			},
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     []string{"create"},
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		r.logger(ctx).Info("Managed object upsert successful",
+			logFieldObjectRef, client.ObjectKeyFromObject(role).String(),
+			logFieldObjectKind, "Role",
+			logFieldOperation, op)
+	}
+
+	rb := &rbacv1.RoleBinding{}
+	rb.Name = name
+	rb.Namespace = reconcilerRef.Namespace
+	op, err = CreateOrUpdate(ctx, r.client, rb, func() error {
+		core.AddLabels(rb, labelMap)
+		rb.RoleRef = rolereference(name, "Role")
+		rb.Subjects = []rbacv1.Subject{r.serviceAccountSubject(reconcilerRef)}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		r.logger(ctx).Info("Managed object upsert successful",
+			logFieldObjectRef, client.ObjectKeyFromObject(rb).String(),
+			logFieldObjectKind, "RoleBinding",
+			logFieldOperation, op)
+	}
+	return nil
+}
+
 type mutateFn func(client.Object) error
 
 func (r *reconcilerBase) upsertDeployment(ctx context.Context, reconcilerRef types.NamespacedName, labelMap map[string]string, mutateObject mutateFn) (*unstructured.Unstructured, controllerutil.OperationResult, error) {
@@ -180,6 +290,7 @@ func (r *reconcilerBase) upsertDeployment(ctx context.Context, reconcilerRef typ
 	if err := mutateObject(reconcilerDeployment); err != nil {
 		return nil, controllerutil.OperationResultNone, err
 	}
+	r.warnAutopilotResources(ctx, reconcilerDeployment)
 	appliedObj, op, err := r.applyDeployment(ctx, reconcilerDeployment)
 
 	if op != controllerutil.OperationResultNone {
@@ -191,6 +302,21 @@ func (r *reconcilerBase) upsertDeployment(ctx context.Context, reconcilerRef typ
 	return appliedObj, op, err
 }
 
+// warnAutopilotResources logs a warning if the declared resources of the
+// reconciler Pod do not comply with the Autopilot constraints, if it is an
+// Autopilot cluster, because Autopilot will adjust them.
+func (r *reconcilerBase) warnAutopilotResources(ctx context.Context, declared *appsv1.Deployment) {
+	autopilot, err := r.isAutopilot()
+	if err != nil || !autopilot {
+		return
+	}
+	for _, warning := range autopilotResourceWarnings(declared.Spec.Template.Spec) {
+		r.logger(ctx).Info("Reconciler resources will be adjusted by Autopilot: "+warning,
+			logFieldObjectRef, client.ObjectKeyFromObject(declared).String(),
+			logFieldObjectKind, "Deployment")
+	}
+}
+
 // applyDeployment applies the declared deployment.
 // If it exists before apply, and has the GKE Autopilot adjustment annotation,
 // then the declared deployment is modified to match the resource adjustments,
@@ -557,6 +683,29 @@ func (r *reconcilerBase) validateCACertSecret(ctx context.Context, namespace, ca
 	return nil
 }
 
+// validateClientCertSecret verify that clientCertSecretRef is well formed with
+// the client certificate and private key keys
+func (r *reconcilerBase) validateClientCertSecret(ctx context.Context, namespace, clientCertSecretRefName string) error {
+	if useClientCert(clientCertSecretRefName) {
+		secret, err := validateSecretExist(ctx,
+			clientCertSecretRefName,
+			namespace,
+			r.client)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return errors.Errorf("Secret %s not found, create one to allow client connections with client certificate", clientCertSecretRefName)
+			}
+			return errors.Wrapf(err, "Secret %s get failed", clientCertSecretRefName)
+		}
+		for _, key := range []string{ClientCertSecretCertKey, ClientCertSecretKeyKey} {
+			if _, ok := secret.Data[key]; !ok {
+				return errors.Errorf("clientCertSecretRef was set, but %s key is not present in %s Secret", key, clientCertSecretRefName)
+			}
+		}
+	}
+	return nil
+}
+
 // addTypeInformationToObject looks up and adds GVK to a runtime.Object based upon the loaded Scheme
 func (r *reconcilerBase) addTypeInformationToObject(obj runtime.Object) error {
 	gvk, err := kinds.Lookup(obj, r.scheme)
@@ -615,6 +764,11 @@ func (r *reconcilerBase) setupOrTeardown(ctx context.Context, syncObj client.Obj
 
 	if controllerutil.ContainsFinalizer(syncObj, metadata.ReconcilerFinalizer) {
 		// The object is being deleted, but the reconciler finalizer is still running.
+		// Make sure the reconciler is running, even if syncing is suspended,
+		// otherwise nothing removes the reconciler finalizer.
+		if err := r.resumeSuspendedReconciler(ctx, syncObj); err != nil {
+			return errors.Wrap(err, "resuming the suspended reconciler")
+		}
 		// Wait for the reconciler finalizer to complete.
 		r.logger(ctx).Info("Waiting for Reconciler Finalizer to finish")
 		return nil
@@ -646,6 +800,79 @@ func (r *reconcilerBase) setupOrTeardown(ctx context.Context, syncObj client.Obj
 	return nil
 }
 
+// resumeSuspendedReconciler scales the reconciler Deployment of a suspended
+// RootSync or RepoSync back up, so that the reconciler can run its finalizer
+// while the RootSync or RepoSync is being deleted.
+func (r *reconcilerBase) resumeSuspendedReconciler(ctx context.Context, syncObj client.Object) error {
+	var reconcilerRef types.NamespacedName
+	var replicas *int32
+	switch rs := syncObj.(type) {
+	case *v1beta1.RootSync:
+		if !rs.Spec.Suspend {
+			return nil
+		}
+		reconcilerRef = core.RootReconcilerObjectKey(rs.Name)
+		replicas = rs.Spec.SafeOverride().Replicas
+	case *v1beta1.RepoSync:
+		if !rs.Spec.Suspend {
+			return nil
+		}
+		reconcilerRef = core.NsReconcilerObjectKey(rs.Namespace, rs.Name)
+		replicas = rs.Spec.SafeOverride().Replicas
+	default:
+		return nil
+	}
+	d := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, reconcilerRef, d); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return NewObjectOperationErrorWithKey(err, d, OperationGet, reconcilerRef)
+	}
+	if d.Spec.Replicas == nil || *d.Spec.Replicas > 0 {
+		return nil
+	}
+	if replicas == nil || *replicas == 0 {
+		replicas = pointer.Int32(1)
+	}
+	r.logger(ctx).Info("Scaling up the suspended reconciler to run its finalizer",
+		logFieldObjectRef, reconcilerRef.String(),
+		logFieldObjectKind, "Deployment")
+	d.Spec.Replicas = pointer.Int32(*replicas)
+	if err := r.client.Update(ctx, d); err != nil {
+		return NewObjectOperationError(err, d, OperationUpdate)
+	}
+	return nil
+}
+
+// deletionGracePeriodRemaining returns how much longer teardown should wait
+// for the reconciler to finish syncing before deleting the reconciler.
+// Returns zero if the sync object is not being deleted, no grace period is
+// configured, the reconciler is not syncing, or the grace period has elapsed.
+func deletionGracePeriodRemaining(syncObj client.Object, gracePeriod *metav1.Duration, syncing bool, now time.Time) time.Duration {
+	deletionTimestamp := syncObj.GetDeletionTimestamp()
+	if deletionTimestamp.IsZero() || gracePeriod == nil || !syncing {
+		return 0
+	}
+	remaining := deletionTimestamp.Add(gracePeriod.Duration).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// newDeletionGracePeriodError returns an InProgress ObjectReconcileError for
+// the reconciler Deployment, which delays teardown until the next event.
+func newDeletionGracePeriodError(reconcilerRef types.NamespacedName, remaining time.Duration) *ObjectReconcileError {
+	err := errors.Errorf("waiting up to %v for the reconciler to finish syncing before deletion",
+		remaining.Round(time.Second))
+	id := core.ID{
+		ObjectKey: reconcilerRef,
+		GroupKind: kinds.Deployment().GroupKind(),
+	}
+	return NewObjectReconcileErrorWithID(err, id, kstatus.InProgressStatus)
+}
+
 // ManagedByLabel is a uniform label that is applied to all resources which are
 // managed by reconciler-manager.
 func ManagedByLabel() map[string]string {
@@ -695,3 +922,66 @@ func (r *reconcilerBase) isKnownHostsEnabled(auth configsync.AuthType) bool {
 	}
 	return false
 }
+
+// isWebhookEnabled returns true if the Config Sync admission webhook is
+// enabled, which is indicated by the existence of its
+// ValidatingWebhookConfiguration. Errors are logged and treated as disabled,
+// because the webhook state is informational for the reconciler.
+func (r *reconcilerBase) isWebhookEnabled(ctx context.Context) bool {
+	webhookConfig := &admissionv1.ValidatingWebhookConfiguration{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: webhookconfiguration.Name}, webhookConfig)
+	switch {
+	case apierrors.IsNotFound(err):
+		return false
+	case err != nil:
+		r.logger(ctx).Error(err, "Failed to get the admission webhook configuration")
+		return false
+	}
+	return true
+}
+
+// webhookConfigurationPredicate filters watch events down to the creation and
+// deletion of the Config Sync admission webhook configuration, which toggle the
+// result of isWebhookEnabled.
+func webhookConfigurationPredicate() predicate.Predicate {
+	p := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetName() == webhookconfiguration.Name
+	})
+	p.UpdateFunc = func(event.UpdateEvent) bool { return false }
+	return p
+}
+
+// statusContention returns a message identifying the potential writers of the
+// RSync status, and true, if updating the status repeatedly conflicted within
+// the conflict detection window.
+func (r *reconcilerBase) statusContention(ctx context.Context, syncObj client.Object) (string, bool) {
+	if r.statusConflicts == nil || !r.statusConflicts.InStorm(syncObj) {
+		return "", false
+	}
+	writers := statusWriters(syncObj)
+	r.logger(ctx).Info("Detected repeated status update conflicts",
+		"conflictThreshold", r.statusConflicts.Threshold,
+		"conflictWindow", r.statusConflicts.Window,
+		"statusWriters", writers)
+	writerList := "unknown"
+	if len(writers) > 0 {
+		writerList = strings.Join(writers, ", ")
+	}
+	return fmt.Sprintf("Updating the %s status conflicted at least %d times within %v. "+
+		"Another actor may be repeatedly writing the status. Potential writers: %s",
+		r.syncKind, r.statusConflicts.Threshold, r.statusConflicts.Window, writerList), true
+}
+
+// statusWriters returns the sorted names of the field managers which wrote the
+// status of the object.
+func statusWriters(obj client.Object) []string {
+	var writers []string
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Subresource != "status" || entry.Manager == "" {
+			continue
+		}
+		writers = append(writers, entry.Manager)
+	}
+	sort.Strings(writers)
+	return slices.Compact(writers)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +28,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/pointer"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -954,3 +956,99 @@ func mapToPodResources(m map[string]corev1.ResourceRequirements) *util.PodResour
 	}
 	return &util.PodResources{Containers: containers}
 }
+
+func TestDeletionGracePeriodRemaining(t *testing.T) {
+	now := time.Now()
+	deletionTimestamp := metav1.NewTime(now.Add(-1 * time.Minute))
+	testCases := []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		gracePeriod       *metav1.Duration
+		syncing           bool
+		want              time.Duration
+	}{
+		{
+			name:        "not deleting",
+			gracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+			syncing:     true,
+			want:        0,
+		},
+		{
+			name:              "no grace period",
+			deletionTimestamp: &deletionTimestamp,
+			syncing:           true,
+			want:              0,
+		},
+		{
+			name:              "not syncing",
+			deletionTimestamp: &deletionTimestamp,
+			gracePeriod:       &metav1.Duration{Duration: 5 * time.Minute},
+			syncing:           false,
+			want:              0,
+		},
+		{
+			name:              "syncing within grace period",
+			deletionTimestamp: &deletionTimestamp,
+			gracePeriod:       &metav1.Duration{Duration: 5 * time.Minute},
+			syncing:           true,
+			want:              4 * time.Minute,
+		},
+		{
+			name:              "syncing after grace period",
+			deletionTimestamp: &deletionTimestamp,
+			gracePeriod:       &metav1.Duration{Duration: 30 * time.Second},
+			syncing:           true,
+			want:              0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := fake.RepoSyncObjectV1Beta1("test-ns", "repo-sync")
+			rs.DeletionTimestamp = tc.deletionTimestamp
+			got := deletionGracePeriodRemaining(rs, tc.gracePeriod, tc.syncing, now)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAutopilotResourceWarnings(t *testing.T) {
+	container := func(cpu, memory string) corev1.Container {
+		return corev1.Container{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+	testCases := map[string]struct {
+		containers []corev1.Container
+		want       []string
+	}{
+		"requests within the Autopilot constraints": {
+			containers: []corev1.Container{container("700m", "512Mi"), container("20m", "32Mi")},
+		},
+		"total requests below the Autopilot minimums": {
+			containers: []corev1.Container{container("20m", "20Mi"), container("10m", "16Mi")},
+			want: []string{
+				"the total cpuRequest 30m is below the Autopilot minimum of 50m per Pod",
+				"the total memoryRequest 36Mi is below the Autopilot minimum of 52Mi per Pod",
+			},
+		},
+		"ratio of memory to CPU above the Autopilot maximum": {
+			containers: []corev1.Container{container("100m", "1Gi")},
+			want: []string{
+				"the ratio of the total memoryRequest 1Gi to the total cpuRequest 100m exceeds the Autopilot maximum of 6.5 GiB per vCPU",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := autopilotResourceWarnings(corev1.PodSpec{Containers: tc.containers})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("autopilotResourceWarnings() diff (- want, + got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package controllers
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -182,6 +184,73 @@ func setContainerResourceDefaults(overrides []v1beta1.ContainerResourcesSpec, de
 	return overrides
 }
 
+// validateContainerResourceLimits validates that the resource limits in the
+// overrides are not below the minimums for each container, which are the
+// requests from ReconcilerContainerResourceDefaults. Containers with lower
+// limits are likely to be throttled or OOMKilled and crash loop.
+func validateContainerResourceLimits(overrides []v1beta1.ContainerResourcesSpec) error {
+	minimums := ReconcilerContainerResourceDefaults()
+	for _, override := range overrides {
+		minimum, found := minimums[override.ContainerName]
+		if !found {
+			continue
+		}
+		if !override.CPULimit.IsZero() && override.CPULimit.Cmp(minimum.CPURequest) < 0 {
+			return fmt.Errorf("spec.override.resources: cpuLimit %s of the %q container is below the minimum of %s",
+				override.CPULimit.String(), override.ContainerName, minimum.CPURequest.String())
+		}
+		if !override.MemoryLimit.IsZero() && override.MemoryLimit.Cmp(minimum.MemoryRequest) < 0 {
+			return fmt.Errorf("spec.override.resources: memoryLimit %s of the %q container is below the minimum of %s",
+				override.MemoryLimit.String(), override.ContainerName, minimum.MemoryRequest.String())
+		}
+	}
+	return nil
+}
+
+// Autopilot resource constraints of a Pod of the general-purpose compute class.
+// See https://cloud.google.com/kubernetes-engine/docs/concepts/autopilot-resource-requests
+var (
+	// autopilotMinPodCPURequest is the minimum CPU request of a Pod.
+	autopilotMinPodCPURequest = resource.MustParse("50m")
+	// autopilotMinPodMemoryRequest is the minimum memory request of a Pod.
+	autopilotMinPodMemoryRequest = resource.MustParse("52Mi")
+)
+
+// autopilotMaxMemoryPerCPU is the maximum ratio of memory (GiB) to CPU (vCPU)
+// of a Pod.
+const autopilotMaxMemoryPerCPU = 6.5
+
+// autopilotResourceWarnings returns a warning for each Autopilot constraint
+// that the resource requests of the Pod do not comply with. Autopilot does
+// not reject such Pods, but adjusts their requests when admitting them, so
+// the reconciler runs with other resources than the ones declared.
+func autopilotResourceWarnings(podSpec corev1.PodSpec) []string {
+	var cpuRequest, memoryRequest resource.Quantity
+	for _, c := range podSpec.Containers {
+		cpuRequest.Add(*c.Resources.Requests.Cpu())
+		memoryRequest.Add(*c.Resources.Requests.Memory())
+	}
+	var warnings []string
+	if cpuRequest.Cmp(autopilotMinPodCPURequest) < 0 {
+		warnings = append(warnings, fmt.Sprintf("the total cpuRequest %s is below the Autopilot minimum of %s per Pod",
+			cpuRequest.String(), autopilotMinPodCPURequest.String()))
+	}
+	if memoryRequest.Cmp(autopilotMinPodMemoryRequest) < 0 {
+		warnings = append(warnings, fmt.Sprintf("the total memoryRequest %s is below the Autopilot minimum of %s per Pod",
+			memoryRequest.String(), autopilotMinPodMemoryRequest.String()))
+	}
+	if cpuRequest.IsZero() || memoryRequest.IsZero() {
+		return warnings
+	}
+	memoryGiB := float64(memoryRequest.Value()) / (1 << 30)
+	cpu := float64(cpuRequest.MilliValue()) / 1000
+	if memoryGiB/cpu > autopilotMaxMemoryPerCPU {
+		warnings = append(warnings, fmt.Sprintf("the ratio of the total memoryRequest %s to the total cpuRequest %s exceeds the Autopilot maximum of %v GiB per vCPU",
+			memoryRequest.String(), cpuRequest.String(), autopilotMaxMemoryPerCPU))
+	}
+	return warnings
+}
+
 func mutateContainerResource(c *corev1.Container, overrides []v1beta1.ContainerResourcesSpec) {
 	if len(overrides) == 0 {
 		return
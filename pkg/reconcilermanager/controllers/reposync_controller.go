@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
@@ -49,6 +51,8 @@ import (
 	"kpt.dev/configsync/pkg/util/compare"
 	"kpt.dev/configsync/pkg/util/mutate"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
+	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -88,7 +92,7 @@ const (
 )
 
 // NewRepoSyncReconciler returns a new RepoSyncReconciler.
-func NewRepoSyncReconciler(clusterName string, reconcilerPollingPeriod, hydrationPollingPeriod time.Duration, client client.Client, watcher client.WithWatch, dynamicClient dynamic.Interface, log logr.Logger, scheme *runtime.Scheme) *RepoSyncReconciler {
+func NewRepoSyncReconciler(clusterName string, reconcilerPollingPeriod, hydrationPollingPeriod time.Duration, statusConflicts *mutate.ConflictDetector, client client.Client, watcher client.WithWatch, dynamicClient dynamic.Interface, log logr.Logger, scheme *runtime.Scheme) *RepoSyncReconciler {
 	return &RepoSyncReconciler{
 		reconcilerBase: reconcilerBase{
 			loggingController: loggingController{
@@ -101,6 +105,7 @@ func NewRepoSyncReconciler(clusterName string, reconcilerPollingPeriod, hydratio
 			scheme:                  scheme,
 			reconcilerPollingPeriod: reconcilerPollingPeriod,
 			hydrationPollingPeriod:  hydrationPollingPeriod,
+			statusConflicts:         statusConflicts,
 			syncKind:                configsync.RepoSyncKind,
 			knownHostExist:          false,
 		},
@@ -200,6 +205,11 @@ func (r *RepoSyncReconciler) Reconcile(ctx context.Context, req controllerruntim
 	}
 
 	metrics.RecordReconcileDuration(ctx, metrics.StatusTagKey(nil), start)
+	if remaining := r.deletionGracePeriodRemaining(rs); remaining > 0 {
+		// Requeue to delete the reconciler when the grace period elapses,
+		// in case the reconciler does not finish syncing before then.
+		return controllerruntime.Result{RequeueAfter: remaining}, nil
+	}
 	return controllerruntime.Result{}, nil
 }
 
@@ -223,7 +233,14 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 		return errors.Wrap(err, "upserting CA cert secret")
 	}
 
-	if err := r.deleteSecrets(ctx, reconcilerRef, authSecret.Name, caSecret.Name); err != nil {
+	// Create secret in config-management-system namespace using the
+	// existing secret in the reposync.namespace.
+	clientCertSecret, err := r.upsertClientCertSecret(ctx, rs, reconcilerRef, labelMap)
+	if err != nil {
+		return errors.Wrap(err, "upserting client cert secret")
+	}
+
+	if err := r.deleteSecrets(ctx, reconcilerRef, authSecret.Name, caSecret.Name, clientCertSecret.Name); err != nil {
 		return errors.Wrap(err, "garbage collecting secrets")
 	}
 
@@ -253,8 +270,17 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 		return errors.Wrap(err, "upserting role binding")
 	}
 
-	if err := r.upsertHelmConfigMaps(ctx, rs, labelMap); err != nil {
-		return errors.Wrap(err, "upserting helm config maps")
+	// Reconcile the permissions to manage the leader election Lease.
+	if leaderElectionEnabled(rs.Spec.SafeOverride().Replicas) {
+		if err := r.upsertLeaderElectionRBAC(ctx, reconcilerRef, labelMap); err != nil {
+			return errors.Wrap(err, "upserting leader election RBAC")
+		}
+	} else if err := r.deleteLeaderElectionRBAC(ctx, reconcilerRef); err != nil {
+		return errors.Wrap(err, "deleting leader election RBAC")
+	}
+
+	if err := r.upsertConfigMapCopies(ctx, rs, labelMap); err != nil {
+		return errors.Wrap(err, "upserting config maps")
 	}
 
 	containerEnvs := r.populateContainerEnvs(ctx, rs, reconcilerRef.Name)
@@ -313,10 +339,33 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 // - Update the RepoSync status
 func (r *RepoSyncReconciler) setup(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RepoSync) error {
 	err := r.upsertManagedObjects(ctx, reconcilerRef, rs)
+	nsManagerMessage, nsErr := r.namespaceManagedByRootSyncMessage(ctx, rs.Namespace)
+	if nsErr != nil {
+		// Don't block the setup if the Namespace can't be read.
+		// The condition is left unchanged until the next reconcile.
+		r.logger(ctx).Error(nsErr, "Failed to check the RepoSync namespace management")
+	} else if nsManagerMessage != "" {
+		r.logger(ctx).V(3).Info("RepoSync namespace is managed by a RootSync", "message", nsManagerMessage)
+	}
 	updated, updateErr := r.updateSyncStatus(ctx, rs, reconcilerRef, func(syncObj *v1beta1.RepoSync) error {
+		// Report the credentials which the reconciler Deployment is
+		// configured with.
+		syncObj.Status.FleetWorkloadIdentity = fleetWorkloadIdentityStatus(repoSyncAuthType(syncObj), r.membership)
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
 		err = r.handleReconcileError(ctx, err, syncObj, "Setup")
+		if syncObj.Spec.Suspend {
+			reposync.SetSuspended(syncObj, "Suspended", "Syncing is suspended")
+		} else {
+			reposync.RemoveCondition(syncObj, v1beta1.RepoSyncSuspended)
+		}
+		if nsErr == nil {
+			if nsManagerMessage != "" {
+				reposync.SetNamespaceManagedByRootSync(syncObj, "NamespaceManagedByRootSync", nsManagerMessage)
+			} else {
+				reposync.RemoveCondition(syncObj, v1beta1.RepoSyncNamespaceManagedByRootSync)
+			}
+		}
 		return nil
 	})
 	switch {
@@ -337,13 +386,72 @@ func (r *RepoSyncReconciler) setup(ctx context.Context, reconcilerRef types.Name
 	}
 }
 
+// namespaceManagedByRootSyncMessage returns a message describing the deletion
+// semantics if the Namespace of a RepoSync is managed by a RootSync, according
+// to the management annotations on the Namespace. Otherwise, it returns an
+// empty string.
+func (r *RepoSyncReconciler) namespaceManagedByRootSyncMessage(ctx context.Context, namespace string) (string, error) {
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", status.APIServerError(err, "failed to get Namespace "+namespace)
+	}
+	if core.GetAnnotation(ns, metadata.ResourceManagementKey) != metadata.ResourceManagementEnabled {
+		return "", nil
+	}
+	manager := core.GetAnnotation(ns, metadata.ResourceManagerKey)
+	// Objects applied by a reconciler always have an owning inventory.
+	if !declared.IsRootManager(manager) || core.GetAnnotation(ns, metadata.OwningInventoryKey) == "" {
+		return "", nil
+	}
+	_, rootSyncName := declared.ManagerScopeAndName(manager)
+	if core.GetAnnotation(ns, common.LifecycleDeleteAnnotation) == common.PreventDeletion {
+		return fmt.Sprintf("Namespace %q is managed by RootSync %s/%s with deletion prevented. "+
+			"Deleting the RootSync or removing the Namespace from its source will not delete the Namespace, "+
+			"so this RepoSync and its managed objects are kept.",
+			namespace, configsync.ControllerNamespace, rootSyncName), nil
+	}
+	return fmt.Sprintf("Namespace %q is managed by RootSync %s/%s. "+
+		"Deleting the RootSync or removing the Namespace from its source will delete the Namespace, "+
+		"including this RepoSync and its managed objects.",
+		namespace, configsync.ControllerNamespace, rootSyncName), nil
+}
+
+// repoSyncAuthType returns the auth type of the source of truth of the RepoSync.
+func repoSyncAuthType(rs *v1beta1.RepoSync) configsync.AuthType {
+	switch v1beta1.SourceType(rs.Spec.SourceType) {
+	case v1beta1.GitSource:
+		if rs.Spec.Git != nil {
+			return rs.Spec.Auth
+		}
+	case v1beta1.OciSource:
+		if rs.Spec.Oci != nil {
+			return rs.Spec.Oci.Auth
+		}
+	case v1beta1.HelmSource:
+		if rs.Spec.Helm != nil {
+			return rs.Spec.Helm.Auth
+		}
+	}
+	return ""
+}
+
 // teardown performs the following teardown steps:
 // - Delete managed objects
 // - Convert any error into RepoSync status conditions
 // - Update the RepoSync status
 func (r *RepoSyncReconciler) teardown(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RepoSync) error {
 	rsRef := client.ObjectKeyFromObject(rs)
-	err := r.deleteManagedObjects(ctx, reconcilerRef, rsRef)
+	var err error
+	if remaining := r.deletionGracePeriodRemaining(rs); remaining > 0 {
+		// Give the reconciler a chance to finish applying and pruning
+		// before its Deployment is deleted.
+		err = newDeletionGracePeriodError(reconcilerRef, remaining)
+	} else {
+		err = r.deleteManagedObjects(ctx, reconcilerRef, rsRef)
+	}
 	updated, updateErr := r.updateSyncStatus(ctx, rs, reconcilerRef, func(syncObj *v1beta1.RepoSync) error {
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
@@ -368,6 +476,12 @@ func (r *RepoSyncReconciler) teardown(ctx context.Context, reconcilerRef types.N
 	}
 }
 
+// deletionGracePeriodRemaining returns how much longer teardown should wait
+// for the namespace reconciler to finish syncing.
+func (r *RepoSyncReconciler) deletionGracePeriodRemaining(rs *v1beta1.RepoSync) time.Duration {
+	return deletionGracePeriodRemaining(rs, rs.Spec.SafeOverride().DeletionGracePeriod, reposync.IsSyncing(rs), time.Now())
+}
+
 // handleReconcileError updates the sync object status to reflect the Reconcile
 // error. If the error requires immediate retry, it will be returned.
 func (r *RepoSyncReconciler) handleReconcileError(ctx context.Context, err error, rs *v1beta1.RepoSync, stage string) error {
@@ -443,6 +557,10 @@ func (r *RepoSyncReconciler) deleteManagedObjects(ctx context.Context, reconcile
 		return errors.Wrap(err, "deleting role binding")
 	}
 
+	if err := r.deleteLeaderElectionRBAC(ctx, reconcilerRef); err != nil {
+		return errors.Wrap(err, "deleting leader election RBAC")
+	}
+
 	if err := r.deleteHelmConfigMapCopies(ctx, rsRef, nil); err != nil {
 		return errors.Wrap(err, "deleting helm config maps")
 	}
@@ -475,7 +593,17 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		// in the namespace of the RepoSync. Only maps to existing RepoSyncs.
 		Watches(&source.Kind{Type: &rbacv1.RoleBinding{}},
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRepoSync),
-			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}))
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Watch Namespaces to update the NamespaceManagedByRootSync condition
+		// when a RootSync starts or stops managing the RepoSync namespace.
+		Watches(&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToRepoSyncs),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		// Watch the admission webhook configuration to update the reconciler
+		// Deployments when the webhook is enabled or disabled.
+		Watches(&source.Kind{Type: &admissionv1.ValidatingWebhookConfiguration{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapWebhookConfigurationToRepoSyncs),
+			builder.WithPredicates(webhookConfigurationPredicate()))
 
 	if watchFleetMembership {
 		// Custom Watch for membership to trigger reconciliation.
@@ -493,8 +621,7 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 func (r *RepoSyncReconciler) watchConfigMaps(rs *v1beta1.RepoSync) error {
 	// We add watches dynamically at runtime based on the RepoSync namespace
 	// in order to avoid watching ConfigMaps in the entire cluster.
-	if rs == nil || rs.Spec.SourceType != string(v1beta1.HelmSource) || rs.Spec.Helm == nil ||
-		len(rs.Spec.Helm.ValuesFileRefs) == 0 {
+	if len(repoSyncConfigMapNames(rs)) == 0 {
 		// TODO: When it's available, we should remove unneeded watches from the controller
 		// when all RepoSyncs with ConfigMap references in a particular namespace are
 		// deleted (or are no longer referencing ConfigMaps).
@@ -525,8 +652,10 @@ func (r *RepoSyncReconciler) mapMembershipToRepoSyncs() func(client.Object) []re
 		// Clear the membership if the cluster is unregistered
 		if err := r.client.Get(ctx, types.NamespacedName{Name: fleetMembershipName}, &hubv1.Membership{}); err != nil {
 			if apierrors.IsNotFound(err) {
-				klog.Info("Fleet Membership not found, clearing membership cache")
-				r.membership = nil
+				if !r.updateMembership(nil) {
+					return nil
+				}
+				klog.Info("Fleet Membership not found, cleared membership cache")
 				return r.requeueAllRepoSyncs()
 			}
 			klog.Errorf("Fleet Membership get failed: %v", err)
@@ -542,11 +671,26 @@ func (r *RepoSyncReconciler) mapMembershipToRepoSyncs() func(client.Object) []re
 			klog.Errorf("Fleet Membership name expected %q, found %q", fleetMembershipName, m.Name)
 			return nil
 		}
-		r.membership = m
+		if !r.updateMembership(m) {
+			klog.V(3).Infof("Fleet Membership generation %d unchanged, skipping reconciliation", m.Generation)
+			return nil
+		}
 		return r.requeueAllRepoSyncs()
 	}
 }
 
+// mapWebhookConfigurationToRepoSyncs handles the creation and deletion of the
+// admission webhook configuration, which changes whether the webhook is
+// enabled for every RepoSync reconciler.
+func (r *RepoSyncReconciler) mapWebhookConfigurationToRepoSyncs(obj client.Object) []reconcile.Request {
+	if obj.GetName() != webhookconfiguration.Name {
+		return nil
+	}
+	klog.Infof("Changes to the admission webhook configuration %q trigger reconciliations for all RepoSync objects",
+		obj.GetName())
+	return r.requeueAllRepoSyncs()
+}
+
 func (r *RepoSyncReconciler) requeueAllRepoSyncs() []reconcile.Request {
 	//TODO: pass through context (reqs updating controller-runtime)
 	ctx := context.Background()
@@ -563,7 +707,7 @@ func (r *RepoSyncReconciler) requeueAllRepoSyncs() []reconcile.Request {
 		}
 	}
 	if len(requests) > 0 {
-		klog.Infof("Changes to membership trigger reconciliations for %d RepoSync objects.", len(allRepoSyncs.Items))
+		klog.Infof("Triggering reconciliations for %d RepoSync objects.", len(allRepoSyncs.Items))
 	}
 	return requests
 }
@@ -573,6 +717,7 @@ func (r *RepoSyncReconciler) requeueAllRepoSyncs() []reconcile.Request {
 // - `spec.git.secretRef.name`
 // - `spec.git.caCertSecretRef.name`
 // - `spec.helm.secretRef.name`
+// - `spec.{git,oci,helm}.clientCertSecretRef.name`
 // The update to the Secret object will trigger a reconciliation of the RepoSync objects.
 func (r *RepoSyncReconciler) mapSecretToRepoSyncs(secret client.Object) []reconcile.Request {
 	//TODO: pass through context (reqs updating controller-runtime)
@@ -637,7 +782,7 @@ func (r *RepoSyncReconciler) mapSecretToRepoSyncs(secret client.Object) []reconc
 		switch sRef.Name {
 		case repoSyncGitSecretName(&rs), repoSyncGitCACertSecretName(&rs),
 			repoSyncOCICACertSecretName(&rs), repoSyncHelmCACertSecretName(&rs),
-			repoSyncHelmSecretName(&rs):
+			repoSyncHelmSecretName(&rs), repoSyncClientCertSecretName(&rs):
 			attachedRSNames = append(attachedRSNames, rs.GetName())
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
@@ -703,6 +848,14 @@ func repoSyncHelmCACertSecretName(rs *v1beta1.RepoSync) string {
 	return rs.Spec.Helm.CACertSecretRef.Name
 }
 
+func repoSyncClientCertSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	name, _ := getClientCertName(rs)
+	return name
+}
+
 func repoSyncHelmSecretName(rs *v1beta1.RepoSync) string {
 	if rs == nil {
 		return ""
@@ -760,7 +913,7 @@ func (r *RepoSyncReconciler) mapConfigMapToRepoSyncs(obj client.Object) []reconc
 	for _, rs := range repoSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
 		//TODO: Use stdlib slices.Contains in Go 1.21+
-		if slices.Contains(repoSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(repoSyncConfigMapNames(&rs), objRef.Name) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -774,6 +927,24 @@ func (r *RepoSyncReconciler) mapConfigMapToRepoSyncs(obj client.Object) []reconc
 	return requests
 }
 
+// repoSyncConfigMapNames returns the names of the ConfigMaps referenced by the
+// RepoSync, which are copied to the config-management-system namespace.
+func repoSyncConfigMapNames(rs *v1beta1.RepoSync) []string {
+	if rs == nil {
+		return nil
+	}
+	var names []string
+	switch v1beta1.SourceType(rs.Spec.SourceType) {
+	case v1beta1.HelmSource:
+		names = repoSyncHelmValuesFileNames(rs)
+	case v1beta1.GitSource:
+		if name := knownHostsConfigMapName(rs.Spec.Git); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func repoSyncHelmValuesFileNames(rs *v1beta1.RepoSync) []string {
 	if rs == nil {
 		return nil
@@ -854,6 +1025,31 @@ func (r *RepoSyncReconciler) mapObjectToRepoSync(obj client.Object) []reconcile.
 	return requests
 }
 
+// mapNamespaceToRepoSyncs returns a request for each RepoSync in the
+// Namespace.
+func (r *RepoSyncReconciler) mapNamespaceToRepoSyncs(obj client.Object) []reconcile.Request {
+	//TODO: pass through context (reqs updating controller-runtime)
+	ctx := context.Background()
+
+	repoSyncs := &v1beta1.RepoSyncList{}
+	if err := r.client.List(ctx, repoSyncs, client.InNamespace(obj.GetName())); err != nil {
+		klog.Errorf("failed to list RepoSyncs for Namespace (%s): %v", obj.GetName(), err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, rs := range repoSyncs.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&rs),
+		})
+	}
+	if len(requests) > 0 {
+		klog.Infof("Changes to Namespace (%s) triggers a reconciliation for %d RepoSync(s)",
+			obj.GetName(), len(requests))
+	}
+	return requests
+}
+
 func requeueRepoSyncRequest(obj client.Object, rsRef types.NamespacedName) []reconcile.Request {
 	klog.Infof("Changes to %s triggered a reconciliation for the RepoSync (%s).",
 		kinds.ObjectSummary(obj), rsRef)
@@ -875,20 +1071,41 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			pollPeriod:     r.hydrationPollingPeriod.String(),
 		}),
 		reconcilermanager.Reconciler: reconcilerEnvs(reconcilerOptions{
-			clusterName:       r.clusterName,
-			syncName:          rs.Name,
-			syncGeneration:    rs.Generation,
-			reconcilerName:    reconcilerName,
-			reconcilerScope:   declared.Scope(rs.Namespace),
-			sourceType:        rs.Spec.SourceType,
-			gitConfig:         rs.Spec.Git,
-			ociConfig:         rs.Spec.Oci,
-			helmConfig:        reposync.GetHelmBase(rs.Spec.Helm),
-			pollPeriod:        r.reconcilerPollingPeriod.String(),
-			statusMode:        rs.Spec.SafeOverride().StatusMode,
-			reconcileTimeout:  v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
-			apiServerTimeout:  v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
-			requiresRendering: annotationEnabled(metadata.RequiresRenderingAnnotationKey, rs.GetAnnotations()),
+			clusterName:               r.clusterName,
+			syncName:                  rs.Name,
+			syncGeneration:            rs.Generation,
+			reconcilerName:            reconcilerName,
+			reconcilerScope:           declared.Scope(rs.Namespace),
+			sourceType:                rs.Spec.SourceType,
+			gitConfig:                 rs.Spec.Git,
+			ociConfig:                 rs.Spec.Oci,
+			helmConfig:                reposync.GetHelmBase(rs.Spec.Helm),
+			pollPeriod:                r.reconcilerPollingPeriod.String(),
+			statusMode:                rs.Spec.SafeOverride().StatusMode,
+			reconcileTimeout:          v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
+			apiServerTimeout:          v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
+			clientQPS:                 pointer.Int32Deref(rs.Spec.SafeOverride().ClientQPS, 0),
+			clientBurst:               pointer.Int32Deref(rs.Spec.SafeOverride().ClientBurst, 0),
+			minRemediationInterval:    rs.Spec.SafeOverride().MinRemediationInterval,
+			applyDebouncePeriod:       rs.Spec.SafeOverride().ApplyDebouncePeriod,
+			applyCallTimeout:          rs.Spec.SafeOverride().ApplyCallTimeout,
+			resyncPeriod:              rs.Spec.SafeOverride().ResyncPeriod,
+			statusUpdatePeriod:        rs.Spec.SafeOverride().StatusUpdatePeriod,
+			renderingTimeout:          rs.Spec.SafeOverride().RenderingTimeout,
+			fieldManager:              rs.Spec.SafeOverride().FieldManager,
+			requiredMetadata:          rs.Spec.SafeOverride().RequiredMetadata,
+			annotateSyncGeneration:    pointer.BoolDeref(rs.Spec.SafeOverride().AnnotateSyncGeneration, false),
+			pauseApply:                pointer.BoolDeref(rs.Spec.SafeOverride().PauseApply, false),
+			maxPruneCount:             pointer.Int32Deref(rs.Spec.SafeOverride().MaxPruneCount, 0),
+			circuitBreakerThreshold:   pointer.Int32Deref(rs.Spec.SafeOverride().CircuitBreakerThreshold, 0),
+			circuitBreakerProbePeriod: rs.Spec.SafeOverride().CircuitBreakerProbePeriod,
+			validateWithAdmission:     pointer.BoolDeref(rs.Spec.SafeOverride().ValidateWithAdmission, false),
+			remediatorExcludedKinds:   rs.Spec.SafeOverride().RemediatorExcludedKinds,
+			objectSelector:            rs.Spec.SafeOverride().ObjectSelector,
+			substituteClusterName:     pointer.BoolDeref(rs.Spec.SafeOverride().SubstituteClusterName, false),
+			leaderElection:            leaderElectionEnabled(rs.Spec.SafeOverride().Replicas),
+			requiresRendering:         annotationEnabled(metadata.RequiresRenderingAnnotationKey, rs.GetAnnotations()),
+			webhookEnabled:            r.isWebhookEnabled(ctx),
 			// Namespace reconciler doesn't support NamespaceSelector at all.
 			dynamicNSSelectorEnabled: false,
 		}),
@@ -896,34 +1113,41 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 	switch v1beta1.SourceType(rs.Spec.SourceType) {
 	case v1beta1.GitSource:
 		result[reconcilermanager.GitSync] = gitSyncEnvs(ctx, options{
-			ref:             rs.Spec.Git.Revision,
-			branch:          rs.Spec.Git.Branch,
-			repo:            rs.Spec.Git.Repo,
-			secretType:      rs.Spec.Git.Auth,
-			period:          v1beta1.GetPeriod(rs.Spec.Git.Period, configsync.DefaultReconcilerPollingPeriod),
-			proxy:           rs.Spec.Proxy,
-			depth:           rs.Spec.SafeOverride().GitSyncDepth,
-			noSSLVerify:     rs.Spec.Git.NoSSLVerify,
-			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef),
-			knownHost:       r.isKnownHostsEnabled(rs.Spec.Git.Auth),
+			ref:                 rs.Spec.Git.Revision,
+			branch:              rs.Spec.Git.Branch,
+			repo:                rs.Spec.Git.Repo,
+			secretType:          rs.Spec.Git.Auth,
+			period:              v1beta1.GetPeriod(rs.Spec.Git.Period, configsync.DefaultReconcilerPollingPeriod),
+			proxy:               rs.Spec.Proxy,
+			noProxy:             rs.Spec.Git.NoProxy,
+			depth:               rs.Spec.SafeOverride().GitSyncDepth,
+			syncTimeout:         rs.Spec.SafeOverride().GitSyncTimeout,
+			noSSLVerify:         rs.Spec.Git.NoSSLVerify,
+			caCertSecretRef:     v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef),
+			clientCertSecretRef: v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef),
+			knownHost:           r.isKnownHostsEnabled(rs.Spec.Git.Auth) || knownHostsConfigMapName(rs.Spec.Git) != "",
 		})
 		if enableAskpassSidecar(rs.Spec.SourceType, rs.Spec.Git.Auth) {
 			result[reconcilermanager.GCENodeAskpassSidecar] = gceNodeAskPassSidecarEnvs(rs.Spec.GCPServiceAccountEmail)
 		}
 	case v1beta1.OciSource:
 		result[reconcilermanager.OciSync] = ociSyncEnvs(ociOptions{
-			image:           rs.Spec.Oci.Image,
-			auth:            rs.Spec.Oci.Auth,
-			period:          v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
-			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			image:               rs.Spec.Oci.Image,
+			auth:                rs.Spec.Oci.Auth,
+			period:              v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			caCertSecretRef:     v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			clientCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef),
+			proxy:               rs.Spec.Oci.Proxy,
+			noProxy:             rs.Spec.Oci.NoProxy,
 		})
 	case v1beta1.HelmSource:
 		result[reconcilermanager.HelmSync] = helmSyncEnvs(helmOptions{
 			helmBase:         &rs.Spec.Helm.HelmBase,
 			releaseNamespace: rs.Namespace,
 			// RepoSync API doesn't support specifying deployNamespace
-			deployNamespace: "",
-			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			deployNamespace:     "",
+			caCertSecretRef:     v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			clientCertSecretRef: v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef),
 		})
 	}
 	return result
@@ -942,6 +1166,30 @@ func (r *RepoSyncReconciler) validateRepoSync(ctx context.Context, rs *v1beta1.R
 		return err
 	}
 
+	if err := validateContainerResourceLimits(rs.Spec.SafeOverride().Resources); err != nil {
+		return err
+	}
+
+	if err := validateFieldManager(rs.Spec.SafeOverride().FieldManager); err != nil {
+		return err
+	}
+
+	if err := validateRequiredMetadata(rs.Spec.SafeOverride().RequiredMetadata); err != nil {
+		return err
+	}
+
+	if err := validateObjectSelector(rs.Spec.SafeOverride().ObjectSelector); err != nil {
+		return err
+	}
+
+	if err := validateNoContainerImages(rs.Spec.SafeOverride().OverrideSpec); err != nil {
+		return err
+	}
+
+	if err := validateImagePullSecrets(rs.Spec.SafeOverride().ImagePullSecrets); err != nil {
+		return err
+	}
+
 	return r.validateValuesFileSourcesRefs(ctx, rs)
 }
 
@@ -971,14 +1219,20 @@ func (r *RepoSyncReconciler) validateHelmSpec(ctx context.Context, rs *v1beta1.R
 	if err := validate.HelmSpec(reposync.GetHelmBase(rs.Spec.Helm), rs); err != nil {
 		return err
 	}
-	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef))
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateClientCertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef))
 }
 
 func (r *RepoSyncReconciler) validateOciSpec(ctx context.Context, rs *v1beta1.RepoSync) error {
 	if err := validate.OciSpec(rs.Spec.Oci, rs); err != nil {
 		return err
 	}
-	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef))
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateClientCertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef))
 }
 
 func (r *RepoSyncReconciler) validateGitSpec(ctx context.Context, rs *v1beta1.RepoSync, reconcilerName string) error {
@@ -988,6 +1242,12 @@ func (r *RepoSyncReconciler) validateGitSpec(ctx context.Context, rs *v1beta1.Re
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef)); err != nil {
 		return err
 	}
+	if err := r.validateClientCertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef)); err != nil {
+		return err
+	}
+	if err := validate.KnownHostsConfigMapRef(ctx, r.client, rs, rs.Spec.Git.KnownHostsConfigMapRef); err != nil {
+		return err
+	}
 	return r.validateNamespaceSecret(ctx, rs, reconcilerName)
 }
 
@@ -1064,10 +1324,15 @@ func (r *RepoSyncReconciler) updateSyncStatus(ctx context.Context, rs *v1beta1.R
 		err := updateFn(syncObj)
 		syncObj.Status.Reconciler = reconcilerRef.Name
 		syncObj.Status.ObservedGeneration = syncObj.Generation
+		if message, found := r.statusContention(ctx, syncObj); found {
+			reposync.SetStatusContention(syncObj, "ConflictStorm", message)
+		} else {
+			reposync.RemoveCondition(syncObj, v1beta1.RepoSyncStatusContention)
+		}
 		return err
 	}
 
-	updated, err := mutate.Status(ctx, r.client, rs, func() error {
+	updated, err := mutate.StatusWithConflictDetector(ctx, r.client, r.statusConflicts, rs, func() error {
 		before := rs.DeepCopy()
 		if err := updateFn2(rs); err != nil {
 			return err
@@ -1115,21 +1380,25 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 		var gcpSAEmail string
 		var secretRefName string
 		var caCertSecretRefName string
+		var clientCertSecretRefName string
 		switch v1beta1.SourceType(rs.Spec.SourceType) {
 		case v1beta1.GitSource:
 			auth = rs.Spec.Auth
 			gcpSAEmail = rs.Spec.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef)
+			clientCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef)
 		case v1beta1.OciSource:
 			auth = rs.Spec.Oci.Auth
 			gcpSAEmail = rs.Spec.Oci.GCPServiceAccountEmail
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)
+			clientCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef)
 		case v1beta1.HelmSource:
 			auth = rs.Spec.Helm.Auth
 			gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)
+			clientCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef)
 		}
 		injectFWICreds := useFWIAuth(auth, r.membership)
 		if injectFWICreds {
@@ -1138,6 +1407,18 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 				return nil
 			}
 			core.SetAnnotation(&d.Spec.Template, metadata.FleetWorkloadIdentityCredentials, creds)
+			// Record the Membership generation used to build the credentials,
+			// so that the pods are rolled out whenever the Membership changes.
+			core.SetAnnotation(&d.Spec.Template, metadata.FleetMembershipGeneration, fmt.Sprint(r.membership.Generation))
+		}
+
+		// Scale the reconciler down while syncing is suspended.
+		// Otherwise, keep the replicas from the Deployment manifest, unless
+		// overridden.
+		if rs.Spec.Suspend {
+			d.Spec.Replicas = pointer.Int32(0)
+		} else if replicas := rs.Spec.SafeOverride().Replicas; replicas != nil {
+			d.Spec.Replicas = pointer.Int32(*replicas)
 		}
 
 		// Add sync-generation label
@@ -1153,6 +1434,10 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 		// The Deployment object fetched from the API server has the field defined.
 		// Update DeprecatedServiceAccount to avoid discrepancy in equality check.
 		templateSpec.DeprecatedServiceAccount = reconcilerName
+
+		// Reference the image pull Secrets, if any. The Secrets are neither
+		// copied nor managed by the reconciler-manager.
+		templateSpec.ImagePullSecrets = rs.Spec.SafeOverride().ImagePullSecrets
 		// Mutate secret.secretname to secret reference specified in RepoSync CR.
 		// Secret reference is the name of the secret used by git-sync or helm-sync container to
 		// authenticate with the git or helm repository using the authorization method specified
@@ -1161,7 +1446,10 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 		if useCACert(caCertSecretRefName) {
 			caCertSecretRefName = ReconcilerResourceName(reconcilerName, caCertSecretRefName)
 		}
-		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, auth, secretName, caCertSecretRefName, rs.Spec.SourceType, r.membership)
+		if useClientCert(clientCertSecretRefName) {
+			clientCertSecretRefName = ReconcilerResourceName(reconcilerName, clientCertSecretRefName)
+		}
+		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, auth, secretName, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, r.membership)
 
 		autopilot, err := r.isAutopilot()
 		if err != nil {
@@ -1194,7 +1482,10 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 					addContainer = false
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
-					container.Image = updateHydrationControllerImage(container.Image, rs.Spec.SafeOverride().OverrideSpec)
+					// Image overrides are not supported for RepoSync.
+					container.Image = updateHydrationControllerImage(container.Image, v1beta1.OverrideSpec{
+						EnableShellInRendering: rs.Spec.SafeOverride().EnableShellInRendering,
+					})
 				}
 			case reconcilermanager.OciSync:
 				// Don't add the oci-sync container when sourceType is NOT oci.
@@ -1202,7 +1493,7 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 					addContainer = false
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
-					container.VolumeMounts = volumeMounts(rs.Spec.Oci.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.VolumeMounts = volumeMounts(rs.Spec.Oci.Auth, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
 			case reconcilermanager.HelmSync:
@@ -1211,7 +1502,7 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 					addContainer = false
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
-					container.VolumeMounts = volumeMounts(rs.Spec.Helm.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.VolumeMounts = volumeMounts(rs.Spec.Helm.Auth, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
 					if authTypeToken(rs.Spec.Helm.Auth) {
 						container.Env = append(container.Env, helmSyncTokenAuthEnv(secretName)...)
 					}
@@ -1225,7 +1516,7 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
 					// Don't mount git-creds volume if auth is 'none' or 'gcenode'.
-					container.VolumeMounts = volumeMounts(rs.Spec.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.VolumeMounts = volumeMounts(rs.Spec.Auth, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
 					// Update Environment variables for `token` Auth, which
 					// passes the credentials as the Username and Password.
 					if authTypeToken(rs.Spec.Auth) {
@@ -1234,6 +1525,7 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 					sRef := client.ObjectKey{Namespace: rs.Namespace, Name: v1beta1.GetSecretName(rs.Spec.SecretRef)}
 					keys := GetSecretKeys(ctx, r.client, sRef)
 					container.Env = append(container.Env, gitSyncHTTPSProxyEnv(secretName, keys)...)
+					mountKnownHostsConfigMap(templateSpec, &container, r.getReconcilerKnownHostsConfigMapRef(rs))
 				}
 			case reconcilermanager.GCENodeAskpassSidecar:
 				if !enableAskpassSidecar(rs.Spec.SourceType, auth) {
@@ -42,8 +42,10 @@ import (
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
+	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/client/restconfig"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/reconcilermanager"
@@ -51,7 +53,9 @@ import (
 	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/fake"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/mutate"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -316,6 +320,7 @@ func setupNSReconciler(t *testing.T, objs ...client.Object) (*syncerFake.Client,
 		testCluster,
 		filesystemPollingPeriod,
 		hydrationPollingPeriod,
+		mutate.NewConflictDetector(DefaultStatusConflictThreshold, DefaultStatusConflictWindow),
 		cs.Client,
 		cs.Client,
 		cs.DynamicClient,
@@ -503,6 +508,9 @@ func TestUpdateNamespaceReconcilerWithOverride(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	wantRs.Spec = rs.Spec
@@ -756,6 +764,9 @@ func TestRepoSyncCreateWithNoSSLVerify(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	wantRs.Spec = rs.Spec
@@ -799,6 +810,9 @@ func TestRepoSyncUpdateNoSSLVerify(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	wantRs.Spec = rs.Spec
@@ -1108,13 +1122,16 @@ func TestRepoSyncCreateWithCACert(t *testing.T) {
 	gitSecret.Data[GitSecretConfigKeyTokenUsername] = []byte("test-user")
 	certSecret := secretObj(t, caCertSecret, GitSecretConfigKeyToken, v1beta1.GitSource, core.Namespace(rs.Namespace))
 	certSecret.Data[CACertSecretKey] = []byte("test-cert")
-	_, fakeDynamicClient, testReconciler := setupNSReconciler(t, rs, gitSecret, certSecret)
+	fakeClient, fakeDynamicClient, testReconciler := setupNSReconciler(t, rs, gitSecret, certSecret)
 
 	// Test creating Deployment resources.
 	ctx := context.Background()
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	repoContainerEnvs := testReconciler.populateContainerEnvs(ctx, rs, nsReconcilerName)
 	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
@@ -1156,6 +1173,9 @@ func TestRepoSyncUpdateCACert(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	repoContainerEnvs := testReconciler.populateContainerEnvs(ctx, rs, nsReconcilerName)
 	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
@@ -1299,6 +1319,67 @@ func TestRepoSyncReconcileWithInvalidCACertSecret(t *testing.T) {
 	}
 }
 
+func TestRepoSyncReconcileWithOverrideResourceLimits(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	testCases := map[string]struct {
+		resources []v1beta1.ContainerResourcesSpec
+		wantErr   error
+	}{
+		"cpuLimit below the minimum": {
+			resources: []v1beta1.ContainerResourcesSpec{{
+				ContainerName: reconcilermanager.HydrationController,
+				CPULimit:      resource.MustParse("5m"),
+			}},
+			wantErr: fmt.Errorf("spec.override.resources: cpuLimit 5m of the %q container is below the minimum of 10m", reconcilermanager.HydrationController),
+		},
+		"memoryLimit below the minimum": {
+			resources: []v1beta1.ContainerResourcesSpec{{
+				ContainerName: reconcilermanager.Reconciler,
+				MemoryLimit:   resource.MustParse("100Mi"),
+			}},
+			wantErr: fmt.Errorf("spec.override.resources: memoryLimit 100Mi of the %q container is below the minimum of 200Mi", reconcilermanager.Reconciler),
+		},
+		"limits at or above the minimum": {
+			resources: []v1beta1.ContainerResourcesSpec{
+				{
+					ContainerName: reconcilermanager.Reconciler,
+					CPULimit:      resource.MustParse("1"),
+					MemoryLimit:   resource.MustParse("200Mi"),
+				},
+				{
+					ContainerName: reconcilermanager.HydrationController,
+					CPULimit:      resource.MustParse("10m"),
+					MemoryLimit:   resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rs := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch),
+				reposyncSecretType(configsync.AuthNone), reposyncOverrideResources(tc.resources))
+			reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+			fakeClient, _, testReconciler := setupNSReconciler(t, rs)
+
+			ctx := context.Background()
+			if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+				t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+			}
+
+			wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
+			if tc.wantErr != nil {
+				reposync.SetStalled(wantRs, "Validation", tc.wantErr)
+			} else {
+				reposync.SetReconciling(wantRs, "Deployment",
+					fmt.Sprintf("Deployment (config-management-system/%s) InProgress: Replicas: 0/1", nsReconcilerName))
+			}
+			validateRepoSyncStatus(t, wantRs, fakeClient)
+		})
+	}
+}
+
 func TestRepoSyncValidateCACertSecret(t *testing.T) {
 	caCertSecret := "foo-secret"
 	testCases := map[string]struct {
@@ -1376,6 +1457,9 @@ func TestRepoSyncUpdateOverrideGitSyncDepth(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	repoContainerEnv := testReconciler.populateContainerEnvs(ctx, rs, nsReconcilerName)
 	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
@@ -1564,6 +1648,9 @@ func TestRepoSyncUpdateOverrideReconcileTimeout(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	repoContainerEnv := testReconciler.populateContainerEnvs(ctx, rs, nsReconcilerName)
 	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
@@ -1716,6 +1803,9 @@ func TestRepoSyncUpdateOverrideAPIServerTimeout(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	repoContainerEnv := testReconciler.populateContainerEnvs(ctx, rs, nsReconcilerName)
 	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
@@ -1836,6 +1926,9 @@ func TestRepoSyncSwitchAuthTypes(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	label := map[string]string{
 		metadata.SyncNamespaceLabel:       rs.Namespace,
@@ -1958,6 +2051,9 @@ func TestRepoSyncReconcilerRestart(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	wantRs.Spec = rs.Spec
@@ -2034,6 +2130,52 @@ func TestRepoSyncReconcilerRestart(t *testing.T) {
 	t.Log("Deployment successfully updated")
 }
 
+func TestRepoSyncNamespaceManagedByRootSync(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch), reposyncSecretType(configsync.AuthSSH), reposyncSecretRef(reposyncSSHKey))
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	// The RepoSync namespace is implicitly created by a RootSync with deletion prevented.
+	ns := fake.NamespaceObject(reposyncNs,
+		core.Annotation(metadata.ResourceManagementKey, metadata.ResourceManagementEnabled),
+		core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootReconciler, rootsyncName)),
+		core.Annotation(metadata.OwningInventoryKey, applier.InventoryID(rootsyncName, configsync.ControllerNamespace)),
+		core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion))
+	fakeClient, _, testReconciler := setupNSReconciler(t, rs, ns, secretObj(t, reposyncSSHKey, configsync.AuthSSH, v1beta1.GitSource, core.Namespace(rs.Namespace)))
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
+	reposync.SetReconciling(wantRs, "Deployment",
+		fmt.Sprintf("Deployment (config-management-system/%s) InProgress: Replicas: 0/1", nsReconcilerName))
+	reposync.SetNamespaceManagedByRootSync(wantRs, "NamespaceManagedByRootSync",
+		fmt.Sprintf("Namespace %q is managed by RootSync %s/%s with deletion prevented. "+
+			"Deleting the RootSync or removing the Namespace from its source will not delete the Namespace, "+
+			"so this RepoSync and its managed objects are kept.",
+			reposyncNs, configsync.ControllerNamespace, rootsyncName))
+	validateRepoSyncStatus(t, wantRs, fakeClient)
+
+	// Simulate the RootSync abandoning the namespace.
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), ns); err != nil {
+		t.Fatalf("failed to get the namespace: %v", err)
+	}
+	core.RemoveAnnotations(ns, metadata.ResourceManagementKey, metadata.ResourceManagerKey, metadata.OwningInventoryKey, common.LifecycleDeleteAnnotation)
+	if err := fakeClient.Update(ctx, ns); err != nil {
+		t.Fatalf("failed to update the namespace: %v", err)
+	}
+
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	reposync.RemoveCondition(wantRs, v1beta1.RepoSyncNamespaceManagedByRootSync)
+	validateRepoSyncStatus(t, wantRs, fakeClient)
+}
+
 // This test reconcilers multiple RepoSyncs with different auth types.
 // - rs1: "my-repo-sync", namespace is bookinfo, auth type is ssh.
 // - rs2: uses the default "repo-sync" name, namespace is videoinfo, and auth type is gcenode
@@ -2075,6 +2217,9 @@ func TestMultipleRepoSyncs(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName1); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs1), rs1); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs1 := fake.RepoSyncObjectV1Beta1(rs1.Namespace, rs1.Name)
 	wantRs1.Spec = rs1.Spec
@@ -2138,6 +2283,9 @@ func TestMultipleRepoSyncs(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName2); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs2), rs2); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs2 := fake.RepoSyncObjectV1Beta1(rs2.Namespace, rs2.Name)
 	wantRs2.Spec = rs2.Spec
@@ -2199,6 +2347,9 @@ func TestMultipleRepoSyncs(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName3); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs3), rs3); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs3 := fake.RepoSyncObjectV1Beta1(rs3.Namespace, rs3.Name)
 	wantRs3.Spec = rs3.Spec
@@ -2259,6 +2410,9 @@ func TestMultipleRepoSyncs(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName4); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs4), rs4); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs4 := fake.RepoSyncObjectV1Beta1(rs4.Namespace, rs4.Name)
 	wantRs4.Spec = rs4.Spec
@@ -2319,6 +2473,9 @@ func TestMultipleRepoSyncs(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName5); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs5), rs5); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs5 := fake.RepoSyncObjectV1Beta1(rs5.Namespace, rs5.Name)
 	wantRs5.Spec = rs5.Spec
@@ -2972,6 +3129,9 @@ func TestInjectFleetWorkloadIdentityCredentialsToRepoSync(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 	repoContainerEnv := testReconciler.populateContainerEnvs(ctx, rs, nsReconcilerName)
 	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
 	repoDeployment := repoSyncDeployment(
@@ -3011,6 +3171,7 @@ func TestInjectFleetWorkloadIdentityCredentialsToRepoSync(t *testing.T) {
 		nsReconcilerName,
 		setAnnotations(map[string]string{
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/config-sync@cs-project.iam.gserviceaccount.com:generateAccessToken","subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fleetWorkloadIdentityMutator(workloadIdentityPool),
@@ -3118,7 +3279,11 @@ func TestRepoSyncWithHelm(t *testing.T) {
 	}
 
 	// test 1: validations
-	// No need to get RepoSync for a refreshed generation because the spec.override field is not updated.
+	// Get RepoSync to refresh the generation, which is passed as the `SYNC_GENERATION` env.
+	// The generation is updated because the validateRepoSync function resets the spec.override field.
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 	labels := map[string]string{
 		metadata.SyncNamespaceLabel:       rs.Namespace,
 		metadata.SyncNameLabel:            rs.Name,
@@ -3252,6 +3417,7 @@ func TestRepoSyncWithHelm(t *testing.T) {
 		nsReconcilerName,
 		setAnnotations(map[string]string{
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/config-sync@cs-project.iam.gserviceaccount.com:generateAccessToken","subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.HelmSync),
@@ -3291,6 +3457,7 @@ func TestRepoSyncWithHelm(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.HelmSync),
@@ -3336,6 +3503,7 @@ func TestRepoSyncWithHelm(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.HelmSync),
@@ -3368,7 +3536,11 @@ func TestRepoSyncWithOCI(t *testing.T) {
 	}
 
 	// test 1: validations
-	// No need to get RepoSync for a refreshed generation because the spec.override field is not updated.
+	// Get RepoSync to refresh the generation, which is passed as the `SYNC_GENERATION` env.
+	// The generation is updated because the validateRepoSync function resets the spec.override field.
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 	labels := map[string]string{
 		metadata.SyncNamespaceLabel:       rs.Namespace,
 		metadata.SyncNameLabel:            rs.Name,
@@ -3505,6 +3677,7 @@ func TestRepoSyncWithOCI(t *testing.T) {
 		nsReconcilerName,
 		setAnnotations(map[string]string{
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/config-sync@cs-project.iam.gserviceaccount.com:generateAccessToken","subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.OciSync),
@@ -3549,6 +3722,7 @@ func TestRepoSyncWithOCI(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.OciSync),
@@ -3597,6 +3771,7 @@ func TestRepoSyncWithOCI(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(nsReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.OciSync),
@@ -3807,6 +3982,82 @@ func TestRepoSyncSpecValidation(t *testing.T) {
 	validateRepoSyncStatus(t, wantRs, fakeClient)
 }
 
+func TestRepoSyncImagePullSecrets(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	imagePullSecrets := []corev1.LocalObjectReference{{Name: "registry-creds"}}
+	rs := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch),
+		reposyncSecretType(configsync.AuthNone))
+	rs.Spec.SafeOverride().ImagePullSecrets = imagePullSecrets
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupNSReconciler(t, rs)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: nsReconcilerName}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, imagePullSecrets, deployment.Spec.Template.Spec.ImagePullSecrets)
+
+	// The image pull Secret is only referenced, not created.
+	secretKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: "registry-creds"}
+	err := fakeClient.Get(ctx, secretKey, &corev1.Secret{})
+	require.True(t, apierrors.IsNotFound(err), "expected the image pull Secret to not be created, got error: %v", err)
+
+	// Invalid Secret names are rejected.
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
+	rs.Spec.SafeOverride().ImagePullSecrets = []corev1.LocalObjectReference{{Name: "Registry_Creds"}}
+	if err := fakeClient.Update(ctx, rs); err != nil {
+		t.Fatalf("failed to update the repo sync request, got error: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
+	cond := reposync.GetCondition(rs.Status.Conditions, v1beta1.RepoSyncStalled)
+	require.NotNil(t, cond, "expected a Stalled condition")
+	require.Equal(t, "Validation", cond.Reason)
+	require.Contains(t, cond.Message, `spec.override.imagePullSecrets[0].name: invalid Secret name "Registry_Creds"`)
+}
+
+func TestRepoSyncOverrideImagesRejected(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch),
+		reposyncSecretType(configsync.AuthNone))
+	rs.Spec.SafeOverride().GitSyncImage = "registry.example.com/mirror/git-sync:pinned"
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupNSReconciler(t, rs)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
+	cond := reposync.GetCondition(rs.Status.Conditions, v1beta1.RepoSyncStalled)
+	require.NotNil(t, cond, "expected a Stalled condition")
+	require.Equal(t, "Validation", cond.Reason)
+	require.Contains(t, cond.Message, "spec.override.gitSyncImage: image overrides are only supported for RootSync")
+
+	// The reconciler is not created with the overridden image.
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: nsReconcilerName}
+	err := fakeClient.Get(ctx, deploymentKey, &appsv1.Deployment{})
+	require.True(t, apierrors.IsNotFound(err), "expected the reconciler deployment to not be created, got error: %v", err)
+}
+
 func TestRepoSyncReconcileStaleClientCache(t *testing.T) {
 	rs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
@@ -4007,6 +4258,9 @@ func TestUpdateNamespaceReconcilerLogLevelWithOverride(t *testing.T) {
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	wantRs.Spec = rs.Spec
@@ -4115,6 +4369,9 @@ func TestCreateAndUpdateNamespaceReconcilerWithOverrideOnAutopilot(t *testing.T)
 	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
 		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
 	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the repo sync: %v", err)
+	}
 
 	wantRs := fake.RepoSyncObjectV1Beta1(reposyncNs, reposyncName)
 	wantRs.Spec = rs.Spec
@@ -4453,6 +4710,94 @@ func TestRepoReconcilerWithoutKnownHosts(t *testing.T) {
 	require.Equal(t, false, isKnownHosts)
 }
 
+func TestRepoReconcilerKnownHostsConfigMap(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	knownHostsCM := "known-hosts"
+	rs := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch), reposyncSecretType(configsync.AuthSSH), reposyncSecretRef(reposyncSSHKey))
+	rs.Spec.Git.KnownHostsConfigMapRef = &v1beta1.KnownHostsConfigMapRef{Name: knownHostsCM}
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: knownHostsCM, Namespace: rs.Namespace},
+		Data:       map[string]string{KnownHostsKey: "github.com ssh-ed25519 AAAA"},
+	}
+	fakeClient, fakeDynamicClient, testReconciler := setupNSReconciler(t, rs, cm, secretObj(t, reposyncSSHKey, configsync.AuthSSH, v1beta1.GitSource, core.Namespace(rs.Namespace)))
+	// Skip adding the ConfigMap watch, which requires a registered controller.
+	testReconciler.configMapWatches[rs.Namespace] = true
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	// The ConfigMap is copied to the config-management-system namespace.
+	copyRef := getHelmConfigMapCopyRef(knownHostsCM, client.ObjectKeyFromObject(rs))
+	copyCM := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, copyRef, copyCM))
+	require.Equal(t, cm.Data, copyCM.Data)
+
+	uObj, err := fakeDynamicClient.Resource(kinds.DeploymentResource()).
+		Namespace(configsync.ControllerNamespace).Get(ctx, nsReconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	obj, err := kinds.ToTypedObject(uObj, core.Scheme)
+	require.NoError(t, err)
+	assertKnownHostsConfigMapMounted(t, obj.(*appsv1.Deployment), copyRef.Name, KnownHostsKey)
+
+	// The copy is deleted once the ConfigMap is no longer referenced.
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	rs.Spec.Git.KnownHostsConfigMapRef = nil
+	require.NoError(t, fakeClient.Update(ctx, rs))
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	err = fakeClient.Get(ctx, copyRef, copyCM)
+	require.True(t, apierrors.IsNotFound(err), "expected the ConfigMap copy to be deleted, got %v", err)
+}
+
+func TestRepoReconcilerClientCertSecret(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	clientCertSecret := "client-cert"
+	rs := repoSyncWithGit(reposyncNs, reposyncName, reposyncRef(gitRevision), reposyncBranch(branch), reposyncSecretType(configsync.AuthNone))
+	rs.Spec.Git.ClientCertSecretRef = &v1beta1.SecretReference{Name: clientCertSecret}
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	userSecret := clientCertSecretObj(clientCertSecret, rs.Namespace, ClientCertSecretCertKey, ClientCertSecretKeyKey)
+	fakeClient, fakeDynamicClient, testReconciler := setupNSReconciler(t, rs, userSecret)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	// The Secret is copied to the config-management-system namespace.
+	copyRef := client.ObjectKey{
+		Namespace: configsync.ControllerNamespace,
+		Name:      ReconcilerResourceName(nsReconcilerName, clientCertSecret),
+	}
+	copySecret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, copyRef, copySecret))
+	require.Equal(t, userSecret.Data, copySecret.Data)
+
+	uObj, err := fakeDynamicClient.Resource(kinds.DeploymentResource()).
+		Namespace(configsync.ControllerNamespace).Get(ctx, nsReconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	obj, err := kinds.ToTypedObject(uObj, core.Scheme)
+	require.NoError(t, err)
+	assertClientCertSecretMounted(t, obj.(*appsv1.Deployment), reconcilermanager.GitSync, copyRef.Name, GitSSLCert, GitSSLKey)
+
+	// The copy is deleted once the Secret is no longer referenced.
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+	rs.Spec.Git.ClientCertSecretRef = nil
+	require.NoError(t, fakeClient.Update(ctx, rs))
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	err = fakeClient.Get(ctx, copyRef, copySecret)
+	require.True(t, apierrors.IsNotFound(err), "expected the Secret copy to be deleted, got %v", err)
+}
+
 func validateRepoSyncStatus(t *testing.T, want *v1beta1.RepoSync, fakeClient *syncerFake.Client) {
 	t.Helper()
 
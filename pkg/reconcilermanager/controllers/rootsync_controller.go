@@ -26,7 +26,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -37,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
@@ -51,6 +54,7 @@ import (
 	"kpt.dev/configsync/pkg/util/compare"
 	"kpt.dev/configsync/pkg/util/mutate"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
+	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
 	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -81,7 +85,7 @@ type RootSyncReconciler struct {
 }
 
 // NewRootSyncReconciler returns a new RootSyncReconciler.
-func NewRootSyncReconciler(clusterName string, reconcilerPollingPeriod, hydrationPollingPeriod time.Duration, client client.Client, watcher client.WithWatch, dynamicClient dynamic.Interface, log logr.Logger, scheme *runtime.Scheme) *RootSyncReconciler {
+func NewRootSyncReconciler(clusterName string, reconcilerPollingPeriod, hydrationPollingPeriod time.Duration, statusConflicts *mutate.ConflictDetector, client client.Client, watcher client.WithWatch, dynamicClient dynamic.Interface, log logr.Logger, scheme *runtime.Scheme) *RootSyncReconciler {
 	return &RootSyncReconciler{
 		reconcilerBase: reconcilerBase{
 			loggingController: loggingController{
@@ -94,6 +98,7 @@ func NewRootSyncReconciler(clusterName string, reconcilerPollingPeriod, hydratio
 			scheme:                  scheme,
 			reconcilerPollingPeriod: reconcilerPollingPeriod,
 			hydrationPollingPeriod:  hydrationPollingPeriod,
+			statusConflicts:         statusConflicts,
 			syncKind:                configsync.RootSyncKind,
 			knownHostExist:          false,
 		},
@@ -102,6 +107,7 @@ func NewRootSyncReconciler(clusterName string, reconcilerPollingPeriod, hydratio
 
 // +kubebuilder:rbac:groups=configsync.gke.io,resources=rootsyncs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=configsync.gke.io,resources=rootsyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 
 // Reconcile the RootSync resource.
 func (r *RootSyncReconciler) Reconcile(ctx context.Context, req controllerruntime.Request) (controllerruntime.Result, error) {
@@ -171,6 +177,11 @@ func (r *RootSyncReconciler) Reconcile(ctx context.Context, req controllerruntim
 	}
 
 	metrics.RecordReconcileDuration(ctx, metrics.StatusTagKey(nil), start)
+	if remaining := r.deletionGracePeriodRemaining(rs); remaining > 0 {
+		// Requeue to delete the reconciler when the grace period elapses,
+		// in case the reconciler does not finish syncing before then.
+		return controllerruntime.Result{RequeueAfter: remaining}, nil
+	}
 	return controllerruntime.Result{}, nil
 }
 
@@ -210,6 +221,15 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 		return errors.Wrap(err, "configuring RBAC bindings")
 	}
 
+	// Reconcile the permissions to manage the leader election Lease.
+	if leaderElectionEnabled(rs.Spec.SafeOverride().Replicas) {
+		if err := r.upsertLeaderElectionRBAC(ctx, reconcilerRef, labelMap); err != nil {
+			return errors.Wrap(err, "upserting leader election RBAC")
+		}
+	} else if err := r.deleteLeaderElectionRBAC(ctx, reconcilerRef); err != nil {
+		return errors.Wrap(err, "deleting leader election RBAC")
+	}
+
 	containerEnvs := r.populateContainerEnvs(ctx, rs, reconcilerRef.Name)
 	mut := r.mutationsFor(ctx, rs, containerEnvs)
 
@@ -261,14 +281,30 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 
 // setup performs the following steps:
 // - Create or update managed objects
+// - Create the post-sync verification Job, if any
 // - Convert any error into RootSync status conditions
 // - Update the RootSync status
 func (r *RootSyncReconciler) setup(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync) error {
 	err := r.upsertManagedObjects(ctx, reconcilerRef, rs)
+	var verificationJob *batchv1.Job
+	if err == nil {
+		// Only verify syncs once the reconciler is available.
+		labelMap := ManagedObjectLabelMap(r.syncKind, client.ObjectKeyFromObject(rs))
+		verificationJob, err = r.managePostSyncVerification(ctx, reconcilerRef, rs, labelMap)
+	}
 	updated, updateErr := r.updateSyncStatus(ctx, rs, reconcilerRef, func(syncObj *v1beta1.RootSync) error {
+		// Report the credentials which the reconciler Deployment is
+		// configured with.
+		syncObj.Status.FleetWorkloadIdentity = fleetWorkloadIdentityStatus(rootSyncAuthType(syncObj), r.membership)
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
 		err = r.handleReconcileError(ctx, err, syncObj, "Setup")
+		if syncObj.Spec.Suspend {
+			rootsync.SetSuspended(syncObj, "Suspended", "Syncing is suspended")
+		} else {
+			rootsync.RemoveCondition(syncObj, v1beta1.RootSyncSuspended)
+		}
+		setPostSyncVerificationCondition(syncObj, verificationJob)
 		return nil
 	})
 	switch {
@@ -289,13 +325,39 @@ func (r *RootSyncReconciler) setup(ctx context.Context, reconcilerRef types.Name
 	}
 }
 
+// rootSyncAuthType returns the auth type of the source of truth of the RootSync.
+func rootSyncAuthType(rs *v1beta1.RootSync) configsync.AuthType {
+	switch v1beta1.SourceType(rs.Spec.SourceType) {
+	case v1beta1.GitSource:
+		if rs.Spec.Git != nil {
+			return rs.Spec.Auth
+		}
+	case v1beta1.OciSource:
+		if rs.Spec.Oci != nil {
+			return rs.Spec.Oci.Auth
+		}
+	case v1beta1.HelmSource:
+		if rs.Spec.Helm != nil {
+			return rs.Spec.Helm.Auth
+		}
+	}
+	return ""
+}
+
 // teardown performs the following steps:
 // - Delete managed objects
 // - Convert any error into RootSync status conditions
 // - Update the RootSync status
 func (r *RootSyncReconciler) teardown(ctx context.Context, reconcilerRef types.NamespacedName, rs *v1beta1.RootSync) error {
 	rsRef := client.ObjectKeyFromObject(rs)
-	err := r.deleteManagedObjects(ctx, reconcilerRef, rsRef)
+	var err error
+	if remaining := r.deletionGracePeriodRemaining(rs); remaining > 0 {
+		// Give the reconciler a chance to finish applying and pruning
+		// before its Deployment is deleted.
+		err = newDeletionGracePeriodError(reconcilerRef, remaining)
+	} else {
+		err = r.deleteManagedObjects(ctx, reconcilerRef, rsRef)
+	}
 	updated, updateErr := r.updateSyncStatus(ctx, rs, reconcilerRef, func(syncObj *v1beta1.RootSync) error {
 		// Modify the sync status,
 		// but keep the upsert error separate from the status update error.
@@ -320,6 +382,12 @@ func (r *RootSyncReconciler) teardown(ctx context.Context, reconcilerRef types.N
 	}
 }
 
+// deletionGracePeriodRemaining returns how much longer teardown should wait
+// for the root reconciler to finish syncing.
+func (r *RootSyncReconciler) deletionGracePeriodRemaining(rs *v1beta1.RootSync) time.Duration {
+	return deletionGracePeriodRemaining(rs, rs.Spec.SafeOverride().DeletionGracePeriod, rootsync.IsSyncing(rs), time.Now())
+}
+
 // handleReconcileError updates the sync object status to reflect the Reconcile
 // error. If the error requires immediate retry, it will be returned.
 func (r *RootSyncReconciler) handleReconcileError(ctx context.Context, err error, rs *v1beta1.RootSync, stage string) error {
@@ -379,6 +447,10 @@ func (r *RootSyncReconciler) deleteManagedObjects(ctx context.Context, reconcile
 		return errors.Wrap(err, "deleting reconciler deployment")
 	}
 
+	if err := r.deletePostSyncVerificationJob(ctx, reconcilerRef); err != nil {
+		return errors.Wrap(err, "deleting post-sync verification job")
+	}
+
 	// Note: ConfigMaps have been replaced by Deployment env vars.
 	// Using env vars auto-updates the Deployment when they change.
 	// This deletion remains to clean up after users upgrade.
@@ -394,6 +466,10 @@ func (r *RootSyncReconciler) deleteManagedObjects(ctx context.Context, reconcile
 		return errors.Wrap(err, "deleting RBAC bindings")
 	}
 
+	if err := r.deleteLeaderElectionRBAC(ctx, reconcilerRef); err != nil {
+		return errors.Wrap(err, "deleting leader election RBAC")
+	}
+
 	if err := r.deleteServiceAccount(ctx, reconcilerRef); err != nil {
 		return errors.Wrap(err, "deleting service account")
 	}
@@ -421,13 +497,21 @@ func (r *RootSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		Watches(&source.Kind{Type: withNamespace(&corev1.ServiceAccount{}, configsync.ControllerNamespace)},
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Watches(&source.Kind{Type: withNamespace(&batchv1.Job{}, configsync.ControllerNamespace)},
+			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
 		Watches(&source.Kind{Type: &rbacv1.ClusterRoleBinding{}},
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
 		// TODO: is it possible to watch with a label filter?
 		Watches(&source.Kind{Type: &rbacv1.RoleBinding{}},
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRootSync),
-			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}))
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Watch the admission webhook configuration to update the reconciler
+		// Deployments when the webhook is enabled or disabled.
+		Watches(&source.Kind{Type: &admissionv1.ValidatingWebhookConfiguration{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapWebhookConfigurationToRootSyncs),
+			builder.WithPredicates(webhookConfigurationPredicate()))
 
 	if watchFleetMembership {
 		// Custom Watch for membership to trigger reconciliation.
@@ -450,8 +534,10 @@ func (r *RootSyncReconciler) mapMembershipToRootSyncs() func(client.Object) []re
 		// Clear the membership if the cluster is unregistered
 		if err := r.client.Get(ctx, types.NamespacedName{Name: fleetMembershipName}, &hubv1.Membership{}); err != nil {
 			if apierrors.IsNotFound(err) {
-				klog.Info("Fleet Membership not found, clearing membership cache")
-				r.membership = nil
+				if !r.updateMembership(nil) {
+					return nil
+				}
+				klog.Info("Fleet Membership not found, cleared membership cache")
 				return r.requeueAllRootSyncs()
 			}
 			klog.Errorf("Fleet Membership get failed: %v", err)
@@ -467,7 +553,10 @@ func (r *RootSyncReconciler) mapMembershipToRootSyncs() func(client.Object) []re
 			klog.Errorf("Fleet Membership name expected %q, found %q", fleetMembershipName, m.Name)
 			return nil
 		}
-		r.membership = m
+		if !r.updateMembership(m) {
+			klog.V(3).Infof("Fleet Membership generation %d unchanged, skipping reconciliation", m.Generation)
+			return nil
+		}
 		return r.requeueAllRootSyncs()
 	}
 }
@@ -494,7 +583,8 @@ func (r *RootSyncReconciler) mapConfigMapsToRootSyncs(obj client.Object) []recon
 	for _, rs := range rootSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
 		//TODO: Use stdlib slices.Contains in Go 1.21+
-		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) ||
+			knownHostsConfigMapName(rs.Spec.Git) == objRef.Name {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -584,6 +674,10 @@ func (r *RootSyncReconciler) mapObjectToRootSync(obj client.Object) []reconcile.
 			if strings.HasPrefix(objRef.Name, reconcilerName) {
 				return requeueRootSyncRequest(obj, &rs)
 			}
+		case *batchv1.Job: // The post-sync verification Job is in the controller Namespace
+			if objRef.Name == postSyncVerificationJobName(reconcilerName) && objRef.Namespace == configsync.ControllerNamespace {
+				return requeueRootSyncRequest(obj, &rs)
+			}
 		default: // Deployment and ServiceAccount are in the controller Namespace
 			if objRef.Name == reconcilerName && objRef.Namespace == configsync.ControllerNamespace {
 				return requeueRootSyncRequest(obj, &rs)
@@ -608,6 +702,18 @@ func requeueRootSyncRequest(obj client.Object, rs *v1beta1.RootSync) []reconcile
 	}
 }
 
+// mapWebhookConfigurationToRootSyncs handles the creation and deletion of the
+// admission webhook configuration, which changes whether the webhook is
+// enabled for every RootSync reconciler.
+func (r *RootSyncReconciler) mapWebhookConfigurationToRootSyncs(obj client.Object) []reconcile.Request {
+	if obj.GetName() != webhookconfiguration.Name {
+		return nil
+	}
+	klog.Infof("Changes to the admission webhook configuration %q trigger reconciliations for all RootSync objects",
+		obj.GetName())
+	return r.requeueAllRootSyncs()
+}
+
 func (r *RootSyncReconciler) requeueAllRootSyncs() []reconcile.Request {
 	//TODO: pass through context (reqs updating controller-runtime)
 	ctx := context.Background()
@@ -624,7 +730,7 @@ func (r *RootSyncReconciler) requeueAllRootSyncs() []reconcile.Request {
 		}
 	}
 	if len(requests) > 0 {
-		klog.Infof("Changes to membership trigger reconciliations for %d RootSync objects.", len(allRootSyncs.Items))
+		klog.Infof("Triggering reconciliations for %d RootSync objects.", len(allRootSyncs.Items))
 	}
 	return requests
 }
@@ -634,6 +740,7 @@ func (r *RootSyncReconciler) requeueAllRootSyncs() []reconcile.Request {
 // - `spec.git.secretRef.name`
 // - `spec.git.caCertSecretRef.name`
 // - `spec.helm.secretRef.name`
+// - `spec.{git,oci,helm}.clientCertSecretRef.name`
 // The update to the Secret object will trigger a reconciliation of the RootSync objects.
 func (r *RootSyncReconciler) mapSecretToRootSyncs(secret client.Object) []reconcile.Request {
 	//TODO: pass through context (reqs updating controller-runtime)
@@ -663,7 +770,7 @@ func (r *RootSyncReconciler) mapSecretToRootSyncs(secret client.Object) []reconc
 		switch sRef.Name {
 		case rootSyncGitSecretName(&rs), rootSyncGitCACertSecretName(&rs),
 			rootSyncOCICACertSecretName(&rs), rootSyncHelmCACertSecretName(&rs),
-			rootSyncHelmSecretName(&rs):
+			rootSyncHelmSecretName(&rs), rootSyncClientCertSecretName(&rs):
 			attachedRSNames = append(attachedRSNames, rs.GetName())
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
@@ -690,6 +797,27 @@ func rootSyncGitSecretName(rs *v1beta1.RootSync) string {
 	return rs.Spec.Git.SecretRef.Name
 }
 
+func rootSyncClientCertSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	switch v1beta1.SourceType(rs.Spec.SourceType) {
+	case v1beta1.GitSource:
+		if rs.Spec.Git != nil {
+			return v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef)
+		}
+	case v1beta1.OciSource:
+		if rs.Spec.Oci != nil {
+			return v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef)
+		}
+	case v1beta1.HelmSource:
+		if rs.Spec.Helm != nil {
+			return v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef)
+		}
+	}
+	return ""
+}
+
 func rootSyncGitCACertSecretName(rs *v1beta1.RootSync) string {
 	if rs == nil {
 		return ""
@@ -754,56 +882,91 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 		}),
 		reconcilermanager.Reconciler: append(
 			reconcilerEnvs(reconcilerOptions{
-				clusterName:              r.clusterName,
-				syncName:                 rs.Name,
-				syncGeneration:           rs.Generation,
-				reconcilerName:           reconcilerName,
-				reconcilerScope:          declared.RootReconciler,
-				sourceType:               rs.Spec.SourceType,
-				gitConfig:                rs.Spec.Git,
-				ociConfig:                rs.Spec.Oci,
-				helmConfig:               rootsync.GetHelmBase(rs.Spec.Helm),
-				pollPeriod:               r.reconcilerPollingPeriod.String(),
-				statusMode:               rs.Spec.SafeOverride().StatusMode,
-				reconcileTimeout:         v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
-				apiServerTimeout:         v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
-				requiresRendering:        annotationEnabled(metadata.RequiresRenderingAnnotationKey, rs.GetAnnotations()),
-				dynamicNSSelectorEnabled: annotationEnabled(metadata.DynamicNSSelectorEnabledAnnotationKey, rs.GetAnnotations()),
+				clusterName:                r.clusterName,
+				syncName:                   rs.Name,
+				syncGeneration:             rs.Generation,
+				reconcilerName:             reconcilerName,
+				reconcilerScope:            declared.RootReconciler,
+				sourceType:                 rs.Spec.SourceType,
+				gitConfig:                  rs.Spec.Git,
+				ociConfig:                  rs.Spec.Oci,
+				helmConfig:                 rootsync.GetHelmBase(rs.Spec.Helm),
+				pollPeriod:                 r.reconcilerPollingPeriod.String(),
+				statusMode:                 rs.Spec.SafeOverride().StatusMode,
+				reconcileTimeout:           v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
+				apiServerTimeout:           v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
+				clientQPS:                  pointer.Int32Deref(rs.Spec.SafeOverride().ClientQPS, 0),
+				clientBurst:                pointer.Int32Deref(rs.Spec.SafeOverride().ClientBurst, 0),
+				minRemediationInterval:     rs.Spec.SafeOverride().MinRemediationInterval,
+				applyDebouncePeriod:        rs.Spec.SafeOverride().ApplyDebouncePeriod,
+				applyCallTimeout:           rs.Spec.SafeOverride().ApplyCallTimeout,
+				resyncPeriod:               rs.Spec.SafeOverride().ResyncPeriod,
+				statusUpdatePeriod:         rs.Spec.SafeOverride().StatusUpdatePeriod,
+				renderingTimeout:           rs.Spec.SafeOverride().RenderingTimeout,
+				fieldManager:               rs.Spec.SafeOverride().FieldManager,
+				requiredMetadata:           rs.Spec.SafeOverride().RequiredMetadata,
+				annotateSyncGeneration:     pointer.BoolDeref(rs.Spec.SafeOverride().AnnotateSyncGeneration, false),
+				pauseApply:                 pointer.BoolDeref(rs.Spec.SafeOverride().PauseApply, false),
+				maxPruneCount:              pointer.Int32Deref(rs.Spec.SafeOverride().MaxPruneCount, 0),
+				circuitBreakerThreshold:    pointer.Int32Deref(rs.Spec.SafeOverride().CircuitBreakerThreshold, 0),
+				circuitBreakerProbePeriod:  rs.Spec.SafeOverride().CircuitBreakerProbePeriod,
+				validateWithAdmission:      pointer.BoolDeref(rs.Spec.SafeOverride().ValidateWithAdmission, false),
+				remediatorExcludedKinds:    rs.Spec.SafeOverride().RemediatorExcludedKinds,
+				objectSelector:             rs.Spec.SafeOverride().ObjectSelector,
+				substituteClusterName:      pointer.BoolDeref(rs.Spec.SafeOverride().SubstituteClusterName, false),
+				leaderElection:             leaderElectionEnabled(rs.Spec.SafeOverride().Replicas),
+				deferUnestablishedCRs:      pointer.BoolDeref(rs.Spec.SafeOverride().DeferUnestablishedCRs, false),
+				requiresRendering:          annotationEnabled(metadata.RequiresRenderingAnnotationKey, rs.GetAnnotations()),
+				dynamicNSSelectorEnabled:   annotationEnabled(metadata.DynamicNSSelectorEnabledAnnotationKey, rs.GetAnnotations()),
+				dynamicNamespaceSelector:   pointer.BoolDeref(rs.Spec.SafeOverride().DynamicNamespaceSelector, false),
+				clusterScopedConflictCheck: pointer.BoolDeref(rs.Spec.SafeOverride().ClusterScopedConflictCheck, false),
+				webhookEnabled:             r.isWebhookEnabled(ctx),
 			}),
 			sourceFormatEnv(rs.Spec.SourceFormat),
 			namespaceStrategyEnv(rs.Spec.SafeOverride().NamespaceStrategy),
+			ambiguousSourceFormatEnv(rs.Spec.SafeOverride().AmbiguousSourceFormat),
+			namespaceMismatchPolicyEnv(rs.Spec.SafeOverride().NamespaceMismatchPolicy),
+			conflictPolicyEnv(rs.Spec.SafeOverride().ConflictPolicy),
+			syncModeEnv(rs.Spec.Mode),
 		),
 	}
 	switch v1beta1.SourceType(rs.Spec.SourceType) {
 	case v1beta1.GitSource:
 		result[reconcilermanager.GitSync] = gitSyncEnvs(ctx, options{
-			ref:             rs.Spec.Git.Revision,
-			branch:          rs.Spec.Git.Branch,
-			repo:            rs.Spec.Git.Repo,
-			secretType:      rs.Spec.Git.Auth,
-			period:          v1beta1.GetPeriod(rs.Spec.Git.Period, configsync.DefaultReconcilerPollingPeriod),
-			proxy:           rs.Spec.Proxy,
-			depth:           rs.Spec.SafeOverride().GitSyncDepth,
-			noSSLVerify:     rs.Spec.Git.NoSSLVerify,
-			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef),
-			knownHost:       r.isKnownHostsEnabled(rs.Spec.Git.Auth),
+			ref:                 rs.Spec.Git.Revision,
+			branch:              rs.Spec.Git.Branch,
+			repo:                rs.Spec.Git.Repo,
+			secretType:          rs.Spec.Git.Auth,
+			period:              v1beta1.GetPeriod(rs.Spec.Git.Period, configsync.DefaultReconcilerPollingPeriod),
+			proxy:               rs.Spec.Proxy,
+			noProxy:             rs.Spec.Git.NoProxy,
+			depth:               rs.Spec.SafeOverride().GitSyncDepth,
+			syncTimeout:         rs.Spec.SafeOverride().GitSyncTimeout,
+			noSSLVerify:         rs.Spec.Git.NoSSLVerify,
+			caCertSecretRef:     v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef),
+			clientCertSecretRef: v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef),
+			knownHost:           r.isKnownHostsEnabled(rs.Spec.Git.Auth) || knownHostsConfigMapName(rs.Spec.Git) != "",
 		})
 		if enableAskpassSidecar(rs.Spec.SourceType, rs.Spec.Git.Auth) {
 			result[reconcilermanager.GCENodeAskpassSidecar] = gceNodeAskPassSidecarEnvs(rs.Spec.GCPServiceAccountEmail)
 		}
 	case v1beta1.OciSource:
 		result[reconcilermanager.OciSync] = ociSyncEnvs(ociOptions{
-			image:           rs.Spec.Oci.Image,
-			auth:            rs.Spec.Oci.Auth,
-			period:          v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
-			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			image:               rs.Spec.Oci.Image,
+			auth:                rs.Spec.Oci.Auth,
+			period:              v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			caCertSecretRef:     v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			clientCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef),
+			proxy:               rs.Spec.Oci.Proxy,
+			noProxy:             rs.Spec.Oci.NoProxy,
 		})
 	case v1beta1.HelmSource:
 		result[reconcilermanager.HelmSync] = helmSyncEnvs(helmOptions{
-			helmBase:         &rs.Spec.Helm.HelmBase,
-			releaseNamespace: rs.Spec.Helm.Namespace,
-			deployNamespace:  rs.Spec.Helm.DeployNamespace,
-			caCertSecretRef:  v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			helmBase:            &rs.Spec.Helm.HelmBase,
+			releaseNamespace:    rs.Spec.Helm.Namespace,
+			deployNamespace:     rs.Spec.Helm.DeployNamespace,
+			caCertSecretRef:     v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			clientCertSecretRef: v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef),
 		})
 	}
 	return result
@@ -826,6 +989,34 @@ func (r *RootSyncReconciler) validateRootSync(ctx context.Context, rs *v1beta1.R
 		return err
 	}
 
+	if err := validateContainerResourceLimits(rs.Spec.SafeOverride().Resources); err != nil {
+		return err
+	}
+
+	if err := validateFieldManager(rs.Spec.SafeOverride().FieldManager); err != nil {
+		return err
+	}
+
+	if err := validateRequiredMetadata(rs.Spec.SafeOverride().RequiredMetadata); err != nil {
+		return err
+	}
+
+	if err := validateObjectSelector(rs.Spec.SafeOverride().ObjectSelector); err != nil {
+		return err
+	}
+
+	if err := validateContainerImages(rs.Spec.SafeOverride().OverrideSpec); err != nil {
+		return err
+	}
+
+	if err := validateImagePullSecrets(rs.Spec.SafeOverride().ImagePullSecrets); err != nil {
+		return err
+	}
+
+	if err := validatePostSyncVerification(rs.Spec.SafeOverride().PostSyncVerification); err != nil {
+		return err
+	}
+
 	return r.validateValuesFileSourcesRefs(ctx, rs)
 }
 
@@ -864,7 +1055,10 @@ func (r *RootSyncReconciler) validateOciSpec(ctx context.Context, rs *v1beta1.Ro
 	if err := validate.OciSpec(rs.Spec.Oci, rs); err != nil {
 		return err
 	}
-	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef))
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateClientCertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef))
 }
 
 func (r *RootSyncReconciler) validateHelmSpec(ctx context.Context, rs *v1beta1.RootSync) error {
@@ -876,7 +1070,10 @@ func (r *RootSyncReconciler) validateHelmSpec(ctx context.Context, rs *v1beta1.R
 			return err
 		}
 	}
-	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef))
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateClientCertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef))
 }
 
 func (r *RootSyncReconciler) validateGitSpec(ctx context.Context, rs *v1beta1.RootSync, reconcilerName string) error {
@@ -886,6 +1083,12 @@ func (r *RootSyncReconciler) validateGitSpec(ctx context.Context, rs *v1beta1.Ro
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef)); err != nil {
 		return err
 	}
+	if err := r.validateClientCertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef)); err != nil {
+		return err
+	}
+	if err := validate.KnownHostsConfigMapRef(ctx, r.client, rs, rs.Spec.Git.KnownHostsConfigMapRef); err != nil {
+		return err
+	}
 	return r.validateRootSecret(ctx, rs, reconcilerName)
 }
 
@@ -1107,10 +1310,15 @@ func (r *RootSyncReconciler) updateSyncStatus(ctx context.Context, rs *v1beta1.R
 		err := updateFn(syncObj)
 		syncObj.Status.Reconciler = reconcilerRef.Name
 		syncObj.Status.ObservedGeneration = syncObj.Generation
+		if message, found := r.statusContention(ctx, syncObj); found {
+			rootsync.SetStatusContention(syncObj, "ConflictStorm", message)
+		} else {
+			rootsync.RemoveCondition(syncObj, v1beta1.RootSyncStatusContention)
+		}
 		return err
 	}
 
-	updated, err := mutate.Status(ctx, r.client, rs, func() error {
+	updated, err := mutate.StatusWithConflictDetector(ctx, r.client, r.statusConflicts, rs, func() error {
 		before := rs.DeepCopy()
 		if err := updateFn2(rs); err != nil {
 			return err
@@ -1178,21 +1386,25 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 		var gcpSAEmail string
 		var secretRefName string
 		var caCertSecretRefName string
+		var clientCertSecretRefName string
 		switch v1beta1.SourceType(rs.Spec.SourceType) {
 		case v1beta1.GitSource:
 			auth = rs.Spec.Auth
 			gcpSAEmail = rs.Spec.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef)
+			clientCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef)
 		case v1beta1.OciSource:
 			auth = rs.Spec.Oci.Auth
 			gcpSAEmail = rs.Spec.Oci.GCPServiceAccountEmail
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)
+			clientCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef)
 		case v1beta1.HelmSource:
 			auth = rs.Spec.Helm.Auth
 			gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)
+			clientCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef)
 		}
 		injectFWICreds := useFWIAuth(auth, r.membership)
 		if injectFWICreds {
@@ -1201,6 +1413,18 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 				return nil
 			}
 			core.SetAnnotation(&d.Spec.Template, metadata.FleetWorkloadIdentityCredentials, creds)
+			// Record the Membership generation used to build the credentials,
+			// so that the pods are rolled out whenever the Membership changes.
+			core.SetAnnotation(&d.Spec.Template, metadata.FleetMembershipGeneration, fmt.Sprint(r.membership.Generation))
+		}
+
+		// Scale the reconciler down while syncing is suspended.
+		// Otherwise, keep the replicas from the Deployment manifest, unless
+		// overridden.
+		if rs.Spec.Suspend {
+			d.Spec.Replicas = pointer.Int32(0)
+		} else if replicas := rs.Spec.SafeOverride().Replicas; replicas != nil {
+			d.Spec.Replicas = pointer.Int32(*replicas)
 		}
 
 		// Add sync-generation label
@@ -1218,11 +1442,15 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 		// Update DeprecatedServiceAccount to avoid discrepancy in equality check.
 		templateSpec.DeprecatedServiceAccount = reconcilerName
 
+		// Reference the image pull Secrets, if any. The Secrets are neither
+		// copied nor managed by the reconciler-manager.
+		templateSpec.ImagePullSecrets = rs.Spec.SafeOverride().ImagePullSecrets
+
 		// Mutate secret.secretname to secret reference specified in RootSync CR.
 		// Secret reference is the name of the secret used by git-sync or helm-sync container to
 		// authenticate with the git or helm repository using the authorization method specified
 		// in the RootSync CR.
-		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, auth, secretRefName, caCertSecretRefName, rs.Spec.SourceType, r.membership)
+		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, auth, secretRefName, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, r.membership)
 
 		autopilot, err := r.isAutopilot()
 		if err != nil {
@@ -1262,7 +1490,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 					addContainer = false
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
-					container.VolumeMounts = volumeMounts(rs.Spec.Oci.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.VolumeMounts = volumeMounts(rs.Spec.Oci.Auth, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
 			case reconcilermanager.HelmSync:
@@ -1271,7 +1499,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 					addContainer = false
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
-					container.VolumeMounts = volumeMounts(rs.Spec.Helm.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.VolumeMounts = volumeMounts(rs.Spec.Helm.Auth, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
 					if authTypeToken(rs.Spec.Helm.Auth) {
 						container.Env = append(container.Env, helmSyncTokenAuthEnv(secretRefName)...)
 					}
@@ -1285,7 +1513,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
 					// Don't mount git-creds volume if auth is 'none' or 'gcenode'.
-					container.VolumeMounts = volumeMounts(rs.Spec.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.VolumeMounts = volumeMounts(rs.Spec.Auth, caCertSecretRefName, clientCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
 					// Update Environment variables for `token` Auth, which
 					// passes the credentials as the Username and Password.
 					secretName := v1beta1.GetSecretName(rs.Spec.SecretRef)
@@ -1295,6 +1523,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 					sRef := client.ObjectKey{Namespace: rs.Namespace, Name: secretName}
 					keys := GetSecretKeys(ctx, r.client, sRef)
 					container.Env = append(container.Env, gitSyncHTTPSProxyEnv(secretName, keys)...)
+					mountKnownHostsConfigMap(templateSpec, &container, r.getReconcilerKnownHostsConfigMapRef(rs))
 				}
 			case reconcilermanager.GCENodeAskpassSidecar:
 				if !enableAskpassSidecar(rs.Spec.SourceType, auth) {
@@ -1312,6 +1541,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 			if addContainer {
 				// Common mutations for all containers
 				mutateContainerResource(&container, containerResources)
+				mutateContainerImage(&container, rs.Spec.SafeOverride().OverrideSpec)
 				if err := mutateContainerLogLevel(&container, containerLogLevels); err != nil {
 					return err
 				}
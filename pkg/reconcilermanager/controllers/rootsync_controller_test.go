@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,9 +28,12 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -50,11 +54,14 @@ import (
 	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/fake"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/mutate"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
+	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -203,6 +210,7 @@ func setupRootReconciler(t *testing.T, objs ...client.Object) (*syncerFake.Clien
 		testCluster,
 		filesystemPollingPeriod,
 		hydrationPollingPeriod,
+		mutate.NewConflictDetector(DefaultStatusConflictThreshold, DefaultStatusConflictWindow),
 		cs.Client,
 		cs.Client,
 		cs.DynamicClient,
@@ -287,6 +295,145 @@ func rootsyncOverrideAPIServerTimeout(apiServerTimout metav1.Duration) func(*v1b
 	}
 }
 
+func rootsyncOverrideDynamicNamespaceSelector(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().DynamicNamespaceSelector = &enabled
+	}
+}
+
+func rootsyncOverrideClusterScopedConflictCheck(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ClusterScopedConflictCheck = &enabled
+	}
+}
+
+func rootsyncOverrideGitSyncTimeout(timeout time.Duration) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().GitSyncTimeout = &metav1.Duration{Duration: timeout}
+	}
+}
+
+func rootsyncOverrideDeferUnestablishedCRs(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().DeferUnestablishedCRs = &enabled
+	}
+}
+
+func rootsyncOverridePauseApply(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().PauseApply = &enabled
+	}
+}
+
+func rootsyncOverrideReplicas(replicas int32) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().Replicas = &replicas
+	}
+}
+
+func rootsyncOverridePostSyncVerification(template batchv1.JobTemplateSpec) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().PostSyncVerification = &v1beta1.PostSyncVerification{JobTemplate: template}
+	}
+}
+
+func rootsyncOverrideAmbiguousSourceFormat(policy configsync.AmbiguousSourceFormatPolicy) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().AmbiguousSourceFormat = policy
+	}
+}
+
+func rootsyncOverrideNamespaceMismatchPolicy(policy configsync.NamespaceMismatchPolicy) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().NamespaceMismatchPolicy = policy
+	}
+}
+
+func rootsyncMode(mode configsync.SyncMode) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.Mode = mode
+	}
+}
+
+func rootsyncOverrideConflictPolicy(policy configsync.ConflictPolicy) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ConflictPolicy = policy
+	}
+}
+
+func rootsyncOverrideFieldManager(fieldManager string) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().FieldManager = fieldManager
+	}
+}
+
+func rootsyncOverrideResyncPeriod(resyncPeriod metav1.Duration) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ResyncPeriod = &resyncPeriod
+	}
+}
+
+func rootsyncOverrideStatusUpdatePeriod(statusUpdatePeriod metav1.Duration) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().StatusUpdatePeriod = &statusUpdatePeriod
+	}
+}
+
+func rootsyncOverrideRenderingTimeout(renderingTimeout metav1.Duration) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().RenderingTimeout = &renderingTimeout
+	}
+}
+
+func rootsyncOverrideCircuitBreaker(threshold int32, probePeriod metav1.Duration) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().CircuitBreakerThreshold = &threshold
+		rs.Spec.SafeOverride().CircuitBreakerProbePeriod = &probePeriod
+	}
+}
+
+func rootsyncOverrideAnnotateSyncGeneration(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().AnnotateSyncGeneration = &enabled
+	}
+}
+
+func rootsyncOverrideValidateWithAdmission(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ValidateWithAdmission = &enabled
+	}
+}
+
+func rootsyncOverrideRemediatorExcludedKinds(kinds ...v1beta1.RemediatorExcludedKind) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().RemediatorExcludedKinds = kinds
+	}
+}
+
+func rootsyncOverrideObjectSelector(selector *metav1.LabelSelector) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ObjectSelector = selector
+	}
+}
+
+func rootsyncOverrideSubstituteClusterName(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().SubstituteClusterName = &enabled
+	}
+}
+
+func rootsyncOverrideApplyDebouncePeriod(period time.Duration) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ApplyDebouncePeriod = &metav1.Duration{Duration: period}
+	}
+}
+
+func rootsyncOverrideRequiredMetadata(required ...v1beta1.RequiredMetadata) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().RequiredMetadata = required
+	}
+}
+
 func rootsyncOverrideRoleRefs(roleRefs ...v1beta1.RootSyncRoleRef) func(*v1beta1.RootSync) {
 	return func(rs *v1beta1.RootSync) {
 		rs.Spec.SafeOverride().RoleRefs = roleRefs
@@ -1128,6 +1275,67 @@ func TestRootSyncReconcileWithInvalidCACertSecret(t *testing.T) {
 	}
 }
 
+func TestRootSyncReconcileWithOverrideResourceLimits(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	testCases := map[string]struct {
+		resources []v1beta1.ContainerResourcesSpec
+		wantErr   error
+	}{
+		"cpuLimit below the minimum": {
+			resources: []v1beta1.ContainerResourcesSpec{{
+				ContainerName: reconcilermanager.Reconciler,
+				CPULimit:      resource.MustParse("10m"),
+			}},
+			wantErr: fmt.Errorf("spec.override.resources: cpuLimit 10m of the %q container is below the minimum of 50m", reconcilermanager.Reconciler),
+		},
+		"memoryLimit below the minimum": {
+			resources: []v1beta1.ContainerResourcesSpec{{
+				ContainerName: reconcilermanager.GitSync,
+				MemoryLimit:   resource.MustParse("8Mi"),
+			}},
+			wantErr: fmt.Errorf("spec.override.resources: memoryLimit 8Mi of the %q container is below the minimum of 16Mi", reconcilermanager.GitSync),
+		},
+		"limits at or above the minimum": {
+			resources: []v1beta1.ContainerResourcesSpec{
+				{
+					ContainerName: reconcilermanager.Reconciler,
+					CPULimit:      resource.MustParse("50m"),
+					MemoryLimit:   resource.MustParse("1Gi"),
+				},
+				{
+					ContainerName: reconcilermanager.GitSync,
+					CPULimit:      resource.MustParse("1"),
+					MemoryLimit:   resource.MustParse("16Mi"),
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+				rootsyncSecretType(configsync.AuthNone), rootsyncOverrideResources(tc.resources))
+			reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+			fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+
+			ctx := context.Background()
+			if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+				t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+			}
+
+			wantRs := fake.RootSyncObjectV1Beta1(rootsyncName)
+			if tc.wantErr != nil {
+				rootsync.SetStalled(wantRs, "Validation", tc.wantErr)
+			} else {
+				rootsync.SetReconciling(wantRs, "Deployment",
+					fmt.Sprintf("Deployment (config-management-system/%s) InProgress: Replicas: 0/1", rootReconcilerName))
+			}
+			validateRootSyncStatus(t, wantRs, fakeClient)
+		})
+	}
+}
+
 func TestRootSyncValidateCACertSecret(t *testing.T) {
 	caCertSecret := "foo-secret"
 	testCases := map[string]struct {
@@ -2885,6 +3093,7 @@ func TestInjectFleetWorkloadIdentityCredentialsToRootSync(t *testing.T) {
 	rootDeployment = rootSyncDeployment(rootReconcilerName,
 		setAnnotations(map[string]string{
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/config-sync@cs-project.iam.gserviceaccount.com:generateAccessToken","subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(rootReconcilerName),
 		fleetWorkloadIdentityMutator(workloadIdentityPool),
@@ -2977,6 +3186,86 @@ func TestInjectFleetWorkloadIdentityCredentialsToRootSync(t *testing.T) {
 	t.Log("Deployment successfully updated")
 }
 
+func TestRootSyncRefreshFleetWorkloadIdentityCredentials(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch), rootsyncSecretType(configsync.AuthGCPServiceAccount), rootsyncGCPSAEmail(gcpSAEmail))
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	membership := &hubv1.Membership{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       fleetMembershipName,
+			Generation: 1,
+		},
+		Spec: hubv1.MembershipSpec{
+			WorkloadIdentityPool: "test-gke-dev.svc.id.goog",
+			IdentityProvider:     "https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/cluster-1",
+		},
+	}
+	fakeClient, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs, membership)
+	mapMembership := testReconciler.mapMembershipToRootSyncs()
+	ctx := context.Background()
+
+	validateCreds := func(wantGeneration int64, wantProvider string) {
+		t.Helper()
+		uObj, err := fakeDynamicClient.Resource(kinds.DeploymentResource()).
+			Namespace(configsync.ControllerNamespace).
+			Get(ctx, rootReconcilerName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get the reconciler Deployment: %v", err)
+		}
+		obj, err := kinds.ToTypedObject(uObj, core.Scheme)
+		if err != nil {
+			t.Fatalf("failed to convert the reconciler Deployment: %v", err)
+		}
+		annotations := obj.(*appsv1.Deployment).Spec.Template.Annotations
+		if got, want := annotations[metadata.FleetMembershipGeneration], fmt.Sprint(wantGeneration); got != want {
+			t.Errorf("got Fleet Membership generation %q, want %q", got, want)
+		}
+		if creds := annotations[metadata.FleetWorkloadIdentityCredentials]; !strings.Contains(creds, wantProvider) {
+			t.Errorf("got Fleet Workload Identity credentials %q, want credentials for identity provider %q", creds, wantProvider)
+		}
+		gotRs := &v1beta1.RootSync{}
+		if err := fakeClient.Get(ctx, reqNamespacedName.NamespacedName, gotRs); err != nil {
+			t.Fatalf("failed to get the RootSync: %v", err)
+		}
+		wantStatus := &v1beta1.FleetWorkloadIdentityStatus{
+			MembershipGeneration: wantGeneration,
+			WorkloadIdentityPool: membership.Spec.WorkloadIdentityPool,
+			IdentityProvider:     wantProvider,
+		}
+		if diff := cmp.Diff(wantStatus, gotRs.Status.FleetWorkloadIdentity); diff != "" {
+			t.Errorf("unexpected status.fleetWorkloadIdentity diff (- want, + got):\n%s", diff)
+		}
+	}
+
+	if reqs := mapMembership(membership.DeepCopy()); len(reqs) != 1 {
+		t.Fatalf("got %d reconcile requests for a new Membership, want 1", len(reqs))
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	validateCreds(1, membership.Spec.IdentityProvider)
+
+	// An event for an unchanged Membership does not trigger a refresh.
+	if reqs := mapMembership(membership.DeepCopy()); len(reqs) != 0 {
+		t.Errorf("got %d reconcile requests for an unchanged Membership, want 0", len(reqs))
+	}
+
+	// Updating the Membership refreshes the credentials.
+	membership.Spec.IdentityProvider = "https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/cluster-2"
+	if err := fakeClient.Update(ctx, membership); err != nil {
+		t.Fatalf("failed to update the Membership: %v", err)
+	}
+	if reqs := mapMembership(membership.DeepCopy()); len(reqs) != 1 {
+		t.Fatalf("got %d reconcile requests for an updated Membership, want 1", len(reqs))
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	validateCreds(membership.Generation, membership.Spec.IdentityProvider)
+}
+
 func TestRootSyncWithHelm(t *testing.T) {
 	// Mock out parseDeployment for testing.
 	parseDeployment = helmParsedDeployment
@@ -3131,6 +3420,7 @@ func TestRootSyncWithHelm(t *testing.T) {
 	rootDeployment = rootSyncDeployment(rootReconcilerName,
 		setAnnotations(map[string]string{
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/config-sync@cs-project.iam.gserviceaccount.com:generateAccessToken","subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(rootReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.HelmSync),
@@ -3170,6 +3460,7 @@ func TestRootSyncWithHelm(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(rootReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.HelmSync),
@@ -3217,6 +3508,7 @@ func TestRootSyncWithHelm(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(rootReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.HelmSync),
@@ -3384,6 +3676,7 @@ func TestRootSyncWithOCI(t *testing.T) {
 	rootDeployment = rootSyncDeployment(rootReconcilerName,
 		setAnnotations(map[string]string{
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/config-sync@cs-project.iam.gserviceaccount.com:generateAccessToken","subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(rootReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.OciSync),
@@ -3423,6 +3716,7 @@ func TestRootSyncWithOCI(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		setServiceAccountName(rootReconcilerName),
 		fwiMutator(workloadIdentityPool, reconcilermanager.OciSync),
@@ -3472,6 +3766,7 @@ func TestRootSyncWithOCI(t *testing.T) {
 		setAnnotations(map[string]string{
 			// `service_account_impersonation_url` is removed from the annotation,
 			metadata.FleetWorkloadIdentityCredentials: `{"audience":"identitynamespace:test-gke-dev.svc.id.goog:https://container.googleapis.com/v1/projects/test-gke-dev/locations/us-central1-c/clusters/fleet-workload-identity-test-cluster","credential_source":{"file":"/var/run/secrets/tokens/gcp-ksa/token"},"subject_token_type":"urn:ietf:params:oauth:token-type:jwt","token_url":"https://sts.googleapis.com/v1/token","type":"external_account"}`,
+			metadata.FleetMembershipGeneration:        "0",
 		}),
 		fwiMutator(workloadIdentityPool, reconcilermanager.OciSync),
 		containerResourcesMutator(resourceOverrides),
@@ -3684,67 +3979,432 @@ func TestRootSyncSpecValidation(t *testing.T) {
 	validateRootSyncStatus(t, wantRs, fakeClient)
 }
 
-func TestRootSyncReconcileStaleClientCache(t *testing.T) {
-	rs := fake.RootSyncObjectV1Beta1(rootsyncName)
+func TestRootSyncPostSyncVerification(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	jobTemplate := batchv1.JobTemplateSpec{
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    "smoke-test",
+						Image:   "busybox",
+						Command: []string{"/bin/sh", "-c", "exit 1"},
+					}},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone), rootsyncOverridePostSyncVerification(jobTemplate))
 	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
 	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+
 	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
 
-	rs.ResourceVersion = "1"
-	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
-	err := fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
-	require.NoError(t, err, "unexpected Get error")
-	oldRS := rs.DeepCopy()
+	// Simulate Deployment becoming Available
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	replicas := *deployment.Spec.Replicas
+	deployment.Status.Replicas = replicas
+	deployment.Status.UpdatedReplicas = replicas
+	deployment.Status.ReadyReplicas = replicas
+	deployment.Status.AvailableReplicas = replicas
+	deployment.Status.Conditions = append(deployment.Status.Conditions,
+		*newDeploymentCondition(appsv1.DeploymentAvailable, corev1.ConditionTrue, "unused", "unused"),
+		*newDeploymentCondition(appsv1.DeploymentProgressing, corev1.ConditionTrue, "NewReplicaSetAvailable", "unused"),
+	)
+	if err := fakeClient.Status().Update(ctx, deployment); err != nil {
+		t.Fatalf("failed to update the reconciler deployment status: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
 
-	// Reconcile should succeed and update the RootSync
-	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
-	require.NoError(t, err, "unexpected Reconcile error")
+	// No Job is created before a commit is synced
+	jobKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: postSyncVerificationJobName(rootReconcilerName)}
+	job := &batchv1.Job{}
+	if err := fakeClient.Get(ctx, jobKey, job); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the post-sync verification job to not exist, got error: %v", err)
+	}
 
-	// Expect Stalled condition with True status, because the RootSync is invalid
-	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
-	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
-	require.NoError(t, err, "unexpected Get error")
-	reconcilingCondition := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
-	require.NotNilf(t, reconcilingCondition, "status: %+v", rs.Status)
-	require.Equal(t, reconcilingCondition.Status, metav1.ConditionTrue, "unexpected Stalled condition status")
-	require.Contains(t, reconcilingCondition.Message, "KNV1061: RootSyncs must specify spec.sourceType", "unexpected Stalled condition message")
+	// Simulate the reconciler syncing a commit
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	rootsync.SetSyncing(rs, false, "Sync", "Sync Completed", "abc123", nil, nil, metav1.Now())
+	if err := fakeClient.Status().Update(ctx, rs); err != nil {
+		t.Fatalf("failed to update the root sync status: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
 
-	// Simulate stale cache (rollback to previous resource version)
-	err = fakeClient.Storage().TestPut(oldRS)
-	require.NoError(t, err)
+	// The Job is created for the synced commit
+	if err := fakeClient.Get(ctx, jobKey, job); err != nil {
+		t.Fatalf("failed to get the post-sync verification job: %v", err)
+	}
+	require.Equal(t, "abc123", core.GetAnnotation(job, metadata.PostSyncVerificationCommit))
+	require.Equal(t, jobTemplate.Spec.Template.Spec.Containers, job.Spec.Template.Spec.Containers)
 
-	// Expect next Reconcile to succeed but NOT update the RootSync
-	// This means the client cache hasn't been updated and isn't returning the latest version.
-	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
-	require.NoError(t, err, "unexpected Reconcile error")
+	// Simulate the Job failing
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  "BackoffLimitExceeded",
+		Message: "Job has reached the specified backoff limit",
+	})
+	if err := fakeClient.Status().Update(ctx, job); err != nil {
+		t.Fatalf("failed to update the post-sync verification job status: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
 
-	// Simulate cache update from watch event (roll forward to the latest resource version)
-	err = fakeClient.Storage().TestPut(rs)
-	require.NoError(t, err)
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	cond := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncPostSyncVerificationFailed)
+	require.NotNil(t, cond, "expected a PostSyncVerificationFailed condition")
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, "JobFailed", cond.Reason)
+	require.Equal(t, "abc123", cond.Commit)
+	require.Equal(t, fmt.Sprintf("Job (%s) failed: Job has reached the specified backoff limit", jobKey), cond.Message)
+}
 
-	// Reconcile should succeed but NOT update the RootSync
-	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
-	require.NoError(t, err, "unexpected Reconcile error")
+func TestRootSyncPostSyncVerificationServiceAccount(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
 
-	// Expect the same Stalled condition error message
-	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
-	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
-	require.NoError(t, err, "unexpected Get error")
-	reconcilingCondition = rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
-	require.NotNilf(t, reconcilingCondition, "status: %+v", rs.Status)
-	require.Equal(t, reconcilingCondition.Status, metav1.ConditionTrue, "unexpected Stalled condition status")
-	require.Contains(t, reconcilingCondition.Message, "KNV1061: RootSyncs must specify spec.sourceType", "unexpected Stalled condition message")
+	jobTemplate := batchv1.JobTemplateSpec{
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					// The ServiceAccount of the reconciler has broader permissions.
+					ServiceAccountName: rootReconcilerName,
+					Containers: []corev1.Container{{
+						Name:  "smoke-test",
+						Image: "busybox",
+					}},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone), rootsyncOverridePostSyncVerification(jobTemplate))
+	rootsync.SetSyncing(rs, false, "Sync", "Sync Completed", "abc123", nil, nil, metav1.Now())
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
 
-	// Simulate a spec update, with ResourceVersion updated by the apiserver
-	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
-	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
-	require.NoError(t, err, "unexpected Get error")
-	rs.Spec.SourceType = string(v1beta1.GitSource)
-	err = fakeClient.Update(ctx, rs)
-	require.NoError(t, err, "unexpected Update error")
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
 
-	// Reconcile should succeed and update the RootSync
-	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	cond := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
+	require.NotNil(t, cond, "expected a Stalled condition")
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, "Validation", cond.Reason)
+	require.Contains(t, cond.Message, "spec.override.postSyncVerification.jobTemplate.spec.template.spec.serviceAccountName: must not be set")
+
+	// No Job runs as the ServiceAccount of the reconciler
+	jobKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: postSyncVerificationJobName(rootReconcilerName)}
+	if err := fakeClient.Get(ctx, jobKey, &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the post-sync verification job to not exist, got error: %v", err)
+	}
+}
+
+func TestRootSyncSuspend(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone))
+	rs.Spec.Suspend = true
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	// The reconciler is scaled down while suspended
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	cond := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncSuspended)
+	require.NotNil(t, cond, "expected a Suspended condition")
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+
+	// The reconciler is scaled back up when resumed
+	rs.Spec.Suspend = false
+	if err := fakeClient.Update(ctx, rs); err != nil {
+		t.Fatalf("failed to update the root sync: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, reconcilerDeploymentReplicaCount, *deployment.Spec.Replicas)
+
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	require.Nil(t, rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncSuspended))
+}
+
+func TestRootSyncDeleteSuspended(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone))
+	rs.Spec.Suspend = true
+	// The reconciler finalizer is added by the reconciler when deletion
+	// propagation is enabled.
+	controllerutil.AddFinalizer(rs, metadata.ReconcilerFinalizer)
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	// The reconciler is scaled down while suspended
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	if err := fakeClient.Delete(ctx, rs); err != nil {
+		t.Fatalf("failed to delete the root sync: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	// The reconciler is scaled back up to run its finalizer
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, reconcilerDeploymentReplicaCount, *deployment.Spec.Replicas)
+
+	// Simulate the reconciler finalizer completing
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	controllerutil.RemoveFinalizer(rs, metadata.ReconcilerFinalizer)
+	if err := fakeClient.Update(ctx, rs); err != nil {
+		t.Fatalf("failed to update the root sync: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	// The RootSync and its reconciler are deleted
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs)
+	require.True(t, apierrors.IsNotFound(err), "expected the root sync to be deleted, got error: %v", err)
+	err = fakeClient.Get(ctx, deploymentKey, deployment)
+	require.True(t, apierrors.IsNotFound(err), "expected the reconciler deployment to be deleted, got error: %v", err)
+}
+
+func TestRootSyncOverrideGitSyncImage(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	const gitSyncImage = "registry.example.com/mirror/git-sync:pinned"
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone))
+	rs.Spec.SafeOverride().GitSyncImage = gitSyncImage
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	var found bool
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == reconcilermanager.GitSync {
+			found = true
+			require.Equal(t, gitSyncImage, container.Image)
+		} else {
+			require.NotEqual(t, gitSyncImage, container.Image)
+		}
+	}
+	require.True(t, found, "expected a git-sync container")
+}
+
+func TestRootSyncOverrideReplicas(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone), rootsyncOverrideReplicas(3))
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	deploymentKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, int32(3), *deployment.Spec.Replicas)
+
+	// The reconciler may only manage its own Lease, in its own namespace.
+	rbacKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: rootReconcilerName + "-leader-election"}
+	role := &rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(ctx, rbacKey, role))
+	require.Equal(t, []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"coordination.k8s.io"},
+			Resources:     []string{"leases"},
+			ResourceNames: []string{rootReconcilerName},
+			Verbs:         []string{"get", "update"},
+		},
+		{
+			APIGroups: []string{"coordination.k8s.io"},
+			Resources: []string{"leases"},
+			Verbs:     []string{"create"},
+		},
+	}, role.Rules)
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, fakeClient.Get(ctx, rbacKey, rb))
+	require.Equal(t, rolereference(rbacKey.Name, "Role"), rb.RoleRef)
+	require.Equal(t, []rbacv1.Subject{newSubject(rootReconcilerName, configsync.ControllerNamespace, "ServiceAccount")}, rb.Subjects)
+
+	// Suspending takes precedence over the replicas override
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	rs.Spec.Suspend = true
+	if err := fakeClient.Update(ctx, rs); err != nil {
+		t.Fatalf("failed to update the root sync: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	if err := fakeClient.Get(ctx, deploymentKey, deployment); err != nil {
+		t.Fatalf("failed to get the reconciler deployment: %v", err)
+	}
+	require.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	// Leader election RBAC is removed with a single replica.
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+	rs.Spec.Suspend = false
+	rs.Spec.SafeOverride().Replicas = pointer.Int32(1)
+	if err := fakeClient.Update(ctx, rs); err != nil {
+		t.Fatalf("failed to update the root sync: %v", err)
+	}
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	require.True(t, apierrors.IsNotFound(fakeClient.Get(ctx, rbacKey, &rbacv1.Role{})))
+	require.True(t, apierrors.IsNotFound(fakeClient.Get(ctx, rbacKey, &rbacv1.RoleBinding{})))
+}
+
+func TestRootSyncReconcileStaleClientCache(t *testing.T) {
+	rs := fake.RootSyncObjectV1Beta1(rootsyncName)
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+	ctx := context.Background()
+
+	rs.ResourceVersion = "1"
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err := fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+	oldRS := rs.DeepCopy()
+
+	// Reconcile should succeed and update the RootSync
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err, "unexpected Reconcile error")
+
+	// Expect Stalled condition with True status, because the RootSync is invalid
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+	reconcilingCondition := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
+	require.NotNilf(t, reconcilingCondition, "status: %+v", rs.Status)
+	require.Equal(t, reconcilingCondition.Status, metav1.ConditionTrue, "unexpected Stalled condition status")
+	require.Contains(t, reconcilingCondition.Message, "KNV1061: RootSyncs must specify spec.sourceType", "unexpected Stalled condition message")
+
+	// Simulate stale cache (rollback to previous resource version)
+	err = fakeClient.Storage().TestPut(oldRS)
+	require.NoError(t, err)
+
+	// Expect next Reconcile to succeed but NOT update the RootSync
+	// This means the client cache hasn't been updated and isn't returning the latest version.
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err, "unexpected Reconcile error")
+
+	// Simulate cache update from watch event (roll forward to the latest resource version)
+	err = fakeClient.Storage().TestPut(rs)
+	require.NoError(t, err)
+
+	// Reconcile should succeed but NOT update the RootSync
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
+	require.NoError(t, err, "unexpected Reconcile error")
+
+	// Expect the same Stalled condition error message
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+	reconcilingCondition = rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
+	require.NotNilf(t, reconcilingCondition, "status: %+v", rs.Status)
+	require.Equal(t, reconcilingCondition.Status, metav1.ConditionTrue, "unexpected Stalled condition status")
+	require.Contains(t, reconcilingCondition.Message, "KNV1061: RootSyncs must specify spec.sourceType", "unexpected Stalled condition message")
+
+	// Simulate a spec update, with ResourceVersion updated by the apiserver
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+	rs.Spec.SourceType = string(v1beta1.GitSource)
+	err = fakeClient.Update(ctx, rs)
+	require.NoError(t, err, "unexpected Update error")
+
+	// Reconcile should succeed and update the RootSync
+	_, err = testReconciler.Reconcile(ctx, reqNamespacedName)
 	require.NoError(t, err, "unexpected Reconcile error")
 
 	// Expect Stalled condition with True status, because the RootSync is differently invalid
@@ -3757,6 +4417,73 @@ func TestRootSyncReconcileStaleClientCache(t *testing.T) {
 	require.Contains(t, reconcilingCondition.Message, "RootSyncs must specify spec.git when spec.sourceType is \"git\"", "unexpected Stalled condition message")
 }
 
+func TestRootSyncStatusConflictStorm(t *testing.T) {
+	rs := fake.RootSyncObjectV1Beta1(rootsyncName)
+	fakeClient, _, testReconciler := setupRootReconciler(t, rs)
+	testReconciler.statusConflicts = mutate.NewConflictDetector(3, time.Minute)
+	// Simulate another actor repeatedly writing the RootSync status
+	conflictingClient := &statusConflictClient{Client: fakeClient, conflicts: 3}
+	testReconciler.client = conflictingClient
+	ctx := context.Background()
+
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err := fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+
+	// The status update succeeds after the conflicts, and reports the contention
+	reconcilerRef := core.RootReconcilerObjectKey(rs.Name)
+	updated, err := testReconciler.updateSyncStatus(ctx, rs, reconcilerRef, func(*v1beta1.RootSync) error {
+		return nil
+	})
+	require.NoError(t, err, "unexpected status update error")
+	require.True(t, updated)
+	require.Equal(t, 0, conflictingClient.conflicts)
+
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+	cond := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStatusContention)
+	require.NotNilf(t, cond, "status: %+v", rs.Status)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Contains(t, cond.Message, "Updating the RootSync status conflicted at least 3 times within 1m0s")
+
+	// The condition is removed once the conflicts are outside the window
+	testReconciler.statusConflicts.Window = 0
+	_, err = testReconciler.updateSyncStatus(ctx, rs, reconcilerRef, func(*v1beta1.RootSync) error {
+		return nil
+	})
+	require.NoError(t, err, "unexpected status update error")
+	rs = fake.RootSyncObjectV1Beta1(rootsyncName)
+	err = fakeClient.Get(ctx, core.ObjectNamespacedName(rs), rs)
+	require.NoError(t, err, "unexpected Get error")
+	require.Nil(t, rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStatusContention))
+}
+
+// statusConflictClient is a client which fails the specified number of status
+// updates with a ResourceVersion conflict.
+type statusConflictClient struct {
+	client.Client
+	conflicts int
+}
+
+func (c *statusConflictClient) Status() client.SubResourceWriter {
+	return &statusConflictWriter{SubResourceWriter: c.Client.Status(), client: c}
+}
+
+type statusConflictWriter struct {
+	client.SubResourceWriter
+	client *statusConflictClient
+}
+
+func (w *statusConflictWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if w.client.conflicts > 0 {
+		w.client.conflicts--
+		return apierrors.NewConflict(kinds.RootSyncResource().GroupResource(), obj.GetName(),
+			errors.New("the object has been modified"))
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
 func TestPopulateRootContainerEnvs(t *testing.T) {
 	defaults := map[string]map[string]string{
 		reconcilermanager.HydrationController: {
@@ -3779,6 +4506,10 @@ func TestPopulateRootContainerEnvs(t *testing.T) {
 			reconcilermanager.SourceTypeKey:           string(gitSource),
 			filesystem.SourceFormatKey:                "",
 			reconcilermanager.NamespaceStrategy:       string(configsync.NamespaceStrategyImplicit),
+			reconcilermanager.AmbiguousSourceFormat:   string(configsync.AmbiguousSourceFormatWarn),
+			reconcilermanager.NamespaceMismatchPolicy: string(configsync.NamespaceMismatchError),
+			reconcilermanager.ConflictPolicy:          string(configsync.ConflictPolicyAdoptAll),
+			reconcilermanager.SyncMode:                string(configsync.SyncModeSync),
 			reconcilermanager.StatusMode:              "enabled",
 			reconcilermanager.SourceBranchKey:         "master",
 			reconcilermanager.SourceRevKey:            "HEAD",
@@ -3827,6 +4558,7 @@ func TestPopulateRootContainerEnvs(t *testing.T) {
 	testCases := []struct {
 		name     string
 		rootSync *v1beta1.RootSync
+		objs     []client.Object
 		expected map[string][]corev1.EnvVar
 	}{
 		{
@@ -3865,13 +4597,278 @@ func TestPopulateRootContainerEnvs(t *testing.T) {
 				reconcilermanager.Reconciler: {reconcilermanager.DynamicNSSelectorEnabled: "true"},
 			}),
 		},
+		{
+			name: "dynamicNamespaceSelector override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideDynamicNamespaceSelector(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.DynamicNamespaceSelector: "true"},
+			}),
+		},
+		{
+			name: "clusterScopedConflictCheck override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideClusterScopedConflictCheck(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ClusterScopedConflictCheck: "true"},
+			}),
+		},
+		{
+			name: "ambiguousSourceFormat override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideAmbiguousSourceFormat(configsync.AmbiguousSourceFormatError),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.AmbiguousSourceFormat: string(configsync.AmbiguousSourceFormatError)},
+			}),
+		},
+		{
+			name: "fieldManager override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideFieldManager("team-a-sync"),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.FieldManager: "team-a-sync"},
+			}),
+		},
+		{
+			name: "resyncPeriod override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideResyncPeriod(metav1.Duration{Duration: 10 * time.Minute}),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ResyncPeriod: "10m0s"},
+			}),
+		},
+		{
+			name: "statusUpdatePeriod override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideStatusUpdatePeriod(metav1.Duration{Duration: 30 * time.Second}),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.StatusUpdatePeriod: "30s"},
+			}),
+		},
+		{
+			name: "renderingTimeout override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideRenderingTimeout(metav1.Duration{Duration: 10 * time.Minute}),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.RenderingTimeout: "10m0s"},
+			}),
+		},
+		{
+			name: "circuitBreaker overrides set env vars",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideCircuitBreaker(3, metav1.Duration{Duration: time.Minute}),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {
+					reconcilermanager.CircuitBreakerThreshold:   "3",
+					reconcilermanager.CircuitBreakerProbePeriod: "1m0s",
+				},
+			}),
+		},
+		{
+			name: "requiredMetadata override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideRequiredMetadata(
+					v1beta1.RequiredMetadata{Key: "cost-center", Type: configsync.RequiredMetadataLabel},
+					v1beta1.RequiredMetadata{Key: "example.com/owner", Type: configsync.RequiredMetadataAnnotation},
+				),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.RequiredMetadata: "label:cost-center,annotation:example.com/owner"},
+			}),
+		},
+		{
+			name: "annotateSyncGeneration override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideAnnotateSyncGeneration(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.AnnotateSyncGeneration: "true"},
+			}),
+		},
+		{
+			name: "validateWithAdmission override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideValidateWithAdmission(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ValidateWithAdmission: "true"},
+			}),
+		},
+		{
+			name: "remediatorExcludedKinds override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideRemediatorExcludedKinds(
+					v1beta1.RemediatorExcludedKind{Kind: "ConfigMap"},
+					v1beta1.RemediatorExcludedKind{Group: "example.com", Kind: "Event"},
+				),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.RemediatorExcludedKinds: "ConfigMap,Event.example.com"},
+			}),
+		},
+		{
+			name: "objectSelector override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideObjectSelector(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"cluster": "prod"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"us", "eu"}},
+					},
+				}),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ObjectSelector: "cluster=prod,region in (eu,us)"},
+			}),
+		},
+		{
+			name: "substituteClusterName override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideSubstituteClusterName(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.SubstituteClusterName: "true"},
+			}),
+		},
+		{
+			name: "applyDebouncePeriod override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideApplyDebouncePeriod(30*time.Second),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ApplyDebouncePeriod: "30s"},
+			}),
+		},
+		{
+			name: "namespaceMismatchPolicy override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideNamespaceMismatchPolicy(configsync.NamespaceMismatchCorrect),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.NamespaceMismatchPolicy: string(configsync.NamespaceMismatchCorrect)},
+			}),
+		},
+		{
+			name: "conflictPolicy override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideConflictPolicy(configsync.ConflictPolicyAdoptIfNoInventory),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ConflictPolicy: string(configsync.ConflictPolicyAdoptIfNoInventory)},
+			}),
+		},
+		{
+			name: "observe mode sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncMode(configsync.SyncModeObserve),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.SyncMode: string(configsync.SyncModeObserve)},
+			}),
+		},
+		{
+			name: "gitSyncTimeout override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideGitSyncTimeout(5*time.Minute),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.GitSync: {gitSyncTimeout: "5m0s"},
+			}),
+		},
+		{
+			name: "deferUnestablishedCRs override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideDeferUnestablishedCRs(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.DeferUnestablishedCRs: "true"},
+			}),
+		},
+		{
+			name: "pauseApply override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverridePauseApply(true),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.PauseApply: "true"},
+			}),
+		},
+		{
+			name: "pauseApply override set to false omits env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverridePauseApply(false),
+			),
+			expected: createEnv(map[string]map[string]string{}),
+		},
+		{
+			name: "replicas override above one enables leader election",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideReplicas(3),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.LeaderElection: "true"},
+			}),
+		},
+		{
+			name: "replicas override of one omits env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+				rootsyncOverrideReplicas(1),
+			),
+			expected: createEnv(map[string]map[string]string{}),
+		},
+		{
+			name: "admission webhook configuration sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRenderingRequired(false),
+			),
+			objs: []client.Object{
+				&admissionv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: webhookconfiguration.Name},
+				},
+			},
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.WebhookEnabled: "true"},
+			}),
+		},
 	}
 
 	ctx := context.Background()
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, _, testReconciler := setupRootReconciler(t, tc.rootSync, secretObj(t, reposyncSSHKey, configsync.AuthSSH, v1beta1.GitSource, core.Namespace(tc.rootSync.Namespace)))
+			objs := append([]client.Object{tc.rootSync, secretObj(t, reposyncSSHKey, configsync.AuthSSH, v1beta1.GitSource, core.Namespace(tc.rootSync.Namespace))}, tc.objs...)
+			_, _, testReconciler := setupRootReconciler(t, objs...)
 
 			env := testReconciler.populateContainerEnvs(ctx, tc.rootSync, rootReconcilerName)
 
@@ -3884,6 +4881,45 @@ func TestPopulateRootContainerEnvs(t *testing.T) {
 	}
 }
 
+func TestMapWebhookConfigurationToRootSyncs(t *testing.T) {
+	rs1 := rootSyncWithGit(rootsyncName)
+	rs2 := rootSyncWithGit("other-root-sync")
+	_, _, testReconciler := setupRootReconciler(t, rs1, rs2)
+
+	webhookConfig := &admissionv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: webhookconfiguration.Name},
+	}
+	otherConfig := &admissionv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-webhook"},
+	}
+
+	want := []reconcile.Request{
+		{NamespacedName: client.ObjectKeyFromObject(rs1)},
+		{NamespacedName: client.ObjectKeyFromObject(rs2)},
+	}
+	got := testReconciler.mapWebhookConfigurationToRootSyncs(webhookConfig)
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b reconcile.Request) bool { return a.Name < b.Name })); diff != "" {
+		t.Errorf("unexpected requests for the admission webhook configuration; diff: %s", diff)
+	}
+	if got := testReconciler.mapWebhookConfigurationToRootSyncs(otherConfig); len(got) != 0 {
+		t.Errorf("got requests %v for another webhook configuration, want none", got)
+	}
+
+	p := webhookConfigurationPredicate()
+	if !p.Create(event.CreateEvent{Object: webhookConfig}) {
+		t.Error("got create of the admission webhook configuration filtered, want it enqueued")
+	}
+	if !p.Delete(event.DeleteEvent{Object: webhookConfig}) {
+		t.Error("got delete of the admission webhook configuration filtered, want it enqueued")
+	}
+	if p.Update(event.UpdateEvent{ObjectOld: webhookConfig, ObjectNew: webhookConfig}) {
+		t.Error("got update of the admission webhook configuration enqueued, want it filtered")
+	}
+	if p.Create(event.CreateEvent{Object: otherConfig}) {
+		t.Error("got create of another webhook configuration enqueued, want it filtered")
+	}
+}
+
 func TestUpdateRootReconcilerLogLevelWithOverride(t *testing.T) {
 	// Mock out parseDeployment for testing.
 	parseDeployment = parsedDeployment
@@ -4144,6 +5180,172 @@ func TestRootReconcilerWithoutKnownHosts(t *testing.T) {
 	require.Equal(t, false, isKnownHosts)
 }
 
+func TestRootReconcilerKnownHostsConfigMap(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	knownHostsCM := "known-hosts"
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch), rootsyncSecretType(GitSecretConfigKeySSH), rootsyncSecretRef(rootsyncSSHKey))
+	rs.Spec.Git.KnownHostsConfigMapRef = &v1beta1.KnownHostsConfigMapRef{Name: knownHostsCM, DataKey: "hosts"}
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: knownHostsCM, Namespace: rs.Namespace},
+		Data:       map[string]string{"hosts": "github.com ssh-ed25519 AAAA"},
+	}
+	_, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs, cm, secretObj(t, rootsyncSSHKey, configsync.AuthSSH, v1beta1.GitSource, core.Namespace(rs.Namespace)))
+
+	// The ConfigMap must exist and have the data key.
+	ctx := context.Background()
+	require.NoError(t, testReconciler.validateGitSpec(ctx, rs, rootReconcilerName))
+	invalid := rs.DeepCopy()
+	invalid.Spec.Git.KnownHostsConfigMapRef.DataKey = "missing"
+	require.Error(t, testReconciler.validateGitSpec(ctx, invalid, rootReconcilerName))
+
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	uObj, err := fakeDynamicClient.Resource(kinds.DeploymentResource()).
+		Namespace(configsync.ControllerNamespace).Get(ctx, rootReconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	obj, err := kinds.ToTypedObject(uObj, core.Scheme)
+	require.NoError(t, err)
+	assertKnownHostsConfigMapMounted(t, obj.(*appsv1.Deployment), knownHostsCM, "hosts")
+}
+
+// assertKnownHostsConfigMapMounted asserts that the git-sync container of the
+// Deployment reads the known_hosts from the data key of the ConfigMap.
+func assertKnownHostsConfigMapMounted(t *testing.T, d *appsv1.Deployment, cmName, dataKey string) {
+	t.Helper()
+	var volume *corev1.Volume
+	for i, v := range d.Spec.Template.Spec.Volumes {
+		if v.Name == KnownHostsVolume {
+			volume = &d.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	require.NotNil(t, volume, "missing %s volume", KnownHostsVolume)
+	require.NotNil(t, volume.ConfigMap)
+	require.Equal(t, cmName, volume.ConfigMap.Name)
+	require.Equal(t, []corev1.KeyToPath{{Key: dataKey, Path: KnownHostsKey}}, volume.ConfigMap.Items)
+
+	var gitSync *corev1.Container
+	for i, c := range d.Spec.Template.Spec.Containers {
+		if c.Name == reconcilermanager.GitSync {
+			gitSync = &d.Spec.Template.Spec.Containers[i]
+		}
+	}
+	require.NotNil(t, gitSync, "missing %s container", reconcilermanager.GitSync)
+	require.Contains(t, gitSync.VolumeMounts, corev1.VolumeMount{Name: KnownHostsVolume, MountPath: KnownHostsPath, ReadOnly: true})
+	require.Contains(t, gitSync.Env, corev1.EnvVar{Name: GitSyncKnownHosts, Value: "true"})
+	require.Contains(t, gitSync.Env, corev1.EnvVar{Name: GitSyncKnownHostsFile, Value: KnownHostsPath + "/" + KnownHostsKey})
+}
+
+func TestRootSyncValidateClientCertSecret(t *testing.T) {
+	clientCertSecret := "client-cert"
+	testCases := map[string]struct {
+		objs []client.Object
+		err  string
+	}{
+		"clientCertSecretRef set but missing Secret": {
+			err: fmt.Sprintf("Secret %s not found, create one to allow client connections with client certificate", clientCertSecret),
+		},
+		"clientCertSecretRef set but missing cert": {
+			objs: []client.Object{
+				fake.SecretObject(clientCertSecret, core.Namespace(configsync.ControllerNamespace)),
+			},
+			err: fmt.Sprintf("clientCertSecretRef was set, but %s key is not present in %s Secret", ClientCertSecretCertKey, clientCertSecret),
+		},
+		"clientCertSecretRef set but missing key": {
+			objs: []client.Object{
+				clientCertSecretObj(clientCertSecret, configsync.ControllerNamespace, ClientCertSecretCertKey),
+			},
+			err: fmt.Sprintf("clientCertSecretRef was set, but %s key is not present in %s Secret", ClientCertSecretKeyKey, clientCertSecret),
+		},
+		"clientCertSecretRef set with valid Secret": {
+			objs: []client.Object{
+				clientCertSecretObj(clientCertSecret, configsync.ControllerNamespace, ClientCertSecretCertKey, ClientCertSecretKeyKey),
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, testReconciler := setupRootReconciler(t, tc.objs...)
+			ctx := context.Background()
+
+			err := testReconciler.validateClientCertSecret(ctx, configsync.ControllerNamespace, clientCertSecret)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRootReconcilerClientCertSecret(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	clientCertSecret := "client-cert"
+	rs := rootSyncWithOCI(rootsyncName, rootsyncOCIAuthType(configsync.AuthNone))
+	rs.Spec.Oci.ClientCertSecretRef = &v1beta1.SecretReference{Name: clientCertSecret}
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	_, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs,
+		clientCertSecretObj(clientCertSecret, rs.Namespace, ClientCertSecretCertKey, ClientCertSecretKeyKey))
+
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	uObj, err := fakeDynamicClient.Resource(kinds.DeploymentResource()).
+		Namespace(configsync.ControllerNamespace).Get(ctx, rootReconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	obj, err := kinds.ToTypedObject(uObj, core.Scheme)
+	require.NoError(t, err)
+	assertClientCertSecretMounted(t, obj.(*appsv1.Deployment), reconcilermanager.OciSync, clientCertSecret,
+		reconcilermanager.OciClientCert, reconcilermanager.OciClientKey)
+}
+
+func clientCertSecretObj(name, namespace string, keys ...string) *corev1.Secret {
+	secret := fake.SecretObject(name, core.Namespace(namespace))
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = map[string][]byte{}
+	for _, key := range keys {
+		secret.Data[key] = []byte("test-data")
+	}
+	return secret
+}
+
+// assertClientCertSecretMounted asserts that the sync container of the
+// Deployment reads the client certificate and key from the Secret.
+func assertClientCertSecretMounted(t *testing.T, d *appsv1.Deployment, containerName, secretName, certEnv, keyEnv string) {
+	t.Helper()
+	var volume *corev1.Volume
+	for i, v := range d.Spec.Template.Spec.Volumes {
+		if v.Name == ClientCertVolume {
+			volume = &d.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	require.NotNil(t, volume, "missing %s volume", ClientCertVolume)
+	require.NotNil(t, volume.Secret)
+	require.Equal(t, secretName, volume.Secret.SecretName)
+	require.Equal(t, []corev1.KeyToPath{
+		{Key: ClientCertSecretCertKey, Path: ClientCertSecretCertKey},
+		{Key: ClientCertSecretKeyKey, Path: ClientCertSecretKeyKey},
+	}, volume.Secret.Items)
+	require.Equal(t, &clientCertMode, volume.Secret.DefaultMode)
+
+	var container *corev1.Container
+	for i, c := range d.Spec.Template.Spec.Containers {
+		if c.Name == containerName {
+			container = &d.Spec.Template.Spec.Containers[i]
+		}
+	}
+	require.NotNil(t, container, "missing %s container", containerName)
+	require.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: ClientCertVolume, MountPath: ClientCertPath, ReadOnly: true})
+	require.Contains(t, container.Env, corev1.EnvVar{Name: certEnv, Value: ClientCertPath + "/" + ClientCertSecretCertKey})
+	require.Contains(t, container.Env, corev1.EnvVar{Name: keyEnv, Value: ClientCertPath + "/" + ClientCertSecretKeyKey})
+}
+
 func validateRootSyncStatus(t *testing.T, want *v1beta1.RootSync, fakeClient *syncerFake.Client) {
 	t.Helper()
 
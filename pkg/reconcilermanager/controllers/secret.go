@@ -35,6 +35,9 @@ func isUpsertedSecret(rs *v1beta1.RepoSync, secretName string) bool {
 	if name, ok := getCACertName(rs); ok && useCACert(name) && secretName == ReconcilerResourceName(reconcilerName, name) {
 		return true
 	}
+	if name, ok := getClientCertName(rs); ok && useClientCert(name) && secretName == ReconcilerResourceName(reconcilerName, name) {
+		return true
+	}
 	if shouldUpsertGitSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.Git.SecretRef)) {
 		return true
 	}
@@ -66,6 +69,28 @@ func getCACertName(rs *v1beta1.RepoSync) (string, bool) {
 	}
 }
 
+func getClientCertName(rs *v1beta1.RepoSync) (string, bool) {
+	switch v1beta1.SourceType(rs.Spec.SourceType) {
+	case v1beta1.GitSource:
+		if rs.Spec.Git == nil || rs.Spec.Git.ClientCertSecretRef == nil {
+			return "", false
+		}
+		return v1beta1.GetSecretName(rs.Spec.Git.ClientCertSecretRef), true
+	case v1beta1.OciSource:
+		if rs.Spec.Oci == nil || rs.Spec.Oci.ClientCertSecretRef == nil {
+			return "", false
+		}
+		return v1beta1.GetSecretName(rs.Spec.Oci.ClientCertSecretRef), true
+	case v1beta1.HelmSource:
+		if rs.Spec.Helm == nil || rs.Spec.Helm.ClientCertSecretRef == nil {
+			return "", false
+		}
+		return v1beta1.GetSecretName(rs.Spec.Helm.ClientCertSecretRef), true
+	default:
+		return "", false
+	}
+}
+
 func shouldUpsertGitSecret(rs *v1beta1.RepoSync) bool {
 	return v1beta1.SourceType(rs.Spec.SourceType) == v1beta1.GitSource && rs.Spec.Git != nil && rs.Spec.Git.SecretRef != nil && !SkipForAuth(rs.Spec.Auth)
 }
@@ -120,6 +145,24 @@ func (r *reconcilerBase) upsertCACertSecret(ctx context.Context, rs *v1beta1.Rep
 	return client.ObjectKey{}, nil
 }
 
+// upsertClientCertSecret creates or updates the client cert secret in the
+// config-management-system namespace using an existing secret in the RepoSync
+// namespace.
+func (r *reconcilerBase) upsertClientCertSecret(ctx context.Context, rs *v1beta1.RepoSync, reconcilerRef types.NamespacedName, labelMap map[string]string) (client.ObjectKey, error) {
+	rsRef := client.ObjectKeyFromObject(rs)
+	if secretName, ok := getClientCertName(rs); ok && useClientCert(secretName) {
+		nsSecretRef, cmsSecretRef := getSecretRefs(rsRef, reconcilerRef, secretName)
+		userSecret, err := getUserSecret(ctx, r.client, nsSecretRef)
+		if err != nil {
+			return cmsSecretRef, errors.Wrap(err, "user secret required for client cert authentication")
+		}
+		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
+		return cmsSecretRef, err
+	}
+	// No secret required
+	return client.ObjectKey{}, nil
+}
+
 func getSecretRefs(rsRef, reconcilerRef client.ObjectKey, secretName string) (nsSecretRef, cmsSecretRef client.ObjectKey) {
 	// User managed secret
 	nsSecretRef = client.ObjectKey{
@@ -17,10 +17,12 @@ package controllers
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -31,17 +33,104 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
 )
 
+// fieldManagerRegex matches the field manager names which are safe to use
+// with server-side apply.
+var fieldManagerRegex = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9_.:]*[a-zA-Z0-9])?$`)
+
+// maxFieldManagerLength is the maximum length of a field manager name
+// accepted by the API server.
+const maxFieldManagerLength = 128
+
 // updateHydrationControllerImage sets the image of hydration-controller based
-// on whether enableShellInRendering is set.
+// on whether enableShellInRendering is set, unless hydrationControllerImage is
+// set.
 func updateHydrationControllerImage(image string, overrides v1beta1.OverrideSpec) string {
+	if overrides.HydrationControllerImage != "" {
+		return overrides.HydrationControllerImage
+	}
 	if overrides.EnableShellInRendering == nil || !*overrides.EnableShellInRendering {
 		return strings.ReplaceAll(image, reconcilermanager.HydrationControllerWithShell, reconcilermanager.HydrationController)
 	}
 	return strings.ReplaceAll(image, reconcilermanager.HydrationController+":", reconcilermanager.HydrationControllerWithShell+":")
 }
 
+// mutateContainerImage sets the image of the git-sync, oci-sync or helm-sync
+// container if it is overridden.
+func mutateContainerImage(container *corev1.Container, overrides v1beta1.OverrideSpec) {
+	var image string
+	switch container.Name {
+	case reconcilermanager.GitSync:
+		image = overrides.GitSyncImage
+	case reconcilermanager.OciSync:
+		image = overrides.OciSyncImage
+	case reconcilermanager.HelmSync:
+		image = overrides.HelmSyncImage
+	}
+	if image != "" {
+		container.Image = image
+	}
+}
+
+// containerImageOverride is an image override of a reconciler container.
+type containerImageOverride struct {
+	field string
+	image string
+}
+
+// containerImageOverrides returns the image overrides of a RootSync or
+// RepoSync.
+func containerImageOverrides(overrides v1beta1.OverrideSpec) []containerImageOverride {
+	return []containerImageOverride{
+		{field: "hydrationControllerImage", image: overrides.HydrationControllerImage},
+		{field: "gitSyncImage", image: overrides.GitSyncImage},
+		{field: "ociSyncImage", image: overrides.OciSyncImage},
+		{field: "helmSyncImage", image: overrides.HelmSyncImage},
+	}
+}
+
+// validateContainerImages validates the image overrides of a RootSync.
+func validateContainerImages(overrides v1beta1.OverrideSpec) error {
+	for _, i := range containerImageOverrides(overrides) {
+		if i.image == "" {
+			continue
+		}
+		if _, err := name.ParseReference(i.image); err != nil {
+			return fmt.Errorf("spec.override.%s: invalid image %q: %w", i.field, i.image, err)
+		}
+	}
+	return nil
+}
+
+// validateNoContainerImages rejects the image overrides of a RepoSync. The
+// reconciler of a RepoSync runs in the config-management-system namespace, so
+// only cluster admins may choose its images, through a RootSync.
+func validateNoContainerImages(overrides v1beta1.OverrideSpec) error {
+	for _, i := range containerImageOverrides(overrides) {
+		if i.image != "" {
+			return fmt.Errorf("spec.override.%s: image overrides are only supported for RootSync", i.field)
+		}
+	}
+	return nil
+}
+
+// validateImagePullSecrets validates the spec.override.imagePullSecrets of a
+// RootSync or RepoSync.
+func validateImagePullSecrets(secrets []corev1.LocalObjectReference) error {
+	for i, secret := range secrets {
+		if errs := validation.IsDNS1123Subdomain(secret.Name); len(errs) > 0 {
+			return fmt.Errorf("spec.override.imagePullSecrets[%d].name: invalid Secret name %q: %s",
+				i, secret.Name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
 type hydrationOptions struct {
 	sourceType     string
 	gitConfig      *v1beta1.Git
@@ -94,21 +183,45 @@ func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 }
 
 type reconcilerOptions struct {
-	clusterName              string
-	syncName                 string
-	syncGeneration           int64
-	reconcilerName           string
-	reconcilerScope          declared.Scope
-	sourceType               string
-	gitConfig                *v1beta1.Git
-	ociConfig                *v1beta1.Oci
-	helmConfig               *v1beta1.HelmBase
-	pollPeriod               string
-	statusMode               string
-	reconcileTimeout         string
-	apiServerTimeout         string
-	requiresRendering        bool
-	dynamicNSSelectorEnabled bool
+	clusterName                string
+	syncName                   string
+	syncGeneration             int64
+	reconcilerName             string
+	reconcilerScope            declared.Scope
+	sourceType                 string
+	gitConfig                  *v1beta1.Git
+	ociConfig                  *v1beta1.Oci
+	helmConfig                 *v1beta1.HelmBase
+	pollPeriod                 string
+	statusMode                 string
+	reconcileTimeout           string
+	apiServerTimeout           string
+	clientQPS                  int32
+	clientBurst                int32
+	minRemediationInterval     *metav1.Duration
+	applyDebouncePeriod        *metav1.Duration
+	applyCallTimeout           *metav1.Duration
+	resyncPeriod               *metav1.Duration
+	statusUpdatePeriod         *metav1.Duration
+	renderingTimeout           *metav1.Duration
+	fieldManager               string
+	requiredMetadata           []v1beta1.RequiredMetadata
+	annotateSyncGeneration     bool
+	pauseApply                 bool
+	maxPruneCount              int32
+	circuitBreakerThreshold    int32
+	circuitBreakerProbePeriod  *metav1.Duration
+	validateWithAdmission      bool
+	remediatorExcludedKinds    []v1beta1.RemediatorExcludedKind
+	objectSelector             *metav1.LabelSelector
+	substituteClusterName      bool
+	leaderElection             bool
+	deferUnestablishedCRs      bool
+	requiresRendering          bool
+	dynamicNSSelectorEnabled   bool
+	dynamicNamespaceSelector   bool
+	clusterScopedConflictCheck bool
+	webhookEnabled             bool
 }
 
 // reconcilerEnvs returns environment variables for namespace reconciler.
@@ -122,6 +235,8 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 	var syncBranch string
 	var syncRevision string
 	var syncDir string
+	var syncDirs []string
+	var dirsCollisionPolicy configsync.DirsCollisionPolicy
 	switch v1beta1.SourceType(opts.sourceType) {
 	case v1beta1.OciSource:
 		syncRepo = opts.ociConfig.Image
@@ -137,6 +252,8 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 	case v1beta1.GitSource:
 		syncRepo = opts.gitConfig.Repo
 		syncDir = opts.gitConfig.Dir
+		syncDirs = opts.gitConfig.Dirs
+		dirsCollisionPolicy = opts.gitConfig.DirsCollisionPolicy
 		if opts.gitConfig.Branch != "" {
 			syncBranch = opts.gitConfig.Branch
 		} else {
@@ -209,6 +326,211 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		},
 	)
 
+	if len(syncDirs) > 0 {
+		if dirsCollisionPolicy == "" {
+			dirsCollisionPolicy = configsync.DirsCollisionOverride
+		}
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.SyncDirsKey,
+				Value: strings.Join(syncDirs, ","),
+			},
+			corev1.EnvVar{
+				Name:  reconcilermanager.DirsCollisionPolicy,
+				Value: string(dirsCollisionPolicy),
+			},
+		)
+	}
+
+	if opts.minRemediationInterval != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.MinRemediationInterval,
+				Value: opts.minRemediationInterval.Duration.String(),
+			},
+		)
+	}
+
+	if opts.applyDebouncePeriod != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ApplyDebouncePeriod,
+				Value: opts.applyDebouncePeriod.Duration.String(),
+			},
+		)
+	}
+
+	if opts.applyCallTimeout != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ApplyCallTimeout,
+				Value: opts.applyCallTimeout.Duration.String(),
+			},
+		)
+	}
+
+	if opts.resyncPeriod != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ResyncPeriod,
+				Value: opts.resyncPeriod.Duration.String(),
+			},
+		)
+	}
+
+	if opts.statusUpdatePeriod != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.StatusUpdatePeriod,
+				Value: opts.statusUpdatePeriod.Duration.String(),
+			},
+		)
+	}
+
+	if opts.renderingTimeout != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.RenderingTimeout,
+				Value: opts.renderingTimeout.Duration.String(),
+			},
+		)
+	}
+
+	if opts.fieldManager != "" {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.FieldManager,
+				Value: opts.fieldManager,
+			},
+		)
+	}
+
+	if len(opts.requiredMetadata) > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.RequiredMetadata,
+				Value: requiredMetadataEnvValue(opts.requiredMetadata),
+			},
+		)
+	}
+
+	if opts.annotateSyncGeneration {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.AnnotateSyncGeneration,
+				Value: strconv.FormatBool(opts.annotateSyncGeneration),
+			},
+		)
+	}
+
+	if opts.pauseApply {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.PauseApply,
+				Value: strconv.FormatBool(opts.pauseApply),
+			},
+		)
+	}
+
+	if opts.clientQPS > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ClientQPS,
+				Value: strconv.Itoa(int(opts.clientQPS)),
+			},
+		)
+	}
+
+	if opts.clientBurst > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ClientBurst,
+				Value: strconv.Itoa(int(opts.clientBurst)),
+			},
+		)
+	}
+
+	if opts.maxPruneCount > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.MaxPruneCount,
+				Value: strconv.Itoa(int(opts.maxPruneCount)),
+			},
+		)
+	}
+
+	if opts.circuitBreakerThreshold > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.CircuitBreakerThreshold,
+				Value: strconv.Itoa(int(opts.circuitBreakerThreshold)),
+			},
+		)
+	}
+
+	if opts.circuitBreakerProbePeriod != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.CircuitBreakerProbePeriod,
+				Value: opts.circuitBreakerProbePeriod.Duration.String(),
+			},
+		)
+	}
+
+	if opts.validateWithAdmission {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ValidateWithAdmission,
+				Value: strconv.FormatBool(opts.validateWithAdmission),
+			},
+		)
+	}
+
+	if len(opts.remediatorExcludedKinds) > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.RemediatorExcludedKinds,
+				Value: remediatorExcludedKindsEnvValue(opts.remediatorExcludedKinds),
+			},
+		)
+	}
+
+	if opts.objectSelector != nil {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ObjectSelector,
+				Value: objectSelectorEnvValue(opts.objectSelector),
+			},
+		)
+	}
+
+	if opts.substituteClusterName {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.SubstituteClusterName,
+				Value: strconv.FormatBool(opts.substituteClusterName),
+			},
+		)
+	}
+
+	if opts.leaderElection {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.LeaderElection,
+				Value: strconv.FormatBool(opts.leaderElection),
+			},
+		)
+	}
+
+	if opts.deferUnestablishedCRs {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.DeferUnestablishedCRs,
+				Value: strconv.FormatBool(opts.deferUnestablishedCRs),
+			},
+		)
+	}
+
 	if opts.dynamicNSSelectorEnabled {
 		result = append(result,
 			corev1.EnvVar{
@@ -218,6 +540,33 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		)
 	}
 
+	if opts.webhookEnabled {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.WebhookEnabled,
+				Value: strconv.FormatBool(opts.webhookEnabled),
+			},
+		)
+	}
+
+	if opts.dynamicNamespaceSelector {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.DynamicNamespaceSelector,
+				Value: strconv.FormatBool(opts.dynamicNamespaceSelector),
+			},
+		)
+	}
+
+	if opts.clusterScopedConflictCheck {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ClusterScopedConflictCheck,
+				Value: strconv.FormatBool(opts.clusterScopedConflictCheck),
+			},
+		)
+	}
+
 	if syncBranch != "" {
 		result = append(result, corev1.EnvVar{
 			Name:  reconcilermanager.SourceBranchKey,
@@ -252,11 +601,92 @@ func namespaceStrategyEnv(strategy configsync.NamespaceStrategy) corev1.EnvVar {
 	}
 }
 
+// leaderElectionEnabled returns whether the reconciler uses leader election,
+// which is needed when it runs more than one replica.
+func leaderElectionEnabled(replicas *int32) bool {
+	return pointer.Int32Deref(replicas, 1) > 1
+}
+
+// ambiguousSourceFormatEnv returns the environment variable for AMBIGUOUS_SOURCE_FORMAT in the reconciler container.
+func ambiguousSourceFormatEnv(policy configsync.AmbiguousSourceFormatPolicy) corev1.EnvVar {
+	if policy == "" {
+		policy = configsync.AmbiguousSourceFormatWarn
+	}
+	return corev1.EnvVar{
+		Name:  reconcilermanager.AmbiguousSourceFormat,
+		Value: string(policy),
+	}
+}
+
+// namespaceMismatchPolicyEnv returns the environment variable for NAMESPACE_MISMATCH_POLICY in the reconciler container.
+func namespaceMismatchPolicyEnv(policy configsync.NamespaceMismatchPolicy) corev1.EnvVar {
+	if policy == "" {
+		policy = configsync.NamespaceMismatchError
+	}
+	return corev1.EnvVar{
+		Name:  reconcilermanager.NamespaceMismatchPolicy,
+		Value: string(policy),
+	}
+}
+
+// conflictPolicyEnv returns the environment variable for CONFLICT_POLICY in the reconciler container.
+func conflictPolicyEnv(policy configsync.ConflictPolicy) corev1.EnvVar {
+	if policy == "" {
+		policy = configsync.ConflictPolicyAdoptAll
+	}
+	return corev1.EnvVar{
+		Name:  reconcilermanager.ConflictPolicy,
+		Value: string(policy),
+	}
+}
+
+// syncModeEnv returns the environment variable for SYNC_MODE in the reconciler container.
+func syncModeEnv(mode configsync.SyncMode) corev1.EnvVar {
+	if mode == "" {
+		mode = configsync.SyncModeSync
+	}
+	return corev1.EnvVar{
+		Name:  reconcilermanager.SyncMode,
+		Value: string(mode),
+	}
+}
+
 type ociOptions struct {
-	image           string
-	auth            configsync.AuthType
-	period          float64
-	caCertSecretRef string
+	image               string
+	auth                configsync.AuthType
+	period              float64
+	caCertSecretRef     string
+	clientCertSecretRef string
+	proxy               string
+	noProxy             string
+}
+
+const (
+	// httpsProxyEnv is the environment variable used by oci-sync and
+	// helm-sync to route registry requests through an HTTPS proxy.
+	httpsProxyEnv = "HTTPS_PROXY"
+	// noProxyEnv is the environment variable listing the hosts that should
+	// bypass the proxy.
+	noProxyEnv = "NO_PROXY"
+)
+
+// proxyEnvs returns the proxy environment variables for the oci-sync and
+// helm-sync containers. NO_PROXY is only set along with HTTPS_PROXY.
+func proxyEnvs(proxy, noProxy string) []corev1.EnvVar {
+	if proxy == "" {
+		return nil
+	}
+	result := []corev1.EnvVar{{
+		Name:  httpsProxyEnv,
+		Value: proxy,
+	}}
+	if noProxy != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  noProxyEnv,
+			Value: noProxy,
+		})
+	}
+	return result
 }
 
 // ociSyncEnvs returns the environment variables for the oci-sync container.
@@ -278,6 +708,16 @@ func ociSyncEnvs(opts ociOptions) []corev1.EnvVar {
 			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
 		})
 	}
+	if useClientCert(opts.clientCertSecretRef) {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.OciClientCert,
+			Value: fmt.Sprintf("%s/%s", ClientCertPath, ClientCertSecretCertKey),
+		}, corev1.EnvVar{
+			Name:  reconcilermanager.OciClientKey,
+			Value: fmt.Sprintf("%s/%s", ClientCertPath, ClientCertSecretKeyKey),
+		})
+	}
+	result = append(result, proxyEnvs(opts.proxy, opts.noProxy)...)
 	return result
 }
 
@@ -288,10 +728,11 @@ const (
 )
 
 type helmOptions struct {
-	helmBase         *v1beta1.HelmBase
-	releaseNamespace string
-	deployNamespace  string
-	caCertSecretRef  string
+	helmBase            *v1beta1.HelmBase
+	releaseNamespace    string
+	deployNamespace     string
+	caCertSecretRef     string
+	clientCertSecretRef string
 }
 
 // helmSyncEnvs returns the environment variables for the helm-sync container.
@@ -332,12 +773,34 @@ func helmSyncEnvs(opts helmOptions) []corev1.EnvVar {
 		Name:  reconcilermanager.HelmSyncWait,
 		Value: fmt.Sprintf("%f", v1beta1.GetPeriod(opts.helmBase.Period, configsync.DefaultHelmSyncVersionPollingPeriod).Seconds()),
 	})
+	if len(opts.helmBase.APIVersions) > 0 {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmAPIVersions,
+			Value: strings.Join(opts.helmBase.APIVersions, ","),
+		})
+	}
+	if opts.helmBase.KubeVersion != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmKubeVersion,
+			Value: opts.helmBase.KubeVersion,
+		})
+	}
 	if useCACert(opts.caCertSecretRef) {
 		result = append(result, corev1.EnvVar{
 			Name:  reconcilermanager.HelmCACert,
 			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
 		})
 	}
+	if useClientCert(opts.clientCertSecretRef) {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmClientCert,
+			Value: fmt.Sprintf("%s/%s", ClientCertPath, ClientCertSecretCertKey),
+		}, corev1.EnvVar{
+			Name:  reconcilermanager.HelmClientKey,
+			Value: fmt.Sprintf("%s/%s", ClientCertPath, ClientCertSecretKeyKey),
+		})
+	}
+	result = append(result, proxyEnvs(opts.helmBase.Proxy, opts.helmBase.NoProxy)...)
 	return result
 }
 
@@ -399,3 +862,98 @@ func useFWIAuth(authType configsync.AuthType, membership *hubv1.Membership) bool
 		membership.Spec.IdentityProvider != "" &&
 		membership.Spec.WorkloadIdentityPool != ""
 }
+
+// fleetWorkloadIdentityStatus returns the status of the Fleet Workload Identity
+// credentials injected into the reconciler, or nil if ConfigSync does not use
+// fleet workload identity for authentication.
+func fleetWorkloadIdentityStatus(authType configsync.AuthType, membership *hubv1.Membership) *v1beta1.FleetWorkloadIdentityStatus {
+	if !useFWIAuth(authType, membership) {
+		return nil
+	}
+	return &v1beta1.FleetWorkloadIdentityStatus{
+		MembershipGeneration: membership.Generation,
+		WorkloadIdentityPool: membership.Spec.WorkloadIdentityPool,
+		IdentityProvider:     membership.Spec.IdentityProvider,
+	}
+}
+
+// validateFieldManager validates the spec.override.fieldManager of a RootSync
+// or RepoSync. An empty field manager uses the default.
+func validateFieldManager(fieldManager string) error {
+	if fieldManager == "" {
+		return nil
+	}
+	if len(fieldManager) > maxFieldManagerLength || !fieldManagerRegex.MatchString(fieldManager) {
+		return fmt.Errorf("spec.override.fieldManager: invalid field manager %q: must be no more than %d characters, "+
+			"consist of alphanumeric characters, '-', '_', '.' or ':', and start and end with an alphanumeric character",
+			fieldManager, maxFieldManagerLength)
+	}
+	return nil
+}
+
+// requiredMetadataEnvValue formats the required labels and annotations as a
+// comma-separated list of `<type>:<key>` pairs.
+func requiredMetadataEnvValue(required []v1beta1.RequiredMetadata) string {
+	values := make([]string, len(required))
+	for i, r := range required {
+		values[i] = fmt.Sprintf("%s:%s", r.Type, r.Key)
+	}
+	return strings.Join(values, ",")
+}
+
+// remediatorExcludedKindsEnvValue formats the excluded resource types as a
+// comma-separated list of `Kind.group`, like `Event.example.com`, or `Kind`
+// for the core group.
+func remediatorExcludedKindsEnvValue(kinds []v1beta1.RemediatorExcludedKind) string {
+	values := make([]string, len(kinds))
+	for i, k := range kinds {
+		values[i] = schema.GroupKind{Group: k.Group, Kind: k.Kind}.String()
+	}
+	return strings.Join(values, ",")
+}
+
+// objectSelectorEnvValue formats the object selector as a label selector
+// string, like `env=prod,region in (us, eu)`. The selector must have been
+// validated with validateObjectSelector.
+func objectSelectorEnvValue(selector *metav1.LabelSelector) string {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return ""
+	}
+	return s.String()
+}
+
+// validateObjectSelector validates the spec.override.objectSelector of a
+// RootSync or RepoSync.
+func validateObjectSelector(selector *metav1.LabelSelector) error {
+	if selector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return fmt.Errorf("spec.override.objectSelector: invalid label selector: %w", err)
+	}
+	return nil
+}
+
+// validateRequiredMetadata validates the spec.override.requiredMetadata of a
+// RootSync or RepoSync.
+func validateRequiredMetadata(required []v1beta1.RequiredMetadata) error {
+	seen := make(map[v1beta1.RequiredMetadata]bool)
+	for i, r := range required {
+		switch r.Type {
+		case configsync.RequiredMetadataLabel, configsync.RequiredMetadataAnnotation:
+		default:
+			return fmt.Errorf("spec.override.requiredMetadata[%d].type: invalid type %q: must be %q or %q",
+				i, r.Type, configsync.RequiredMetadataLabel, configsync.RequiredMetadataAnnotation)
+		}
+		if errs := validation.IsQualifiedName(r.Key); len(errs) > 0 {
+			return fmt.Errorf("spec.override.requiredMetadata[%d].key: invalid %s key %q: %s",
+				i, r.Type, r.Key, strings.Join(errs, ", "))
+		}
+		if seen[r] {
+			return fmt.Errorf("spec.override.requiredMetadata[%d]: duplicate %s key %q", i, r.Type, r.Key)
+		}
+		seen[r] = true
+	}
+	return nil
+}
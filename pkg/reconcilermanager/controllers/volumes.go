@@ -41,9 +41,25 @@ const CACertSecretKey = "cert"
 // CACertPath is the path where the certificate is mounted.
 const CACertPath = "/etc/ca-cert"
 
+// ClientCertVolume is the volume name of the client certificate.
+const ClientCertVolume = "client-cert"
+
+// ClientCertSecretCertKey is the name of the key in the Secret's data map whose value holds the client cert
+const ClientCertSecretCertKey = corev1.TLSCertKey
+
+// ClientCertSecretKeyKey is the name of the key in the Secret's data map whose value holds the client private key
+const ClientCertSecretKeyKey = corev1.TLSPrivateKeyKey
+
+// ClientCertPath is the path where the client certificate and key are mounted.
+const ClientCertPath = "/etc/client-cert"
+
 // defaultMode is the default permission of the `gcp-ksa` volume.
 var defaultMode int32 = 0644
 
+// clientCertMode is the permission of the client certificate volume, which
+// holds a private key.
+var clientCertMode int32 = 0440
+
 // expirationSeconds is the requested duration of validity of the service account token.
 // As the token approaches expiration, the kubelet volume plugin will proactively rotate the service account token.
 // It sets to 48 hours.
@@ -52,7 +68,7 @@ var expirationSeconds = int64((48 * time.Hour).Seconds())
 // filterVolumes returns the volumes depending on different auth types.
 // If authType is `none`, `gcenode`, or `gcpserviceaccount`, it won't mount the `git-creds` volume.
 // If authType is `gcpserviceaccount` with fleet membership available, it also mounts a `gcp-ksa` volume.
-func filterVolumes(existing []corev1.Volume, authType configsync.AuthType, secretName, caCertSecretName, sourceType string, membership *hubv1.Membership) []corev1.Volume {
+func filterVolumes(existing []corev1.Volume, authType configsync.AuthType, secretName, caCertSecretName, clientCertSecretName, sourceType string, membership *hubv1.Membership) []corev1.Volume {
 	var updatedVolumes []corev1.Volume
 
 	for _, volume := range existing {
@@ -89,6 +105,28 @@ func filterVolumes(existing []corev1.Volume, authType configsync.AuthType, secre
 		})
 	}
 
+	if useClientCert(clientCertSecretName) {
+		updatedVolumes = append(updatedVolumes, corev1.Volume{
+			Name: ClientCertVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: clientCertSecretName,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  ClientCertSecretCertKey,
+							Path: ClientCertSecretCertKey,
+						},
+						{
+							Key:  ClientCertSecretKeyKey,
+							Path: ClientCertSecretKeyKey,
+						},
+					},
+					DefaultMode: &clientCertMode,
+				},
+			},
+		})
+	}
+
 	if useFWIAuth(authType, membership) {
 		updatedVolumes = append(updatedVolumes, corev1.Volume{
 			Name: gcpKSAVolumeName,
@@ -125,7 +163,7 @@ func filterVolumes(existing []corev1.Volume, authType configsync.AuthType, secre
 
 // volumeMounts returns a sorted list of VolumeMounts by filtering out git-creds
 // VolumeMount when secret is 'none' or 'gcenode'.
-func volumeMounts(auth configsync.AuthType, caCertSecretRef, sourceType string, vm []corev1.VolumeMount) []corev1.VolumeMount {
+func volumeMounts(auth configsync.AuthType, caCertSecretRef, clientCertSecretRef, sourceType string, vm []corev1.VolumeMount) []corev1.VolumeMount {
 	var volumeMount []corev1.VolumeMount
 	if useCACert(caCertSecretRef) {
 		volumeMount = append(volumeMount, corev1.VolumeMount{
@@ -134,6 +172,13 @@ func volumeMounts(auth configsync.AuthType, caCertSecretRef, sourceType string,
 			ReadOnly:  true,
 		})
 	}
+	if useClientCert(clientCertSecretRef) {
+		volumeMount = append(volumeMount, corev1.VolumeMount{
+			MountPath: ClientCertPath,
+			Name:      ClientCertVolume,
+			ReadOnly:  true,
+		})
+	}
 	for _, volume := range vm {
 		if volume.Name == GitCredentialVolume && (SkipForAuth(auth) || sourceType != string(v1beta1.GitSource)) {
 			continue
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/util/workqueue"
@@ -59,6 +60,7 @@ type Interface interface {
 	Done(obj client.Object)
 	Forget(obj client.Object)
 	Retry(obj client.Object)
+	AddAfter(obj client.Object, duration time.Duration)
 	ShutDown()
 }
 
@@ -150,6 +152,11 @@ func (q *ObjectQueue) Retry(obj client.Object) {
 	q.delayer.AddAfter(obj, q.rateLimiter.When(gvknn))
 }
 
+// AddAfter schedules the object to be requeued after the specified duration.
+func (q *ObjectQueue) AddAfter(obj client.Object, duration time.Duration) {
+	q.delayer.AddAfter(obj, duration)
+}
+
 // Get blocks until one of the following conditions:
 // A) An item is ready to be processed
 // B) The context is cancelled or times out
@@ -37,6 +37,21 @@ type reconcilerInterface interface {
 	GetClient() client.Client
 }
 
+// DriftMode indicates how the remediator handles the objects which drifted
+// from the source.
+type DriftMode int
+
+const (
+	// CorrectDrift corrects the drifted objects.
+	CorrectDrift DriftMode = iota
+	// ReportPausedDrift reports the drifted objects without correcting them,
+	// because applying is paused.
+	ReportPausedDrift
+	// ReportObservedDrift reports the drifted objects without correcting them,
+	// because the RootSync is in observe mode.
+	ReportObservedDrift
+)
+
 // reconciler ensures objects are consistent with their declared state in the
 // repository.
 type reconciler struct {
@@ -48,6 +63,13 @@ type reconciler struct {
 	declared *declared.Resources
 
 	fightHandler fight.Handler
+	// throttler bounds the rate of corrections to each object.
+	throttler *Throttler
+	// driftMode indicates whether to correct the drifted objects, or only
+	// report them.
+	driftMode DriftMode
+	// editTracker records the out-of-band edits corrected by the reconciler.
+	editTracker *fight.Detector
 }
 
 // newReconciler instantiates a new reconciler.
@@ -57,6 +79,9 @@ func newReconciler(
 	applier syncerreconcile.Applier,
 	declared *declared.Resources,
 	fightHandler fight.Handler,
+	throttler *Throttler,
+	driftMode DriftMode,
+	editTracker *fight.Detector,
 ) *reconciler {
 	return &reconciler{
 		scope:        scope,
@@ -64,6 +89,9 @@ func newReconciler(
 		applier:      applier,
 		declared:     declared,
 		fightHandler: fightHandler,
+		throttler:    throttler,
+		driftMode:    driftMode,
+		editTracker:  editTracker,
 	}
 }
 
@@ -85,6 +113,51 @@ func (r *reconciler) Remediate(ctx context.Context, id core.ID, obj client.Objec
 		Actual:   obj,
 	}
 
+	operation := objDiff.Operation(r.scope, r.syncName)
+	if r.driftMode != CorrectDrift {
+		// Surface the drift, but leave the object as is.
+		if operation == diff.Update {
+			drifted, err := objDiff.HasDrifted()
+			if err != nil {
+				return err
+			}
+			if !drifted {
+				operation = diff.NoOp
+			}
+		}
+		switch operation {
+		case diff.NoOp:
+			r.fightHandler.RemoveFightError(id)
+			return nil
+		case diff.Create, diff.Update, diff.Delete, diff.Abandon:
+			resource := obj
+			if resource == nil {
+				resource = decl
+			}
+			r.fightHandler.AddFightError(id, r.driftError(string(operation), resource))
+			return nil
+		}
+	}
+	// The reconcile-now annotation requests an immediate correction.
+	reconcileNow := obj != nil && metadata.HasReconcileNow(obj)
+	if operation != diff.NoOp && !reconcileNow {
+		if delay := r.throttler.Delay(id); delay > 0 {
+			// Surface the fight, but delay the correction until the minimum
+			// remediation interval has elapsed.
+			resource := obj
+			if resource == nil {
+				resource = decl
+			}
+			err := &throttledError{
+				ResourceError: status.RemediationThrottledError(r.throttler.minInterval, resource),
+				delay:         delay,
+			}
+			metrics.RecordResourceFight(ctx, string(operation))
+			r.fightHandler.AddFightError(id, err)
+			return err
+		}
+	}
+
 	err := r.remediate(ctx, id, objDiff)
 
 	// Record duration, even if there's an error
@@ -103,10 +176,33 @@ func (r *reconciler) Remediate(ctx context.Context, id core.ID, obj client.Objec
 		return err
 	}
 
+	if operation != diff.NoOp {
+		r.throttler.Corrected(id)
+		r.recordEdit(id, operation, objDiff)
+	}
 	r.fightHandler.RemoveFightError(id)
 	return nil
 }
 
+// recordEdit records an out-of-band edit to the object, if the remediation
+// reverted one, either by recreating a deleted object or by updating a
+// drifted object.
+func (r *reconciler) recordEdit(id core.ID, operation diff.Operation, objDiff diff.Diff) {
+	if r.editTracker == nil {
+		return
+	}
+	switch operation {
+	case diff.Create:
+	case diff.Update:
+		if drifted, err := objDiff.HasDrifted(); err != nil || !drifted {
+			return
+		}
+	default:
+		return
+	}
+	r.editTracker.RecordUpdate(time.Now(), id)
+}
+
 // Remediate takes diff (declared & actual) and ensures the server matches the
 // declared state.
 func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Diff) status.Error {
@@ -159,6 +255,15 @@ func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Dif
 	}
 }
 
+// driftError returns the error reporting that the object drifted, but was not
+// corrected.
+func (r *reconciler) driftError(operation string, resource client.Object) status.Error {
+	if r.driftMode == ReportObservedDrift {
+		return status.ObservedDriftError(operation, resource)
+	}
+	return status.RemediationPausedError(operation, resource)
+}
+
 // GetClient returns the reconciler's underlying client.Client.
 func (r *reconciler) GetClient() client.Client {
 	return r.applier.GetClient()
@@ -17,13 +17,16 @@ package reconcile
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
@@ -33,6 +36,7 @@ import (
 	"kpt.dev/configsync/pkg/policycontroller"
 	"kpt.dev/configsync/pkg/status"
 	syncerclient "kpt.dev/configsync/pkg/syncer/client"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 	"kpt.dev/configsync/pkg/syncer/syncertest"
 	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
 	"kpt.dev/configsync/pkg/testing/fake"
@@ -238,7 +242,7 @@ func TestRemediator_Reconcile(t *testing.T) {
 			// Simulate the Parser having already parsed the resource and recorded it.
 			d := makeDeclared(t, "unused", tc.declared)
 
-			r := newReconciler(declared.RootReconciler, configsync.RootSyncName, c.Applier(), d, testingfake.NewFightHandler())
+			r := newReconciler(declared.RootReconciler, configsync.RootSyncName, c.Applier(), d, testingfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -363,7 +367,7 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 			fakeApplier.UpdateError = tc.updateError
 			fakeApplier.DeleteError = tc.deleteError
 
-			reconciler := newReconciler(declared.RootReconciler, configsync.RootSyncName, fakeApplier, d, testingfake.NewFightHandler())
+			reconciler := newReconciler(declared.RootReconciler, configsync.RootSyncName, fakeApplier, d, testingfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -402,6 +406,190 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 	}
 }
 
+// TestRemediator_Reconcile_Throttled verifies that an object being changed
+// rapidly by another controller is corrected at a bounded rate.
+func TestRemediator_Reconcile_Throttled(t *testing.T) {
+	ctx := context.Background()
+	minInterval := time.Minute
+	changeInterval := 5 * time.Second
+	duration := 3 * time.Minute
+
+	declaredObj := fake.RoleObject(core.Namespace("example"), core.Name("example"),
+		syncertest.ManagementEnabled,
+		core.Label("new-label", "one"))
+	id := core.IDOf(declaredObj)
+
+	c := testingfake.NewClient(t, core.Scheme,
+		fake.RoleObject(core.Namespace("example"), core.Name("example")))
+	d := makeDeclared(t, "abc123", declaredObj)
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	fightHandler := fight.NewHandler()
+	r := newReconciler(declared.RootReconciler, configsync.RootSyncName, c.Applier(), d,
+		fightHandler, newThrottler(fakeClock, minInterval), CorrectDrift, nil)
+
+	corrections := 0
+	throttled := 0
+	for elapsed := time.Duration(0); elapsed < duration; elapsed += changeInterval {
+		// Simulate another controller reverting the correction.
+		actual := &rbacv1.Role{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(declaredObj), actual); err != nil {
+			t.Fatalf("Failed to get object from fake client: %v", err)
+		}
+		actual.SetLabels(nil)
+		if err := c.Update(ctx, actual); err != nil {
+			t.Fatalf("Failed to update object in fake client: %v", err)
+		}
+
+		err := r.Remediate(ctx, id, actual)
+		var throttledErr *throttledError
+		switch {
+		case err == nil:
+			corrections++
+		case errors.As(err, &throttledErr):
+			throttled++
+			if throttledErr.delay <= 0 || throttledErr.delay > minInterval {
+				t.Errorf("Unexpected throttle delay: %v", throttledErr.delay)
+			}
+			if err.Code() != status.FightErrorCode {
+				t.Errorf("Unexpected error code: want %s, got %s", status.FightErrorCode, err.Code())
+			}
+		default:
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		fakeClock.Step(changeInterval)
+	}
+
+	wantCorrections := int(duration / minInterval)
+	if corrections != wantCorrections {
+		t.Errorf("Unexpected number of corrections: want %d, got %d", wantCorrections, corrections)
+	}
+	wantThrottled := int(duration/changeInterval) - wantCorrections
+	if throttled != wantThrottled {
+		t.Errorf("Unexpected number of throttled corrections: want %d, got %d", wantThrottled, throttled)
+	}
+	// The last change was throttled, so the fight should still be surfaced.
+	if len(fightHandler.FightErrors()) != 1 {
+		t.Errorf("Expected the fight to be surfaced, got fight errors: %v", fightHandler.FightErrors())
+	}
+}
+
+// TestRemediator_Reconcile_ReadOnly verifies that a read-only reconciler
+// reports a drifted object without correcting it, and stops reporting it once
+// the object no longer drifts.
+func TestRemediator_Reconcile_ReadOnly(t *testing.T) {
+	testCases := []struct {
+		name      string
+		driftMode DriftMode
+		wantCode  string
+	}{
+		{
+			name:      "paused",
+			driftMode: ReportPausedDrift,
+			wantCode:  status.RemediationPausedErrorCode,
+		},
+		{
+			name:      "observed",
+			driftMode: ReportObservedDrift,
+			wantCode:  status.ObservedDriftErrorCode,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			declaredObj := fake.RoleObject(core.Namespace("example"), core.Name("example"),
+				syncertest.ManagementEnabled,
+				core.Label("new-label", "one"))
+			id := core.IDOf(declaredObj)
+
+			c := testingfake.NewClient(t, core.Scheme,
+				fake.RoleObject(core.Namespace("example"), core.Name("example")))
+			d := makeDeclared(t, "abc123", declaredObj)
+			fightHandler := fight.NewHandler()
+			r := newReconciler(declared.RootReconciler, configsync.RootSyncName, c.Applier(), d,
+				fightHandler, nil, tc.driftMode, nil)
+
+			actual := &rbacv1.Role{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(declaredObj), actual); err != nil {
+				t.Fatalf("Failed to get object from fake client: %v", err)
+			}
+			if err := r.Remediate(ctx, id, actual); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(declaredObj), actual); err != nil {
+				t.Fatalf("Failed to get object from fake client: %v", err)
+			}
+			if _, found := actual.GetLabels()["new-label"]; found {
+				t.Errorf("Expected the drifted object not to be corrected, got labels: %v", actual.GetLabels())
+			}
+			fightErrs := fightHandler.FightErrors()
+			if len(fightErrs) != 1 {
+				t.Fatalf("Expected the drift to be surfaced, got fight errors: %v", fightErrs)
+			}
+			if fightErrs[0].Code() != tc.wantCode {
+				t.Errorf("Unexpected error code: want %s, got %s", tc.wantCode, fightErrs[0].Code())
+			}
+
+			// Simulate the drift being corrected out-of-band.
+			if err := newReconciler(declared.RootReconciler, configsync.RootSyncName, c.Applier(), d,
+				fight.NewHandler(), nil, CorrectDrift, nil).Remediate(ctx, id, actual); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(declaredObj), actual); err != nil {
+				t.Fatalf("Failed to get object from fake client: %v", err)
+			}
+			if err := r.Remediate(ctx, id, actual); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(fightHandler.FightErrors()) != 0 {
+				t.Errorf("Expected the drift to be cleared, got fight errors: %v", fightHandler.FightErrors())
+			}
+		})
+	}
+}
+
+func TestRemediator_Reconcile_FrequentlyEdited(t *testing.T) {
+	ctx := context.Background()
+	declaredObj := fake.RoleObject(core.Namespace("example"), core.Name("example"),
+		syncertest.ManagementEnabled,
+		core.Label("team", "one"))
+	id := core.IDOf(declaredObj)
+	inSyncObj := fake.RoleObject(core.Namespace("example"), core.Name("in-sync"),
+		syncertest.ManagementEnabled)
+
+	c := testingfake.NewClient(t, core.Scheme, declaredObj.DeepCopy(), inSyncObj.DeepCopy())
+	d := makeDeclared(t, "abc123", declaredObj, inSyncObj)
+	editTracker := fight.NewDetector()
+	r := newReconciler(declared.RootReconciler, configsync.RootSyncName, c.Applier(), d,
+		testingfake.NewFightHandler(), nil, CorrectDrift, &editTracker)
+
+	actual := &rbacv1.Role{}
+	for i := 0; i < 3; i++ {
+		// Simulate a manual edit, which is reverted by the reconciler.
+		if err := c.Get(ctx, client.ObjectKeyFromObject(declaredObj), actual); err != nil {
+			t.Fatalf("Failed to get object from fake client: %v", err)
+		}
+		core.SetLabel(actual, "team", "two")
+		if err := c.Update(ctx, actual); err != nil {
+			t.Fatalf("Failed to update object in fake client: %v", err)
+		}
+		if err := r.Remediate(ctx, id, actual); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	inSyncActual := &rbacv1.Role{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(inSyncObj), inSyncActual); err != nil {
+		t.Fatalf("Failed to get object from fake client: %v", err)
+	}
+	if err := r.Remediate(ctx, core.IDOf(inSyncObj), inSyncActual); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := editTracker.FrequentUpdates(time.Now(), 2.0, 10)
+	if len(got) != 1 || got[0].ID != id || got[0].PerMinute < 2.0 {
+		t.Errorf("Expected only %v to be frequently edited, got: %v", id, got)
+	}
+}
+
 func makeDeclared(t *testing.T, commit string, objs ...client.Object) *declared.Resources {
 	t.Helper()
 	d := &declared.Resources{}
@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// Throttler bounds the rate at which the remediator corrects any single
+// object, so that an object being changed rapidly by another controller does
+// not keep the remediator busy reverting it.
+//
+// Instantiate with NewThrottler().
+//
+// A nil Throttler never throttles.
+type Throttler struct {
+	clock clock.PassiveClock
+	// minInterval is the minimum time between two corrections of the same
+	// object. Zero disables throttling.
+	minInterval time.Duration
+
+	// mux guards corrections.
+	mux sync.Mutex
+	// corrections is a record of when each recently corrected object was last
+	// corrected. Entries are removed once their interval has elapsed.
+	corrections map[core.ID]time.Time
+}
+
+// NewThrottler instantiates a Throttler with the specified minimum interval
+// between corrections of the same object.
+func NewThrottler(minInterval time.Duration) *Throttler {
+	return newThrottler(clock.RealClock{}, minInterval)
+}
+
+func newThrottler(c clock.PassiveClock, minInterval time.Duration) *Throttler {
+	return &Throttler{
+		clock:       c,
+		minInterval: minInterval,
+		corrections: make(map[core.ID]time.Time),
+	}
+}
+
+// Delay returns how long to wait before the object with the specified ID may
+// be corrected again. Returns zero if the object may be corrected now.
+func (t *Throttler) Delay(id core.ID) time.Duration {
+	if t == nil || t.minInterval <= 0 {
+		return 0
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	last, found := t.corrections[id]
+	if !found {
+		return 0
+	}
+	delay := t.minInterval - t.clock.Since(last)
+	if delay <= 0 {
+		delete(t.corrections, id)
+		return 0
+	}
+	return delay
+}
+
+// Corrected records that the object with the specified ID was just corrected.
+func (t *Throttler) Corrected(id core.ID) {
+	if t == nil || t.minInterval <= 0 {
+		return
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.corrections[id] = t.clock.Now()
+}
+
+// throttledError is returned by the reconciler when remediation of an object
+// is delayed by the Throttler.
+type throttledError struct {
+	status.ResourceError
+	// delay is how long to wait before retrying remediation.
+	delay time.Duration
+}
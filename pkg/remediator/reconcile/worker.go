@@ -16,6 +16,7 @@ package reconcile
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -42,10 +43,10 @@ type Worker struct {
 
 // NewWorker returns a new Worker for the given queue and declared resources.
 func NewWorker(scope declared.Scope, syncName string, a syncerreconcile.Applier,
-	q *queue.ObjectQueue, d *declared.Resources, fh fight.Handler) *Worker {
+	q *queue.ObjectQueue, d *declared.Resources, fh fight.Handler, t *Throttler, driftMode DriftMode, et *fight.Detector) *Worker {
 	return &Worker{
 		objectQueue: q,
-		reconciler:  newReconciler(scope, syncName, a, d, fh),
+		reconciler:  newReconciler(scope, syncName, a, d, fh, t, driftMode, et),
 	}
 }
 
@@ -108,6 +109,14 @@ func (w *Worker) process(ctx context.Context, obj client.Object) error {
 
 	err := w.reconciler.Remediate(ctx, id, toRemediate)
 	if err != nil {
+		var throttledErr *throttledError
+		if errors.As(err, &throttledErr) {
+			// Requeue the object to be corrected after the delay, instead of
+			// retrying with the rate limiter.
+			klog.Warningf("Worker throttled remediation of %q for %v: %v", id, throttledErr.delay, err)
+			w.objectQueue.AddAfter(obj, throttledErr.delay)
+			return nil
+		}
 		// To debug the set of events we've missed, you may need to comment out this
 		// block. Specifically, this makes things smooth for production, but can
 		// hide bugs (for example, if we don't properly process delete events).
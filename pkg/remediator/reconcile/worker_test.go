@@ -81,7 +81,7 @@ func TestWorker_Run_Remediates(t *testing.T) {
 	}
 
 	d := makeDeclared(t, randomCommitHash(), declaredObjs...)
-	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler())
+	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -188,7 +188,7 @@ func TestWorker_Run_RemediatesExisting(t *testing.T) {
 	}
 
 	d := makeDeclared(t, randomCommitHash(), declaredObjs...)
-	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler())
+	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -296,7 +296,7 @@ func TestWorker_ProcessNextObject(t *testing.T) {
 			}
 
 			d := makeDeclared(t, randomCommitHash(), tc.declared...)
-			w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler())
+			w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 			for _, obj := range tc.toProcess {
 				if err := w.processNextObject(context.Background()); err != nil {
@@ -316,7 +316,7 @@ func TestWorker_Run_CancelledWhenEmpty(t *testing.T) {
 	defer q.ShutDown()
 	c := testingfake.NewClient(t, core.Scheme)
 	d := makeDeclared(t, randomCommitHash()) // no resources declared
-	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler())
+	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, c.Applier(), q, d, syncertestfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -389,7 +389,7 @@ func TestWorker_Run_CancelledWhenNotEmpty(t *testing.T) {
 
 	d := makeDeclared(t, randomCommitHash(), declaredObjs...)
 	a := &testingfake.Applier{Client: c}
-	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, a, q, d, syncertestfake.NewFightHandler())
+	w := NewWorker(declared.RootReconciler, configsync.RootSyncName, a, q, d, syncertestfake.NewFightHandler(), nil, CorrectDrift, nil)
 
 	// Run worker in the background
 	doneCh := make(chan struct{})
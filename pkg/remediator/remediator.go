@@ -17,6 +17,7 @@ package remediator
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -60,8 +61,20 @@ type Remediator struct {
 
 	conflictHandler conflict.Handler
 	fightHandler    fight.Handler
+
+	// editTracker estimates how frequently the managed objects are edited
+	// out-of-band, from the edits reverted by the workers.
+	editTracker *fight.Detector
+	// frequentEditThreshold is the estimated number of out-of-band edits per
+	// minute at which an object is reported as frequently edited.
+	// Zero disables the reporting.
+	frequentEditThreshold float64
 }
 
+// frequentlyEditedLimit is the maximum number of frequently edited objects
+// reported by the Remediator.
+const frequentlyEditedLimit = 10
+
 // Interface is a fake-able subset of the interface Remediator implements that
 // accepts a new set of declared configuration.
 //
@@ -83,6 +96,9 @@ type Interface interface {
 	ConflictErrors() []status.ManagementConflictError
 	// FightErrors returns the fight errors (KNV2005) the remediator encounters.
 	FightErrors() []status.Error
+	// FrequentlyEdited returns the objects which are most frequently edited
+	// out-of-band, most frequently edited first.
+	FrequentlyEdited() []fight.UpdateFrequency
 }
 
 var _ Interface = &Remediator{}
@@ -92,23 +108,43 @@ var _ Interface = &Remediator{}
 //
 // It is safe for decls to be modified after they have been passed into the
 // Remediator.
-func New(scope declared.Scope, syncName string, cfg *rest.Config, applier syncerreconcile.Applier, decls *declared.Resources, numWorkers int) (*Remediator, error) {
+//
+// Unless driftMode is CorrectDrift, the workers report drifted objects as
+// errors instead of correcting them.
+//
+// Objects edited out-of-band at least frequentEditThreshold times per minute
+// are reported as frequently edited.
+func New(scope declared.Scope, syncName string, cfg *rest.Config, applier syncerreconcile.Applier, decls *declared.Resources, numWorkers int, minRemediationInterval time.Duration, driftMode reconcile.DriftMode, frequentEditThreshold, watchJitterFactor float64) (*Remediator, error) {
 	q := queue.New(string(scope))
 	workers := make([]*reconcile.Worker, numWorkers)
 	fightHandler := fight.NewHandler()
 	conflictHandler := conflict.NewHandler()
+	editTracker := fight.NewDetector()
+	// The throttler is shared by all the workers, because any worker may
+	// process the next event for an object.
+	throttler := reconcile.NewThrottler(minRemediationInterval)
 	for i := 0; i < numWorkers; i++ {
-		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, fightHandler)
+		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, fightHandler, throttler, driftMode, &editTracker)
 	}
 
 	remediator := &Remediator{
-		workers:         workers,
-		objectQueue:     q,
-		fightHandler:    fightHandler,
-		conflictHandler: conflictHandler,
+		workers:               workers,
+		objectQueue:           q,
+		fightHandler:          fightHandler,
+		conflictHandler:       conflictHandler,
+		editTracker:           &editTracker,
+		frequentEditThreshold: frequentEditThreshold,
+	}
+
+	watchOpts, err := watch.DefaultOptions(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating watch manager")
+	}
+	if watchJitterFactor > 0 {
+		watchOpts.JitterFactor = watchJitterFactor
 	}
 
-	watchMgr, err := watch.NewManager(scope, syncName, cfg, q, decls, nil, conflictHandler)
+	watchMgr, err := watch.NewManager(scope, syncName, cfg, q, decls, watchOpts, conflictHandler)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating watch manager")
 	}
@@ -214,3 +250,18 @@ func (r *Remediator) ConflictErrors() []status.ManagementConflictError {
 func (r *Remediator) FightErrors() []status.Error {
 	return r.fightHandler.FightErrors()
 }
+
+// FightHandler returns the handler of the errors reported by the workers, so
+// that the drift observed outside of the remediator is reported once, along
+// with the drift observed by the workers.
+func (r *Remediator) FightHandler() fight.Handler {
+	return r.fightHandler
+}
+
+// FrequentlyEdited implements Interface.
+func (r *Remediator) FrequentlyEdited() []fight.UpdateFrequency {
+	if r.frequentEditThreshold <= 0 {
+		return nil
+	}
+	return r.editTracker.FrequentUpdates(time.Now(), r.frequentEditThreshold, frequentlyEditedLimit)
+}
@@ -19,7 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"math/rand"
 	"reflect"
 	"sync"
 	"time"
@@ -28,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
@@ -45,9 +45,13 @@ import (
 const (
 	// Copying strategy from k8s.io/client-go/tools/cache/reflector.go
 	// We try to spread the load on apiserver by setting timeouts for
-	// watch requests - it is random in [minWatchTimeout, 2*minWatchTimeout].
+	// watch requests - it is random in [minWatchTimeout, (1+jitterFactor)*minWatchTimeout].
 	minWatchTimeout = 5 * time.Minute
 
+	// DefaultJitterFactor is the default maximum fraction by which the watch
+	// timeouts and retry delays are randomly extended.
+	DefaultJitterFactor = 1.0
+
 	// RESTConfigTimeout sets the REST config timeout for the remediator to 1 hour.
 	//
 	// RESTConfigTimeout should be longer than 2*minWatchTimeout to respect
@@ -100,6 +104,10 @@ type filteredWatcher struct {
 	queue      *queue.ObjectQueue
 	scope      declared.Scope
 	syncName   string
+	// jitterFactor is the maximum fraction by which the watch timeout and the
+	// retry delay are randomly extended, to avoid all the watchers restarting
+	// at the same time.
+	jitterFactor float64
 	// errorTracker maps an error to the time when the same error happened last time.
 	errorTracker map[string]time.Time
 
@@ -123,6 +131,7 @@ func NewFiltered(cfg watcherConfig) Runnable {
 		queue:           cfg.queue,
 		scope:           cfg.scope,
 		syncName:        cfg.syncName,
+		jitterFactor:    cfg.jitterFactor,
 		base:            watch.NewEmptyWatch(),
 		errorTracker:    make(map[string]time.Time),
 		conflictHandler: cfg.conflictHandler,
@@ -235,13 +244,25 @@ func isExpiredError(err error) bool {
 }
 
 // TODO: Use wait.ExponentialBackoff in the watch retry logic
-func waitUntilNextRetry(retries int) {
+func waitUntilNextRetry(retries int, jitterFactor float64) {
+	time.Sleep(retryDelay(retries, jitterFactor))
+}
+
+// retryDelay returns the delay before the next watch retry. It is random in
+// [2^retries ms, (1+jitterFactor) * 2^retries ms], with retries capped at
+// maxWatchRetryFactor.
+func retryDelay(retries int, jitterFactor float64) time.Duration {
 	if retries > maxWatchRetryFactor {
 		retries = maxWatchRetryFactor
 	}
 	milliseconds := int64(math.Pow(2, float64(retries)))
-	duration := time.Duration(milliseconds) * time.Millisecond
-	time.Sleep(duration)
+	return wait.Jitter(time.Duration(milliseconds)*time.Millisecond, jitterFactor)
+}
+
+// watchTimeout returns the timeout for a watch request. It is random in
+// [minWatchTimeout, (1+jitterFactor) * minWatchTimeout].
+func watchTimeout(jitterFactor float64) time.Duration {
+	return wait.Jitter(minWatchTimeout, jitterFactor)
 }
 
 // isContextCancelledStatusError returns true if the error is a *StatusError and
@@ -344,7 +365,7 @@ Watcher:
 						klog.Errorf("Watch for %s at resource version %q ended with: %v", w.gvk, resourceVersion, err)
 					}
 					retriesForWatchError++
-					waitUntilNextRetry(retriesForWatchError)
+					waitUntilNextRetry(retriesForWatchError, w.jitterFactor)
 					// Restart the watcher
 					break EventHandler
 				}
@@ -376,7 +397,7 @@ func (w *filteredWatcher) start(ctx context.Context, resourceVersion string) (bo
 
 	// We want to avoid situations of hanging watchers. Stop any watchers that
 	// do not receive any events within the timeout window.
-	timeoutSeconds := int64(minWatchTimeout.Seconds() * (rand.Float64() + 1.0))
+	timeoutSeconds := int64(watchTimeout(w.jitterFactor).Seconds())
 	options := metav1.ListOptions{
 		AllowWatchBookmarks: true,
 		ResourceVersion:     resourceVersion,
@@ -388,3 +388,61 @@ func TestFilteredWatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchJitter(t *testing.T) {
+	testCases := []struct {
+		name         string
+		jitterFactor float64
+		retries      int
+		wantMinDelay time.Duration
+		wantMaxDelay time.Duration
+		wantMaxWatch time.Duration
+	}{
+		{
+			name:         "default jitter factor",
+			jitterFactor: DefaultJitterFactor,
+			retries:      3,
+			wantMinDelay: 8 * time.Millisecond,
+			wantMaxDelay: 16 * time.Millisecond,
+			wantMaxWatch: 2 * minWatchTimeout,
+		},
+		{
+			name:         "non-positive jitter factor uses the default",
+			jitterFactor: 0,
+			retries:      3,
+			wantMinDelay: 8 * time.Millisecond,
+			wantMaxDelay: 16 * time.Millisecond,
+			wantMaxWatch: 2 * minWatchTimeout,
+		},
+		{
+			name:         "small jitter factor",
+			jitterFactor: 0.1,
+			retries:      10,
+			wantMinDelay: 1024 * time.Millisecond,
+			wantMaxDelay: 1126400 * time.Microsecond,
+			wantMaxWatch: 330 * time.Second,
+		},
+		{
+			name:         "retries are capped",
+			jitterFactor: 0.5,
+			retries:      maxWatchRetryFactor + 5,
+			wantMinDelay: 262144 * time.Millisecond,
+			wantMaxDelay: 393216 * time.Millisecond,
+			wantMaxWatch: 450 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				delay := retryDelay(tc.retries, tc.jitterFactor)
+				require.GreaterOrEqual(t, delay, tc.wantMinDelay)
+				require.LessOrEqual(t, delay, tc.wantMaxDelay)
+
+				timeout := watchTimeout(tc.jitterFactor)
+				require.GreaterOrEqual(t, timeout, minWatchTimeout)
+				require.LessOrEqual(t, timeout, tc.wantMaxWatch)
+			}
+		})
+	}
+}
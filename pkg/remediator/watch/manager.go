@@ -49,6 +49,10 @@ type Manager struct {
 	// watcherFactory is the function to create a watcher.
 	watcherFactory watcherFactory
 
+	// jitterFactor is the maximum fraction by which the watch timeouts and
+	// retry delays of the watchers are randomly extended.
+	jitterFactor float64
+
 	// The following fields are guarded by the mutex.
 	mux sync.Mutex
 	// watcherMap maps GVKs to their associated watchers
@@ -61,6 +65,10 @@ type Manager struct {
 // Options contains options for creating a watch manager.
 type Options struct {
 	watcherFactory watcherFactory
+	// JitterFactor is the maximum fraction by which the watch timeouts and
+	// retry delays are randomly extended. Non-positive values use
+	// DefaultJitterFactor.
+	JitterFactor float64
 }
 
 // DefaultOptions return the default options with a ListerWatcherFactory built
@@ -73,6 +81,7 @@ func DefaultOptions(cfg *rest.Config) (*Options, error) {
 
 	return &Options{
 		watcherFactory: watcherFactoryFromListerWatcherFactory(factory),
+		JitterFactor:   DefaultJitterFactor,
 	}, nil
 }
 
@@ -94,6 +103,7 @@ func NewManager(scope declared.Scope, syncName string, cfg *rest.Config,
 		resources:       decls,
 		watcherMap:      make(map[schema.GroupVersionKind]Runnable),
 		watcherFactory:  options.watcherFactory,
+		jitterFactor:    options.JitterFactor,
 		queue:           q,
 		conflictHandler: ch,
 	}, nil
@@ -196,6 +206,7 @@ func (m *Manager) startWatcher(ctx context.Context, gvk schema.GroupVersionKind)
 		scope:           m.scope,
 		syncName:        m.syncName,
 		conflictHandler: m.conflictHandler,
+		jitterFactor:    m.jitterFactor,
 	}
 	w, err := m.watcherFactory(cfg)
 	if err != nil {
@@ -38,6 +38,7 @@ type watcherConfig struct {
 	syncName        string
 	startWatch      WatchFunc
 	conflictHandler conflict.Handler
+	jitterFactor    float64
 }
 
 // watcherFactory knows how to build watch.Runnables.
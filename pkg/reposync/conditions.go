@@ -36,6 +36,12 @@ func IsStalled(rs *v1beta1.RepoSync) bool {
 	return cond != nil && cond.Status == metav1.ConditionTrue
 }
 
+// IsSyncing returns true if the given RepoSync has a True Syncing condition.
+func IsSyncing(rs *v1beta1.RepoSync) bool {
+	cond := GetCondition(rs.Status.Conditions, v1beta1.RepoSyncSyncing)
+	return cond != nil && cond.Status == metav1.ConditionTrue
+}
+
 // ReconcilingMessage returns the message from a True Reconciling condition or
 // an empty string if no True Reconciling condition was found.
 func ReconcilingMessage(rs *v1beta1.RepoSync) string {
@@ -152,6 +158,34 @@ func SetReconcilerFinalizerFailure(rs *v1beta1.RepoSync, errs status.MultiError)
 	return updated
 }
 
+// SetNamespaceManagedByRootSync sets the NamespaceManagedByRootSync condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetNamespaceManagedByRootSync(rs *v1beta1.RepoSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RepoSyncNamespaceManagedByRootSync, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
+// SetSuspended sets the Suspended condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetSuspended(rs *v1beta1.RepoSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RepoSyncSuspended, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
+// SetStatusContention sets the StatusContention condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetStatusContention(rs *v1beta1.RepoSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RepoSyncStatusContention, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
+// SetAPIServerUnavailable sets the APIServerUnavailable condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetAPIServerUnavailable(rs *v1beta1.RepoSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RepoSyncAPIServerUnavailable, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
 // setCondition adds or updates the specified condition with a True status.
 // Returns whether the condition was updated (any change) or transitioned
 // (status change).
@@ -640,7 +640,7 @@ func TestSetReconcilerFinalizerFailure(t *testing.T) {
 		{
 			name: "Set new finalizer failure condition with error",
 			rs:   fake.RepoSyncObjectV1Beta1(testNs, configsync.RepoSyncName),
-			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID),
+			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID, ""),
 			want: []v1beta1.RepoSyncCondition{
 				// Update and transition
 				{
@@ -708,7 +708,7 @@ func TestSetReconcilerFinalizerFailure(t *testing.T) {
 						LastUpdateTime:     initialNow,
 						LastTransitionTime: initialNow,
 					})),
-			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID),
+			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID, ""),
 			want: []v1beta1.RepoSyncCondition{
 				// Update but no transition
 				{
@@ -186,6 +186,7 @@ func (r *reconciler) startReconcilingStatus(status v1alpha1.ResourceGroupStatus)
 		ObservedGeneration: status.ObservedGeneration,
 		ResourceStatuses:   status.ResourceStatuses,
 		SubgroupStatuses:   status.SubgroupStatuses,
+		PrunedResources:    status.PrunedResources,
 		Conditions: []v1alpha1.Condition{
 			newReconcilingCondition(v1alpha1.TrueConditionStatus, StartReconciling, startReconcilingMsg),
 			newStalledCondition(v1alpha1.FalseConditionStatus, "", ""),
@@ -203,7 +204,10 @@ func (r *reconciler) endReconcilingStatus(
 	generation int64,
 ) v1alpha1.ResourceGroupStatus {
 	// reset newStatus to make sure the former setting of newStatus does not carry over
-	newStatus := v1alpha1.ResourceGroupStatus{}
+	newStatus := v1alpha1.ResourceGroupStatus{
+		// The pruned resources are recorded by the applier.
+		PrunedResources: status.PrunedResources,
+	}
 	startTime := time.Now()
 	reconcileTimeout := getReconcileTimeOut(len(spec.Subgroups) + len(spec.Resources))
 
@@ -339,6 +343,7 @@ func (r *reconciler) computeStatus(
 			resStatus.Actuation = aStatus.Actuation
 			resStatus.Strategy = aStatus.Strategy
 			resStatus.Reconcile = aStatus.Reconcile
+			resStatus.ActuationTime = aStatus.ActuationTime
 
 			resStatus.Status = ActuationStatusToLegacy(resStatus)
 		}
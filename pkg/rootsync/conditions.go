@@ -59,6 +59,12 @@ func IsStalled(rs *v1beta1.RootSync) bool {
 	return cond != nil && cond.Status == metav1.ConditionTrue
 }
 
+// IsSyncing returns true if the given RootSync has a True Syncing condition.
+func IsSyncing(rs *v1beta1.RootSync) bool {
+	cond := GetCondition(rs.Status.Conditions, v1beta1.RootSyncSyncing)
+	return cond != nil && cond.Status == metav1.ConditionTrue
+}
+
 // ReconcilingMessage returns the message from a True Reconciling condition or
 // an empty string if no True Reconciling condition was found.
 func ReconcilingMessage(rs *v1beta1.RootSync) string {
@@ -152,6 +158,41 @@ func SetReconcilerFinalizerFailure(rs *v1beta1.RootSync, errs status.MultiError)
 	return updated
 }
 
+// SetPostSyncVerificationFailed sets the PostSyncVerificationFailed condition.
+// If failed, the status is True, otherwise False.
+// Use RemoveCondition to remove this condition when post-sync verification is
+// disabled.
+func SetPostSyncVerificationFailed(rs *v1beta1.RootSync, failed bool, reason, message, commit string) (updated bool) {
+	conditionStatus := metav1.ConditionFalse
+	if failed {
+		conditionStatus = metav1.ConditionTrue
+	}
+	updated, _ = setCondition(rs, v1beta1.RootSyncPostSyncVerificationFailed,
+		conditionStatus, reason, message, commit, nil, nil, nil, now())
+	return updated
+}
+
+// SetSuspended sets the Suspended condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetSuspended(rs *v1beta1.RootSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RootSyncSuspended, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
+// SetStatusContention sets the StatusContention condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetStatusContention(rs *v1beta1.RootSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RootSyncStatusContention, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
+// SetAPIServerUnavailable sets the APIServerUnavailable condition to True.
+// Use RemoveCondition to remove this condition. It should never be set to False.
+func SetAPIServerUnavailable(rs *v1beta1.RootSync, reason, message string) (updated bool) {
+	updated, _ = setCondition(rs, v1beta1.RootSyncAPIServerUnavailable, metav1.ConditionTrue, reason, message, "", nil, nil, nil, now())
+	return updated
+}
+
 // setCondition adds or updates the specified condition with a True status.
 // Returns whether the condition was updated (any change) or transitioned
 // (status change).
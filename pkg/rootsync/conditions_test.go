@@ -605,6 +605,87 @@ func TestSetReconcilerFinalizing(t *testing.T) {
 	}
 }
 
+func TestSetPostSyncVerificationFailed(t *testing.T) {
+	now = func() metav1.Time {
+		return initialNow
+	}
+	testCases := []struct {
+		name        string
+		rs          *v1beta1.RootSync
+		failed      bool
+		reason      string
+		message     string
+		commit      string
+		want        []v1beta1.RootSyncCondition
+		wantUpdated bool
+	}{
+		{
+			name:    "Set new failed condition",
+			rs:      fake.RootSyncObjectV1Beta1(configsync.RootSyncName),
+			failed:  true,
+			reason:  "JobFailed",
+			message: "Job failed",
+			commit:  "abc123",
+			want: []v1beta1.RootSyncCondition{
+				// Update and transition
+				{
+					Type:               v1beta1.RootSyncPostSyncVerificationFailed,
+					Status:             metav1.ConditionTrue,
+					Reason:             "JobFailed",
+					Message:            "Job failed",
+					Commit:             "abc123",
+					LastUpdateTime:     updatedNow,
+					LastTransitionTime: updatedNow,
+				},
+			},
+			wantUpdated: true,
+		},
+		{
+			name: "Update failed condition when a new commit is verified",
+			rs: fake.RootSyncObjectV1Beta1(configsync.RootSyncName,
+				withConditions(
+					v1beta1.RootSyncCondition{
+						Type:               v1beta1.RootSyncPostSyncVerificationFailed,
+						Status:             metav1.ConditionTrue,
+						Reason:             "JobFailed",
+						Message:            "Job failed",
+						Commit:             "abc123",
+						LastUpdateTime:     initialNow,
+						LastTransitionTime: initialNow,
+					})),
+			failed:  false,
+			reason:  "JobComplete",
+			message: "Job completed",
+			commit:  "def456",
+			want: []v1beta1.RootSyncCondition{
+				// Update and transition
+				{
+					Type:               v1beta1.RootSyncPostSyncVerificationFailed,
+					Status:             metav1.ConditionFalse,
+					Reason:             "JobComplete",
+					Message:            "Job completed",
+					Commit:             "def456",
+					LastUpdateTime:     updatedNow,
+					LastTransitionTime: updatedNow,
+				},
+			},
+			wantUpdated: true,
+		},
+	}
+	now = func() metav1.Time {
+		return updatedNow
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			updated := SetPostSyncVerificationFailed(tc.rs, tc.failed, tc.reason, tc.message, tc.commit)
+			if diff := cmp.Diff(tc.want, tc.rs.Status.Conditions); diff != "" {
+				t.Error(diff)
+			}
+			assert.Equal(t, tc.wantUpdated, updated, "updated")
+		})
+	}
+}
+
 func TestSetReconcilerFinalizerFailure(t *testing.T) {
 	deployment1 := fake.DeploymentObject()
 	deployment1ID := core.IDOf(deployment1)
@@ -639,7 +720,7 @@ func TestSetReconcilerFinalizerFailure(t *testing.T) {
 		{
 			name: "Set new finalizer failure condition with error",
 			rs:   fake.RootSyncObjectV1Beta1(configsync.RootSyncName),
-			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID),
+			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID, ""),
 			want: []v1beta1.RootSyncCondition{
 				// Update and transition
 				{
@@ -707,7 +788,7 @@ func TestSetReconcilerFinalizerFailure(t *testing.T) {
 						LastUpdateTime:     initialNow,
 						LastTransitionTime: initialNow,
 					})),
-			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID),
+			errs: applier.DeleteErrorForResource(errors.New("fake error"), deployment1ID, ""),
 			want: []v1beta1.RootSyncCondition{
 				// Update but no transition
 				{
@@ -15,7 +15,11 @@
 package status
 
 import (
+	"context"
+	"errors"
+
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -68,3 +72,37 @@ func APIServerErrorWrap(err error, resources ...client.Object) Error {
 	}
 	return errorBuilder.BuildWithResources(resources...)
 }
+
+// HasAPIServerUnavailableErrors returns whether `errs` include any APIServer
+// or applier errors caused by the API server being unavailable or overloaded,
+// rather than by the request itself.
+func HasAPIServerUnavailableErrors(errs MultiError) bool {
+	if errs == nil {
+		return false
+	}
+	for _, err := range errs.Errors() {
+		switch err.Code() {
+		case APIServerErrorCode, ApplierErrorCode:
+		default:
+			continue
+		}
+		if isAPIServerUnavailable(err.Cause()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAPIServerUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, context.DeadlineExceeded) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsConnectionReset(err)
+}
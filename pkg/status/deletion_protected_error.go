@@ -0,0 +1,37 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeletionProtectedErrorCode is the error code for objects which are removed
+// from the source, but not pruned because they are protected from deletion.
+const DeletionProtectedErrorCode = "2024"
+
+var deletionProtectedError = NewErrorBuilder(DeletionProtectedErrorCode)
+
+// DeletionProtectedError reports that an object is removed from the source,
+// but not pruned because of the deletion-protection annotation.
+// It does not block the sync.
+func DeletionProtectedError(resource client.Object) Error {
+	return deletionProtectedError.
+		Sprintf("the object is removed from the source, but not pruned because of the %s: %s annotation. "+
+			"Remove the annotation to prune the object, or declare the object in the source again",
+			metadata.DeletionProtectionAnnotationKey, metadata.DeletionProtectionEnabled).
+		BuildWithResources(resource)
+}
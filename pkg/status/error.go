@@ -248,6 +248,17 @@ func cseFromResourceError(err ResourceError) v1beta1.ConfigSyncError {
 	return cse
 }
 
+// SourceFetchErrorCodes are the codes of the errors fetching the source of
+// truth. They are counted by error code, in addition to errorclass, so that the
+// fetch failures can be told apart.
+var SourceFetchErrorCodes = []string{
+	SourceErrorCode,
+	SourceTimeoutErrorCode,
+	SourceBranchNotFoundErrorCode,
+	SourceAuthErrorCode,
+	SourceUnreachableErrorCode,
+}
+
 // CountErrorByClass counts the errors by errorclass.
 // The errorclass is a string derived from the error code. For example, the errorclass
 // of a ConfigSyncErrow with the error code of "1323" is "1xxx".
@@ -258,12 +269,21 @@ func CountErrorByClass(errs []v1beta1.ConfigSyncError) map[string]int64 {
 		if err.Code == "" {
 			continue
 		}
+		result[fmt.Sprintf("%sxxx", string(err.Code[0]))]++
+	}
+	return result
+}
 
-		errorclass := fmt.Sprintf("%sxxx", string(err.Code[0]))
-		if _, ok := result[errorclass]; !ok {
-			result[errorclass] = 1
-		} else {
-			result[errorclass]++
+// CountSourceFetchErrorByCode counts the errors fetching the source of truth by
+// error code. The other errors are not counted.
+func CountSourceFetchErrorByCode(errs []v1beta1.ConfigSyncError) map[string]int64 {
+	var result = make(map[string]int64)
+	for _, err := range errs {
+		for _, code := range SourceFetchErrorCodes {
+			if err.Code == code {
+				result[code]++
+				break
+			}
 		}
 	}
 	return result
@@ -151,6 +151,18 @@ func TestSplitErrorByClass(t *testing.T) {
 				"9xxx": 2,
 			},
 		},
+		{
+			name: "source fetch errors are counted in the 2xxx errorclass",
+			errs: []v1beta1.ConfigSyncError{
+				{Code: SourceErrorCode},
+				{Code: SourceAuthErrorCode},
+				{Code: SourceUnreachableErrorCode},
+				{Code: "2122"},
+			},
+			want: map[string]int64{
+				"2xxx": 4,
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -160,3 +172,22 @@ func TestSplitErrorByClass(t *testing.T) {
 		})
 	}
 }
+
+func TestCountSourceFetchErrorByCode(t *testing.T) {
+	errs := []v1beta1.ConfigSyncError{
+		{Code: SourceErrorCode},
+		{Code: SourceAuthErrorCode},
+		{Code: SourceAuthErrorCode},
+		{Code: SourceUnreachableErrorCode},
+		{Code: "2122"},
+		{Code: "9998"},
+	}
+	want := map[string]int64{
+		SourceErrorCode:            1,
+		SourceAuthErrorCode:        2,
+		SourceUnreachableErrorCode: 1,
+	}
+	if got := CountSourceFetchErrorByCode(errs); !cmp.Equal(got, want) {
+		t.Errorf("CountSourceFetchErrorByCode(errs) = %v, want %v", got, want)
+	}
+}
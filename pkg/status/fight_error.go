@@ -14,7 +14,11 @@
 
 package status
 
-import "sigs.k8s.io/controller-runtime/pkg/client"
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 // FightErrorCode is the error code for Config Sync fighting with other controllers.
 const FightErrorCode = "2005"
@@ -28,3 +32,13 @@ func FightError(frequency float64, resource client.Object) ResourceError {
 		"This may indicate Config Sync is fighting with another controller over the object.", int(frequency)).
 		BuildWithResources(resource)
 }
+
+// RemediationThrottledError represents when the remediator delays correcting a
+// resource object, because the object was already corrected less than the
+// minimum remediation interval ago.
+func RemediationThrottledError(minInterval time.Duration, resource client.Object) ResourceError {
+	return fightErrorBuilder.Sprintf("detected object updates within the minimum remediation interval of %v, "+
+		"so further corrections are throttled. "+
+		"This may indicate Config Sync is fighting with another controller over the object.", minInterval).
+		BuildWithResources(resource)
+}
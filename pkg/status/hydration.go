@@ -20,6 +20,22 @@ const InternalHydrationErrorCode = "2015"
 // ActionableHydrationErrorCode is the error code for a user actionable Error related to the hydration process.
 const ActionableHydrationErrorCode = "1068"
 
+// RenderingTimeoutErrorCode is the error code for an Error caused by the
+// rendering of a commit taking longer than the configured timeout.
+const RenderingTimeoutErrorCode = "2020"
+
+// RenderingTimeoutError is an ErrorBuilder for errors caused by the rendering of
+// a commit taking longer than the configured timeout.
+var RenderingTimeoutError = NewErrorBuilder(RenderingTimeoutErrorCode)
+
+// RenderingChecksumMismatchErrorCode is the error code for an Error caused by
+// the hydrated configs not being rendered from the current source configs.
+const RenderingChecksumMismatchErrorCode = "2021"
+
+// RenderingChecksumMismatchError is an ErrorBuilder for errors caused by the
+// hydrated configs not being rendered from the current source configs.
+var RenderingChecksumMismatchError = NewErrorBuilder(RenderingChecksumMismatchErrorCode)
+
 // internalHydrationErrorBuilder is an ErrorBuilder for internal errors related to the hydration process.
 var internalHydrationErrorBuilder = NewErrorBuilder(InternalHydrationErrorCode)
 
@@ -34,6 +34,8 @@ var ManagementConflictErrorBuilder = NewErrorBuilder(ManagementConflictErrorCode
 type ManagementConflictError interface {
 	// ConflictingManager returns the annotation value of the other conflicting manager.
 	ConflictingManager() string
+	// NewManager returns the annotation value of the manager which detected the conflict.
+	NewManager() string
 	// CurrentManagerError returns the error that will be surfaced to the current manager.
 	CurrentManagerError() ManagementConflictError
 	// ConflictingManagerError returns the error that will be surfaced to the other conflicting manager.
@@ -70,6 +72,10 @@ func (m managementConflictErrorImpl) ConflictingManager() string {
 	return m.currentManager
 }
 
+func (m managementConflictErrorImpl) NewManager() string {
+	return m.newManager
+}
+
 func (m managementConflictErrorImpl) CurrentManagerError() ManagementConflictError {
 	return ManagementConflictErrorBuilder.
 		Sprint(currentErrorMsg(m.newManager, m.newManager)).
@@ -34,6 +34,9 @@ type MultiError interface {
 var nonBlockingErrorCodes = map[string]struct{}{
 	UnknownKindErrorCode:         {},
 	EncodeDeclaredFieldErrorCode: {},
+	DeletionProtectedErrorCode:   {},
+	ObservedDriftErrorCode:       {},
+	RemediationPausedErrorCode:   {},
 }
 
 // HasBlockingErrors return whether `errs` include any blocking errors.
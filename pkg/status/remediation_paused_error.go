@@ -0,0 +1,34 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RemediationPausedErrorCode is the error code for objects which drifted from
+// the source of an RSync while applying is paused.
+const RemediationPausedErrorCode = "2026"
+
+var remediationPausedError = NewErrorBuilder(RemediationPausedErrorCode)
+
+// RemediationPausedError reports that an object drifted from the source, but
+// is not corrected because applying is paused.
+// It does not block the sync.
+func RemediationPausedError(operation string, resource client.Object) ResourceError {
+	return remediationPausedError.
+		Sprintf("detected that the object drifted from the source, but the remediator did not %s it because applying is paused", operation).
+		BuildWithResources(resource)
+}
@@ -19,3 +19,35 @@ const SourceErrorCode = "2004"
 
 // SourceError is an ErrorBuilder for errors related to the repo's source of truth.
 var SourceError = NewErrorBuilder(SourceErrorCode)
+
+// SourceTimeoutErrorCode is the error code for a status Error caused by fetching
+// the repo's source of truth taking longer than the configured timeout.
+const SourceTimeoutErrorCode = "2018"
+
+// SourceTimeoutError is an ErrorBuilder for errors caused by fetching the repo's
+// source of truth taking longer than the configured timeout.
+var SourceTimeoutError = NewErrorBuilder(SourceTimeoutErrorCode)
+
+// SourceBranchNotFoundErrorCode is the error code for a status Error caused by
+// the configured branch not existing in the repo's source of truth.
+const SourceBranchNotFoundErrorCode = "2019"
+
+// SourceBranchNotFoundError is an ErrorBuilder for errors caused by the
+// configured branch not existing in the repo's source of truth.
+var SourceBranchNotFoundError = NewErrorBuilder(SourceBranchNotFoundErrorCode)
+
+// SourceAuthErrorCode is the error code for a status Error caused by failing
+// to authenticate to, or being denied access to, the repo's source of truth.
+const SourceAuthErrorCode = "2022"
+
+// SourceAuthError is an ErrorBuilder for errors caused by failing to
+// authenticate to, or being denied access to, the repo's source of truth.
+var SourceAuthError = NewErrorBuilder(SourceAuthErrorCode)
+
+// SourceUnreachableErrorCode is the error code for a status Error caused by
+// the host of the repo's source of truth being unreachable.
+const SourceUnreachableErrorCode = "2023"
+
+// SourceUnreachableError is an ErrorBuilder for errors caused by the host of
+// the repo's source of truth being unreachable.
+var SourceUnreachableError = NewErrorBuilder(SourceUnreachableErrorCode)
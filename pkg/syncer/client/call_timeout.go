@@ -0,0 +1,103 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// CallTimeoutConfig returns a copy of cfg whose clients bound each create,
+// update, patch, or delete request by timeout, for the clients which are not
+// built on Client, like the ones of the applier.
+// Patch and delete requests which time out are retried up to defaultMaxTries
+// times.
+// Create and update requests are not retried, because they may have succeeded
+// on the server, and the applier retries them on the next sync.
+// Zero timeout returns cfg unchanged.
+func CallTimeoutConfig(cfg *rest.Config, timeout time.Duration) *rest.Config {
+	if timeout <= 0 {
+		return cfg
+	}
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return callTimeoutRoundTripper{delegate: rt, timeout: timeout, maxTries: defaultMaxTries}
+	})
+	return cfg
+}
+
+// callTimeoutRoundTripper is an http.RoundTripper which bounds each request
+// which is not a read by a timeout.
+type callTimeoutRoundTripper struct {
+	delegate http.RoundTripper
+	timeout  time.Duration
+	maxTries int
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt callTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		// Reads include long-running watches, which must not time out.
+		return rt.delegate.RoundTrip(req)
+	}
+	retriable := req.Method == http.MethodPatch || req.Method == http.MethodDelete
+	var err error
+	for tryNum := 0; tryNum < rt.maxTries; tryNum++ {
+		if tryNum > 0 {
+			if req.GetBody == nil {
+				return nil, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		callCtx, cancel := context.WithTimeout(req.Context(), rt.timeout)
+		var resp *http.Response
+		resp, err = rt.delegate.RoundTrip(req.WithContext(callCtx))
+		if err == nil {
+			// Keep the call context until the response body is read.
+			resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		cancel()
+		if !retriable || !isCallTimeout(req.Context(), err) {
+			return nil, err
+		}
+		klog.V(2).Infof("API call timed out after %v, attempt=%d: %s %s", rt.timeout, tryNum+1, req.Method, req.URL.Path)
+	}
+	return nil, err
+}
+
+// cancelOnClose is an io.ReadCloser which cancels a context when it is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
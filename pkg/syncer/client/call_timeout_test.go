@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	syncerclient "kpt.dev/configsync/pkg/syncer/client"
+)
+
+func TestCallTimeoutConfig(t *testing.T) {
+	const callTimeout = 50 * time.Millisecond
+	var mux sync.Mutex
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		calls[r.Method]++
+		// The first call of each method is slow, and every create is slow.
+		slow := calls[r.Method] == 1 || r.Method == http.MethodPost
+		mux.Unlock()
+		if slow {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(10 * callTimeout):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm","namespace":"foo"}}`))
+	}))
+	defer server.Close()
+
+	cs, err := kubernetes.NewForConfig(syncerclient.CallTimeoutConfig(&rest.Config{Host: server.URL}, callTimeout))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Reads are not bounded by the call timeout.
+	_, err = cs.CoreV1().ConfigMaps("foo").Get(ctx, "cm", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// Patches which time out are retried.
+	_, err = cs.CoreV1().ConfigMaps("foo").Patch(ctx, "cm", types.MergePatchType, []byte(`{"data":{"key":"value"}}`), metav1.PatchOptions{})
+	require.NoError(t, err)
+
+	// Creates which time out are not retried.
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "foo"}}
+	_, err = cs.CoreV1().ConfigMaps("foo").Create(ctx, cm, metav1.CreateOptions{})
+	assert.Error(t, err)
+
+	mux.Lock()
+	defer mux.Unlock()
+	assert.Equal(t, map[string]int{
+		http.MethodGet:   1,
+		http.MethodPatch: 2,
+		http.MethodPost:  1,
+	}, calls)
+}
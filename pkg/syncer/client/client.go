@@ -39,13 +39,22 @@ type Client struct {
 	client.Client
 	latencyMetric *prometheus.HistogramVec
 	MaxTries      int
+	// CallTimeout bounds the context of each individual create, update, patch,
+	// or delete call. Calls which time out are retried up to MaxTries times,
+	// except Update, because an update which timed out may have succeeded on
+	// the server, and retrying it would fail with a conflict.
+	// Zero means no per-call timeout.
+	CallTimeout time.Duration
 }
 
+// defaultMaxTries is the default number of tries of each call.
+const defaultMaxTries = 5
+
 // New returns a new Client.
 func New(client client.Client, latencyMetric *prometheus.HistogramVec) *Client {
 	return &Client{
 		Client:        client,
-		MaxTries:      5,
+		MaxTries:      defaultMaxTries,
 		latencyMetric: latencyMetric,
 	}
 }
@@ -66,7 +75,17 @@ func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.C
 	klog.V(1).Infof("Creating %s", description)
 
 	start := time.Now()
-	err := c.Client.Create(ctx, obj, opts...)
+	retried := false
+	err := c.withCallTimeout(ctx, func(ctx context.Context) error {
+		err := c.Client.Create(ctx, obj, opts...)
+		if retried && apierrors.IsAlreadyExists(err) {
+			// The create call which timed out succeeded after all.
+			klog.V(2).Infof("Create of %s timed out but succeeded", description)
+			return c.Client.Get(ctx, getNamespacedName(obj), obj)
+		}
+		retried = true
+		return err
+	})
 	c.recordLatency(start, "Create", metrics.StatusLabel(err))
 	m.RecordAPICallDuration(ctx, "create", m.StatusTagKey(err), start)
 
@@ -103,7 +122,9 @@ func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.D
 
 	start := time.Now()
 	opts = append(opts, client.PropagationPolicy(metav1.DeletePropagationBackground))
-	err := c.Client.Delete(ctx, obj, opts...)
+	err := c.withCallTimeout(ctx, func(ctx context.Context) error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
 
 	switch {
 	case err == nil:
@@ -182,7 +203,9 @@ func (c *Client) apply(ctx context.Context, obj client.Object, updateFn update,
 		}
 
 		start := time.Now()
-		err = clientUpdateFn(ctx, newObj)
+		err = c.withCallTimeout(ctx, func(ctx context.Context) error {
+			return clientUpdateFn(ctx, newObj)
+		})
 		c.recordLatency(start, "update", metrics.StatusLabel(err))
 		m.RecordAPICallDuration(ctx, "update", m.StatusTagKey(err), start)
 
@@ -211,12 +234,19 @@ func (c *Client) apply(ctx context.Context, obj client.Object, updateFn update,
 
 // Update posts the update to the server and records latency metrics.
 // Specify a ResourceVersion to avoid overwriting asynchronous changes.
+// The call is bounded by CallTimeout, but not retried if it times out.
 func (c *Client) Update(ctx context.Context, obj client.Object) status.Error {
 	description := getResourceInfo(obj)
 	klog.V(1).Infof("Will update %s to %s", description, spew.Sdump(obj))
 	oldV := resourceVersion(obj)
 	start := time.Now()
-	err := c.Client.Update(ctx, obj)
+	callCtx := ctx
+	if c.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, c.CallTimeout)
+		defer cancel()
+	}
+	err := c.Client.Update(callCtx, obj)
 	c.recordLatency(start, "update", metrics.StatusLabel(err))
 	m.RecordAPICallDuration(ctx, "update", m.StatusTagKey(err), start)
 	switch {
@@ -234,6 +264,43 @@ func (c *Client) Update(ctx context.Context, obj client.Object) status.Error {
 	return nil
 }
 
+// Patch patches the given obj in the Kubernetes cluster, bounding the call by
+// CallTimeout.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.withCallTimeout(ctx, func(ctx context.Context) error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+// withCallTimeout calls fn with a context bounded by CallTimeout.
+// If the call times out, it is retried up to MaxTries times, unless the parent
+// context is done.
+func (c *Client) withCallTimeout(ctx context.Context, fn func(context.Context) error) error {
+	if c.CallTimeout <= 0 {
+		return fn(ctx)
+	}
+	var err error
+	for tryNum := 0; tryNum < c.MaxTries; tryNum++ {
+		callCtx, cancel := context.WithTimeout(ctx, c.CallTimeout)
+		err = fn(callCtx)
+		cancel()
+		if !isCallTimeout(ctx, err) {
+			return err
+		}
+		klog.V(2).Infof("API call timed out after %v, attempt=%d: %v", c.CallTimeout, tryNum+1, err)
+	}
+	return err
+}
+
+// isCallTimeout returns true if the error is a transient timeout of a single
+// call, and the parent context is not done.
+func isCallTimeout(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	return errors.Is(err, context.DeadlineExceeded) || apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err)
+}
+
 func (c *Client) recordLatency(start time.Time, lvs ...string) {
 	if c.latencyMetric == nil {
 		return
@@ -17,6 +17,7 @@ package client_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -71,6 +72,42 @@ func TestClient_Create(t *testing.T) {
 	}
 }
 
+// slowCreateClient is a client whose first Create call creates the object, but
+// blocks until its context is done, like a call which times out after the
+// server handled it.
+type slowCreateClient struct {
+	client.Client
+	calls int
+}
+
+func (c *slowCreateClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.calls++
+	err := c.Client.Create(ctx, obj, opts...)
+	if c.calls == 1 && err == nil {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return err
+}
+
+func TestClient_Create_TimedOut(t *testing.T) {
+	fakeClient := syncertestfake.NewClient(t, core.Scheme)
+	slowClient := &slowCreateClient{Client: fakeClient}
+	c := syncerclient.New(slowClient, nil)
+	c.CallTimeout = 50 * time.Millisecond
+
+	declared := fake.RoleObject(core.Name("admin"), core.Namespace("billing"))
+	if err := c.Create(context.Background(), declared); err != nil {
+		t.Errorf("Create() got error %v, want nil", err)
+	}
+	if slowClient.calls != 2 {
+		t.Errorf("Create() made %d calls, want 2", slowClient.calls)
+	}
+	if declared.GetUID() == "" {
+		t.Errorf("Create() did not populate the created object")
+	}
+}
+
 func TestClient_Apply(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -156,3 +193,41 @@ func TestClient_Update(t *testing.T) {
 		})
 	}
 }
+
+// slowUpdateClient is a client whose Update calls update the object, but block
+// until their context is done, like calls which time out after the server
+// handled them.
+type slowUpdateClient struct {
+	client.Client
+	calls int
+}
+
+func (c *slowUpdateClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.calls++
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestClient_Update_TimedOut(t *testing.T) {
+	fakeClient := syncertestfake.NewClient(t, core.Scheme)
+	declared := fake.RoleObject(core.Name("admin"), core.Namespace("billing"))
+	if err := fakeClient.Create(context.Background(), declared); err != nil {
+		t.Fatal(err)
+	}
+	slowClient := &slowUpdateClient{Client: fakeClient}
+	c := syncerclient.New(slowClient, nil)
+	c.CallTimeout = 50 * time.Millisecond
+
+	// The update which timed out is not retried, since a retry would fail
+	// with a conflict if the first update succeeded on the server.
+	declared.SetLabels(map[string]string{"team": "billing"})
+	if err := c.Update(context.Background(), declared); err == nil {
+		t.Errorf("Update() got nil error, want a timeout error")
+	}
+	if slowClient.calls != 1 {
+		t.Errorf("Update() made %d calls, want 1", slowClient.calls)
+	}
+}
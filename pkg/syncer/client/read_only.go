@@ -0,0 +1,131 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrReadOnly is returned by the read-only clients for every write.
+var ErrReadOnly = errors.New("writes are rejected in observe mode")
+
+// readOnlyClient is a client.Client which rejects every write, so that a
+// reconciler in observe mode can never mutate the cluster.
+type readOnlyClient struct {
+	client.Client
+}
+
+var _ client.Client = readOnlyClient{}
+
+// NewReadOnly returns a client.Client which reads through c, and returns
+// ErrReadOnly for every Create, Update, Patch, and Delete call, including
+// those of the subresources.
+func NewReadOnly(c client.Client) client.Client {
+	return readOnlyClient{Client: c}
+}
+
+// Create implements client.Writer.
+func (c readOnlyClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	return readOnlyError("create", obj)
+}
+
+// Update implements client.Writer.
+func (c readOnlyClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return readOnlyError("update", obj)
+}
+
+// Patch implements client.Writer.
+func (c readOnlyClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return readOnlyError("patch", obj)
+}
+
+// Delete implements client.Writer.
+func (c readOnlyClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	return readOnlyError("delete", obj)
+}
+
+// DeleteAllOf implements client.Writer.
+func (c readOnlyClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	return readOnlyError("delete all of", obj)
+}
+
+// Status implements client.StatusClient.
+func (c readOnlyClient) Status() client.SubResourceWriter {
+	return readOnlySubResourceClient{SubResourceReader: c.Client.SubResource("status")}
+}
+
+// SubResource implements client.SubResourceClientConstructor.
+func (c readOnlyClient) SubResource(subResource string) client.SubResourceClient {
+	return readOnlySubResourceClient{SubResourceReader: c.Client.SubResource(subResource)}
+}
+
+// readOnlySubResourceClient is a client.SubResourceClient which rejects every
+// write.
+type readOnlySubResourceClient struct {
+	client.SubResourceReader
+}
+
+// Create implements client.SubResourceWriter.
+func (c readOnlySubResourceClient) Create(_ context.Context, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return readOnlyError("create", obj)
+}
+
+// Update implements client.SubResourceWriter.
+func (c readOnlySubResourceClient) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return readOnlyError("update", obj)
+}
+
+// Patch implements client.SubResourceWriter.
+func (c readOnlySubResourceClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	return readOnlyError("patch", obj)
+}
+
+func readOnlyError(verb string, obj client.Object) error {
+	return errors.Wrapf(ErrReadOnly, "failed to %s %s", verb, core.GKNN(obj))
+}
+
+// ReadOnlyConfig returns a copy of cfg whose clients return ErrReadOnly for
+// every request which is not a read, for the clients which are not built on
+// client.Client, like the dynamic client.
+func ReadOnlyConfig(cfg *rest.Config) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return readOnlyRoundTripper{delegate: rt}
+	})
+	return cfg
+}
+
+// readOnlyRoundTripper is an http.RoundTripper which rejects every request
+// which is not a read.
+type readOnlyRoundTripper struct {
+	delegate http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt readOnlyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return rt.delegate.RoundTrip(req)
+	default:
+		return nil, fmt.Errorf("failed to %s %s: %w", req.Method, req.URL.Path, ErrReadOnly)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"kpt.dev/configsync/pkg/core"
+	syncerclient "kpt.dev/configsync/pkg/syncer/client"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	existing := fake.ConfigMapObject(core.Namespace("foo"), core.Name("existing"))
+	fakeClient := syncertestfake.NewClient(t, core.Scheme, existing)
+	c := syncerclient.NewReadOnly(fakeClient)
+
+	// Reads are allowed.
+	got := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), got))
+	require.NoError(t, c.List(ctx, &corev1.ConfigMapList{}))
+
+	// Writes are rejected.
+	created := fake.ConfigMapObject(core.Namespace("foo"), core.Name("created"))
+	updated := got.DeepCopy()
+	updated.Data = map[string]string{"key": "value"}
+	writes := map[string]error{
+		"create":        c.Create(ctx, created),
+		"update":        c.Update(ctx, updated),
+		"patch":         c.Patch(ctx, updated, client.Merge),
+		"delete":        c.Delete(ctx, got),
+		"delete all of": c.DeleteAllOf(ctx, &corev1.ConfigMap{}, client.InNamespace("foo")),
+		"status update": c.Status().Update(ctx, updated),
+		"status patch":  c.Status().Patch(ctx, updated, client.Merge),
+	}
+	for name, err := range writes {
+		assert.ErrorIs(t, err, syncerclient.ErrReadOnly, name)
+	}
+
+	// The cluster is left untouched.
+	fakeClient.Check(t, existing)
+}
+
+func TestReadOnlyConfig(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"cm","namespace":"foo"}}`))
+	}))
+	defer server.Close()
+
+	cs, err := kubernetes.NewForConfig(syncerclient.ReadOnlyConfig(&rest.Config{Host: server.URL}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Reads are sent to the API server.
+	_, err = cs.CoreV1().ConfigMaps("foo").Get(ctx, "cm", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// Writes are rejected before reaching the API server.
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "foo"}}
+	_, err = cs.CoreV1().ConfigMaps("foo").Create(ctx, cm, metav1.CreateOptions{})
+	assert.ErrorIs(t, err, syncerclient.ErrReadOnly)
+	_, err = cs.CoreV1().ConfigMaps("foo").Update(ctx, cm, metav1.UpdateOptions{})
+	assert.ErrorIs(t, err, syncerclient.ErrReadOnly)
+	err = cs.CoreV1().ConfigMaps("foo").Delete(ctx, "cm", metav1.DeleteOptions{})
+	assert.ErrorIs(t, err, syncerclient.ErrReadOnly)
+
+	assert.Equal(t, []string{http.MethodGet}, methods)
+}
@@ -23,6 +23,7 @@ import (
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/lifecycle"
 	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Type indicates the state of the given resource
@@ -128,6 +129,12 @@ func (d Diff) Type() Type {
 			return NoOp
 		}
 
+		if HasDeletionProtection(d.Actual) {
+			// This object is protected from deletion until the annotation is
+			// removed. The applier reports it and prunes it afterwards.
+			return NoOp
+		}
+
 		if lifecycle.HasPreventDeletion(d.Actual) {
 			// This object is marked with the lifecycle annotation that says to not
 			// delete it. We should orphan the objects by unmanaging them.
@@ -212,3 +219,9 @@ type invalidInput struct {
 func (i *invalidInput) String() string {
 	return i.desc
 }
+
+// HasDeletionProtection returns true if the object has the deletion-protection
+// annotation set to enabled.
+func HasDeletionProtection(obj client.Object) bool {
+	return obj.GetAnnotations()[metadata.DeletionProtectionAnnotationKey] == metadata.DeletionProtectionEnabled
+}
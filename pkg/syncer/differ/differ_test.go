@@ -175,6 +175,14 @@ func TestDiffType(t *testing.T) {
 			actual:     buildUnstructured(managedByConfigSync(), owned()),
 			expectType: NoOp,
 		},
+		{
+			name: "in cluster only with deletion protection, noop",
+			actual: buildUnstructured(managedByConfigSync(),
+				func(u *unstructured.Unstructured) {
+					core.SetAnnotation(u, metadata.DeletionProtectionAnnotationKey, metadata.DeletionProtectionEnabled)
+				}),
+			expectType: NoOp,
+		},
 		{
 			name: "in cluster only and owned and prevent deletion, unmanage",
 			actual: buildUnstructured(managedByConfigSync(), owned(),
@@ -37,7 +37,6 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/util"
 	"k8s.io/kubectl/pkg/util/openapi"
-	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
@@ -69,16 +68,18 @@ type clientApplier struct {
 	openAPIResources openapi.Resources
 	client           *syncerclient.Client
 	fights           fight.Detector
+	fieldManager     string
 }
 
 var _ Applier = &clientApplier{}
 
 // NewApplierForMultiRepo returns a new clientApplier for callers with multi repo feature enabled.
-func NewApplierForMultiRepo(cfg *rest.Config, client *syncerclient.Client) (Applier, error) {
-	return newApplier(cfg, client)
+// The fieldManager is the field manager name used for server-side apply.
+func NewApplierForMultiRepo(cfg *rest.Config, client *syncerclient.Client, fieldManager string) (Applier, error) {
+	return newApplier(cfg, client, fieldManager)
 }
 
-func newApplier(cfg *rest.Config, client *syncerclient.Client) (Applier, error) {
+func newApplier(cfg *rest.Config, client *syncerclient.Client, fieldManager string) (Applier, error) {
 	c, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -100,6 +101,7 @@ func newApplier(cfg *rest.Config, client *syncerclient.Client) (Applier, error)
 		openAPIResources: oa,
 		client:           client,
 		fights:           fight.NewDetector(),
+		fieldManager:     fieldManager,
 	}, nil
 }
 
@@ -111,7 +113,7 @@ func (c *clientApplier) Create(ctx context.Context, intendedState *unstructured.
 	if intendedState.GroupVersionKind().GroupKind() == kinds.APIService().GroupKind() {
 		err = c.create(ctx, intendedState)
 	} else {
-		if err1 := c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(configsync.FieldManager)); err1 != nil {
+		if err1 := c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(c.fieldManager)); err1 != nil {
 			err = status.ResourceWrap(err1, "unable to apply resource", intendedState)
 		}
 	}
@@ -147,6 +149,15 @@ func (c *clientApplier) Update(ctx context.Context, intendedState, currentState
 		return status.ResourceWrap(err, "unable to update resource", intendedState)
 	}
 
+	if metadata.HasReconcileNow(currentState) {
+		if err := ClearReconcileNow(ctx, c.client.Client, currentState, c.fieldManager); err != nil {
+			// The annotation is advisory, so the force-apply is retried until
+			// the annotation is cleared.
+			klog.Warningf("Failed to clear the %s annotation of %v: %v",
+				metadata.ReconcileNowAnnotationKey, core.GKNN(currentState), err)
+		}
+	}
+
 	updated := !isNoOpPatch(patch)
 	if updated {
 		logFight, err := c.fights.DetectFight(time.Now(), intendedState)
@@ -238,16 +249,18 @@ func (c *clientApplier) update(ctx context.Context, intendedState, currentState
 	objCopy := intendedState.DeepCopy()
 	// Run the server-side apply dryrun first.
 	// If the returned object doesn't change, skip running server-side apply.
-	err := c.client.Patch(ctx, objCopy, client.Apply, client.FieldOwner(configsync.FieldManager), client.ForceOwnership, client.DryRunAll)
+	err := c.client.Patch(ctx, objCopy, client.Apply, client.FieldOwner(c.fieldManager), client.ForceOwnership, client.DryRunAll)
 	if err != nil {
 		return nil, err
 	}
-	if equal(objCopy, currentState) {
+	// Force the apply if the reconcile-now annotation is set, even if the
+	// object does not change.
+	if equal(objCopy, currentState) && !metadata.HasReconcileNow(currentState) {
 		return nil, nil
 	}
 
 	start := time.Now()
-	err = c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(configsync.FieldManager), client.ForceOwnership)
+	err = c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(c.fieldManager), client.ForceOwnership)
 	duration := time.Since(start).Seconds()
 	metrics.APICallDuration.WithLabelValues("update", metrics.StatusLabel(err)).Observe(duration)
 	m.RecordAPICallDuration(ctx, "update", m.StatusTagKey(err), start)
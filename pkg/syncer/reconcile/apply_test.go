@@ -15,12 +15,17 @@
 package reconcile
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
+	syncerclient "kpt.dev/configsync/pkg/syncer/client"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 	"kpt.dev/configsync/pkg/testing/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -73,3 +78,94 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+// slowPatchClient is a client whose first slowCalls Patch calls block until
+// their context is done.
+type slowPatchClient struct {
+	client.Client
+	slowCalls int
+
+	mux       sync.Mutex
+	calls     int
+	deadlines []time.Duration
+}
+
+func (c *slowPatchClient) Patch(ctx context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.mux.Lock()
+	c.calls++
+	slow := c.calls <= c.slowCalls
+	if deadline, ok := ctx.Deadline(); ok {
+		c.deadlines = append(c.deadlines, time.Until(deadline))
+	}
+	c.mux.Unlock()
+	if slow {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func TestCreate_ApplyCallTimeout(t *testing.T) {
+	testcases := []struct {
+		name        string
+		callTimeout time.Duration
+		slowCalls   int
+		wantCalls   int
+		wantErr     bool
+	}{
+		{
+			name:        "no per-call timeout",
+			callTimeout: 0,
+			slowCalls:   0,
+			wantCalls:   1,
+		},
+		{
+			name:        "slow call is retried",
+			callTimeout: 50 * time.Millisecond,
+			slowCalls:   2,
+			wantCalls:   3,
+		},
+		{
+			name:        "every call is slow",
+			callTimeout: 50 * time.Millisecond,
+			slowCalls:   10,
+			wantCalls:   5,
+			wantErr:     true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &slowPatchClient{slowCalls: tc.slowCalls}
+			sc := syncerclient.New(fakeClient, nil)
+			sc.CallTimeout = tc.callTimeout
+			applier := &clientApplier{
+				client: sc,
+				fights: fight.NewDetector(),
+			}
+
+			err := applier.Create(context.Background(), fake.UnstructuredObject(kinds.Role(), core.Name("admin"), core.Namespace("billing")))
+			if tc.wantErr && err == nil {
+				t.Errorf("Create() got nil error, want error")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("Create() got error %v, want nil", err)
+			}
+			if fakeClient.calls != tc.wantCalls {
+				t.Errorf("Create() made %d Patch calls, want %d", fakeClient.calls, tc.wantCalls)
+			}
+			if tc.callTimeout == 0 {
+				if len(fakeClient.deadlines) != 0 {
+					t.Errorf("Create() set a deadline on Patch calls, want none")
+				}
+				return
+			}
+			if len(fakeClient.deadlines) != tc.wantCalls {
+				t.Fatalf("Create() set a deadline on %d Patch calls, want %d", len(fakeClient.deadlines), tc.wantCalls)
+			}
+			for _, d := range fakeClient.deadlines {
+				if d > tc.callTimeout {
+					t.Errorf("Create() Patch call deadline %v exceeds the call timeout %v", d, tc.callTimeout)
+				}
+			}
+		})
+	}
+}
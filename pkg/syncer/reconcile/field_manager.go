@@ -0,0 +1,65 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RemoveStaleFieldManager removes the server-side apply managed fields of the
+// staleManager from the object on the cluster, once the object is applied
+// with fieldManager.
+// Otherwise the fields which were applied with the staleManager stay owned
+// by it, and are not removed from the object when removed from the source.
+// It is a no-op if the object has no managed fields from the staleManager.
+func RemoveStaleFieldManager(ctx context.Context, c client.Client, obj *unstructured.Unstructured, staleManager, fieldManager string) error {
+	if staleManager == fieldManager {
+		return nil
+	}
+	entries := obj.GetManagedFields()
+	var kept []metav1.ManagedFieldsEntry
+	for _, entry := range entries {
+		if entry.Manager == staleManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	// An empty list would reset the managed fields of every manager.
+	if len(kept) == len(entries) || len(kept) == 0 {
+		return nil
+	}
+	// The resourceVersion makes the patch fail with a conflict, instead of
+	// dropping the managed fields of concurrent updates.
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": obj.GetResourceVersion(),
+			"managedFields":   kept,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(obj.GroupVersionKind())
+	u.SetNamespace(obj.GetNamespace())
+	u.SetName(obj.GetName())
+	return c.Patch(ctx, u, client.RawPatch(types.MergePatchType, patch), client.FieldOwner(fieldManager))
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestRemoveStaleFieldManager(t *testing.T) {
+	staleEntry := metav1.ManagedFieldsEntry{Manager: configsync.FieldManager, Operation: metav1.ManagedFieldsOperationApply}
+	newEntry := metav1.ManagedFieldsEntry{Manager: "team-a-sync", Operation: metav1.ManagedFieldsOperationApply}
+	updateEntry := metav1.ManagedFieldsEntry{Manager: configsync.FieldManager, Operation: metav1.ManagedFieldsOperationUpdate}
+	testCases := []struct {
+		name          string
+		fieldManager  string
+		managedFields []metav1.ManagedFieldsEntry
+		wantPatch     string
+	}{
+		{
+			name:          "stale apply entry is removed",
+			fieldManager:  "team-a-sync",
+			managedFields: []metav1.ManagedFieldsEntry{staleEntry, newEntry, updateEntry},
+			wantPatch: `{"metadata":{"resourceVersion":"7","managedFields":[` +
+				`{"manager":"team-a-sync","operation":"Apply"},` +
+				`{"manager":"configsync.gke.io","operation":"Update"}]}}`,
+		},
+		{
+			name:          "no stale entry",
+			fieldManager:  "team-a-sync",
+			managedFields: []metav1.ManagedFieldsEntry{newEntry, updateEntry},
+		},
+		{
+			name:          "field manager unchanged",
+			fieldManager:  configsync.FieldManager,
+			managedFields: []metav1.ManagedFieldsEntry{staleEntry},
+		},
+		{
+			name:          "only the stale entry",
+			fieldManager:  "team-a-sync",
+			managedFields: []metav1.ManagedFieldsEntry{staleEntry},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := fake.UnstructuredObject(kinds.Role(), core.Name("admin"), core.Namespace("billing"))
+			obj.SetResourceVersion("7")
+			obj.SetManagedFields(tc.managedFields)
+			c := &recordingPatchClient{}
+
+			err := RemoveStaleFieldManager(context.Background(), c, obj, configsync.FieldManager, tc.fieldManager)
+			require.NoError(t, err)
+
+			if tc.wantPatch == "" {
+				assert.Nil(t, c.patch, "unexpected patch")
+				return
+			}
+			assert.Equal(t, types.MergePatchType, c.patch.Type())
+			assert.JSONEq(t, tc.wantPatch, string(c.data))
+			assert.Equal(t, tc.fieldManager, c.opts.FieldManager)
+			assert.Equal(t, core.IDOf(obj), core.IDOf(c.obj))
+		})
+	}
+}
@@ -16,6 +16,7 @@ package fight
 
 import (
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -86,6 +87,52 @@ func (d *Detector) DetectFight(now time.Time, obj client.Object) (bool, status.R
 	return false, nil
 }
 
+// RecordUpdate records an update to the resource without detecting a fight.
+// Returns the estimated frequency of updates per minute.
+func (d *Detector) RecordUpdate(now time.Time, id core.ID) float64 {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if d.fights[id] == nil {
+		d.fights[id] = &fight{}
+	}
+	return d.fights[id].refreshUpdateFrequency(now)
+}
+
+// UpdateFrequency is the estimated frequency of updates to a resource.
+type UpdateFrequency struct {
+	// ID identifies the resource.
+	ID core.ID
+	// PerMinute is the estimated number of updates per minute.
+	PerMinute float64
+}
+
+// FrequentUpdates returns at most limit resources whose estimated frequency of
+// updates at now is at least threshold, most frequently updated first.
+func (d *Detector) FrequentUpdates(now time.Time, threshold float64, limit int) []UpdateFrequency {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+
+	var result []UpdateFrequency
+	for id, f := range d.fights {
+		frequency := f.updateFrequencyAt(now)
+		if frequency < threshold {
+			continue
+		}
+		result = append(result, UpdateFrequency{ID: id, PerMinute: frequency})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].PerMinute != result[j].PerMinute {
+			return result[i].PerMinute > result[j].PerMinute
+		}
+		return result[i].ID.String() < result[j].ID.String()
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
 // fight estimates how often a specific API resource is updated by the Syncer.
 type fight struct {
 	// heat is an estimate of the number of times a resource is updated per minute.
@@ -128,3 +175,10 @@ func (f *fight) refreshUpdateFrequency(now time.Time) float64 {
 	f.heat++
 	return f.heat
 }
+
+// updateFrequencyAt returns the estimated frequency of updates per minute at
+// now, without recording an update.
+func (f *fight) updateFrequencyAt(now time.Time) float64 {
+	d := math.Max(0.0, now.Sub(f.last).Minutes())
+	return f.heat * math.Exp(-d)
+}
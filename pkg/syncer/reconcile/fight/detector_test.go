@@ -217,3 +217,31 @@ func TestFightDetector(t *testing.T) {
 		})
 	}
 }
+
+func TestFrequentUpdates(t *testing.T) {
+	fd := NewDetector()
+
+	now := time.Now()
+	updates := map[core.ID][]time.Duration{
+		roleID("admin", "foo"):        sixUpdatesAtOnce,
+		roleBindingID("admin", "foo"): fourUpdatesAtOnce,
+		roleID("admin", "bar"):        durations(0, 2),
+		roleID("user", "foo"):         fourUpdatesAtOnce,
+	}
+	for id, durations := range updates {
+		for _, d := range durations {
+			fd.RecordUpdate(now.Add(d), id)
+		}
+	}
+
+	got := fd.FrequentUpdates(now, 3.0, 2)
+	require.Len(t, got, 2)
+	require.Equal(t, roleID("admin", "foo"), got[0].ID)
+	require.InDelta(t, 6.0, got[0].PerMinute, 0.001)
+	// Ties are broken by ID.
+	require.Equal(t, roleID("user", "foo"), got[1].ID)
+	require.InDelta(t, 4.0, got[1].PerMinute, 0.001)
+
+	// The frequencies decay without further updates.
+	require.Empty(t, fd.FrequentUpdates(now.Add(2*time.Minute), 3.0, 2))
+}
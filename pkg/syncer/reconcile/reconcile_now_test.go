@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recordingPatchClient is a client which records the Patch calls.
+type recordingPatchClient struct {
+	client.Client
+
+	obj   client.Object
+	patch client.Patch
+	data  []byte
+	opts  client.PatchOptions
+}
+
+func (c *recordingPatchClient) Patch(_ context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.obj = obj
+	c.patch = patch
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	c.data = data
+	c.opts.ApplyOptions(opts)
+	return nil
+}
+
+func TestClearReconcileNow(t *testing.T) {
+	obj := fake.UnstructuredObject(kinds.Role(), core.Name("admin"), core.Namespace("billing"),
+		core.Annotation(metadata.ReconcileNowAnnotationKey, "true"),
+		core.Annotation("acme.com/owner", "team-a"))
+	c := &recordingPatchClient{}
+
+	err := ClearReconcileNow(context.Background(), c, obj, "team-a-sync")
+	require.NoError(t, err)
+
+	assert.Equal(t, types.MergePatchType, c.patch.Type())
+	assert.JSONEq(t, `{"metadata":{"annotations":{"configsync.gke.io/reconcile-now":null}}}`, string(c.data))
+	assert.Equal(t, "team-a-sync", c.opts.FieldManager)
+	assert.Equal(t, core.IDOf(obj), core.IDOf(c.obj))
+	// The given object is not modified.
+	assert.Equal(t, "team-a", obj.GetAnnotations()["acme.com/owner"])
+}
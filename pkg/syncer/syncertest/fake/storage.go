@@ -62,6 +62,11 @@ type MemoryStorage struct {
 	// Default impl is RealNow.
 	Now func() metav1.Time
 
+	// FieldManager is the only field manager accepted by the write calls,
+	// other than none.
+	// Default is configsync.FieldManager.
+	FieldManager string
+
 	lock sync.RWMutex
 	// objects caches the stored objects in memory.
 	// The map is indexed by ID.
@@ -75,6 +80,7 @@ func NewInMemoryStorage(scheme *runtime.Scheme, watchSupervisor *WatchSupervisor
 		scheme:          scheme,
 		watchSupervisor: watchSupervisor,
 		Now:             RealNow,
+		FieldManager:    configsync.FieldManager,
 		objects:         make(map[core.ID]*unstructured.Unstructured),
 	}
 }
@@ -471,7 +477,7 @@ func (ms *MemoryStorage) validateCreateOptions(opts *client.CreateOptions) error
 			return errors.Errorf("invalid dry run option: %+v", opts.DryRun)
 		}
 	}
-	if opts.FieldManager != "" && opts.FieldManager != configsync.FieldManager {
+	if opts.FieldManager != "" && opts.FieldManager != ms.FieldManager {
 		return errors.Errorf("invalid field manager option: %v", opts.FieldManager)
 	}
 	return nil
@@ -752,7 +758,7 @@ func (ms *MemoryStorage) validateUpdateOptions(opts *client.UpdateOptions) error
 			return errors.Errorf("invalid dry run option: %+v", opts.DryRun)
 		}
 	}
-	if opts.FieldManager != "" && opts.FieldManager != configsync.FieldManager {
+	if opts.FieldManager != "" && opts.FieldManager != ms.FieldManager {
 		return errors.Errorf("invalid field manager option: %v", opts.FieldManager)
 	}
 	return nil
@@ -930,7 +936,7 @@ func (ms *MemoryStorage) validatePatchOptions(opts *client.PatchOptions, patch c
 			return errors.Errorf("invalid dry run option: %+v", opts.DryRun)
 		}
 	}
-	if opts.FieldManager != "" && opts.FieldManager != configsync.FieldManager {
+	if opts.FieldManager != "" && opts.FieldManager != ms.FieldManager {
 		return errors.Errorf("invalid field manager option: %v", opts.FieldManager)
 	}
 	if patch != client.Apply && opts.Force != nil {
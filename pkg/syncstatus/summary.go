@@ -0,0 +1,206 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package syncstatus summarizes the status of RootSyncs and RepoSyncs.
+package syncstatus
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/reposync"
+	"kpt.dev/configsync/pkg/rootsync"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ComponentStatus is the summarized status of one stage of a RootSync or
+// RepoSync: reading the source, rendering, or syncing.
+type ComponentStatus struct {
+	// Commit is the hash of the source of truth handled by the stage.
+	Commit string
+	// LastUpdate is when the reconciler last updated the status of the stage.
+	LastUpdate metav1.Time
+	// ErrorCount is the total number of errors of the stage, including the
+	// errors truncated from the status.
+	ErrorCount int
+}
+
+// RSyncSummary is the summarized status of a RootSync or RepoSync.
+type RSyncSummary struct {
+	// Kind is either RootSync or RepoSync.
+	Kind string
+	// Namespace and Name identify the RootSync or RepoSync.
+	Namespace string
+	Name      string
+
+	// State is the overall state of the RootSync or RepoSync.
+	State configsync.SyncState
+	// Stalled indicates that the reconciler of the RootSync or RepoSync could
+	// not be reconciled, so the other fields may be out of date.
+	Stalled bool
+
+	Source    ComponentStatus
+	Rendering ComponentStatus
+	Sync      ComponentStatus
+
+	// ErrorSources are the status fields which contain errors.
+	ErrorSources []v1beta1.ErrorSource
+	// ErrorSummary summarizes the source, rendering and sync errors.
+	ErrorSummary *v1beta1.ErrorSummary
+}
+
+// Summary is the aggregated status of all the RootSyncs and RepoSyncs.
+type Summary struct {
+	// RSyncs are the summaries of the RootSyncs, followed by the summaries of
+	// the RepoSyncs, sorted by namespace and name.
+	RSyncs []RSyncSummary
+	// StateCounts is the number of RootSyncs and RepoSyncs in each state.
+	StateCounts map[configsync.SyncState]int
+	// ErrorCount is the total number of errors across all RootSyncs and
+	// RepoSyncs.
+	ErrorCount int
+}
+
+// Healthy returns true if none of the RootSyncs and RepoSyncs has errors.
+func (s *Summary) Healthy() bool {
+	return s.StateCounts[configsync.SyncStateError] == 0 && s.ErrorCount == 0
+}
+
+// Summarize lists the RootSyncs and RepoSyncs in all namespaces and returns
+// their summarized status.
+func Summarize(ctx context.Context, c client.Reader) (*Summary, error) {
+	rootSyncs := &v1beta1.RootSyncList{}
+	if err := c.List(ctx, rootSyncs); err != nil {
+		return nil, status.APIServerError(err, "failed to list RootSyncs")
+	}
+	repoSyncs := &v1beta1.RepoSyncList{}
+	if err := c.List(ctx, repoSyncs); err != nil {
+		return nil, status.APIServerError(err, "failed to list RepoSyncs")
+	}
+
+	var rootSummaries, repoSummaries []RSyncSummary
+	for i := range rootSyncs.Items {
+		rs := &rootSyncs.Items[i]
+		rootSummaries = append(rootSummaries, summarize(configsync.RootSyncKind, rs.ObjectMeta, rs.Status.Status,
+			rootsync.IsStalled(rs), rootsync.IsSyncing(rs)))
+	}
+	for i := range repoSyncs.Items {
+		rs := &repoSyncs.Items[i]
+		repoSummaries = append(repoSummaries, summarize(configsync.RepoSyncKind, rs.ObjectMeta, rs.Status.Status,
+			reposync.IsStalled(rs), reposync.IsSyncing(rs)))
+	}
+	sortSummaries(rootSummaries)
+	sortSummaries(repoSummaries)
+
+	summary := &Summary{
+		RSyncs:      append(rootSummaries, repoSummaries...),
+		StateCounts: make(map[configsync.SyncState]int),
+	}
+	for _, rs := range summary.RSyncs {
+		summary.StateCounts[rs.State]++
+		summary.ErrorCount += rs.ErrorSummary.TotalCount
+	}
+	return summary, nil
+}
+
+func summarize(kind string, meta metav1.ObjectMeta, syncStatus v1beta1.Status, stalled, syncing bool) RSyncSummary {
+	errorSources, errorSummary := SummarizeErrors(syncStatus.Source, syncStatus.Rendering, syncStatus.Sync)
+
+	rsSummary := RSyncSummary{
+		Kind:      kind,
+		Namespace: meta.Namespace,
+		Name:      meta.Name,
+		Stalled:   stalled,
+		Source: ComponentStatus{
+			Commit:     syncStatus.Source.Commit,
+			LastUpdate: syncStatus.Source.LastUpdate,
+			ErrorCount: errorCount(syncStatus.Source.Errors, syncStatus.Source.ErrorSummary),
+		},
+		Rendering: ComponentStatus{
+			Commit:     syncStatus.Rendering.Commit,
+			LastUpdate: syncStatus.Rendering.LastUpdate,
+			ErrorCount: errorCount(syncStatus.Rendering.Errors, syncStatus.Rendering.ErrorSummary),
+		},
+		Sync: ComponentStatus{
+			Commit:     syncStatus.Sync.Commit,
+			LastUpdate: syncStatus.Sync.LastUpdate,
+			ErrorCount: errorCount(syncStatus.Sync.Errors, syncStatus.Sync.ErrorSummary),
+		},
+		ErrorSources: errorSources,
+		ErrorSummary: errorSummary,
+	}
+
+	switch {
+	case stalled || errorSummary.TotalCount > 0:
+		rsSummary.State = configsync.SyncStateError
+	case syncStatus.Summary != nil && syncStatus.Summary.State == configsync.SyncStatePaused:
+		// Whether applying is paused is only known from the status summary.
+		rsSummary.State = configsync.SyncStatePaused
+	case syncing || syncStatus.Sync.Commit == "" || syncStatus.Sync.Commit != syncStatus.Source.Commit:
+		rsSummary.State = configsync.SyncStateSyncing
+	default:
+		rsSummary.State = configsync.SyncStateSynced
+	}
+	return rsSummary
+}
+
+// errorCount returns the total number of errors from the ErrorSummary, or the
+// number of the errors if the ErrorSummary is not set.
+func errorCount(errs []v1beta1.ConfigSyncError, summary *v1beta1.ErrorSummary) int {
+	if summary != nil {
+		return summary.TotalCount
+	}
+	return len(errs)
+}
+
+func sortSummaries(summaries []RSyncSummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+}
+
+// SummarizeErrors summarizes the errors from `sourceStatus`, `renderingStatus`
+// and `syncStatus`, and returns an ErrorSource slice and an ErrorSummary.
+func SummarizeErrors(sourceStatus v1beta1.SourceStatus, renderingStatus v1beta1.RenderingStatus, syncStatus v1beta1.SyncStatus) ([]v1beta1.ErrorSource, *v1beta1.ErrorSummary) {
+	var errorSources []v1beta1.ErrorSource
+	if len(sourceStatus.Errors) > 0 {
+		errorSources = append(errorSources, v1beta1.SourceError)
+	}
+	if len(renderingStatus.Errors) > 0 {
+		errorSources = append(errorSources, v1beta1.RenderingError)
+	}
+	if len(syncStatus.Errors) > 0 {
+		errorSources = append(errorSources, v1beta1.SyncError)
+	}
+
+	errorSummary := &v1beta1.ErrorSummary{}
+	for _, summary := range []*v1beta1.ErrorSummary{sourceStatus.ErrorSummary, renderingStatus.ErrorSummary, syncStatus.ErrorSummary} {
+		if summary == nil {
+			continue
+		}
+		errorSummary.TotalCount += summary.TotalCount
+		errorSummary.ErrorCountAfterTruncation += summary.ErrorCountAfterTruncation
+		if summary.Truncated {
+			errorSummary.Truncated = true
+		}
+	}
+	return errorSources, errorSummary
+}
@@ -0,0 +1,435 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncstatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestSummarize(t *testing.T) {
+	syncErr := v1beta1.ConfigSyncError{Code: "2009", ErrorMessage: "apply failed"}
+	renderingErr := v1beta1.ConfigSyncError{Code: "1068", ErrorMessage: "rendering failed"}
+
+	synced := fake.RootSyncObjectV1Beta1("synced")
+	synced.Status.Source.Commit = "abc"
+	synced.Status.Rendering.Commit = "abc"
+	synced.Status.Sync.Commit = "abc"
+
+	failing := fake.RootSyncObjectV1Beta1("failing")
+	failing.Status.Source.Commit = "abc"
+	failing.Status.Rendering.Commit = "abc"
+	failing.Status.Rendering.Errors = []v1beta1.ConfigSyncError{renderingErr}
+	failing.Status.Rendering.ErrorSummary = &v1beta1.ErrorSummary{TotalCount: 1, ErrorCountAfterTruncation: 1}
+	failing.Status.Sync.Commit = "abc"
+	failing.Status.Sync.Errors = []v1beta1.ConfigSyncError{syncErr}
+	failing.Status.Sync.ErrorSummary = &v1beta1.ErrorSummary{TotalCount: 2, ErrorCountAfterTruncation: 1, Truncated: true}
+
+	syncing := fake.RepoSyncObjectV1Beta1("bookstore", "repo-sync")
+	syncing.Status.Source.Commit = "def"
+	syncing.Status.Sync.Commit = "abc"
+
+	paused := fake.RepoSyncObjectV1Beta1("admin", "repo-sync")
+	paused.Status.Source.Commit = "abc"
+	paused.Status.Sync.Commit = "abc"
+	paused.Status.Summary = &v1beta1.StatusSummary{
+		Version: v1beta1.StatusSummaryVersion,
+		State:   configsync.SyncStatePaused,
+		Commit:  "abc",
+	}
+
+	stalled := fake.RepoSyncObjectV1Beta1("bookstore", "stalled")
+	stalled.Status.Conditions = []v1beta1.RepoSyncCondition{{
+		Type:   v1beta1.RepoSyncStalled,
+		Status: metav1.ConditionTrue,
+	}}
+
+	fakeClient := syncertestfake.NewClient(t, core.Scheme, synced, failing, syncing, paused, stalled)
+	summary, err := Summarize(context.Background(), fakeClient)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RSyncSummary{
+		{
+			Kind:      configsync.RootSyncKind,
+			Namespace: configsync.ControllerNamespace,
+			Name:      "failing",
+			State:     configsync.SyncStateError,
+			Source:    ComponentStatus{Commit: "abc"},
+			Rendering: ComponentStatus{Commit: "abc", ErrorCount: 1},
+			Sync:      ComponentStatus{Commit: "abc", ErrorCount: 2},
+			ErrorSources: []v1beta1.ErrorSource{
+				v1beta1.RenderingError, v1beta1.SyncError,
+			},
+			ErrorSummary: &v1beta1.ErrorSummary{TotalCount: 3, ErrorCountAfterTruncation: 2, Truncated: true},
+		},
+		{
+			Kind:         configsync.RootSyncKind,
+			Namespace:    configsync.ControllerNamespace,
+			Name:         "synced",
+			State:        configsync.SyncStateSynced,
+			Source:       ComponentStatus{Commit: "abc"},
+			Rendering:    ComponentStatus{Commit: "abc"},
+			Sync:         ComponentStatus{Commit: "abc"},
+			ErrorSummary: &v1beta1.ErrorSummary{},
+		},
+		{
+			Kind:         configsync.RepoSyncKind,
+			Namespace:    "admin",
+			Name:         "repo-sync",
+			State:        configsync.SyncStatePaused,
+			Source:       ComponentStatus{Commit: "abc"},
+			Sync:         ComponentStatus{Commit: "abc"},
+			ErrorSummary: &v1beta1.ErrorSummary{},
+		},
+		{
+			Kind:         configsync.RepoSyncKind,
+			Namespace:    "bookstore",
+			Name:         "repo-sync",
+			State:        configsync.SyncStateSyncing,
+			Source:       ComponentStatus{Commit: "def"},
+			Sync:         ComponentStatus{Commit: "abc"},
+			ErrorSummary: &v1beta1.ErrorSummary{},
+		},
+		{
+			Kind:         configsync.RepoSyncKind,
+			Namespace:    "bookstore",
+			Name:         "stalled",
+			State:        configsync.SyncStateError,
+			Stalled:      true,
+			ErrorSummary: &v1beta1.ErrorSummary{},
+		},
+	}, summary.RSyncs)
+	assert.Equal(t, map[configsync.SyncState]int{
+		configsync.SyncStateError:   2,
+		configsync.SyncStateSynced:  1,
+		configsync.SyncStatePaused:  1,
+		configsync.SyncStateSyncing: 1,
+	}, summary.StateCounts)
+	assert.Equal(t, 3, summary.ErrorCount)
+	assert.False(t, summary.Healthy())
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	fakeClient := syncertestfake.NewClient(t, core.Scheme)
+	summary, err := Summarize(context.Background(), fakeClient)
+	require.NoError(t, err)
+	assert.Empty(t, summary.RSyncs)
+	assert.True(t, summary.Healthy())
+}
+
+func TestSummarizeErrors(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		sourceStatus         v1beta1.SourceStatus
+		renderingStatus      v1beta1.RenderingStatus
+		syncStatus           v1beta1.SyncStatus
+		expectedErrorSources []v1beta1.ErrorSource
+		expectedErrorSummary *v1beta1.ErrorSummary
+	}{
+		{
+			name:                 "both sourceStatus and syncStatus are empty",
+			sourceStatus:         v1beta1.SourceStatus{},
+			syncStatus:           v1beta1.SyncStatus{},
+			expectedErrorSources: nil,
+			expectedErrorSummary: &v1beta1.ErrorSummary{},
+		},
+		{
+			name: "sourceStatus is not empty (no trucation), syncStatus is empty",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+					{Code: "1022", ErrorMessage: "1022-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                2,
+					Truncated:                 false,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			syncStatus:           v1beta1.SyncStatus{},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                2,
+				Truncated:                 false,
+				ErrorCountAfterTruncation: 2,
+			},
+		},
+		{
+			name: "sourceStatus is not empty and trucates errors, syncStatus is empty",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+					{Code: "1022", ErrorMessage: "1022-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                100,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			syncStatus:           v1beta1.SyncStatus{},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                100,
+				Truncated:                 true,
+				ErrorCountAfterTruncation: 2,
+			},
+		},
+		{
+			name:         "sourceStatus is empty, syncStatus is not empty (no trucation)",
+			sourceStatus: v1beta1.SourceStatus{},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+					{Code: "2009", ErrorMessage: "webhook error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                2,
+					Truncated:                 false,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                2,
+				Truncated:                 false,
+				ErrorCountAfterTruncation: 2,
+			},
+		},
+		{
+			name:         "sourceStatus is empty, syncStatus is not empty and trucates errors",
+			sourceStatus: v1beta1.SourceStatus{},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+					{Code: "2009", ErrorMessage: "webhook error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                100,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                100,
+				Truncated:                 true,
+				ErrorCountAfterTruncation: 2,
+			},
+		},
+		{
+			name: "neither sourceStatus nor syncStatus is empty or trucates errors",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+					{Code: "1022", ErrorMessage: "1022-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                2,
+					Truncated:                 false,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+					{Code: "2009", ErrorMessage: "webhook error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                2,
+					Truncated:                 false,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                4,
+				Truncated:                 false,
+				ErrorCountAfterTruncation: 4,
+			},
+		},
+		{
+			name: "neither sourceStatus nor syncStatus is empty, sourceStatus trucates errors",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+					{Code: "1022", ErrorMessage: "1022-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                100,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+					{Code: "2009", ErrorMessage: "webhook error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                2,
+					Truncated:                 false,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                102,
+				Truncated:                 true,
+				ErrorCountAfterTruncation: 4,
+			},
+		},
+		{
+			name: "neither sourceStatus nor syncStatus is empty, syncStatus trucates errors",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+					{Code: "1022", ErrorMessage: "1022-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                2,
+					Truncated:                 false,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+					{Code: "2009", ErrorMessage: "webhook error"},
+				},
+
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                100,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                102,
+				Truncated:                 true,
+				ErrorCountAfterTruncation: 4,
+			},
+		},
+		{
+			name: "neither sourceStatus nor syncStatus is empty, both trucates errors",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+					{Code: "1022", ErrorMessage: "1022-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                100,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+					{Code: "2009", ErrorMessage: "webhook error"},
+				},
+
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                100,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 2,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                200,
+				Truncated:                 true,
+				ErrorCountAfterTruncation: 4,
+			},
+		},
+		{
+			name: "renderingStatus is not empty and trucates errors, sourceStatus and syncStatus are empty",
+			renderingStatus: v1beta1.RenderingStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1068", ErrorMessage: "rendering error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                10,
+					Truncated:                 true,
+					ErrorCountAfterTruncation: 1,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.RenderingError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                10,
+				Truncated:                 true,
+				ErrorCountAfterTruncation: 1,
+			},
+		},
+		{
+			name: "sourceStatus, renderingStatus and syncStatus are not empty",
+			sourceStatus: v1beta1.SourceStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1021", ErrorMessage: "1021-error-message"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                1,
+					ErrorCountAfterTruncation: 1,
+				},
+			},
+			renderingStatus: v1beta1.RenderingStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "1068", ErrorMessage: "rendering error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                1,
+					ErrorCountAfterTruncation: 1,
+				},
+			},
+			syncStatus: v1beta1.SyncStatus{
+				Errors: []v1beta1.ConfigSyncError{
+					{Code: "2009", ErrorMessage: "apiserver error"},
+				},
+				ErrorSummary: &v1beta1.ErrorSummary{
+					TotalCount:                1,
+					ErrorCountAfterTruncation: 1,
+				},
+			},
+			expectedErrorSources: []v1beta1.ErrorSource{v1beta1.SourceError, v1beta1.RenderingError, v1beta1.SyncError},
+			expectedErrorSummary: &v1beta1.ErrorSummary{
+				TotalCount:                3,
+				ErrorCountAfterTruncation: 3,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotErrorSources, gotErrorSummary := SummarizeErrors(tc.sourceStatus, tc.renderingStatus, tc.syncStatus)
+			if diff := cmp.Diff(tc.expectedErrorSources, gotErrorSources); diff != "" {
+				t.Errorf("SummarizeErrors() got %v, expected %v", gotErrorSources, tc.expectedErrorSources)
+			}
+			if diff := cmp.Diff(tc.expectedErrorSummary, gotErrorSummary); diff != "" {
+				t.Errorf("SummarizeErrors() got %v, expected %v", gotErrorSummary, tc.expectedErrorSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+// CachedServerResourcer is a ServerResourcer which caches the API Groups and
+// API Resources discovered from the wrapped ServerResourcer for a TTL, so that
+// repeated parses do not discover them again on large clusters.
+//
+// It intentionally does not implement Invalidate, so that GetResources does
+// not bypass the TTL. Use Reset to discover the resources again on the next
+// call, for example when the source uses a type which is not yet discovered.
+type CachedServerResourcer struct {
+	delegate ServerResourcer
+	ttl      time.Duration
+	clock    clock.PassiveClock
+
+	mux       sync.Mutex
+	cached    bool
+	fetched   time.Time
+	groups    []*metav1.APIGroup
+	resources []*metav1.APIResourceList
+}
+
+var _ ServerResourcer = &CachedServerResourcer{}
+
+// NewCachedServerResourcer returns a CachedServerResourcer which caches the
+// results of the ServerResourcer for the TTL.
+func NewCachedServerResourcer(sr ServerResourcer, ttl time.Duration) *CachedServerResourcer {
+	return newCachedServerResourcer(clock.RealClock{}, sr, ttl)
+}
+
+func newCachedServerResourcer(c clock.PassiveClock, sr ServerResourcer, ttl time.Duration) *CachedServerResourcer {
+	return &CachedServerResourcer{
+		delegate: sr,
+		ttl:      ttl,
+		clock:    c,
+	}
+}
+
+// ServerGroupsAndResources returns the cached API Groups and API Resources,
+// or discovers them again if the cache expired or was reset. Failed
+// discoveries are not cached.
+func (c *CachedServerResourcer) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.cached && c.clock.Since(c.fetched) < c.ttl {
+		return c.groups, c.resources, nil
+	}
+	// The wrapped client may have a cache of its own, which is out of date
+	// too by now.
+	if invalidatableDiscoveryClient, isInvalidatable := c.delegate.(invalidatable); isInvalidatable {
+		invalidatableDiscoveryClient.Invalidate()
+	}
+	groups, resources, err := c.delegate.ServerGroupsAndResources()
+	if err != nil {
+		c.cached = false
+		return groups, resources, err
+	}
+	c.cached = true
+	c.fetched = c.clock.Now()
+	c.groups = groups
+	c.resources = resources
+	return groups, resources, nil
+}
+
+// Reset drops the cached API Groups and API Resources, so that the next call
+// discovers them again.
+func (c *CachedServerResourcer) Reset() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.cached {
+		klog.V(3).Info("Resetting the API discovery cache")
+	}
+	c.cached = false
+	c.groups = nil
+	c.resources = nil
+}
+
+// Reset drops the cached API Groups and API Resources of the ServerResourcer,
+// if it caches them with a TTL.
+func Reset(sr ServerResourcer) {
+	if cached, ok := sr.(*CachedServerResourcer); ok {
+		cached.Reset()
+	}
+}
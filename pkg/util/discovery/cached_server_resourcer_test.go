@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// countingServerResourcer counts the discoveries and invalidations.
+type countingServerResourcer struct {
+	resources     []*metav1.APIResourceList
+	err           error
+	discoveries   int
+	invalidations int
+}
+
+func (c *countingServerResourcer) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	c.discoveries++
+	return nil, c.resources, c.err
+}
+
+func (c *countingServerResourcer) Invalidate() {
+	c.invalidations++
+}
+
+func TestCachedServerResourcer(t *testing.T) {
+	anvils := []*metav1.APIResourceList{{GroupVersion: "acme.com/v1", APIResources: []metav1.APIResource{{Kind: "Anvil"}}}}
+	clusters := []*metav1.APIResourceList{{GroupVersion: "clusterregistry.k8s.io/v1alpha1", APIResources: []metav1.APIResource{{Kind: "Cluster"}}}}
+
+	delegate := &countingServerResourcer{resources: anvils}
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	c := newCachedServerResourcer(fakeClock, delegate, time.Minute)
+
+	_, resources, err := c.ServerGroupsAndResources()
+	require.NoError(t, err)
+	assert.Equal(t, anvils, resources)
+	assert.Equal(t, 1, delegate.discoveries)
+	assert.Equal(t, 1, delegate.invalidations)
+
+	// GetResources does not bypass the cache within the TTL.
+	delegate.resources = clusters
+	fakeClock.SetTime(fakeClock.Now().Add(30 * time.Second))
+	resources, err = GetResources(c)
+	require.Nil(t, err)
+	assert.Equal(t, anvils, resources)
+	assert.Equal(t, 1, delegate.discoveries)
+
+	// The resources are discovered again once the TTL expires.
+	fakeClock.SetTime(fakeClock.Now().Add(30 * time.Second))
+	resources, err = GetResources(c)
+	require.Nil(t, err)
+	assert.Equal(t, clusters, resources)
+	assert.Equal(t, 2, delegate.discoveries)
+	assert.Equal(t, 2, delegate.invalidations)
+
+	// Reset discovers the resources again on the next call.
+	delegate.resources = anvils
+	Reset(c)
+	_, resources, err = c.ServerGroupsAndResources()
+	require.NoError(t, err)
+	assert.Equal(t, anvils, resources)
+	assert.Equal(t, 3, delegate.discoveries)
+
+	// Failed discoveries are not cached.
+	Reset(c)
+	delegate.err = errors.New("discovery failed")
+	_, _, err = c.ServerGroupsAndResources()
+	require.Error(t, err)
+	delegate.err = nil
+	_, resources, err = c.ServerGroupsAndResources()
+	require.NoError(t, err)
+	assert.Equal(t, anvils, resources)
+	assert.Equal(t, 5, delegate.discoveries)
+}
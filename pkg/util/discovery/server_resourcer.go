@@ -15,14 +15,30 @@
 package discovery
 
 import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/status"
 )
 
+// DiscoveryRetryBackoff is the backoff of the retries of the API discovery,
+// when it fails with a transient error, such as an API server timeout.
+var DiscoveryRetryBackoff = wait.Backoff{
+	Duration: 1 * time.Second,
+	Factor:   2,
+	Steps:    3,
+	Jitter:   0.1,
+}
+
 // ServerResourcer returns a the API Groups and API Resources available on the
 // API Server.
 //
@@ -47,12 +63,22 @@ type invalidatable interface {
 
 // GetResources gets the APIResourceLists from an existing DiscoveryClient.
 // Invalidates the cache if possible as the server may have new resources since the client was created.
+// Transient failures are retried with DiscoveryRetryBackoff, so that they are
+// not mistaken for unknown types.
 func GetResources(discoveryClient ServerResourcer) ([]*metav1.APIResourceList, status.Error) {
-	if invalidatableDiscoveryClient, isInvalidatable := discoveryClient.(invalidatable); isInvalidatable {
-		// Non-cached DiscoveryClients aren't invalidatable, so we have to allow for this possibility.
-		invalidatableDiscoveryClient.Invalidate()
-	}
-	_, resourceLists, discoveryErr := discoveryClient.ServerGroupsAndResources()
+	var resourceLists []*metav1.APIResourceList
+	discoveryErr := retry.OnError(DiscoveryRetryBackoff, isTransientDiscoveryError, func() error {
+		if invalidatableDiscoveryClient, isInvalidatable := discoveryClient.(invalidatable); isInvalidatable {
+			// Non-cached DiscoveryClients aren't invalidatable, so we have to allow for this possibility.
+			invalidatableDiscoveryClient.Invalidate()
+		}
+		var err error
+		_, resourceLists, err = discoveryClient.ServerGroupsAndResources()
+		if err != nil && isTransientDiscoveryError(err) {
+			klog.Warningf("API discovery failed with a transient error, retrying: %v", err)
+		}
+		return err
+	})
 	if discoveryErr != nil {
 		// b/238836947 ServerGroupsAndResources still returns the resources it discovered when there was an error.
 		// Most errors are fatal, but we want to ignore NotFound errors. This allows for CRDs and CRs to be applied
@@ -75,6 +101,45 @@ func GetResources(discoveryClient ServerResourcer) ([]*metav1.APIResourceList, s
 	return resourceLists, nil
 }
 
+// isTransientDiscoveryError returns whether the discovery error is only caused
+// by transient failures, which may succeed when retried. The API groups which
+// are not found are ignored, since they are not retried either.
+func isTransientDiscoveryError(err error) bool {
+	var discoErr *discovery.ErrGroupDiscoveryFailed
+	if !errors.As(err, &discoErr) {
+		return isTransientError(err)
+	}
+	transient := false
+	for _, subErr := range discoErr.Groups {
+		if apierrors.IsNotFound(subErr) {
+			continue
+		}
+		if !isTransientError(subErr) {
+			return false
+		}
+		transient = true
+	}
+	return transient
+}
+
+// isTransientError returns whether the error is a timeout or a temporary
+// unavailability of the API server.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	// The HTTP client reports its own timeouts as canceled requests.
+	return strings.Contains(err.Error(), "net/http: request canceled")
+}
+
 // APIResourceScoper returns a Scoper that contains scopes for all resources
 // available from the API server.
 func APIResourceScoper(sr ServerResourcer) (Scoper, status.MultiError) {
@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// flakyServerResourcer fails the discovery with the errors in order, and
+// succeeds once they are exhausted.
+type flakyServerResourcer struct {
+	resources   []*metav1.APIResourceList
+	errs        []error
+	discoveries int
+}
+
+func (f *flakyServerResourcer) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	f.discoveries++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, nil, err
+	}
+	return nil, f.resources, nil
+}
+
+func groupDiscoveryError(err error) error {
+	return &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{
+		{Group: "acme.com", Version: "v1"}: err,
+	}}
+}
+
+func TestGetResources_Retry(t *testing.T) {
+	defer func(backoff wait.Backoff) { DiscoveryRetryBackoff = backoff }(DiscoveryRetryBackoff)
+	DiscoveryRetryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	anvils := []*metav1.APIResourceList{{GroupVersion: "acme.com/v1", APIResources: []metav1.APIResource{{Kind: "Anvil"}}}}
+	timeoutErr := groupDiscoveryError(context.DeadlineExceeded)
+	forbiddenErr := groupDiscoveryError(apierrors.NewForbidden(schema.GroupResource{Group: "acme.com", Resource: "anvils"}, "", errors.New("forbidden")))
+
+	testCases := []struct {
+		name            string
+		errs            []error
+		wantResources   []*metav1.APIResourceList
+		wantErr         status.Error
+		wantDiscoveries int
+	}{
+		{
+			name:            "no error",
+			wantResources:   anvils,
+			wantDiscoveries: 1,
+		},
+		{
+			name:            "transient errors are retried",
+			errs:            []error{timeoutErr, groupDiscoveryError(errors.New("net/http: request canceled (Client.Timeout exceeded while awaiting headers)"))},
+			wantResources:   anvils,
+			wantDiscoveries: 3,
+		},
+		{
+			name:            "transient errors fail once the retries are exhausted",
+			errs:            []error{timeoutErr, timeoutErr, timeoutErr},
+			wantErr:         status.APIServerError(timeoutErr, "API discovery failed"),
+			wantDiscoveries: 3,
+		},
+		{
+			name:            "other errors are not retried",
+			errs:            []error{forbiddenErr},
+			wantErr:         status.APIServerError(forbiddenErr, "API discovery failed"),
+			wantDiscoveries: 1,
+		},
+		{
+			name:            "groups which are not found are neither retried nor reported",
+			errs:            []error{groupDiscoveryError(apierrors.NewNotFound(schema.GroupResource{Group: "acme.com"}, ""))},
+			wantDiscoveries: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := &flakyServerResourcer{resources: anvils, errs: tc.errs}
+			resources, err := GetResources(sr)
+			assert.Equal(t, tc.wantResources, resources)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantDiscoveries, sr.discoveries)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/klogr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	// FormatText writes logs as klog text. This is the default.
+	FormatText = "text"
+	// FormatJSON writes logs as JSON objects, one per line.
+	FormatJSON = "json"
+)
+
+// SetFormat configures the controller-runtime logger to write logs in the
+// specified format, sampling info logs as configured. With FormatJSON, klog
+// output is routed to the same JSON logger, so that all logs have the same
+// format and sampling.
+func SetFormat(format string, sampling Sampling) error {
+	logger, err := newLogger(format, os.Stderr)
+	if err != nil {
+		return err
+	}
+	logger = withSampling(logger, sampling)
+	if format == FormatJSON {
+		klog.SetLogger(logger)
+	}
+	ctrl.SetLogger(logger)
+	return nil
+}
+
+// newLogger returns a logger which writes logs in the specified format.
+func newLogger(format string, out io.Writer) (logr.Logger, error) {
+	switch format {
+	case "", FormatText:
+		return klogr.New(), nil
+	case FormatJSON:
+		// logr verbosity V(n) maps to zap level -n, so enable the same
+		// verbosity as the klog -v flag.
+		return zap.New(
+			zap.UseDevMode(false),
+			zap.JSONEncoder(),
+			zap.Level(zapcore.Level(-verbosity())),
+			zap.WriteTo(out),
+		), nil
+	default:
+		return logr.Logger{}, fmt.Errorf("invalid log format %q: must be one of %q or %q",
+			format, FormatText, FormatJSON)
+	}
+}
+
+// verbosity returns the value of the klog -v flag, or zero if not set.
+func verbosity() int {
+	f := flag.Lookup("v")
+	if f == nil {
+		return 0
+	}
+	v, err := strconv.Atoi(f.Value.String())
+	if err != nil {
+		return 0
+	}
+	return v
+}
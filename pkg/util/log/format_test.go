@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		logger, err := newLogger(FormatJSON, &out)
+		require.NoError(t, err)
+
+		logger.Info("Sync successful", "syncKind", "RootSync", "sync", "config-management-system/root-sync")
+
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+		assert.Equal(t, "Sync successful", entry["msg"])
+		assert.Equal(t, "RootSync", entry["syncKind"])
+		assert.Equal(t, "config-management-system/root-sync", entry["sync"])
+	})
+
+	t.Run("text", func(t *testing.T) {
+		_, err := newLogger(FormatText, nil)
+		assert.NoError(t, err)
+		_, err = newLogger("", nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newLogger("xml", nil)
+		assert.EqualError(t, err, `invalid log format "xml": must be one of "text" or "json"`)
+	})
+}
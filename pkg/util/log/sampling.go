@@ -0,0 +1,160 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// DefaultSamplingTick is the default interval over which log messages are
+// counted for sampling.
+const DefaultSamplingTick = time.Second
+
+// Sampling configures the sampling of info logs. Within each Tick, the first
+// Initial logs with the same verbosity and message are written, and then only
+// every Thereafter-th log. Error logs are never sampled.
+//
+// The zero value disables sampling.
+type Sampling struct {
+	// Initial is the number of logs with the same verbosity and message
+	// written in each Tick before sampling starts.
+	Initial int
+	// Thereafter is the sampling rate after the Initial logs: only one in
+	// every Thereafter logs is written. Zero drops all logs after Initial.
+	Thereafter int
+	// Tick is the interval after which the counters are reset.
+	// Defaults to DefaultSamplingTick.
+	Tick time.Duration
+}
+
+// Enabled returns true if the sampling is configured.
+func (s Sampling) Enabled() bool {
+	return s.Initial > 0
+}
+
+// withSampling returns a logger which samples the info logs written to the
+// logger. Loggers derived from the returned logger share the same sampler.
+func withSampling(logger logr.Logger, sampling Sampling) logr.Logger {
+	if !sampling.Enabled() {
+		return logger
+	}
+	if sampling.Tick <= 0 {
+		sampling.Tick = DefaultSamplingTick
+	}
+	sink := logger.GetSink()
+	if withCallDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		// Skip the samplingSink frame when reporting the caller.
+		sink = withCallDepth.WithCallDepth(1)
+	}
+	return logr.New(&samplingSink{
+		sink: sink,
+		sampler: &sampler{
+			Sampling: sampling,
+			counts:   make(map[samplingKey]int),
+			now:      time.Now,
+		},
+	})
+}
+
+// samplingKey identifies the logs counted together for sampling.
+type samplingKey struct {
+	level int
+	msg   string
+}
+
+// sampler counts the logs with the same key within each tick.
+// It is safe for concurrent use.
+type sampler struct {
+	Sampling
+
+	mux       sync.Mutex
+	counts    map[samplingKey]int
+	tickStart time.Time
+	now       func() time.Time
+}
+
+// sample returns true if the log with the specified verbosity and message
+// should be written.
+func (s *sampler) sample(level int, msg string) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := s.now()
+	if now.Sub(s.tickStart) >= s.Tick {
+		s.tickStart = now
+		s.counts = make(map[samplingKey]int)
+	}
+	key := samplingKey{level: level, msg: msg}
+	s.counts[key]++
+	n := s.counts[key]
+	if n <= s.Initial {
+		return true
+	}
+	return s.Thereafter > 0 && (n-s.Initial)%s.Thereafter == 0
+}
+
+// samplingSink is a logr.LogSink which drops info logs not selected by the
+// sampler, and writes all other logs to the underlying LogSink.
+type samplingSink struct {
+	sink    logr.LogSink
+	sampler *sampler
+}
+
+var _ logr.LogSink = &samplingSink{}
+var _ logr.CallDepthLogSink = &samplingSink{}
+
+// Init implements logr.LogSink. The underlying LogSink is already
+// initialized by its own logger.
+func (s *samplingSink) Init(logr.RuntimeInfo) {}
+
+// Enabled implements logr.LogSink.
+func (s *samplingSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+// Info implements logr.LogSink.
+func (s *samplingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if !s.sampler.sample(level, msg) {
+		return
+	}
+	s.sink.Info(level, msg, keysAndValues...)
+}
+
+// Error implements logr.LogSink. Error logs are never sampled.
+func (s *samplingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.sink.Error(err, msg, keysAndValues...)
+}
+
+// WithValues implements logr.LogSink.
+func (s *samplingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &samplingSink{sink: s.sink.WithValues(keysAndValues...), sampler: s.sampler}
+}
+
+// WithName implements logr.LogSink.
+func (s *samplingSink) WithName(name string) logr.LogSink {
+	return &samplingSink{sink: s.sink.WithName(name), sampler: s.sampler}
+}
+
+// WithCallDepth implements logr.CallDepthLogSink.
+func (s *samplingSink) WithCallDepth(depth int) logr.LogSink {
+	sink := s.sink
+	if withCallDepth, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = withCallDepth.WithCallDepth(depth)
+	}
+	return &samplingSink{sink: sink, sampler: s.sampler}
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSampling(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(_, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 3})
+
+	sampled := withSampling(logger, Sampling{Initial: 2, Thereafter: 3})
+	now := time.Now()
+	sampled.GetSink().(*samplingSink).sampler.now = func() time.Time { return now }
+
+	// The sampler is shared with the loggers derived from the sampled logger.
+	child := sampled.WithName("controller").WithValues("sync", "root-sync")
+	for i := 0; i < 10; i++ {
+		child.V(3).Info("Reconciling")
+		sampled.Error(errors.New("failed"), "Reconcile failed")
+	}
+	// 2 initial info logs, then every 3rd of the remaining 8.
+	assert.Equal(t, 2+2, countLogs(lines, `"msg"="Reconciling"`))
+	assert.Equal(t, 10, countLogs(lines, `"msg"="Reconcile failed"`))
+
+	// A different message or verbosity is counted separately.
+	lines = nil
+	child.V(3).Info("Reconcile successful")
+	child.V(2).Info("Reconciling")
+	assert.Len(t, lines, 2)
+
+	// Counters are reset every tick.
+	lines = nil
+	now = now.Add(DefaultSamplingTick)
+	child.V(3).Info("Reconciling")
+	assert.Len(t, lines, 1)
+}
+
+func TestWithSamplingDisabled(t *testing.T) {
+	logger := logr.Discard()
+	assert.Equal(t, logger, withSampling(logger, Sampling{}))
+}
+
+func countLogs(lines []string, substr string) int {
+	count := 0
+	for _, line := range lines {
+		if strings.Contains(line, substr) {
+			count++
+		}
+	}
+	return count
+}
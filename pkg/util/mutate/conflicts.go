@@ -0,0 +1,96 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutate
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConflictDetector counts the ResourceVersion conflicts when updating objects,
+// to detect a conflict storm: Threshold or more conflicts updating the same
+// object within the Window. A conflict storm usually means that another actor
+// is repeatedly writing the same object.
+//
+// It is safe for concurrent use.
+type ConflictDetector struct {
+	// Threshold is the number of conflicts within the Window which make a
+	// conflict storm. Zero disables the detection.
+	Threshold int
+	// Window is the period of time over which the conflicts are counted.
+	Window time.Duration
+
+	mux       sync.Mutex
+	conflicts map[types.NamespacedName][]time.Time
+	now       func() time.Time
+}
+
+// NewConflictDetector constructs a ConflictDetector with the specified
+// threshold and window.
+func NewConflictDetector(threshold int, window time.Duration) *ConflictDetector {
+	return &ConflictDetector{
+		Threshold: threshold,
+		Window:    window,
+		conflicts: make(map[types.NamespacedName][]time.Time),
+		now:       time.Now,
+	}
+}
+
+// RecordConflict records a conflict updating the specified object.
+func (d *ConflictDetector) RecordConflict(obj client.Object) {
+	if d.Threshold <= 0 {
+		return
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	key := client.ObjectKeyFromObject(obj)
+	d.conflicts[key] = append(d.recentConflicts(key), d.now())
+}
+
+// InStorm returns true if the number of conflicts updating the specified
+// object within the Window has reached the Threshold.
+func (d *ConflictDetector) InStorm(obj client.Object) bool {
+	if d.Threshold <= 0 {
+		return false
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	key := client.ObjectKeyFromObject(obj)
+	recent := d.recentConflicts(key)
+	if len(recent) == 0 {
+		delete(d.conflicts, key)
+	} else {
+		d.conflicts[key] = recent
+	}
+	return len(recent) >= d.Threshold
+}
+
+// recentConflicts returns the conflicts of the specified object within the
+// Window. The caller must hold the lock.
+func (d *ConflictDetector) recentConflicts(key types.NamespacedName) []time.Time {
+	cutoff := d.now().Add(-d.Window)
+	var recent []time.Time
+	for _, t := range d.conflicts[key] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
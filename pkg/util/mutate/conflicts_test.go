@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mutate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestConflictDetector(t *testing.T) {
+	obj := fake.RootSyncObjectV1Beta1("root-sync")
+	other := fake.RootSyncObjectV1Beta1("other-sync", core.Namespace(obj.Namespace))
+
+	detector := NewConflictDetector(3, time.Minute)
+	now := time.Now()
+	detector.now = func() time.Time { return now }
+
+	detector.RecordConflict(obj)
+	detector.RecordConflict(obj)
+	assert.False(t, detector.InStorm(obj))
+
+	now = now.Add(30 * time.Second)
+	detector.RecordConflict(obj)
+	assert.True(t, detector.InStorm(obj))
+	// Conflicts are counted per object
+	assert.False(t, detector.InStorm(other))
+
+	// The first two conflicts are outside the window
+	now = now.Add(45 * time.Second)
+	assert.False(t, detector.InStorm(obj))
+
+	// Zero threshold disables the detection
+	disabled := NewConflictDetector(0, time.Minute)
+	disabled.RecordConflict(obj)
+	assert.False(t, disabled.InStorm(obj))
+}
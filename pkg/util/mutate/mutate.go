@@ -67,6 +67,16 @@ func Spec(ctx context.Context, c client.Client, obj client.Object, mutateFn Func
 // Returns an error if the status update fails OR if the mutate func fails OR if
 // the generation changes before the status update succeeds.
 func Status(ctx context.Context, c client.Client, obj client.Object, mutateFn Func) (bool, error) {
+	return withStatusRetry(ctx, &statusClient{client: c}, obj, mutateFn)
+}
+
+// StatusWithConflictDetector is like Status, but records every
+// ResourceVersion conflict in the specified ConflictDetector.
+func StatusWithConflictDetector(ctx context.Context, c client.Client, detector *ConflictDetector, obj client.Object, mutateFn Func) (bool, error) {
+	return withStatusRetry(ctx, &statusClient{client: c, detector: detector}, obj, mutateFn)
+}
+
+func withStatusRetry(ctx context.Context, c *statusClient, obj client.Object, mutateFn Func) (bool, error) {
 	oldGen := obj.GetGeneration()
 	mutateFn2 := func() error {
 		newGen := obj.GetGeneration()
@@ -78,7 +88,7 @@ func Status(ctx context.Context, c client.Client, obj client.Object, mutateFn Fu
 		}
 		return mutateFn()
 	}
-	return withRetry(ctx, &statusClient{client: c}, obj, mutateFn2)
+	return withRetry(ctx, c, obj, mutateFn2)
 }
 
 // withRetry attempts to update an object until successful or update becomes
@@ -189,6 +199,8 @@ func (c *specClient) WrapError(_ context.Context, obj client.Object, err error)
 
 type statusClient struct {
 	client client.Client
+	// detector records the conflicts, if not nil.
+	detector *ConflictDetector
 }
 
 // Get the current status of the specified object.
@@ -198,7 +210,11 @@ func (c *statusClient) Get(ctx context.Context, obj client.Object) error {
 
 // Update the status of the specified object.
 func (c *statusClient) Update(ctx context.Context, obj client.Object) error {
-	return c.client.Status().Update(ctx, obj)
+	err := c.client.Status().Update(ctx, obj)
+	if c.detector != nil && apierrors.IsConflict(err) {
+		c.detector.RecordConflict(obj)
+	}
+	return err
 }
 
 // WrapError returns the specified error wrapped with extra context specific
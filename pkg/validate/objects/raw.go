@@ -17,6 +17,7 @@ package objects
 import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/customresources"
@@ -35,15 +36,18 @@ type ObjectVisitor func(obj ast.FileObject) status.Error
 // Raw contains a collection of FileObjects that have just been parsed from a
 // Git repo for a cluster.
 type Raw struct {
-	ClusterName       string
-	Scope             declared.Scope
-	SyncName          string
-	PolicyDir         cmpath.Relative
-	Objects           []ast.FileObject
-	PreviousCRDs      []*v1beta1.CustomResourceDefinition
-	BuildScoper       utildiscovery.BuildScoperFunc
-	Converter         *declared.ValueConverter
-	AllowUnknownKinds bool
+	ClusterName  string
+	Scope        declared.Scope
+	SyncName     string
+	PolicyDir    cmpath.Relative
+	Objects      []ast.FileObject
+	PreviousCRDs []*v1beta1.CustomResourceDefinition
+	BuildScoper  utildiscovery.BuildScoperFunc
+	Converter    *declared.ValueConverter
+	// SkipDeclaredFields skips annotating each object with its declared fields,
+	// for reconcilers which run without the validating admission webhook.
+	SkipDeclaredFields bool
+	AllowUnknownKinds  bool
 	// AllowAPICall indicates whether the hydration process can send k8s API
 	// calls. Currently, only dynamic NamespaceSelector requires talking to
 	// k8s-api-server.
@@ -51,9 +55,15 @@ type Raw struct {
 	// DynamicNSSelectorEnabled indicates whether the dynamic mode of
 	// NamespaceSelector is enabled.
 	DynamicNSSelectorEnabled bool
+	// DynamicNamespaceSelector indicates whether NamespaceSelectors which do
+	// not set a mode use the dynamic mode.
+	DynamicNamespaceSelector bool
 	// NSControllerState caches the NamespaceSelectors and selected Namespaces
 	// in the namespace controller.
 	NSControllerState *namespacecontroller.State
+	// NamespaceMismatchPolicy indicates how an object in a hierarchical repo
+	// whose metadata.namespace differs from its directory is handled.
+	NamespaceMismatchPolicy configsync.NamespaceMismatchPolicy
 }
 
 // Scoped builds a Scoped collection of objects from the Raw objects.
@@ -72,6 +82,7 @@ func (r *Raw) Scoped() (*Scoped, status.MultiError) {
 		SyncName:                 r.SyncName,
 		AllowAPICall:             r.AllowAPICall,
 		DynamicNSSelectorEnabled: r.DynamicNSSelectorEnabled,
+		DynamicNamespaceSelector: r.DynamicNamespaceSelector,
 		NSControllerState:        r.NSControllerState,
 	}
 	for _, obj := range r.Objects {
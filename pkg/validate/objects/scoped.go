@@ -39,6 +39,9 @@ type Scoped struct {
 	// DynamicNSSelectorEnabled indicates whether the dynamic mode of
 	// NamespaceSelector is enabled.
 	DynamicNSSelectorEnabled bool
+	// DynamicNamespaceSelector indicates whether NamespaceSelectors which do
+	// not set a mode use the dynamic mode.
+	DynamicNamespaceSelector bool
 	// NSControllerState caches the NamespaceSelectors and selected Namespaces
 	// in the namespace controller.
 	NSControllerState *namespacecontroller.State
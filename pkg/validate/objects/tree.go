@@ -156,6 +156,11 @@ func topLevelDirectory(obj ast.FileObject, expectedDir string) (string, status.E
 	if override, hasOverride := topLevelDirectoryOverrides[gvk]; hasOverride {
 		expectedDir = override
 	}
+	if gvk == kinds.HierarchyConfig() && obj.Split()[0] == repo.NamespacesDir {
+		// HierarchyConfigs may also be declared in abstract namespace directories
+		// to override the inheritance modes for that subtree.
+		expectedDir = repo.NamespacesDir
+	}
 
 	if obj.Split()[0] == expectedDir {
 		return expectedDir, nil
@@ -37,6 +37,11 @@ import (
 // Config Sync admission controller webhook to protect these declared fields
 // from being changed by another controller or user.
 func DeclaredFields(objs *objects.Raw) status.MultiError {
+	if objs.SkipDeclaredFields {
+		// Without the webhook, nothing reads the annotation, so skip computing
+		// it altogether.
+		return nil
+	}
 	if objs.Converter == nil {
 		klog.Warning("Skipping declared field hydration. This should only happen for offline executions of nomos vet/hydrate/init.")
 		return nil
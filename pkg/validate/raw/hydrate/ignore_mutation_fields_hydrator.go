@@ -0,0 +1,43 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/objects"
+	"kpt.dev/configsync/pkg/validate/raw/validate"
+)
+
+// IgnoreMutationFields removes the fields listed in the
+// `configsync.gke.io/ignore-mutation-fields` annotation from the given Raw
+// objects, so that neither the applier nor the remediator sets them, and the
+// admission webhook does not protect them from being changed by another
+// controller.
+func IgnoreMutationFields(objs *objects.Raw) status.MultiError {
+	var errs status.MultiError
+	for _, obj := range objs.Objects {
+		paths, err := metadata.IgnoredMutationFields(obj)
+		if err != nil {
+			errs = status.Append(errs, validate.IllegalIgnoreMutationFieldsError(obj, err))
+			continue
+		}
+		for _, fields := range paths {
+			unstructured.RemoveNestedField(obj.Object, fields...)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"kpt.dev/configsync/pkg/validate/objects"
+	"kpt.dev/configsync/pkg/validate/raw/validate"
+)
+
+func deploymentWithReplicas(t *testing.T, opts ...core.MetaMutator) ast.FileObject {
+	obj := fake.UnstructuredAtPath(kinds.Deployment(), "namespaces/foo/deployment.yaml", opts...)
+	require.NoError(t, unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "Recreate", "spec", "strategy", "type"))
+	return obj
+}
+
+func TestIgnoreMutationFields(t *testing.T) {
+	ignoreReplicas := core.Annotation(metadata.IgnoreMutationFieldsAnnotationKey, ".spec.replicas")
+
+	ignored := deploymentWithReplicas(t, ignoreReplicas)
+	wantIgnored := deploymentWithReplicas(t, ignoreReplicas)
+	unstructured.RemoveNestedField(wantIgnored.Object, "spec", "replicas")
+
+	objs := &objects.Raw{
+		Objects: []ast.FileObject{
+			ignored,
+			deploymentWithReplicas(t),
+			deploymentWithReplicas(t, core.Annotation(metadata.IgnoreMutationFieldsAnnotationKey, ".spec.missing.field")),
+		},
+	}
+	want := &objects.Raw{
+		Objects: []ast.FileObject{
+			wantIgnored,
+			deploymentWithReplicas(t),
+			deploymentWithReplicas(t, core.Annotation(metadata.IgnoreMutationFieldsAnnotationKey, ".spec.missing.field")),
+		},
+	}
+
+	if err := IgnoreMutationFields(objs); err != nil {
+		t.Errorf("Got IgnoreMutationFields() error %v, want nil", err)
+	}
+	if diff := cmp.Diff(want, objs, ast.CompareFileObject); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestIgnoreMutationFields_Invalid(t *testing.T) {
+	objs := &objects.Raw{
+		Objects: []ast.FileObject{
+			deploymentWithReplicas(t, core.Annotation(metadata.IgnoreMutationFieldsAnnotationKey, "spec.replicas")),
+		},
+	}
+	err := IgnoreMutationFields(objs)
+	require.Error(t, err)
+	if len(err.Errors()) != 1 || err.Errors()[0].Code() != validate.IllegalIgnoreMutationFieldsErrorCode {
+		t.Errorf("Got IgnoreMutationFields() error %v, want %s", err, validate.IllegalIgnoreMutationFieldsErrorCode)
+	}
+}
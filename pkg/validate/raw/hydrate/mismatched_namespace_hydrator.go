@@ -0,0 +1,53 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configmanagement/v1/repo"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/objects"
+)
+
+// MismatchedNamespaces hydrates the given raw Objects by correcting the
+// metadata namespace field of objects that are located in a namespace
+// directory but declare a different namespace. Objects in abstract namespace
+// directories are left unchanged, so that the mismatch is still reported.
+func MismatchedNamespaces(objs *objects.Raw) status.MultiError {
+	namespaces := make(map[string]bool)
+	for _, obj := range objs.Objects {
+		if isValidHierarchicalNamespace(obj) {
+			namespaces[obj.GetName()] = true
+		}
+	}
+	for _, obj := range objs.Objects {
+		if topLevelDir(obj) != repo.NamespacesDir {
+			continue
+		}
+		gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+		if gk == kinds.Namespace().GroupKind() || gk == kinds.NamespaceSelector().GroupKind() {
+			continue
+		}
+		dir := obj.Dir().Base()
+		if obj.GetNamespace() == "" || obj.GetNamespace() == dir || !namespaces[dir] {
+			continue
+		}
+		klog.Warningf("Correcting the namespace of %s from %q to %q, the namespace of its directory %s",
+			obj.GetName(), obj.GetNamespace(), dir, obj.SlashPath())
+		obj.SetNamespace(dir)
+	}
+	return nil
+}
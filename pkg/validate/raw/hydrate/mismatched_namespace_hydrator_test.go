@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/testing/fake"
+	"kpt.dev/configsync/pkg/validate/objects"
+)
+
+func TestMismatchedNamespaces(t *testing.T) {
+	testCases := []struct {
+		name string
+		objs *objects.Raw
+		want *objects.Raw
+	}{
+		{
+			name: "Correct object with mismatched namespace",
+			objs: &objects.Raw{
+				Objects: []ast.FileObject{
+					fake.Repo(),
+					fake.Namespace("namespaces/foo"),
+					fake.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader"),
+						core.Namespace("bar")),
+				},
+			},
+			want: &objects.Raw{
+				Objects: []ast.FileObject{
+					fake.Repo(),
+					fake.Namespace("namespaces/foo"),
+					fake.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader"),
+						core.Namespace("foo")),
+				},
+			},
+		},
+		{
+			name: "Ignore object without namespace",
+			objs: &objects.Raw{
+				Objects: []ast.FileObject{
+					fake.Repo(),
+					fake.Namespace("namespaces/foo"),
+					fake.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader")),
+				},
+			},
+			want: &objects.Raw{
+				Objects: []ast.FileObject{
+					fake.Repo(),
+					fake.Namespace("namespaces/foo"),
+					fake.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader")),
+				},
+			},
+		},
+		{
+			// Objects in abstract namespace directories must not declare a
+			// namespace, so the validator still reports the error.
+			name: "Ignore object in abstract namespace directory",
+			objs: &objects.Raw{
+				Objects: []ast.FileObject{
+					fake.Repo(),
+					fake.Namespace("namespaces/foo/bar"),
+					fake.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader"),
+						core.Namespace("bar")),
+				},
+			},
+			want: &objects.Raw{
+				Objects: []ast.FileObject{
+					fake.Repo(),
+					fake.Namespace("namespaces/foo/bar"),
+					fake.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader"),
+						core.Namespace("bar")),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if errs := MismatchedNamespaces(tc.objs); errs != nil {
+				t.Errorf("Got MismatchedNamespaces() error %v, want nil", errs)
+			}
+			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -37,11 +37,12 @@ func ObjectNamespaces(objs *objects.Raw) status.MultiError {
 		if topLevelDir(obj) != repo.NamespacesDir {
 			continue
 		}
-		// Namespaces and NamespaceSelectors are the only cluster-scoped objects
-		// expected under the namespace/ directory, so we want to make sure we
-		// don't accidentally assign them a namespace.
+		// Namespaces, NamespaceSelectors and HierarchyConfigs are the only
+		// cluster-scoped objects expected under the namespace/ directory, so we
+		// want to make sure we don't accidentally assign them a namespace.
 		gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
-		if gk == kinds.Namespace().GroupKind() || gk == kinds.NamespaceSelector().GroupKind() {
+		if gk == kinds.Namespace().GroupKind() || gk == kinds.NamespaceSelector().GroupKind() ||
+			gk == kinds.HierarchyConfig().GroupKind() {
 			continue
 		}
 		if obj.GetNamespace() == "" {
@@ -15,6 +15,7 @@
 package raw
 
 import (
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/status"
@@ -28,6 +29,11 @@ import (
 // the Raw objects in-place.
 func Hierarchical(objs *objects.Raw) status.MultiError {
 	var errs status.MultiError
+	if objs.NamespaceMismatchPolicy == configsync.NamespaceMismatchCorrect {
+		// Correct the mismatching namespaces first, so that they are not
+		// reported by the directory validator.
+		errs = status.Append(errs, hydrate.MismatchedNamespaces(objs))
+	}
 	// Note that the ordering here and in all other collections of validators is
 	// somewhat arbitrary. We always run all validators in a collection before
 	// exiting with any errors. We do put more "fundamental" validation checks
@@ -46,6 +52,8 @@ func Hierarchical(objs *objects.Raw) status.MultiError {
 		objects.VisitAllRaw(validate.Directory),
 		objects.VisitAllRaw(validate.HNCLabels),
 		objects.VisitAllRaw(validate.ManagementAnnotation),
+		objects.VisitAllRaw(validate.IgnoreMutationFields),
+		objects.VisitAllRaw(validate.HandoffAnnotation),
 		objects.VisitAllRaw(validate.IllegalCRD),
 		objects.VisitAllRaw(validate.CRDName),
 		objects.VisitAllRaw(validate.RootSync),
@@ -63,14 +71,17 @@ func Hierarchical(objs *objects.Raw) status.MultiError {
 		return errs
 	}
 
-	// First we annotate all objects with their declared fields. It is crucial
-	// that we do this step before any other hydration so that we capture the
-	// object exactly as it is declared in Git. Next we set missing namespaces on
+	// First we remove the fields which Config Sync must ignore, so that they
+	// are not protected as declared fields. Then we annotate all objects with
+	// their declared fields. It is crucial that we do this step before any other
+	// hydration so that we capture the object exactly as it is declared in Git.
+	// Next we set missing namespaces on
 	// objects in namespace directories since cluster selection relies on
 	// namespace if a namespace gets filtered out. Then we perform cluster
 	// selection so that we can filter out irrelevant objects before trying to
 	// modify them.
 	hydrators := []objects.RawVisitor{
+		hydrate.IgnoreMutationFields,
 		hydrate.DeclaredFields,
 		hydrate.DeclaredVersion,
 		hydrate.ObjectNamespaces,
@@ -100,6 +111,8 @@ func Unstructured(objs *objects.Raw) status.MultiError {
 		objects.VisitAllRaw(validate.Name),
 		objects.VisitAllRaw(validate.Namespace),
 		objects.VisitAllRaw(validate.ManagementAnnotation),
+		objects.VisitAllRaw(validate.IgnoreMutationFields),
+		objects.VisitAllRaw(validate.HandoffAnnotation),
 		objects.VisitAllRaw(validate.IllegalCRD),
 		objects.VisitAllRaw(validate.CRDName),
 		objects.VisitAllRaw(validate.RootSync),
@@ -116,11 +129,14 @@ func Unstructured(objs *objects.Raw) status.MultiError {
 		return errs
 	}
 
-	// First we annotate all objects with their declared fields. It is crucial
-	// that we do this step before any other hydration so that we capture the
-	// object exactly as it is declared in Git. Then we perform cluster selection
-	// so that we can filter out irrelevant objects before trying to modify them.
+	// First we remove the fields which Config Sync must ignore, so that they
+	// are not protected as declared fields. Then we annotate all objects with
+	// their declared fields. It is crucial that we do this step before any other
+	// hydration so that we capture the object exactly as it is declared in Git.
+	// Then we perform cluster selection so that we can filter out irrelevant
+	// objects before trying to modify them.
 	hydrators := []objects.RawVisitor{
+		hydrate.IgnoreMutationFields,
 		hydrate.DeclaredFields,
 		hydrate.DeclaredVersion,
 		hydrate.ClusterSelectors,
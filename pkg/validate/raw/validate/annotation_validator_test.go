@@ -63,6 +63,10 @@ func TestAnnotations(t *testing.T) {
 			name: "legal management annotation",
 			obj:  fake.RoleBinding(core.Annotation(csmetadata.ResourceManagementKey, "a")),
 		},
+		{
+			name: "legal deletion-protection annotation",
+			obj:  fake.Role(core.Annotation(csmetadata.DeletionProtectionAnnotationKey, csmetadata.DeletionProtectionEnabled)),
+		},
 		{
 			name:    "illegal ConfigManagement annotation",
 			obj:     fake.Role(core.Annotation(cmAnnotation, "a")),
@@ -0,0 +1,52 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HandoffAnnotation returns an Error if the user-specified handoff-to
+// annotation is not the name of a RootSync.
+func HandoffAnnotation(obj ast.FileObject) status.Error {
+	value, found := obj.GetAnnotations()[metadata.HandoffToAnnotationKey]
+	if !found {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+		return IllegalHandoffAnnotationError(obj, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// IllegalHandoffAnnotationErrorCode is the error code for IllegalHandoffAnnotationError.
+const IllegalHandoffAnnotationErrorCode = "1071"
+
+var illegalHandoffAnnotationErrorBuilder = status.NewErrorBuilder(IllegalHandoffAnnotationErrorCode)
+
+// IllegalHandoffAnnotationError reports that the handoff-to annotation is not
+// the name of a RootSync.
+func IllegalHandoffAnnotationError(resource client.Object, reason string) status.Error {
+	return illegalHandoffAnnotationErrorBuilder.
+		Sprintf("Config has invalid annotation %s: %s. The value must be the name of the RootSync which takes over the management of the resource.",
+			metadata.HandoffToAnnotationKey, reason).
+		BuildWithResources(resource)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestHandoffAnnotation(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  status.Error
+	}{
+		{
+			name:  "RootSync name",
+			value: "root-sync-b",
+		},
+		{
+			name:  "empty name fails",
+			value: "",
+			want:  fake.Error(IllegalHandoffAnnotationErrorCode),
+		},
+		{
+			name:  "invalid name fails",
+			value: "Root_Sync",
+			want:  fake.Error(IllegalHandoffAnnotationErrorCode),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := fake.Deployment("namespaces/foo", core.Annotation(metadata.HandoffToAnnotationKey, tc.value))
+			err := HandoffAnnotation(obj)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("got HandoffAnnotation() error %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if err := HandoffAnnotation(fake.Deployment("namespaces/foo")); err != nil {
+		t.Errorf("got HandoffAnnotation() error %v for an object without the annotation, want nil", err)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IgnoreMutationFields returns an Error if the user-specified
+// ignore-mutation-fields annotation lists an invalid field path.
+func IgnoreMutationFields(obj ast.FileObject) status.Error {
+	if _, err := metadata.IgnoredMutationFields(obj); err != nil {
+		return IllegalIgnoreMutationFieldsError(obj, err)
+	}
+	return nil
+}
+
+// IllegalIgnoreMutationFieldsErrorCode is the error code for IllegalIgnoreMutationFieldsError.
+const IllegalIgnoreMutationFieldsErrorCode = "1070"
+
+var illegalIgnoreMutationFieldsErrorBuilder = status.NewErrorBuilder(IllegalIgnoreMutationFieldsErrorCode)
+
+// IllegalIgnoreMutationFieldsError reports that the ignore-mutation-fields
+// annotation lists an invalid field path.
+func IllegalIgnoreMutationFieldsError(resource client.Object, err error) status.Error {
+	return illegalIgnoreMutationFieldsErrorBuilder.
+		Sprintf("Config has invalid annotation %s: %v. The value must be a comma-separated list of field paths, such as \".spec.replicas\".",
+			metadata.IgnoreMutationFieldsAnnotationKey, err).
+		BuildWithResources(resource)
+}
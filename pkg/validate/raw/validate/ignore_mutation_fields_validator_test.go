@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/fake"
+)
+
+func TestIgnoreMutationFields(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  status.Error
+	}{
+		{
+			name:  "single field path",
+			value: ".spec.replicas",
+		},
+		{
+			name:  "multiple field paths",
+			value: ".spec.replicas, .spec.template.metadata.annotations",
+		},
+		{
+			name:  "field path without leading dot fails",
+			value: "spec.replicas",
+			want:  fake.Error(IllegalIgnoreMutationFieldsErrorCode),
+		},
+		{
+			name:  "empty field path fails",
+			value: ".spec.replicas,",
+			want:  fake.Error(IllegalIgnoreMutationFieldsErrorCode),
+		},
+		{
+			name:  "empty field fails",
+			value: ".spec..replicas",
+			want:  fake.Error(IllegalIgnoreMutationFieldsErrorCode),
+		},
+		{
+			name:  "list index fails",
+			value: ".spec.template.spec.containers[0].image",
+			want:  fake.Error(IllegalIgnoreMutationFieldsErrorCode),
+		},
+		{
+			name:  "metadata field fails",
+			value: ".metadata.labels",
+			want:  fake.Error(IllegalIgnoreMutationFieldsErrorCode),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := fake.Deployment("namespaces/foo", core.Annotation(metadata.IgnoreMutationFieldsAnnotationKey, tc.value))
+			err := IgnoreMutationFields(obj)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("got IgnoreMutationFields() error %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if err := IgnoreMutationFields(fake.Deployment("namespaces/foo")); err != nil {
+		t.Errorf("got IgnoreMutationFields() error %v for an object without the annotation, want nil", err)
+	}
+}
@@ -16,8 +16,12 @@ package validate
 
 import (
 	"context"
+	"net"
+	"path"
+	"regexp"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"kpt.dev/configsync/pkg/api/configsync"
@@ -32,6 +36,15 @@ import (
 // https://cloud.google.com/iam/docs/service-accounts#user-managed
 const gcpSASuffix = ".iam.gserviceaccount.com"
 
+// helmAPIVersionRegex matches a Kubernetes API version in the form of
+// "version", "group/version" or "group/version/Kind", as accepted by
+// `helm template --api-versions`.
+var helmAPIVersionRegex = regexp.MustCompile(`^([a-z0-9]([-a-z0-9.]*[a-z0-9])?/)?v[0-9]+((alpha|beta)[0-9]+)?(/[A-Za-z][A-Za-z0-9]*)?$`)
+
+// noProxyHostRegex matches a host name or domain suffix in a NO_PROXY list,
+// with an optional leading "." or "*." and an optional port.
+var noProxyHostRegex = regexp.MustCompile(`^(\*?\.)?[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?(\.[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?)*(:[0-9]+)?$`)
+
 // HelmValuesFileDefaultDataKey is the default data key to use when
 // spec.helm.valuesFileRefs.dataKey is not specified.
 const HelmValuesFileDefaultDataKey = "values.yaml"
@@ -45,6 +58,19 @@ func HelmValuesFileDataKeyOrDefault(key string) string {
 	return key
 }
 
+// KnownHostsDefaultDataKey is the default data key to use when
+// spec.git.knownHostsConfigMapRef.dataKey is not specified.
+const KnownHostsDefaultDataKey = "known_hosts"
+
+// KnownHostsDataKeyOrDefault returns the key or the default if the key is
+// empty.
+func KnownHostsDataKeyOrDefault(key string) string {
+	if len(key) == 0 {
+		return KnownHostsDefaultDataKey
+	}
+	return key
+}
+
 // RepoSyncSpec validates the Repo Sync source specification for any obvious problems.
 func RepoSyncSpec(sourceType string, git *v1beta1.Git, oci *v1beta1.Oci, helm *v1beta1.HelmRepoSync, rs client.Object) status.Error {
 	switch v1beta1.SourceType(sourceType) {
@@ -111,6 +137,38 @@ func GitSpec(git *v1beta1.Git, rs client.Object) status.Error {
 		return NoOpProxy(rs)
 	}
 
+	// Check that noProxy isn't unnecessarily declared.
+	if git.NoProxy != "" && git.Auth != configsync.AuthNone && git.Auth != configsync.AuthCookieFile && git.Auth != configsync.AuthToken {
+		return NoOpNoProxy(rs)
+	}
+
+	if entry, ok := validNoProxy(git.NoProxy); !ok {
+		return InvalidNoProxy(v1beta1.GitSource, rs, entry)
+	}
+
+	// Check that known_hosts are only read from a ConfigMap for SSH.
+	if git.KnownHostsConfigMapRef != nil && git.Auth != configsync.AuthSSH {
+		return IllegalKnownHostsConfigMapRef(rs)
+	}
+
+	// Check that the directories to sync stay within the repository.
+	if git.Dir != "" && len(git.Dirs) > 0 {
+		return GitDirAndDirs(rs)
+	}
+	if escapesRepoRoot(git.Dir) {
+		return InvalidGitDir(rs, git.Dir)
+	}
+	for _, dir := range git.Dirs {
+		if dir == "" || strings.Contains(dir, ",") || escapesRepoRoot(dir) {
+			return InvalidGitDir(rs, dir)
+		}
+	}
+	switch git.DirsCollisionPolicy {
+	case "", configsync.DirsCollisionOverride, configsync.DirsCollisionError:
+	default:
+		return InvalidGitDirsCollisionPolicy(rs)
+	}
+
 	// Check the secret ref is specified if and only if it is required.
 	switch git.Auth {
 	case configsync.AuthNone, configsync.AuthGCENode, configsync.AuthGCPServiceAccount:
@@ -126,6 +184,14 @@ func GitSpec(git *v1beta1.Git, rs client.Object) status.Error {
 	return nil
 }
 
+// escapesRepoRoot returns true if the directory, which is relative to the root
+// of the repository, points outside of the repository.
+// Some users specify the directory as if the root of the repository is "/".
+func escapesRepoRoot(dir string) bool {
+	cleaned := path.Clean(strings.TrimPrefix(dir, "/"))
+	return cleaned == ".." || strings.HasPrefix(cleaned, "../")
+}
+
 // OciSpec validates the OCI specification for any obvious problems.
 func OciSpec(oci *v1beta1.Oci, rs client.Object) status.Error {
 	if oci == nil {
@@ -152,6 +218,10 @@ func OciSpec(oci *v1beta1.Oci, rs client.Object) status.Error {
 	default:
 		return InvalidOciAuthType(rs)
 	}
+
+	if entry, ok := validNoProxy(oci.NoProxy); !ok {
+		return InvalidNoProxy(v1beta1.OciSource, rs, entry)
+	}
 	return nil
 }
 
@@ -203,6 +273,24 @@ func HelmSpec(helm *v1beta1.HelmBase, rs client.Object) status.Error {
 		}
 	}
 
+	for _, apiVersion := range helm.APIVersions {
+		if !helmAPIVersionRegex.MatchString(apiVersion) {
+			return InvalidHelmAPIVersion(rs, apiVersion)
+		}
+	}
+
+	// Helm parses the kube version as a semantic version, with an optional
+	// "v" prefix.
+	if helm.KubeVersion != "" {
+		if _, err := semver.NewVersion(helm.KubeVersion); err != nil {
+			return InvalidHelmKubeVersion(rs, helm.KubeVersion)
+		}
+	}
+
+	if entry, ok := validNoProxy(helm.NoProxy); !ok {
+		return InvalidNoProxy(v1beta1.HelmSource, rs, entry)
+	}
+
 	return nil
 }
 
@@ -228,6 +316,27 @@ func ValuesFileRefs(ctx context.Context, cl client.Client, rs client.Object, val
 	return nil
 }
 
+// KnownHostsConfigMapRef validates that the ConfigMap specified in
+// spec.git.knownHostsConfigMapRef exists and has the specified data key.
+func KnownHostsConfigMapRef(ctx context.Context, cl client.Client, rs client.Object, ref *v1beta1.KnownHostsConfigMapRef) status.Error {
+	if ref == nil {
+		return nil
+	}
+	objRef := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: rs.GetNamespace(),
+	}
+	var cm corev1.ConfigMap
+	if err := cl.Get(ctx, objRef, &cm); err != nil {
+		return KnownHostsMissingConfigMap(rs, err)
+	}
+	dataKey := KnownHostsDataKeyOrDefault(ref.DataKey)
+	if _, found := cm.Data[dataKey]; !found {
+		return KnownHostsMissingConfigMapKey(rs, objRef.Name, dataKey)
+	}
+	return nil
+}
+
 // InvalidSyncCode is the code for an invalid declared RootSync/RepoSync.
 var InvalidSyncCode = "1061"
 
@@ -251,6 +360,34 @@ func MissingGitRepo(o client.Object) status.Error {
 		BuildWithResources(o)
 }
 
+// GitDirAndDirs reports that a RootSync/RepoSync declares both spec.git.dir and
+// spec.git.dirs, even though they are mutually exclusive.
+func GitDirAndDirs(o client.Object) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify only one of spec.git.dir or spec.git.dirs", kind).
+		BuildWithResources(o)
+}
+
+// InvalidGitDir reports that a RootSync/RepoSync declares a directory to sync
+// which is empty or outside of the repository.
+func InvalidGitDir(o client.Object, dir string) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.git.dir and spec.git.dirs as non-empty directories within the repository without commas, got %q", kind, dir).
+		BuildWithResources(o)
+}
+
+// InvalidGitDirsCollisionPolicy reports that a RootSync/RepoSync doesn't use
+// one of the known policies for objects declared in more than one directory.
+func InvalidGitDirsCollisionPolicy(o client.Object) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.git.dirsCollisionPolicy to be one of %q or %q", kind,
+			configsync.DirsCollisionOverride, configsync.DirsCollisionError).
+		BuildWithResources(o)
+}
+
 // InvalidGitAuthType reports that a RootSync/RepoSync doesn't use one of the known auth
 // methods.
 func InvalidGitAuthType(o client.Object) status.Error {
@@ -272,6 +409,26 @@ func NoOpProxy(o client.Object) status.Error {
 		BuildWithResources(o)
 }
 
+// NoOpNoProxy reports that a RootSync/RepoSync declares a noProxy, but the
+// declaration would do nothing.
+func NoOpNoProxy(o client.Object) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.git.noProxy must also specify spec.git.auth as one of %q, %q or %q",
+			kind, configsync.AuthNone, configsync.AuthCookieFile, configsync.AuthToken).
+		BuildWithResources(o)
+}
+
+// InvalidNoProxy reports that a RootSync/RepoSync declares a noProxy that is
+// not a comma-separated list of hosts or CIDR ranges.
+func InvalidNoProxy(sourceType v1beta1.SourceType, o client.Object, entry string) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.%s.noProxy as a comma-separated list of hosts, domains, IP addresses or CIDR ranges, got invalid entry %q",
+			kind, sourceType, entry).
+		BuildWithResources(o)
+}
+
 // IllegalSecretRef reports that a RootSync/RepoSync declares an auth mode that doesn't
 // allow SecretRefs does declare a SecretRef.
 func IllegalSecretRef(sourceType v1beta1.SourceType, o client.Object) status.Error {
@@ -313,6 +470,31 @@ func MissingGCPSAEmail(sourceType v1beta1.SourceType, o client.Object) status.Er
 		BuildWithResources(o)
 }
 
+// validNoProxy checks that noProxy is a comma-separated list of host names,
+// domain suffixes, IP addresses or CIDR ranges, or the wildcard "*". It
+// returns the first invalid entry if the list is not valid.
+func validNoProxy(noProxy string) (string, bool) {
+	if noProxy == "" || noProxy == "*" {
+		return "", true
+	}
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			return entry, false
+		}
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			continue
+		}
+		if net.ParseIP(entry) != nil {
+			continue
+		}
+		if !noProxyHostRegex.MatchString(entry) {
+			return entry, false
+		}
+	}
+	return "", true
+}
+
 // validGCPServiceAccountEmail verifies whether GCP SA email has correct
 // prefix and suffix format.
 func validGCPServiceAccountEmail(email string) bool {
@@ -406,6 +588,24 @@ func InvalidHelmAuthType(o client.Object) status.Error {
 		BuildWithResources(o)
 }
 
+// InvalidHelmAPIVersion reports that a RootSync/RepoSync declares an invalid
+// Kubernetes API version in spec.helm.apiVersions.
+func InvalidHelmAPIVersion(o client.Object, apiVersion string) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.helm.apiVersions in the form of \"group/version\" or \"group/version/Kind\", got %q", kind, apiVersion).
+		BuildWithResources(o)
+}
+
+// InvalidHelmKubeVersion reports that a RootSync/RepoSync declares a
+// spec.helm.kubeVersion which is not a semantic version.
+func InvalidHelmKubeVersion(o client.Object, kubeVersion string) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.helm.kubeVersion as a semantic version, like \"v1.27.3\", got %q", kind, kubeVersion).
+		BuildWithResources(o)
+}
+
 // HelmNSAndDeployNS reports that a RootSync has both spec.helm.namespace and spec.helm.deployNamespace
 // set, even though they are mutually exclusive
 func HelmNSAndDeployNS(o client.Object) status.Error {
@@ -447,3 +647,30 @@ func HelmValuesConfigMapMustBeImmutable(o client.Object, name string) status.Err
 		Sprintf("%ss must reference valid ConfigMaps in spec.helm.valuesFileRefs: ConfigMap %q in namespace %q is not immutable", kind, name, o.GetNamespace()).
 		BuildWithResources(o)
 }
+
+// IllegalKnownHostsConfigMapRef reports that a RootSync/RepoSync declares
+// spec.git.knownHostsConfigMapRef with an auth type other than `ssh`.
+func IllegalKnownHostsConfigMapRef(o client.Object) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.git.knownHostsConfigMapRef must also specify spec.git.auth as %q", kind, configsync.AuthSSH).
+		BuildWithResources(o)
+}
+
+// KnownHostsMissingConfigMap reports that an RSync is referencing a ConfigMap
+// in spec.git.knownHostsConfigMapRef that doesn't exist.
+func KnownHostsMissingConfigMap(o client.Object, err error) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must reference a valid ConfigMap in spec.git.knownHostsConfigMapRef: %s", kind, err.Error()).
+		BuildWithResources(o)
+}
+
+// KnownHostsMissingConfigMapKey reports that the ConfigMap referenced in RSync
+// spec.git.knownHostsConfigMapRef is missing the data key.
+func KnownHostsMissingConfigMapKey(o client.Object, name, key string) status.Error {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return invalidSyncBuilder.
+		Sprintf("%ss must reference a valid ConfigMap in spec.git.knownHostsConfigMapRef: ConfigMap %q in namespace %q does not have data key %q", kind, name, o.GetNamespace(), key).
+		BuildWithResources(o)
+}
@@ -108,9 +108,13 @@ func buildSelectorMap(ctx context.Context, c client.Client, objs *objects.Scoped
 			}
 			nsSelectorMap[nsSelector.Name] = selector
 
-			if nsSelector.Spec.Mode == v1.NSSelectorDynamicMode {
+			mode := nsSelector.Spec.Mode
+			if mode == "" && objs.DynamicNamespaceSelector {
+				mode = v1.NSSelectorDynamicMode
+			}
+			if mode == v1.NSSelectorDynamicMode {
 				hasDynamicNSSelector = true
-			} else if nsSelector.Spec.Mode != "" && nsSelector.Spec.Mode != v1.NSSelectorStaticMode {
+			} else if mode != "" && mode != v1.NSSelectorStaticMode {
 				errs = status.Append(errs, selectors.UnknownNamespaceSelectorModeError(nsSelector))
 			}
 		default:
@@ -59,6 +59,9 @@ var (
 	devOnlyDynamicNSS = nsSelector("dev-only", v1.NSSelectorDynamicMode,
 		"dev-only-nss.yaml", map[string]string{"environment": "dev"})
 
+	devOnlyDefaultModeNSS = nsSelector("dev-only", "",
+		"dev-only-nss.yaml", map[string]string{"environment": "dev"})
+
 	unknownModeNSS = nsSelector("unknown-mode", "unknown",
 		"unknown-nss.yaml", map[string]string{"environment": "dev"})
 )
@@ -238,6 +241,63 @@ func TestNamespaceSelectors(t *testing.T) {
 			},
 			wantDynamicNSSelectorEnabledAnnotation: true,
 		},
+		{
+			name: "Select on-cluster namespaces when the dynamic mode is the default",
+			objs: &objects.Scoped{
+				Scope:                    declared.RootReconciler,
+				SyncName:                 configsync.RootSyncName,
+				DynamicNamespaceSelector: true,
+				Cluster: []ast.FileObject{
+					devOnlyDefaultModeNSS,
+					fake.Namespace("namespaces/prod", core.Label("environment", "prod")),
+				},
+				Namespace: []ast.FileObject{
+					fake.Role(core.Annotation(metadata.NamespaceSelectorAnnotationKey, devOnlyDefaultModeNSS.Unstructured.GetName())),
+				},
+			},
+			onClusterObjects: []client.Object{
+				fake.NamespaceObject("dev1", core.Label("environment", "dev")),
+			},
+			want: &objects.Scoped{
+				Scope:    declared.RootReconciler,
+				SyncName: configsync.RootSyncName,
+				Cluster: []ast.FileObject{
+					fake.Namespace("namespaces/prod", core.Label("environment", "prod")),
+				},
+				Namespace: []ast.FileObject{
+					fake.Role(
+						core.Namespace("dev1"),
+						core.Annotation(metadata.NamespaceSelectorAnnotationKey, devOnlyDefaultModeNSS.Unstructured.GetName())),
+				},
+			},
+			wantDynamicNSSelectorEnabledAnnotation: true,
+		},
+		{
+			name: "Explicit static mode is kept when the dynamic mode is the default",
+			objs: &objects.Scoped{
+				Scope:                    declared.RootReconciler,
+				SyncName:                 configsync.RootSyncName,
+				DynamicNamespaceSelector: true,
+				Cluster: []ast.FileObject{
+					devOnlyNSS,
+					fake.Namespace("namespaces/prod", core.Label("environment", "prod")),
+				},
+				Namespace: []ast.FileObject{
+					fake.Role(core.Annotation(metadata.NamespaceSelectorAnnotationKey, devOnlyNSS.Unstructured.GetName())),
+				},
+			},
+			onClusterObjects: []client.Object{
+				fake.NamespaceObject("dev1", core.Label("environment", "dev")),
+			},
+			want: &objects.Scoped{
+				Scope:    declared.RootReconciler,
+				SyncName: configsync.RootSyncName,
+				Cluster: []ast.FileObject{
+					fake.Namespace("namespaces/prod", core.Label("environment", "prod")),
+				},
+			},
+			wantDynamicNSSelectorEnabledAnnotation: false,
+		},
 		{
 			name: "Unselect namespace-scoped resources in on-cluster namespaces",
 			objs: &objects.Scoped{
@@ -44,7 +44,16 @@ func Inheritance(objs *objects.Tree) status.MultiError {
 // buildInheritanceSpecs populates the InheritanceHydrator with InheritanceSpecs
 // based upon the HierarchyConfigs in the system directory.
 func buildInheritanceSpecs(objs []ast.FileObject) (inheritanceSpecs, status.Error) {
-	specs := make(map[schema.GroupKind]transform.InheritanceSpec)
+	return inheritanceSpecs{}.override(objs)
+}
+
+// override returns a copy of the InheritanceSpecs in which the modes declared
+// by the given HierarchyConfigs take precedence.
+func (i inheritanceSpecs) override(objs []ast.FileObject) (inheritanceSpecs, status.Error) {
+	specs := make(inheritanceSpecs, len(i))
+	for gk, spec := range i {
+		specs[gk] = spec
+	}
 	for _, obj := range objs {
 		s, err := obj.Structured()
 		if err != nil {
@@ -66,16 +75,30 @@ func buildInheritanceSpecs(objs []ast.FileObject) (inheritanceSpecs, status.Erro
 	return specs, nil
 }
 
+// isInherited returns true if objects of the given GroupKind are copied down
+// into child Namespaces.
+func (i inheritanceSpecs) isInherited(gk schema.GroupKind) bool {
+	spec, found := i[gk]
+	return !found || spec.Mode != v1.HierarchyModeNone
+}
+
 // visitTreeNode recursively hydrates Namespaces by copying inherited resource
-// objects down into child Namespaces.
+// objects down into child Namespaces. HierarchyConfigs declared in an abstract
+// namespace override the InheritanceSpecs for that subtree, so objects
+// inherited from its ancestors may be excluded from its Namespaces.
 func (i inheritanceSpecs) visitTreeNode(node *ast.TreeNode, inherited []ast.FileObject) status.MultiError {
 	var nodeObjs []ast.FileObject
+	var hierarchyConfigs []ast.FileObject
 	isNamespace := false
 	for _, o := range node.Objects {
-		if o.GetObjectKind().GroupVersionKind() == kinds.Namespace() {
+		switch o.GetObjectKind().GroupVersionKind() {
+		case kinds.Namespace():
 			isNamespace = true
-		} else if o.GetObjectKind().GroupVersionKind() != kinds.NamespaceSelector() {
+		case kinds.NamespaceSelector():
 			// Don't copy down NamespaceSelectors.
+		case kinds.HierarchyConfig():
+			hierarchyConfigs = append(hierarchyConfigs, o)
+		default:
 			nodeObjs = append(nodeObjs, o)
 		}
 	}
@@ -84,6 +107,23 @@ func (i inheritanceSpecs) visitTreeNode(node *ast.TreeNode, inherited []ast.File
 		return hydrateNamespace(node, inherited)
 	}
 
+	if len(hierarchyConfigs) > 0 {
+		specs, err := i.override(hierarchyConfigs)
+		if err != nil {
+			return err
+		}
+		i = specs
+		var subtreeInherited []ast.FileObject
+		for _, o := range inherited {
+			if i.isInherited(o.GetObjectKind().GroupVersionKind().GroupKind()) {
+				subtreeInherited = append(subtreeInherited, o)
+			}
+		}
+		inherited = subtreeInherited
+		// HierarchyConfigs only configure the parser, so they are never synced.
+		node.Objects = withoutHierarchyConfigs(node.Objects)
+	}
+
 	err := i.validateAbstractObjects(nodeObjs)
 	inherited = append(inherited, nodeObjs...)
 	for _, c := range node.Children {
@@ -92,14 +132,23 @@ func (i inheritanceSpecs) visitTreeNode(node *ast.TreeNode, inherited []ast.File
 	return err
 }
 
+func withoutHierarchyConfigs(objs []ast.FileObject) []ast.FileObject {
+	var result []ast.FileObject
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind() != kinds.HierarchyConfig() {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
 // validateAbstractObjects returns an error if any invalid objects are declared
 // in an abstract namespace.
 func (i inheritanceSpecs) validateAbstractObjects(objs []ast.FileObject) status.MultiError {
 	var err status.MultiError
 	for _, o := range objs {
 		gvk := o.GetObjectKind().GroupVersionKind()
-		spec, found := i[gvk.GroupKind()]
-		if (found && spec.Mode == v1.HierarchyModeNone) && !transform.IsEphemeral(gvk) && !syntax.IsSystemOnly(gvk) {
+		if !i.isInherited(gvk.GroupKind()) && !transform.IsEphemeral(gvk) && !syntax.IsSystemOnly(gvk) {
 			err = status.Append(err, validation.IllegalAbstractNamespaceObjectKindError(o))
 		}
 	}
@@ -239,6 +239,110 @@ func TestInheritance(t *testing.T) {
 			},
 			wantErrs: validation.IllegalAbstractNamespaceObjectKindError(fake.RoleAtPath("namespaces/hello/role.yaml", core.Name("writer"))),
 		},
+		{
+			name: "Abstract namespace HierarchyConfig excludes inherited objects from subtree",
+			objs: &objects.Tree{
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Objects: []ast.FileObject{
+						fake.RoleAtPath("namespaces/role.yaml", core.Name("reader")),
+						fake.RoleBindingAtPath("namespaces/rb.yaml", core.Name("reader-binding")),
+					},
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/hello"),
+							Type:     node.AbstractNamespace,
+							Objects: []ast.FileObject{
+								fake.HierarchyConfigAtPath("namespaces/hello/hc.yaml",
+									fake.HierarchyConfigKind(v1.HierarchyModeNone, kinds.Role())),
+							},
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/hello/world"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										fake.Namespace("namespaces/hello/world"),
+									},
+								},
+							},
+						},
+						{
+							Relative: cmpath.RelativeSlash("namespaces/goodbye"),
+							Type:     node.Namespace,
+							Objects: []ast.FileObject{
+								fake.Namespace("namespaces/goodbye"),
+							},
+						},
+					},
+				},
+			},
+			want: &objects.Tree{
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Objects: []ast.FileObject{
+						fake.RoleAtPath("namespaces/role.yaml", core.Name("reader")),
+						fake.RoleBindingAtPath("namespaces/rb.yaml", core.Name("reader-binding")),
+					},
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/hello"),
+							Type:     node.AbstractNamespace,
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/hello/world"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										fake.Namespace("namespaces/hello/world"),
+										fake.RoleBindingAtPath("namespaces/rb.yaml", core.Name("reader-binding")),
+									},
+								},
+							},
+						},
+						{
+							Relative: cmpath.RelativeSlash("namespaces/goodbye"),
+							Type:     node.Namespace,
+							Objects: []ast.FileObject{
+								fake.Namespace("namespaces/goodbye"),
+								fake.RoleAtPath("namespaces/role.yaml", core.Name("reader")),
+								fake.RoleBindingAtPath("namespaces/rb.yaml", core.Name("reader-binding")),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Abstract namespace HierarchyConfig validates objects in subtree",
+			objs: &objects.Tree{
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/hello"),
+							Type:     node.AbstractNamespace,
+							Objects: []ast.FileObject{
+								fake.HierarchyConfigAtPath("namespaces/hello/hc.yaml",
+									fake.HierarchyConfigKind(v1.HierarchyModeNone, kinds.Role())),
+								fake.RoleAtPath("namespaces/hello/role.yaml", core.Name("writer")),
+							},
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/hello/world"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										fake.Namespace("namespaces/hello/world"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErrs: validation.IllegalAbstractNamespaceObjectKindError(fake.RoleAtPath("namespaces/hello/role.yaml", core.Name("writer"))),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -26,7 +26,8 @@ import (
 )
 
 // HierarchyConfig verifies that all HierarchyConfig objects specify valid
-// namespace-scoped resource kinds and valid inheritance modes.
+// namespace-scoped resource kinds and valid inheritance modes, and that none
+// are declared in a Namespace directory.
 func HierarchyConfig(tree *objects.Tree) status.MultiError {
 	clusterGKs := make(map[schema.GroupKind]bool)
 	for _, obj := range tree.Cluster {
@@ -37,6 +38,37 @@ func HierarchyConfig(tree *objects.Tree) status.MultiError {
 	for _, obj := range tree.HierarchyConfigs {
 		errs = status.Append(errs, validateHC(obj, clusterGKs))
 	}
+	if tree.Tree != nil {
+		errs = status.Append(errs, validateTreeNodeHCs(tree.Tree, clusterGKs))
+	}
+	return errs
+}
+
+// validateTreeNodeHCs validates the HierarchyConfigs declared in the given
+// node and its descendants.
+func validateTreeNodeHCs(node *ast.TreeNode, clusterGKs map[schema.GroupKind]bool) status.MultiError {
+	var hcs []ast.FileObject
+	isNamespace := false
+	for _, obj := range node.Objects {
+		switch obj.GetObjectKind().GroupVersionKind() {
+		case kinds.Namespace():
+			isNamespace = true
+		case kinds.HierarchyConfig():
+			hcs = append(hcs, obj)
+		}
+	}
+
+	var errs status.MultiError
+	for _, obj := range hcs {
+		if isNamespace {
+			errs = status.Append(errs, hierarchyconfig.IllegalHierarchyConfigPlacementError(obj))
+		} else {
+			errs = status.Append(errs, validateHC(obj, clusterGKs))
+		}
+	}
+	for _, child := range node.Children {
+		errs = status.Append(errs, validateTreeNodeHCs(child, clusterGKs))
+	}
 	return errs
 }
 
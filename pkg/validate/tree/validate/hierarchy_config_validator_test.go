@@ -21,7 +21,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast/node"
 	"kpt.dev/configsync/pkg/importer/analyzer/validation/hierarchyconfig"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/testing/fake"
@@ -116,6 +118,48 @@ func TestHierarchyConfig(t *testing.T) {
 			},
 			wantErrs: fake.Errors(hierarchyconfig.IllegalHierarchyModeErrorCode),
 		},
+		{
+			name: "HierarchyConfig in abstract namespace allowed",
+			objs: &objects.Tree{
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces/foo"),
+					Type:     node.AbstractNamespace,
+					Objects: []ast.FileObject{
+						fake.HierarchyConfigAtPath("namespaces/foo/hc.yaml",
+							fake.HierarchyConfigKind(v1.HierarchyModeNone, kinds.RoleBinding())),
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid HierarchyConfig in abstract namespace not allowed",
+			objs: &objects.Tree{
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces/foo"),
+					Type:     node.AbstractNamespace,
+					Objects: []ast.FileObject{
+						fake.HierarchyConfigAtPath("namespaces/foo/hc.yaml",
+							fake.HierarchyConfigKind(unknownMode, kinds.RoleBinding())),
+					},
+				},
+			},
+			wantErrs: fake.Errors(hierarchyconfig.IllegalHierarchyModeErrorCode),
+		},
+		{
+			name: "HierarchyConfig in Namespace directory not allowed",
+			objs: &objects.Tree{
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces/foo"),
+					Type:     node.Namespace,
+					Objects: []ast.FileObject{
+						fake.Namespace("namespaces/foo"),
+						fake.HierarchyConfigAtPath("namespaces/foo/hc.yaml",
+							fake.HierarchyConfigKind(v1.HierarchyModeNone, kinds.RoleBinding())),
+					},
+				},
+			},
+			wantErrs: fake.Errors(hierarchyconfig.IllegalHierarchyConfigPlacementErrorCode),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
@@ -63,6 +64,9 @@ type Options struct {
 	// annotation on that object so that the validating admission webhook can
 	// prevent those fields from being changed.
 	Converter *declared.ValueConverter
+	// SkipDeclaredFields skips annotating each object with its declared fields,
+	// for reconcilers which run without the validating admission webhook.
+	SkipDeclaredFields bool
 	// AllowUnknownKinds is a flag to determine if we should throw an error or
 	// proceed when the Scoper is unable to determine the scope of an object
 	// kind. We only set this to true if a tool is running in offline mode (eg we
@@ -78,9 +82,15 @@ type Options struct {
 	// DynamicNSSelectorEnabled indicates whether the dynamic mode of
 	// NamespaceSelector is enabled.
 	DynamicNSSelectorEnabled bool
+	// DynamicNamespaceSelector indicates whether NamespaceSelectors which do
+	// not set a mode use the dynamic mode.
+	DynamicNamespaceSelector bool
 	// NSControllerState caches the NamespaceSelectors and selected Namespaces
 	// in the namespace controller.
 	NSControllerState *namespacecontroller.State
+	// NamespaceMismatchPolicy indicates how an object in a hierarchical repo
+	// whose metadata.namespace differs from its directory is handled.
+	NamespaceMismatchPolicy configsync.NamespaceMismatchPolicy
 }
 
 // Hierarchical validates and hydrates the given FileObjects from a structured,
@@ -94,15 +104,18 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 	//   - filtering out resources whose cluster selector does not match
 	//   - adding metadata to resources (such as their filepath in the repo)
 	rawObjects := &objects.Raw{
-		ClusterName:       opts.ClusterName,
-		Scope:             opts.Scope,
-		SyncName:          opts.SyncName,
-		PolicyDir:         opts.PolicyDir,
-		Objects:           objs,
-		PreviousCRDs:      opts.PreviousCRDs,
-		BuildScoper:       opts.BuildScoper,
-		Converter:         opts.Converter,
-		AllowUnknownKinds: opts.AllowUnknownKinds,
+		ClusterName:        opts.ClusterName,
+		Scope:              opts.Scope,
+		SyncName:           opts.SyncName,
+		PolicyDir:          opts.PolicyDir,
+		Objects:            objs,
+		PreviousCRDs:       opts.PreviousCRDs,
+		BuildScoper:        opts.BuildScoper,
+		Converter:          opts.Converter,
+		SkipDeclaredFields: opts.SkipDeclaredFields,
+		AllowUnknownKinds:  opts.AllowUnknownKinds,
+
+		NamespaceMismatchPolicy: opts.NamespaceMismatchPolicy,
 	}
 
 	// nonBlockingErrs tracks the errors which do not block the apply stage
@@ -183,9 +196,11 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 		PreviousCRDs:             opts.PreviousCRDs,
 		BuildScoper:              opts.BuildScoper,
 		Converter:                opts.Converter,
+		SkipDeclaredFields:       opts.SkipDeclaredFields,
 		AllowUnknownKinds:        opts.AllowUnknownKinds,
 		AllowAPICall:             opts.AllowAPICall,
 		DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
+		DynamicNamespaceSelector: opts.DynamicNamespaceSelector,
 		NSControllerState:        opts.NSControllerState,
 	}
 
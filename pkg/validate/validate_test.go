@@ -189,6 +189,41 @@ func TestHierarchical(t *testing.T) {
 					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/foo/role.yaml")),
 			},
 		},
+		{
+			name: "object with mismatched namespace",
+			objs: []ast.FileObject{
+				fake.Repo(),
+				fake.Namespace("namespaces/foo"),
+				fake.RoleAtPath("namespaces/foo/role.yaml",
+					core.Namespace("bar")),
+			},
+			wantErrs: fake.Errors(metadata.IllegalMetadataNamespaceDeclarationErrorCode),
+		},
+		{
+			name: "object with mismatched namespace and correct policy",
+			options: Options{
+				NamespaceMismatchPolicy: configsync.NamespaceMismatchCorrect,
+			},
+			objs: []ast.FileObject{
+				fake.Repo(),
+				fake.Namespace("namespaces/foo"),
+				fake.RoleAtPath("namespaces/foo/role.yaml",
+					core.Namespace("bar")),
+			},
+			want: []ast.FileObject{
+				fake.Namespace("namespaces/foo",
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:spec":{},"f:status":{}}`),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/foo/namespace.yaml"),
+					core.Annotation(csmetadata.HNCManagedBy, csmetadata.ManagedByValue),
+					core.Label("foo.tree.hnc.x-k8s.io/depth", "0")),
+				fake.RoleAtPath("namespaces/foo/role.yaml",
+					core.Namespace("foo"),
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:rules":{}}`),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/foo/role.yaml")),
+			},
+		},
 		{
 			name: "abstract namespaces with object inheritance",
 			objs: []ast.FileObject{
@@ -236,6 +271,42 @@ func TestHierarchical(t *testing.T) {
 					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/qux/role.yaml")),
 			},
 		},
+		{
+			name: "abstract namespace HierarchyConfig excludes inherited objects",
+			objs: []ast.FileObject{
+				fake.Repo(),
+				fake.Namespace("namespaces/bar/foo"),
+				fake.Namespace("namespaces/bar/qux/lym"),
+				fake.RoleAtPath("namespaces/bar/role.yaml",
+					core.Name("first")),
+				fake.HierarchyConfigAtPath("namespaces/bar/qux/hc.yaml",
+					core.Name("qux-hc"),
+					fake.HierarchyConfigKind(v1.HierarchyModeNone, kinds.Role())),
+			},
+			want: []ast.FileObject{
+				fake.Namespace("namespaces/bar/foo",
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:spec":{},"f:status":{}}`),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/foo/namespace.yaml"),
+					core.Annotation(csmetadata.HNCManagedBy, csmetadata.ManagedByValue),
+					core.Label("bar.tree.hnc.x-k8s.io/depth", "1"),
+					core.Label("foo.tree.hnc.x-k8s.io/depth", "0")),
+				fake.RoleAtPath("namespaces/bar/role.yaml",
+					core.Name("first"),
+					core.Namespace("foo"),
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:rules":{}}`),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/role.yaml")),
+				fake.Namespace("namespaces/bar/qux/lym",
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:spec":{},"f:status":{}}`),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/qux/lym/namespace.yaml"),
+					core.Annotation(csmetadata.HNCManagedBy, csmetadata.ManagedByValue),
+					core.Label("bar.tree.hnc.x-k8s.io/depth", "2"),
+					core.Label("qux.tree.hnc.x-k8s.io/depth", "1"),
+					core.Label("lym.tree.hnc.x-k8s.io/depth", "0")),
+			},
+		},
 		{
 			name: "CRD and CR",
 			objs: []ast.FileObject{
@@ -800,18 +871,25 @@ func TestHierarchical(t *testing.T) {
 			name: "system objects under incorrect directory fails",
 			objs: []ast.FileObject{
 				fake.Repo(),
-				fake.HierarchyConfigAtPath("namespaces/foo/hc.yaml",
-					core.Name("namespaces-is-wrong")),
 				fake.HierarchyConfigAtPath("cluster/hc.yaml",
 					core.Name("cluster-is-wrong")),
 				fake.HierarchyConfigAtPath("clusterregistry/hc.yaml",
 					core.Name("clusterregistry-is-wrong")),
 			},
 			wantErrs: fake.Errors(
-				validation.IncorrectTopLevelDirectoryErrorCode,
 				validation.IncorrectTopLevelDirectoryErrorCode,
 				validation.IncorrectTopLevelDirectoryErrorCode),
 		},
+		{
+			name: "HierarchyConfig in namespace directory fails",
+			objs: []ast.FileObject{
+				fake.Repo(),
+				fake.Namespace("namespaces/foo"),
+				fake.HierarchyConfigAtPath("namespaces/foo/hc.yaml",
+					fake.HierarchyConfigKind(v1.HierarchyModeNone, kinds.Role())),
+			},
+			wantErrs: fake.Errors(hierarchyconfig.IllegalHierarchyConfigPlacementErrorCode),
+		},
 		{
 			name: "illegal metadata on objects fails",
 			objs: []ast.FileObject{
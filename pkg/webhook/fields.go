@@ -78,7 +78,9 @@ func ConfigSyncMetadata(set *fieldpath.Set) *fieldpath.Set {
 		s := path.String()
 		if strings.HasPrefix(s, annotations) {
 			s = s[len(annotations):]
-			if csmetadata.IsConfigSyncAnnotationKey(s) {
+			// Users may set the reconcile-now annotation to request a
+			// force-apply of the object.
+			if csmetadata.IsConfigSyncAnnotationKey(s) && s != csmetadata.ReconcileNowAnnotationKey {
 				csSet.Insert(path)
 			}
 		} else if strings.HasPrefix(s, labels) {
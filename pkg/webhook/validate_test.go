@@ -474,6 +474,27 @@ func TestValidator_Handle(t *testing.T) {
 			user: bob(),
 			deny: metav1.StatusReasonForbidden,
 		},
+		{
+			name: "Bob updates a managed object: reconcile-now annotation",
+			oldObj: fake.RoleObject(
+				core.Name("hello"),
+				core.Namespace("world"),
+				core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+				core.Annotation(csmetadata.ResourceManagementKey, csmetadata.ResourceManagementEnabled),
+				core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_world_hello"),
+				core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{}}},"f:rules":{}}`),
+			),
+			newObj: fake.RoleObject(
+				core.Name("hello"),
+				core.Namespace("world"),
+				core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+				core.Annotation(csmetadata.ResourceManagementKey, csmetadata.ResourceManagementEnabled),
+				core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_world_hello"),
+				core.Annotation(csmetadata.ReconcileNowAnnotationKey, "true"),
+				core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{}}},"f:rules":{}}`),
+			),
+			user: bob(),
+		},
 		{
 			name: "Bob updates a object (whose configmanagement.gke.io/managed annotation is unset, but whose configsync.gke.io/resource-id annotation is set): Config Sync metadata",
 			oldObj: fake.RoleObject(